@@ -60,6 +60,12 @@ spec:
         - name: https_proxy
           value: {{ .Values.httpsProxy }}
         {{- end}}
+        {{- if .Values.sharding }}
+        - name: POD_IP
+          valueFrom:
+            fieldRef:
+              fieldPath: status.podIP
+        {{- end}}
         {{- if (and (eq (empty .Values.global.enableMongoSecret) false) (eq (empty .Values.mountMongoSecret) false)) }}
         - name: EMCO_DATA_KEY
           valueFrom:
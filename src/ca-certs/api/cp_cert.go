@@ -4,6 +4,8 @@
 package api
 
 import (
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -43,13 +45,21 @@ func (h *cpCertHandler) handleCertificateGet(w http.ResponseWriter, r *http.Requ
 	var (
 		certs interface{}
 		err   error
+		next  string
 	)
 
 	ctx := r.Context()
 	// get the route variables
 	vars := _cpVars(mux.Vars(r))
 	if len(vars.cert) == 0 {
-		certs, err = h.manager.GetAllCert(ctx, vars.clusterProvider)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			logutils.Error(err.Error(), logutils.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		certs, next, err = h.manager.ListCerts(ctx, vars.clusterProvider, opts)
 	} else {
 		certs, err = h.manager.GetCert(ctx, vars.cert, vars.clusterProvider)
 	}
@@ -60,6 +70,7 @@ func (h *cpCertHandler) handleCertificateGet(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	sendResponse(w, certs, http.StatusOK)
 }
 
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -42,13 +44,21 @@ func (h *lcCertHandler) handleCertificateGet(w http.ResponseWriter, r *http.Requ
 	var (
 		certs interface{}
 		err   error
+		next  string
 	)
 
 	ctx := r.Context()
 	// get the route variables
 	vars := _lcVars(mux.Vars(r))
 	if len(vars.cert) == 0 {
-		certs, err = h.manager.GetAllCert(ctx, vars.project)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			logutils.Error(err.Error(), logutils.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		certs, next, err = h.manager.ListCerts(ctx, vars.project, opts)
 	} else {
 		certs, err = h.manager.GetCert(ctx, vars.cert, vars.project)
 	}
@@ -59,6 +69,7 @@ func (h *lcCertHandler) handleCertificateGet(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	sendResponse(w, certs, http.StatusOK)
 }
 
@@ -4,6 +4,8 @@
 package api
 
 import (
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -42,13 +44,21 @@ func (h *lcClusterHandler) handleClusterGet(w http.ResponseWriter, r *http.Reque
 	var (
 		clusters interface{}
 		err      error
+		next     string
 	)
 
 	ctx := r.Context()
 	// get the route variables
 	vars := _lcVars(mux.Vars(r))
 	if len(vars.cluster) == 0 {
-		clusters, err = h.manager.GetAllClusterGroups(ctx, vars.logicalCloud, vars.cert, vars.project)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			logutils.Error(err.Error(), logutils.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		clusters, next, err = h.manager.ListClusterGroups(ctx, vars.logicalCloud, vars.cert, vars.project, opts)
 	} else {
 		clusters, err = h.manager.GetClusterGroup(ctx, vars.cluster, vars.logicalCloud, vars.cert, vars.project)
 	}
@@ -59,6 +69,7 @@ func (h *lcClusterHandler) handleClusterGet(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	sendResponse(w, clusters, http.StatusOK)
 }
 
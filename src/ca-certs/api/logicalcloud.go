@@ -5,6 +5,8 @@ package api
 
 import (
 	"errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"net/http"
 	"strings"
 
@@ -43,13 +45,21 @@ func (h *lcHandler) handleLogicalCloudGet(w http.ResponseWriter, r *http.Request
 	var (
 		logicalClouds interface{}
 		err           error
+		next          string
 	)
 
 	ctx := r.Context()
 	// get the route variables
 	vars := _lcVars(mux.Vars(r))
 	if len(vars.logicalCloud) == 0 {
-		logicalClouds, err = h.manager.GetAllLogicalClouds(ctx, vars.cert, vars.project)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			logutils.Error(err.Error(), logutils.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		logicalClouds, next, err = h.manager.ListLogicalClouds(ctx, vars.cert, vars.project, opts)
 	} else {
 		logicalClouds, err = h.manager.GetLogicalCloud(ctx, vars.logicalCloud, vars.cert, vars.project)
 	}
@@ -60,6 +70,7 @@ func (h *lcHandler) handleLogicalCloudGet(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	sendResponse(w, logicalClouds, http.StatusOK)
 }
 
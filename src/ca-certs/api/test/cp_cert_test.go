@@ -12,6 +12,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/api"
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common/emcoerror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -91,6 +92,11 @@ func (m *mockClusterProviderCertManager) GetAllCert(ctx context.Context, cluster
 	return certs, nil
 
 }
+
+func (m *mockClusterProviderCertManager) ListCerts(ctx context.Context, clusterProvider string, opts db.FindOptions) ([]module.CaCert, string, error) {
+	items, err := m.GetAllCert(ctx, clusterProvider)
+	return items, "", err
+}
 func (m *mockClusterProviderCertManager) GetCert(ctx context.Context, cert, clusterProvider string) (module.CaCert, error) {
 	if m.Err != nil {
 		return module.CaCert{}, m.Err
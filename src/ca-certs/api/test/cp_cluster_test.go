@@ -14,6 +14,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/api"
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common/emcoerror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 type mockClusterProviderClusterManager struct {
@@ -95,6 +96,11 @@ func (m *mockClusterProviderClusterManager) GetAllClusterGroups(ctx context.Cont
 	return clusterGroups, nil
 }
 
+func (m *mockClusterProviderClusterManager) ListClusterGroups(ctx context.Context, cert, clusterProvider string, opts db.FindOptions) ([]module.ClusterGroup, string, error) {
+	items, err := m.GetAllClusterGroups(ctx, cert, clusterProvider)
+	return items, "", err
+}
+
 // GetClusterGroup
 func (m *mockClusterProviderClusterManager) GetClusterGroup(ctx context.Context, cert, cluster, clusterProvider string) (module.ClusterGroup, error) {
 	if m.Err != nil {
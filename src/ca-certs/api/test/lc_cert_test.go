@@ -12,6 +12,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/api"
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common/emcoerror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -91,6 +92,11 @@ func (m *mockLogicalCloudCertManager) GetAllCert(ctx context.Context, project st
 	return certs, nil
 
 }
+
+func (m *mockLogicalCloudCertManager) ListCerts(ctx context.Context, project string, opts db.FindOptions) ([]module.CaCert, string, error) {
+	items, err := m.GetAllCert(ctx, project)
+	return items, "", err
+}
 func (m *mockLogicalCloudCertManager) GetCert(ctx context.Context, cert, project string) (module.CaCert, error) {
 	if m.Err != nil {
 		return module.CaCert{}, m.Err
@@ -14,6 +14,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/api"
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common/emcoerror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 type mockLogicalCloudClusterManager struct {
@@ -95,6 +96,11 @@ func (m *mockLogicalCloudClusterManager) GetAllClusterGroups(ctx context.Context
 	return clusterGroups, nil
 }
 
+func (m *mockLogicalCloudClusterManager) ListClusterGroups(ctx context.Context, logicalCloud, cert, project string, opts db.FindOptions) ([]module.ClusterGroup, string, error) {
+	items, err := m.GetAllClusterGroups(ctx, logicalCloud, cert, project)
+	return items, "", err
+}
+
 // GetClusterGroup
 func (m *mockLogicalCloudClusterManager) GetClusterGroup(ctx context.Context, cluster, logicalCloud, cert, project string) (module.ClusterGroup, error) {
 	if m.Err != nil {
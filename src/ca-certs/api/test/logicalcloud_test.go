@@ -18,6 +18,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/client/logicalcloud"
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common/emcoerror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 
 	. "github.com/onsi/ginkgo"
@@ -100,6 +101,11 @@ func (m *mockLogicalCloudManager) GetAllLogicalClouds(ctx context.Context, cert,
 	return certs, nil
 
 }
+
+func (m *mockLogicalCloudManager) ListLogicalClouds(ctx context.Context, cert, project string, opts db.FindOptions) ([]logicalcloud.CaCertLogicalCloud, string, error) {
+	items, err := m.GetAllLogicalClouds(ctx, cert, project)
+	return items, "", err
+}
 func (m *mockLogicalCloudManager) GetLogicalCloud(ctx context.Context, logicalCloud, cert, project string) (logicalcloud.CaCertLogicalCloud, error) {
 	if m.Err != nil {
 		return logicalcloud.CaCertLogicalCloud{}, m.Err
@@ -11,6 +11,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/certificate/enrollment"
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common/emcoerror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // CaCertManager exposes all the clusterProvider caCert functionalities
@@ -19,6 +20,7 @@ type CaCertManager interface {
 	CreateCert(ctx context.Context, cert module.CaCert, clusterProvider string, failIfExists bool) (module.CaCert, bool, error)
 	DeleteCert(ctx context.Context, cert, clusterProvider string) error
 	GetAllCert(ctx context.Context, clusterProvider string) ([]module.CaCert, error)
+	ListCerts(ctx context.Context, clusterProvider string, opts db.FindOptions) ([]module.CaCert, string, error)
 	GetCert(ctx context.Context, cert, clusterProvider string) (module.CaCert, error)
 }
 
@@ -168,6 +170,14 @@ func (c *CaCertClient) GetAllCert(ctx context.Context, clusterProvider string) (
 	return module.NewCaCertClient(ck).GetAllCert(ctx)
 }
 
+// ListCerts returns a page of the clusterProvider caCerts and the token of the next page
+func (c *CaCertClient) ListCerts(ctx context.Context, clusterProvider string, opts db.FindOptions) ([]module.CaCert, string, error) {
+	ck := CaCertKey{
+		ClusterProvider: clusterProvider}
+
+	return module.NewCaCertClient(ck).ListCerts(ctx, opts)
+}
+
 // GetCert returns the clusterProvider caCert
 func (c *CaCertClient) GetCert(ctx context.Context, cert, clusterProvider string) (module.CaCert, error) {
 	ck := CaCertKey{
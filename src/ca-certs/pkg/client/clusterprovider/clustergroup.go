@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // ClusterGroupManager exposes all the caCert clusterGroup functionalities
@@ -14,6 +15,7 @@ type ClusterGroupManager interface {
 	CreateClusterGroup(ctx context.Context, cluster module.ClusterGroup, cert, clusterProvider string, failIfExists bool) (module.ClusterGroup, bool, error)
 	DeleteClusterGroup(ctx context.Context, cert, cluster, clusterProvider string) error
 	GetAllClusterGroups(ctx context.Context, cert, clusterProvider string) ([]module.ClusterGroup, error)
+	ListClusterGroups(ctx context.Context, cert, clusterProvider string, opts db.FindOptions) ([]module.ClusterGroup, string, error)
 	GetClusterGroup(ctx context.Context, cert, cluster, clusterProvider string) (module.ClusterGroup, error)
 }
 
@@ -62,6 +64,15 @@ func (c *ClusterGroupClient) GetAllClusterGroups(ctx context.Context, cert, clus
 	return module.NewClusterGroupClient(ck).GetAllClusterGroups(ctx)
 }
 
+// ListClusterGroups returns a page of the caCert clusterGroups and the token of the next page
+func (c *ClusterGroupClient) ListClusterGroups(ctx context.Context, cert, clusterProvider string, opts db.FindOptions) ([]module.ClusterGroup, string, error) {
+	ck := ClusterGroupKey{
+		Cert:            cert,
+		ClusterProvider: clusterProvider}
+
+	return module.NewClusterGroupClient(ck).ListClusterGroups(ctx, opts)
+}
+
 // GetClusterGroup returns the caCert clusterGroup
 func (c *ClusterGroupClient) GetClusterGroup(ctx context.Context, cert, clusterGroup, clusterProvider string) (module.ClusterGroup, error) {
 	ck := ClusterGroupKey{
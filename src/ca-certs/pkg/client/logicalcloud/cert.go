@@ -11,6 +11,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/certificate/enrollment"
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common/emcoerror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // CaCertManager exposes all the caCert functionalities
@@ -19,6 +20,7 @@ type CaCertManager interface {
 	CreateCert(ctx context.Context, cert module.CaCert, project string, failIfExists bool) (module.CaCert, bool, error)
 	DeleteCert(ctx context.Context, cert, project string) error
 	GetAllCert(ctx context.Context, project string) ([]module.CaCert, error)
+	ListCerts(ctx context.Context, project string, opts db.FindOptions) ([]module.CaCert, string, error)
 	GetCert(ctx context.Context, cert, project string) (module.CaCert, error)
 }
 
@@ -165,6 +167,14 @@ func (c *CaCertClient) GetAllCert(ctx context.Context, project string) ([]module
 	return module.NewCaCertClient(ck).GetAllCert(ctx)
 }
 
+// ListCerts returns a page of the logicalCloud caCerts and the token of the next page
+func (c *CaCertClient) ListCerts(ctx context.Context, project string, opts db.FindOptions) ([]module.CaCert, string, error) {
+	ck := CaCertKey{
+		Project: project}
+
+	return module.NewCaCertClient(ck).ListCerts(ctx, opts)
+}
+
 // GetCert returns the logicalCloud caCert
 func (c *CaCertClient) GetCert(ctx context.Context, cert, project string) (module.CaCert, error) {
 	ck := CaCertKey{
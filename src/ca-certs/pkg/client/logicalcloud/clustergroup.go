@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"gitlab.com/project-emco/core/emco-base/src/ca-certs/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // ClusterGroupManager exposes all the caCert clusterGroup functionalities
@@ -14,6 +15,7 @@ type ClusterGroupManager interface {
 	CreateClusterGroup(ctx context.Context, cluster module.ClusterGroup, logicalCloud, cert, project string, failIfExists bool) (module.ClusterGroup, bool, error)
 	DeleteClusterGroup(ctx context.Context, cluster, logicalCloud, cert, project string) error
 	GetAllClusterGroups(ctx context.Context, logicalCloud, cert, project string) ([]module.ClusterGroup, error)
+	ListClusterGroups(ctx context.Context, logicalCloud, cert, project string, opts db.FindOptions) ([]module.ClusterGroup, string, error)
 	GetClusterGroup(ctx context.Context, cluster, logicalCloud, cert, project string) (module.ClusterGroup, error)
 }
 
@@ -66,6 +68,16 @@ func (c *ClusterGroupClient) GetAllClusterGroups(ctx context.Context, caCertLogi
 	return module.NewClusterGroupClient(ck).GetAllClusterGroups(ctx)
 }
 
+// ListClusterGroups returns a page of the caCert clusterGroups and the token of the next page
+func (c *ClusterGroupClient) ListClusterGroups(ctx context.Context, caCertLogicalCloud, cert, project string, opts db.FindOptions) ([]module.ClusterGroup, string, error) {
+	ck := ClusterGroupKey{
+		Cert:               cert,
+		CaCertLogicalCloud: caCertLogicalCloud,
+		Project:            project}
+
+	return module.NewClusterGroupClient(ck).ListClusterGroups(ctx, opts)
+}
+
 // GetClusterGroup returns the caCert clusterGroup
 func (c *ClusterGroupClient) GetClusterGroup(ctx context.Context, clusterGroup, caCertLogicalCloud, cert, project string) (module.ClusterGroup, error) {
 	ck := ClusterGroupKey{
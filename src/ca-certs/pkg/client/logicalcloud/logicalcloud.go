@@ -17,6 +17,7 @@ type CaCertLogicalCloudManager interface {
 	CreateLogicalCloud(ctx context.Context, logicalCloud CaCertLogicalCloud, cert, project string, failIfExists bool) (CaCertLogicalCloud, bool, error)
 	DeleteLogicalCloud(ctx context.Context, logicalCloud, cert, project string) error
 	GetAllLogicalClouds(ctx context.Context, cert, project string) ([]CaCertLogicalCloud, error)
+	ListLogicalClouds(ctx context.Context, cert, project string, opts db.FindOptions) ([]CaCertLogicalCloud, string, error)
 	GetLogicalCloud(ctx context.Context, logicalCloud, cert, project string) (CaCertLogicalCloud, error)
 }
 
@@ -27,6 +28,17 @@ type CaCertLogicalCloudKey struct {
 	Project            string `json:"project"`
 }
 
+// logicalCloudSelectors are the fields a list of caCert logicalClouds can be filtered and sorted on
+var logicalCloudSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":         "data.metadata.name",
+		"logicalCloud": "data.spec.logicalCloud",
+	},
+	TagTypes: map[string]interface{}{
+		"data": CaCertLogicalCloud{},
+	},
+}
+
 // CaCertLogicalCloudClient holds the client properties
 type CaCertLogicalCloudClient struct {
 	dbInfo db.DbInfo
@@ -80,25 +92,37 @@ func (c *CaCertLogicalCloudClient) DeleteLogicalCloud(ctx context.Context, logic
 
 // GetAllLogicalClouds returns all the caCert logicalCloud
 func (c *CaCertLogicalCloudClient) GetAllLogicalClouds(ctx context.Context, cert, project string) ([]CaCertLogicalCloud, error) {
+	res, _, err := c.ListLogicalClouds(ctx, cert, project, db.FindOptions{})
+	return res, err
+}
+
+// ListLogicalClouds returns a page of the caCert logicalClouds, filtered and
+// sorted on the logicalCloudSelectors, and the token of the next page
+func (c *CaCertLogicalCloudClient) ListLogicalClouds(ctx context.Context, cert, project string, opts db.FindOptions) ([]CaCertLogicalCloud, string, error) {
+	opts, err := logicalCloudSelectors.Resolve(opts)
+	if err != nil {
+		return []CaCertLogicalCloud{}, "", err
+	}
+
 	key := CaCertLogicalCloudKey{
 		Cert:    cert,
 		Project: project}
 
-	values, err := db.DBconn.Find(ctx, c.dbInfo.StoreName, key, c.dbInfo.TagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, c.dbInfo.StoreName, key, c.dbInfo.TagMeta, opts)
 	if err != nil {
-		return []CaCertLogicalCloud{}, err
+		return []CaCertLogicalCloud{}, "", err
 	}
 
 	var logicalClouds []CaCertLogicalCloud
 	for _, value := range values {
 		lc := CaCertLogicalCloud{}
 		if err = db.DBconn.Unmarshal(value, &lc); err != nil {
-			return []CaCertLogicalCloud{}, err
+			return []CaCertLogicalCloud{}, "", err
 		}
 		logicalClouds = append(logicalClouds, lc)
 	}
 
-	return logicalClouds, nil
+	return logicalClouds, next, nil
 }
 
 // GetLogicalCloud returns the caCert logicalCloud
@@ -20,9 +20,20 @@ type CaCertManager interface {
 	CreateCert(ctx context.Context, cert CaCert, failIfExists bool) (CaCert, bool, error)
 	DeleteCert(ctx context.Context) error
 	GetAllCert(ctx context.Context) ([]CaCert, error)
+	ListCerts(ctx context.Context, opts db.FindOptions) ([]CaCert, string, error)
 	GetCert(ctx context.Context) (CaCert, error)
 }
 
+// caCertSelectors are the fields a list of caCerts can be filtered and sorted on
+var caCertSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": CaCert{},
+	},
+}
+
 // CaCertClient holds the client properties
 type CaCertClient struct {
 	dbInfo db.DbInfo
@@ -70,21 +81,33 @@ func (c *CaCertClient) DeleteCert(ctx context.Context) error {
 
 // GetAllCert
 func (c *CaCertClient) GetAllCert(ctx context.Context) ([]CaCert, error) {
-	values, err := db.DBconn.Find(ctx, c.dbInfo.StoreName, c.dbKey, c.dbInfo.TagMeta)
+	res, _, err := c.ListCerts(ctx, db.FindOptions{})
+	return res, err
+}
+
+// ListCerts returns a page of the caCerts, filtered and sorted on the
+// caCertSelectors, and the token of the next page
+func (c *CaCertClient) ListCerts(ctx context.Context, opts db.FindOptions) ([]CaCert, string, error) {
+	opts, err := caCertSelectors.Resolve(opts)
+	if err != nil {
+		return []CaCert{}, "", err
+	}
+
+	values, next, err := db.DBconn.FindWithOptions(ctx, c.dbInfo.StoreName, c.dbKey, c.dbInfo.TagMeta, opts)
 	if err != nil {
-		return []CaCert{}, err
+		return []CaCert{}, "", err
 	}
 
 	var certs []CaCert
 	for _, value := range values {
 		cert := CaCert{}
 		if err = db.DBconn.Unmarshal(value, &cert); err != nil {
-			return []CaCert{}, err
+			return []CaCert{}, "", err
 		}
 		certs = append(certs, cert)
 	}
 
-	return certs, nil
+	return certs, next, nil
 }
 
 // GetCert returns the caCert
@@ -19,9 +19,24 @@ type ClusterGroupManager interface {
 	CreateClusterGroup(ctx context.Context, cluster ClusterGroup, failIfExists bool) (ClusterGroup, bool, error)
 	DeleteClusterGroup(ctx context.Context) error
 	GetAllClusterGroups(ctx context.Context) ([]ClusterGroup, error)
+	ListClusterGroups(ctx context.Context, opts db.FindOptions) ([]ClusterGroup, string, error)
 	GetClusterGroup(ctx context.Context) (ClusterGroup, error)
 }
 
+// clusterGroupSelectors are the fields a list of clusterGroups can be filtered and sorted on
+var clusterGroupSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":            "data.metadata.name",
+		"label":           "data.spec.label",
+		"cluster":         "data.spec.cluster",
+		"clusterProvider": "data.spec.clusterProvider",
+		"scope":           "data.spec.scope",
+	},
+	TagTypes: map[string]interface{}{
+		"data": ClusterGroup{},
+	},
+}
+
 // ClusterGroupClient holds the client properties
 type ClusterGroupClient struct {
 	dbInfo db.DbInfo
@@ -68,21 +83,33 @@ func (c *ClusterGroupClient) DeleteClusterGroup(ctx context.Context) error {
 
 // GetAllClusterGroups returns  all the clusterGroup
 func (c *ClusterGroupClient) GetAllClusterGroups(ctx context.Context) ([]ClusterGroup, error) {
-	values, err := db.DBconn.Find(ctx, c.dbInfo.StoreName, c.dbKey, c.dbInfo.TagMeta)
+	res, _, err := c.ListClusterGroups(ctx, db.FindOptions{})
+	return res, err
+}
+
+// ListClusterGroups returns a page of the clusterGroups, filtered and sorted on
+// the clusterGroupSelectors, and the token of the next page
+func (c *ClusterGroupClient) ListClusterGroups(ctx context.Context, opts db.FindOptions) ([]ClusterGroup, string, error) {
+	opts, err := clusterGroupSelectors.Resolve(opts)
 	if err != nil {
-		return []ClusterGroup{}, err
+		return []ClusterGroup{}, "", err
+	}
+
+	values, next, err := db.DBconn.FindWithOptions(ctx, c.dbInfo.StoreName, c.dbKey, c.dbInfo.TagMeta, opts)
+	if err != nil {
+		return []ClusterGroup{}, "", err
 	}
 
 	var clusters []ClusterGroup
 	for _, value := range values {
 		clr := ClusterGroup{}
 		if err = db.DBconn.Unmarshal(value, &clr); err != nil {
-			return []ClusterGroup{}, err
+			return []ClusterGroup{}, "", err
 		}
 		clusters = append(clusters, clr)
 	}
 
-	return clusters, nil
+	return clusters, next, nil
 }
 
 // GetClusterGroup returns the clusterGroup
@@ -59,12 +59,14 @@ func NewRouter(testClient interface{}) *mux.Router {
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}", clusterHandler.putClusterProviderHandler).Methods("PUT")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}", clusterHandler.getClusterProviderHandler).Methods("GET")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}", clusterHandler.deleteClusterProviderHandler).Methods("DELETE")
+	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/watch", clusterHandler.watchClusterProviderHandler).Methods("GET")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters", clusterHandler.createClusterHandler).Methods("POST")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters", clusterHandler.getClusterHandler).Methods("GET")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters", clusterHandler.getClusterHandler).Queries("label", "{label}")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters", clusterHandler.getClusterHandler).Queries("withLabels", "{withLabels}")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters/{cluster}", clusterHandler.getClusterHandler).Methods("GET")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters/{cluster}", clusterHandler.deleteClusterHandler).Methods("DELETE")
+	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters/{cluster}/status", clusterHandler.getClusterStatusHandler).Methods("GET")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters/{cluster}/labels", clusterHandler.createClusterLabelHandler).Methods("POST")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters/{cluster}/labels", clusterHandler.getClusterLabelHandler).Methods("GET")
 	v2Router.HandleFunc("/cluster-providers/{clusterProvider}/clusters/{cluster}/labels/{clusterLabel}", clusterHandler.putClusterLabelHandler).Methods("PUT")
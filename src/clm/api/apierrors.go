@@ -12,6 +12,7 @@ var apiErrors = []apierror.APIError{
 	{ID: "Cluster already exists", Message: "Cluster already exists", Status: http.StatusConflict},
 	{ID: "Cluster not found", Message: "Cluster not found", Status: http.StatusNotFound},
 	{ID: "Cluster StateInfo not found", Message: "Cluster StateInfo not found", Status: http.StatusNotFound},
+	{ID: "Cluster status not found", Message: "Cluster status not found", Status: http.StatusNotFound},
 	{ID: "Cluster Label already exists", Message: "Cluster Label already exists", Status: http.StatusConflict},
 	{ID: "Cluster label not found", Message: "Cluster label not found", Status: http.StatusNotFound},
 	{ID: "Cluster KV Pair already exists", Message: "Cluster KV Pair already exists", Status: http.StatusConflict},
@@ -18,12 +18,16 @@ import (
 	"github.com/gorilla/mux"
 	clusterPkg "gitlab.com/project-emco/core/emco-base/src/clm/pkg/cluster"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apiwatch"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/schedule"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	mtypes "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 )
 
-var cpJSONFile string = "json-schemas/metadata.json"
+var cpJSONFile string = "json-schemas/cluster-provider.json"
 var ckvJSONFile string = "json-schemas/cluster-kv.json"
 var clJSONFile string = "json-schemas/cluster-label.json"
 var copsJSONFile string = "json-schemas/cluster-gitops.json"
@@ -62,6 +66,12 @@ func (h clusterHandler) createClusterProviderHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	if err := schedule.ValidateWindows(p.Spec.MaintenanceWindows); err != nil {
+		log.Error(":: Invalid cluster provider maintenance windows ::", log.Fields{"Error": err})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Name is required.
 	if p.Metadata.Name == "" {
 		log.Error(":: Missing name in cluster provider POST request ::", log.Fields{"Error": err})
@@ -114,6 +124,12 @@ func (h clusterHandler) putClusterProviderHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	if err := schedule.ValidateWindows(p.Spec.MaintenanceWindows); err != nil {
+		log.Error(":: Invalid cluster provider maintenance windows ::", log.Fields{"Error": err})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Name is required.
 	if p.Metadata.Name == "" {
 		log.Error(":: Missing name in cluster provider POST request ::", log.Fields{"Error": err})
@@ -153,9 +169,17 @@ func (h clusterHandler) getClusterProviderHandler(w http.ResponseWriter, r *http
 	name := vars["clusterProvider"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetClusterProviders(ctx)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid get cluster providers list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListClusterProviders(ctx, opts)
 	} else {
 		ret, err = h.client.GetClusterProvider(ctx, name)
 	}
@@ -166,6 +190,7 @@ func (h clusterHandler) getClusterProviderHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -176,6 +201,22 @@ func (h clusterHandler) getClusterProviderHandler(w http.ResponseWriter, r *http
 	}
 }
 
+// Watch handles streaming the changes to a cluster provider and its resources
+func (h clusterHandler) watchClusterProviderHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	name := vars["clusterProvider"]
+
+	events, err := h.client.WatchClusterProvider(ctx, name)
+	if err != nil {
+		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
+		http.Error(w, apiErr.Message, apiErr.Status)
+		return
+	}
+
+	apiwatch.StreamEvents(w, events)
+}
+
 // Delete handles DELETE operations on a particular ClusterProvider  Name
 func (h clusterHandler) deleteClusterProviderHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -305,13 +346,21 @@ func (h clusterHandler) getClusterHandler(w http.ResponseWriter, r *http.Request
 	withLabels := r.URL.Query().Get("withLabels")
 	log.Warn("with Labels ", log.Fields{"val": withLabels})
 	if strings.ToLower(withLabels) == "true" && len(name) == 0 {
-		ret, err := h.client.GetAllClustersAndLabels(ctx, provider)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid get clusters list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ret, next, err := h.client.ListClustersAndLabels(ctx, provider, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
 			return
 		}
 
+		apilist.SetContinue(w, next)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		err = json.NewEncoder(w).Encode(ret)
@@ -326,6 +375,8 @@ func (h clusterHandler) getClusterHandler(w http.ResponseWriter, r *http.Request
 	label := r.URL.Query().Get("label")
 	log.Info(":: get clusters by label parameters ::", log.Fields{"label": label, "provider": provider, "cluster": name})
 	if len(label) != 0 && len(name) == 0 {
+		// the names of the clusters are paged in memory
+		apilist.InMemory(r)
 		ret, err := h.client.GetClustersWithLabel(ctx, provider, label)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
@@ -346,13 +397,21 @@ func (h clusterHandler) getClusterHandler(w http.ResponseWriter, r *http.Request
 
 	// handle the get all clusters case - return a list of only the json parts
 	if len(name) == 0 {
-		ret, err := h.client.GetClusters(ctx, provider)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid get clusters list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ret, next, err := h.client.ListClusters(ctx, provider, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
 			return
 		}
 
+		apilist.SetContinue(w, next)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		err = json.NewEncoder(w).Encode(ret)
@@ -482,6 +541,30 @@ func (h clusterHandler) deleteClusterHandler(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// getClusterStatusHandler handles GET operations on the status of a particular Cluster Name
+func (h clusterHandler) getClusterStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	provider := vars["clusterProvider"]
+	name := vars["cluster"]
+
+	ret, err := h.client.GetClusterStatus(ctx, provider, name)
+	if err != nil {
+		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
+		http.Error(w, apiErr.Message, apiErr.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(ret)
+	if err != nil {
+		log.Error(":: Error encoding cluster status response ::", log.Fields{"Error": err})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 // Create handles creation of the ClusterLabel entry in the database
 func (h clusterHandler) createClusterLabelHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -603,9 +686,17 @@ func (h clusterHandler) getClusterLabelHandler(w http.ResponseWriter, r *http.Re
 
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(label) == 0 {
-		ret, err = h.client.GetClusterLabels(ctx, provider, cluster)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid get cluster labels list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListClusterLabels(ctx, provider, cluster, opts)
 	} else {
 		ret, err = h.client.GetClusterLabel(ctx, provider, cluster, label)
 	}
@@ -616,6 +707,7 @@ func (h clusterHandler) getClusterLabelHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -768,9 +860,17 @@ func (h clusterHandler) getClusterKvPairsHandler(w http.ResponseWriter, r *http.
 
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(kvpair) == 0 {
-		ret, err = h.client.GetAllClusterKvPairs(ctx, provider, cluster)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid get cluster kv pairs list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListClusterKvPairs(ctx, provider, cluster, opts)
 	} else if len(kvkey) != 0 {
 		ret, err = h.client.GetClusterKvPairsValue(ctx, provider, cluster, kvpair, kvkey)
 	} else {
@@ -783,6 +883,7 @@ func (h clusterHandler) getClusterKvPairsHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -933,9 +1034,17 @@ func (h clusterHandler) getClusterSyncObjectsHandler(w http.ResponseWriter, r *h
 
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(syncobject) == 0 {
-		ret, err = h.client.GetAllClusterSyncObjects(ctx, provider)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid get cluster sync objects list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListClusterSyncObjects(ctx, provider, opts)
 	} else if len(syncobjectkey) != 0 {
 		ret, err = h.client.GetClusterSyncObjectsValue(ctx, provider, syncobject, syncobjectkey)
 	} else {
@@ -948,6 +1057,7 @@ func (h clusterHandler) getClusterSyncObjectsHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -16,6 +16,9 @@ import (
 	"testing"
 
 	"gitlab.com/project-emco/core/emco-base/src/clm/pkg/cluster"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/schedule"
 	types "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
 
@@ -32,11 +35,13 @@ type mockClusterManager struct {
 	ClusterItems            []cluster.Cluster
 	ClusterContentItems     []cluster.ClusterContent
 	ClusterStateInfo        []state.StateInfo
+	ClusterStatusItems      []cluster.ClusterStatus
 	ClusterLabelItems       []cluster.ClusterLabel
 	ClusterKvPairsItems     []cluster.ClusterKvPairs
 	ClusterSyncObjectsItems []types.ClusterSyncObjects
 	ClusterList             []string
 	ClusterWithLabels       []cluster.ClusterWithLabels
+	WatchEvents             []db.WatchEvent
 	Err                     error
 }
 
@@ -64,10 +69,28 @@ func (m *mockClusterManager) GetClusterProviders(ctx context.Context) ([]cluster
 	return m.ClusterProviderItems, nil
 }
 
+func (m *mockClusterManager) ListClusterProviders(ctx context.Context, opts db.FindOptions) ([]cluster.ClusterProvider, string, error) {
+	items, err := m.GetClusterProviders(ctx)
+	return items, "", err
+}
+
 func (m *mockClusterManager) DeleteClusterProvider(ctx context.Context, name string) error {
 	return m.Err
 }
 
+func (m *mockClusterManager) WatchClusterProvider(ctx context.Context, name string) (<-chan db.WatchEvent, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	events := make(chan db.WatchEvent, len(m.WatchEvents))
+	for _, e := range m.WatchEvents {
+		events <- e
+	}
+	close(events)
+	return events, nil
+}
+
 func (m *mockClusterManager) CreateCluster(ctx context.Context, provider string, inp cluster.Cluster, inq cluster.ClusterContent) (cluster.Cluster, error) {
 	if m.Err != nil {
 		return cluster.Cluster{}, m.Err
@@ -100,6 +123,14 @@ func (m *mockClusterManager) GetClusterState(ctx context.Context, provider, name
 	return m.ClusterStateInfo[0], nil
 }
 
+func (m *mockClusterManager) GetClusterStatus(ctx context.Context, provider, name string) (cluster.ClusterStatus, error) {
+	if m.Err != nil {
+		return cluster.ClusterStatus{}, m.Err
+	}
+
+	return m.ClusterStatusItems[0], nil
+}
+
 func (m *mockClusterManager) GetClusters(ctx context.Context, provider string) ([]cluster.Cluster, error) {
 	if m.Err != nil {
 		return []cluster.Cluster{}, m.Err
@@ -108,6 +139,18 @@ func (m *mockClusterManager) GetClusters(ctx context.Context, provider string) (
 	return m.ClusterItems, nil
 }
 
+func (m *mockClusterManager) ListClusters(ctx context.Context, provider string, opts db.FindOptions) ([]cluster.Cluster, string, error) {
+	if m.Err != nil {
+		return []cluster.Cluster{}, "", m.Err
+	}
+
+	if opts.Limit > 0 && int64(len(m.ClusterItems)) > opts.Limit {
+		return m.ClusterItems[:opts.Limit], "next", nil
+	}
+
+	return m.ClusterItems, "", nil
+}
+
 func (m *mockClusterManager) GetClustersWithLabel(ctx context.Context, provider, label string) ([]string, error) {
 	if m.Err != nil {
 		return []string{}, m.Err
@@ -123,6 +166,11 @@ func (m *mockClusterManager) GetAllClustersAndLabels(ctx context.Context, provid
 	return m.ClusterWithLabels, nil
 }
 
+func (m *mockClusterManager) ListClustersAndLabels(ctx context.Context, provider string, opts db.FindOptions) ([]cluster.ClusterWithLabels, string, error) {
+	items, err := m.GetAllClustersAndLabels(ctx, provider)
+	return items, "", err
+}
+
 func (m *mockClusterManager) DeleteCluster(ctx context.Context, provider, name string) error {
 	return m.Err
 }
@@ -151,6 +199,11 @@ func (m *mockClusterManager) GetClusterLabels(ctx context.Context, provider, clu
 	return m.ClusterLabelItems, nil
 }
 
+func (m *mockClusterManager) ListClusterLabels(ctx context.Context, provider, clusterName string, opts db.FindOptions) ([]cluster.ClusterLabel, string, error) {
+	items, err := m.GetClusterLabels(ctx, provider, clusterName)
+	return items, "", err
+}
+
 func (m *mockClusterManager) DeleteClusterLabel(ctx context.Context, provider, clusterName, label string) error {
 	return m.Err
 }
@@ -193,6 +246,11 @@ func (m *mockClusterManager) GetAllClusterKvPairs(ctx context.Context, provider,
 	return m.ClusterKvPairsItems, nil
 }
 
+func (m *mockClusterManager) ListClusterKvPairs(ctx context.Context, provider, clusterName string, opts db.FindOptions) ([]cluster.ClusterKvPairs, string, error) {
+	items, err := m.GetAllClusterKvPairs(ctx, provider, clusterName)
+	return items, "", err
+}
+
 func (m *mockClusterManager) DeleteClusterKvPairs(ctx context.Context, provider, clusterName, kvpair string) error {
 	return m.Err
 }
@@ -238,8 +296,13 @@ func (m *mockClusterManager) GetAllClusterSyncObjects(ctx context.Context, provi
 	return m.ClusterSyncObjectsItems, nil
 }
 
+func (m *mockClusterManager) ListClusterSyncObjects(ctx context.Context, provider string, opts db.FindOptions) ([]types.ClusterSyncObjects, string, error) {
+	items, err := m.GetAllClusterSyncObjects(ctx, provider)
+	return items, "", err
+}
+
 func init() {
-	cpJSONFile = "../json-schemas/metadata.json"
+	cpJSONFile = "../json-schemas/cluster-provider.json"
 	ckvJSONFile = "../json-schemas/cluster-kv.json"
 	clJSONFile = "../json-schemas/cluster-label.json"
 	copsJSONFile = "../json-schemas/cluster-gitops.json"
@@ -291,6 +354,66 @@ func TestClusterProviderCreateHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			label:        "Create Cluster Provider With Maintenance Windows",
+			expectedCode: http.StatusCreated,
+			reader: bytes.NewBuffer([]byte(`{
+					"metadata": {
+						"name": "clusterProviderTest"
+					},
+					"spec": {
+						"maintenanceWindows": [
+							{
+								"schedule": "0 22 * * 1-5",
+								"durationMinutes": 360,
+								"timeZone": "Europe/Paris"
+							}
+						]
+					}
+				}`)),
+			expected: cluster.ClusterProvider{
+				Metadata: types.Metadata{
+					Name: "clusterProviderTest",
+				},
+				Spec: cluster.ClusterProviderSpec{
+					MaintenanceWindows: []schedule.Window{
+						{Schedule: "0 22 * * 1-5", DurationMinutes: 360, TimeZone: "Europe/Paris"},
+					},
+				},
+			},
+			clusterClient: &mockClusterManager{
+				ClusterProviderItems: []cluster.ClusterProvider{
+					{
+						Metadata: types.Metadata{
+							Name: "clusterProviderTest",
+						},
+						Spec: cluster.ClusterProviderSpec{
+							MaintenanceWindows: []schedule.Window{
+								{Schedule: "0 22 * * 1-5", DurationMinutes: 360, TimeZone: "Europe/Paris"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			label:        "Invalid Maintenance Window Schedule",
+			expectedCode: http.StatusBadRequest,
+			reader: bytes.NewBuffer([]byte(`{
+					"metadata": {
+						"name": "clusterProviderTest"
+					},
+					"spec": {
+						"maintenanceWindows": [
+							{
+								"schedule": "0 25 * * *",
+								"durationMinutes": 60
+							}
+						]
+					}
+				}`)),
+			clusterClient: &mockClusterManager{},
+		},
 		{
 			label: "Missing ClusterProvider Name in Request Body",
 			reader: bytes.NewBuffer([]byte(`{
@@ -645,6 +768,71 @@ func TestClusterProviderDeleteHandler(t *testing.T) {
 	}
 }
 
+func TestClusterProviderWatchHandler(t *testing.T) {
+
+	testCases := []struct {
+		label         string
+		name          string
+		expected      []db.WatchEvent
+		expectedCode  int
+		clusterClient *mockClusterManager
+	}{
+		{
+			label:        "Watch Cluster Provider",
+			expectedCode: http.StatusOK,
+			name:         "testClusterProvider",
+			expected: []db.WatchEvent{
+				{Type: db.WatchEventInsert, Key: map[string]string{"clusterProvider": "testClusterProvider", "cluster": "testCluster"}},
+				{Type: db.WatchEventUpdate, Key: map[string]string{"clusterProvider": "testClusterProvider", "cluster": "testCluster", "clusterLabel": "edge"}},
+			},
+			clusterClient: &mockClusterManager{
+				WatchEvents: []db.WatchEvent{
+					{Type: db.WatchEventInsert, Key: map[string]string{"clusterProvider": "testClusterProvider", "cluster": "testCluster"}},
+					{Type: db.WatchEventUpdate, Key: map[string]string{"clusterProvider": "testClusterProvider", "cluster": "testCluster", "clusterLabel": "edge"}},
+				},
+			},
+		},
+		{
+			label:        "Watch Non-Existing Cluster Provider",
+			expectedCode: http.StatusNotFound,
+			name:         "testClusterProvider",
+			clusterClient: &mockClusterManager{
+				Err: pkgerrors.New("Cluster provider not found"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/v2/cluster-providers/"+testCase.name+"/watch", nil)
+			resp := executeRequest(request, NewRouter(testCase.clusterClient))
+
+			//Check returned code
+			if resp.StatusCode != testCase.expectedCode {
+				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
+			}
+
+			//Check returned events only if statusOK
+			if resp.StatusCode == http.StatusOK {
+				var got []db.WatchEvent
+				dec := json.NewDecoder(resp.Body)
+				for dec.More() {
+					e := db.WatchEvent{}
+					if err := dec.Decode(&e); err != nil {
+						t.Fatalf("Error decoding watch event: %s", err)
+					}
+					got = append(got, e)
+				}
+
+				if reflect.DeepEqual(testCase.expected, got) == false {
+					t.Errorf("watchHandler returned unexpected events: got %v;"+
+						" expected %v", got, testCase.expected)
+				}
+			}
+		})
+	}
+}
+
 func TestClusterCreateHandler(t *testing.T) {
 	testCases := []struct {
 		label         string
@@ -796,6 +984,7 @@ func TestClusterGetAllHandler(t *testing.T) {
 
 	testCases := []struct {
 		label         string
+		query, next   string
 		expected      []cluster.Cluster
 		name, version string
 		expectedCode  int
@@ -853,11 +1042,44 @@ func TestClusterGetAllHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			label:        "Get First Page Of Clusters",
+			query:        "?limit=1&filter=state=Created",
+			next:         "next",
+			expectedCode: http.StatusOK,
+			expected: []cluster.Cluster{
+				{
+					Metadata: types.Metadata{
+						Name: "testCluster1",
+					},
+				},
+			},
+			clusterClient: &mockClusterManager{
+				ClusterItems: []cluster.Cluster{
+					{
+						Metadata: types.Metadata{
+							Name: "testCluster1",
+						},
+					},
+					{
+						Metadata: types.Metadata{
+							Name: "testCluster2",
+						},
+					},
+				},
+			},
+		},
+		{
+			label:         "Get Clusters With Invalid Continue Token",
+			query:         "?continue=not-a-token",
+			expectedCode:  http.StatusBadRequest,
+			clusterClient: &mockClusterManager{},
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.label, func(t *testing.T) {
-			request := httptest.NewRequest("GET", "/v2/cluster-providers/clusterProvder1/clusters", nil)
+			request := httptest.NewRequest("GET", "/v2/cluster-providers/clusterProvder1/clusters"+testCase.query, nil)
 			resp := executeRequest(request, NewRouter(testCase.clusterClient))
 
 			//Check returned code
@@ -865,6 +1087,11 @@ func TestClusterGetAllHandler(t *testing.T) {
 				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
 			}
 
+			//Check the token of the next page
+			if next := resp.Header.Get(apilist.ContinueHeader); next != testCase.next {
+				t.Fatalf("Expected continue token %q; Got: %q", testCase.next, next)
+			}
+
 			//Check returned body only if statusOK
 			if resp.StatusCode == http.StatusOK {
 				got := []cluster.Cluster{}
@@ -1186,6 +1413,82 @@ func TestClusterDeleteHandler(t *testing.T) {
 	}
 }
 
+func TestClusterStatusGetHandler(t *testing.T) {
+
+	testCases := []struct {
+		label         string
+		expected      cluster.ClusterStatus
+		name          string
+		expectedCode  int
+		clusterClient *mockClusterManager
+	}{
+		{
+			label:        "Get Cluster Status",
+			expectedCode: http.StatusOK,
+			expected: cluster.ClusterStatus{
+				Health:            cluster.ClusterHealthHealthy,
+				Reachable:         true,
+				KubernetesVersion: "v1.23.3",
+				NodeCount:         2,
+				ReadyNodeCount:    2,
+				Allocatable:       cluster.ClusterResources{CPU: "8", Memory: "32Gi"},
+			},
+			name: "testCluster",
+			clusterClient: &mockClusterManager{
+				ClusterStatusItems: []cluster.ClusterStatus{
+					{
+						Health:            cluster.ClusterHealthHealthy,
+						Reachable:         true,
+						KubernetesVersion: "v1.23.3",
+						NodeCount:         2,
+						ReadyNodeCount:    2,
+						Allocatable:       cluster.ClusterResources{CPU: "8", Memory: "32Gi"},
+					},
+				},
+			},
+		},
+		{
+			label:        "Get Status of Non-Probed Cluster",
+			expectedCode: http.StatusNotFound,
+			name:         "nonProbedCluster",
+			clusterClient: &mockClusterManager{
+				Err: pkgerrors.New("Cluster status not found"),
+			},
+		},
+		{
+			label:        "Get Status of Non-Existing Cluster",
+			expectedCode: http.StatusNotFound,
+			name:         "nonExistingCluster",
+			clusterClient: &mockClusterManager{
+				Err: pkgerrors.New("Cluster not found"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/v2/cluster-providers/clusterProvider1/clusters/"+testCase.name+"/status", nil)
+			resp := executeRequest(request, NewRouter(testCase.clusterClient))
+
+			//Check returned code
+			if resp.StatusCode != testCase.expectedCode {
+				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
+			}
+
+			//Check returned body only if statusOK
+			if resp.StatusCode == http.StatusOK {
+				got := cluster.ClusterStatus{}
+				json.NewDecoder(resp.Body).Decode(&got)
+
+				if reflect.DeepEqual(testCase.expected, got) == false {
+					t.Errorf("getClusterStatusHandler returned unexpected body: got %v;"+
+						" expected %v", got, testCase.expected)
+				}
+			}
+		})
+	}
+}
+
 func TestClusterLabelCreateHandler(t *testing.T) {
 	testCases := []struct {
 		label         string
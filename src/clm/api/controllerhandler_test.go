@@ -16,6 +16,7 @@ import (
 
 	pkgerrors "github.com/pkg/errors"
 	clmModel "gitlab.com/project-emco/core/emco-base/src/clm/pkg/model"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	mtypes "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 )
 
@@ -701,6 +702,11 @@ func (m *mockControllerManager) GetControllers(ctx context.Context) ([]clmModel.
 	return []clmModel.Controller{}, m.Err
 }
 
+func (m *mockControllerManager) ListControllers(ctx context.Context, opts db.FindOptions) ([]clmModel.Controller, string, error) {
+	items, err := m.GetControllers(ctx)
+	return items, "", err
+}
+
 func (m *mockControllerManager) DeleteController(ctx context.Context, name string) error {
 	return m.Err
 }
@@ -12,7 +12,9 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"gitlab.com/project-emco/core/emco-base/src/clm/api"
+	"gitlab.com/project-emco/core/emco-base/src/clm/pkg/cluster"
 	"gitlab.com/project-emco/core/emco-base/src/clm/pkg/metrics"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 	contextDb "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
@@ -37,6 +39,13 @@ func main() {
 
 	prometheus.MustRegister(metrics.CLPGauge)
 	prometheus.MustRegister(metrics.ClusterGauge)
+	prometheus.MustRegister(metrics.ClusterReachableGauge)
+
+	// probe the health of the clusters, every minute by default
+	if probeInterval := config.GetConfiguration().ClusterProbeInterval; probeInterval > 0 {
+		go cluster.NewClusterClient().RunHealthProbes(ctx, time.Duration(probeInterval)*time.Second,
+			config.GetConfiguration().ClusterProbeWorkers)
+	}
 
 	server, err := controller.NewControllerServer("clm",
 		api.NewRouter(nil),
@@ -11,6 +11,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/schedule"
 	mtypes "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
 	rsync "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/db"
@@ -27,11 +28,19 @@ type clientDbInfo struct {
 	storeName string // name of the mongodb collection to use for client documents
 	tagMeta   string // attribute key name for the json data of a client document
 	tagState  string // attribute key name for StateInfo object in the cluster
+	tagStatus string // attribute key name for the ClusterStatus of the cluster
+	tagDelete string // attribute key name marking the cluster as being deleted
 }
 
 // ClusterProvider contains the parameters needed for ClusterProviders
 type ClusterProvider struct {
-	Metadata mtypes.Metadata `json:"metadata"`
+	Metadata mtypes.Metadata     `json:"metadata"`
+	Spec     ClusterProviderSpec `json:"spec,omitempty"`
+}
+
+// ClusterProviderSpec has the maintenance windows of the clusters of the provider
+type ClusterProviderSpec struct {
+	MaintenanceWindows []schedule.Window `json:"maintenanceWindows,omitempty"`
 }
 
 type Cluster struct {
@@ -96,33 +105,84 @@ const SEPARATOR = "+"
 const CONTEXT_CLUSTER_APP = "network-intents"
 const CONTEXT_CLUSTER_RESOURCE = "network-intents"
 
+// clusterProviderSelectors are the fields a list of ClusterProviders can be filtered and sorted on
+var clusterProviderSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": ClusterProvider{},
+	},
+}
+
+// clusterLabelSelectors are the fields a list of ClusterLabels can be filtered and sorted on
+var clusterLabelSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.clusterLabel",
+	},
+	TagTypes: map[string]interface{}{
+		"data": ClusterLabel{},
+	},
+}
+
+// clusterKvPairsSelectors are the fields a list of ClusterKvPairs can be filtered and sorted on
+var clusterKvPairsSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": ClusterKvPairs{},
+	},
+}
+
+// clusterSelectors are the fields a list of Clusters can be filtered and sorted on
+var clusterSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":       "data.metadata.name",
+		"gitOpsType": "data.spec.gitOps.gitOpsType",
+		"state":      "stateInfo.actions.-1.state",
+	},
+	TagTypes: map[string]interface{}{
+		"data":      Cluster{},
+		"stateInfo": state.StateInfo{},
+	},
+}
+
 // ClusterManager is an interface exposes the Cluster functionality
 type ClusterManager interface {
 	CreateClusterProvider(ctx context.Context, pr ClusterProvider, exists bool) (ClusterProvider, error)
 	GetClusterProvider(ctx context.Context, name string) (ClusterProvider, error)
 	GetClusterProviders(ctx context.Context) ([]ClusterProvider, error)
+	ListClusterProviders(ctx context.Context, opts db.FindOptions) ([]ClusterProvider, string, error)
 	DeleteClusterProvider(ctx context.Context, name string) error
+	WatchClusterProvider(ctx context.Context, name string) (<-chan db.WatchEvent, error)
 	CreateCluster(ctx context.Context, provider string, pr Cluster, qr ClusterContent) (Cluster, error)
 	GetCluster(ctx context.Context, provider, name string) (Cluster, error)
 	GetClusterContent(ctx context.Context, provider, name string) (ClusterContent, error)
 	GetClusterState(ctx context.Context, provider, name string) (state.StateInfo, error)
+	GetClusterStatus(ctx context.Context, provider, name string) (ClusterStatus, error)
 	GetClusters(ctx context.Context, provider string) ([]Cluster, error)
+	ListClusters(ctx context.Context, provider string, opts db.FindOptions) ([]Cluster, string, error)
 	GetClustersWithLabel(ctx context.Context, provider, label string) ([]string, error)
 	GetAllClustersAndLabels(ctx context.Context, provider string) ([]ClusterWithLabels, error)
+	ListClustersAndLabels(ctx context.Context, provider string, opts db.FindOptions) ([]ClusterWithLabels, string, error)
 	DeleteCluster(ctx context.Context, provider, name string) error
 	CreateClusterLabel(ctx context.Context, provider, cluster string, pr ClusterLabel, exists bool) (ClusterLabel, error)
 	GetClusterLabel(ctx context.Context, provider, cluster, label string) (ClusterLabel, error)
 	GetClusterLabels(ctx context.Context, provider, cluster string) ([]ClusterLabel, error)
+	ListClusterLabels(ctx context.Context, provider, cluster string, opts db.FindOptions) ([]ClusterLabel, string, error)
 	DeleteClusterLabel(ctx context.Context, provider, cluster, label string) error
 	CreateClusterKvPairs(ctx context.Context, provider, cluster string, pr ClusterKvPairs, exists bool) (ClusterKvPairs, error)
 	GetClusterKvPairs(ctx context.Context, provider, cluster, kvpair string) (ClusterKvPairs, error)
 	GetClusterKvPairsValue(ctx context.Context, provider, cluster, kvpair, kvkey string) (interface{}, error)
 	GetAllClusterKvPairs(ctx context.Context, provider, cluster string) ([]ClusterKvPairs, error)
+	ListClusterKvPairs(ctx context.Context, provider, cluster string, opts db.FindOptions) ([]ClusterKvPairs, string, error)
 	DeleteClusterKvPairs(ctx context.Context, provider, cluster, kvpair string) error
 	CreateClusterSyncObjects(ctx context.Context, provider string, pr mtypes.ClusterSyncObjects, exists bool) (mtypes.ClusterSyncObjects, error)
 	GetClusterSyncObjects(ctx context.Context, provider, syncobject string) (mtypes.ClusterSyncObjects, error)
 	GetClusterSyncObjectsValue(ctx context.Context, provider, syncobject, syncobjectkey string) (interface{}, error)
 	GetAllClusterSyncObjects(ctx context.Context, provider string) ([]mtypes.ClusterSyncObjects, error)
+	ListClusterSyncObjects(ctx context.Context, provider string, opts db.FindOptions) ([]mtypes.ClusterSyncObjects, string, error)
 	DeleteClusterSyncObjects(ctx context.Context, provider, syncobject string) error
 }
 
@@ -140,6 +200,8 @@ func NewClusterClient() *ClusterClient {
 			storeName: "resources",
 			tagMeta:   "data",
 			tagState:  "stateInfo",
+			tagStatus: "clusterStatus",
+			tagDelete: "clusterDeleting",
 		},
 	}
 }
@@ -196,15 +258,26 @@ func (v *ClusterClient) GetClusterProvider(ctx context.Context, name string) (Cl
 
 // GetClusterProviderList returns all of the ClusterProvider for corresponding name
 func (v *ClusterClient) GetClusterProviders(ctx context.Context) ([]ClusterProvider, error) {
+	res, _, err := v.ListClusterProviders(ctx, db.FindOptions{})
+	return res, err
+}
+
+// ListClusterProviders returns a page of the ClusterProviders, filtered and
+// sorted on the clusterProviderSelectors, and the token of the next page
+func (v *ClusterClient) ListClusterProviders(ctx context.Context, opts db.FindOptions) ([]ClusterProvider, string, error) {
+	opts, err := clusterProviderSelectors.Resolve(opts)
+	if err != nil {
+		return []ClusterProvider{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := ClusterProviderKey{
 		ClusterProviderName: "",
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []ClusterProvider{}, err
+		return []ClusterProvider{}, "", err
 	}
 
 	resp := make([]ClusterProvider, 0)
@@ -212,12 +285,12 @@ func (v *ClusterClient) GetClusterProviders(ctx context.Context) ([]ClusterProvi
 		cp := ClusterProvider{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []ClusterProvider{}, err
+			return []ClusterProvider{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteClusterProvider the  ClusterProvider from database
@@ -232,6 +305,24 @@ func (v *ClusterClient) DeleteClusterProvider(ctx context.Context, name string)
 	return err
 }
 
+// WatchClusterProvider reports the changes to the ClusterProvider and to all
+// its clusters, labels, kv pairs and sync objects until the context is done
+func (v *ClusterClient) WatchClusterProvider(ctx context.Context, name string) (<-chan db.WatchEvent, error) {
+
+	//Check if this ClusterProvider exists
+	_, err := v.GetClusterProvider(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	//Construct key to select the entries
+	key := ClusterProviderKey{
+		ClusterProviderName: name,
+	}
+
+	return db.DBconn.Watch(ctx, v.db.storeName, key)
+}
+
 // CreateCluster - create a new Cluster for a cluster-provider
 func (v *ClusterClient) CreateCluster(ctx context.Context, provider string, p Cluster, q ClusterContent) (Cluster, error) {
 
@@ -373,21 +464,33 @@ func (v *ClusterClient) GetClusterState(ctx context.Context, provider, name stri
 
 // GetClusters returns all the Clusters for corresponding provider
 func (v *ClusterClient) GetClusters(ctx context.Context, provider string) ([]Cluster, error) {
+	resp, _, err := v.ListClusters(ctx, provider, db.FindOptions{})
+	return resp, err
+}
+
+// ListClusters returns a page of the Clusters for corresponding provider, filtered and
+// sorted on the clusterSelectors, and the token of the next page
+func (v *ClusterClient) ListClusters(ctx context.Context, provider string, opts db.FindOptions) ([]Cluster, string, error) {
 	//Construct key and tag to select the entry
 	key := ClusterKey{
 		ClusterProviderName: provider,
 		ClusterName:         "",
 	}
 
+	opts, err := clusterSelectors.Resolve(opts)
+	if err != nil {
+		return []Cluster{}, "", err
+	}
+
 	//Verify Cluster provider exists
-	_, err := v.GetClusterProvider(ctx, provider)
+	_, err = v.GetClusterProvider(ctx, provider)
 	if err != nil {
-		return []Cluster{}, err
+		return []Cluster{}, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []Cluster{}, err
+		return []Cluster{}, "", err
 	}
 
 	resp := make([]Cluster, 0)
@@ -395,21 +498,28 @@ func (v *ClusterClient) GetClusters(ctx context.Context, provider string) ([]Clu
 		cp := Cluster{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []Cluster{}, err
+			return []Cluster{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // GetAllClustersAndLabels returns all the the clusters and their labels
 func (v *ClusterClient) GetAllClustersAndLabels(ctx context.Context, provider string) ([]ClusterWithLabels, error) {
+	resp, _, err := v.ListClustersAndLabels(ctx, provider, db.FindOptions{})
+	return resp, err
+}
 
-	// Get All clusters
-	cl, err := v.GetClusters(ctx, provider)
+// ListClustersAndLabels returns a page of the clusters, filtered and sorted on
+// the clusterSelectors, with their labels, and the token of the next page
+func (v *ClusterClient) ListClustersAndLabels(ctx context.Context, provider string, opts db.FindOptions) ([]ClusterWithLabels, string, error) {
+
+	// Get the page of clusters
+	cl, next, err := v.ListClusters(ctx, provider, opts)
 	if err != nil {
-		return []ClusterWithLabels{}, err
+		return []ClusterWithLabels{}, "", err
 	}
 
 	resp := make([]ClusterWithLabels, len(cl))
@@ -419,10 +529,10 @@ func (v *ClusterClient) GetAllClustersAndLabels(ctx context.Context, provider st
 		resp[k].Metadata = value.Metadata
 		resp[k].Labels, err = v.GetClusterLabels(ctx, provider, value.Metadata.Name)
 		if err != nil {
-			return []ClusterWithLabels{}, err
+			return []ClusterWithLabels{}, "", err
 		}
 	}
-	return resp, nil
+	return resp, next, nil
 }
 
 // GetClustersWithLabel returns all the Clusters with Labels for provider
@@ -502,9 +612,26 @@ func (v *ClusterClient) DeleteCluster(ctx context.Context, provider, name string
 		}
 	}
 
-	// Remove the cluster resource
-	err = db.DBconn.Remove(ctx, v.db.storeName, key)
+	// Stop the probes from setting the health of the cluster, its health
+	// label is a child of the cluster
+	err = db.DBconn.Insert(ctx, v.db.storeName, key, nil, v.db.tagDelete, true)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error marking the Cluster as being deleted")
+	}
+
+	// Remove the health and the cluster resource, a probe that started before
+	// the cluster was marked may set the health label once more
+	for attempt := 1; ; attempt++ {
+		err = v.removeClusterHealth(ctx, provider, name)
+		if err == nil {
+			err = db.DBconn.Remove(ctx, v.db.storeName, key)
+		}
+		if err == nil || attempt == 2 {
+			break
+		}
+	}
 	if err != nil {
+		db.DBconn.RemoveTag(ctx, v.db.storeName, key, v.db.tagDelete)
 		return pkgerrors.Wrap(err, "Delete Cluster Entry;")
 	}
 
@@ -584,6 +711,18 @@ func (v *ClusterClient) GetClusterLabel(ctx context.Context, provider, cluster,
 
 // GetClusterLabels returns the Cluster Labels for corresponding provider and cluster
 func (v *ClusterClient) GetClusterLabels(ctx context.Context, provider, cluster string) ([]ClusterLabel, error) {
+	res, _, err := v.ListClusterLabels(ctx, provider, cluster, db.FindOptions{})
+	return res, err
+}
+
+// ListClusterLabels returns a page of the Cluster Labels of the cluster, filtered
+// and sorted on the clusterLabelSelectors, and the token of the next page
+func (v *ClusterClient) ListClusterLabels(ctx context.Context, provider, cluster string, opts db.FindOptions) ([]ClusterLabel, string, error) {
+	opts, err := clusterLabelSelectors.Resolve(opts)
+	if err != nil {
+		return []ClusterLabel{}, "", err
+	}
+
 	// Construct key and tag to select the entry
 	key := ClusterLabelKey{
 		ClusterProviderName: provider,
@@ -592,14 +731,14 @@ func (v *ClusterClient) GetClusterLabels(ctx context.Context, provider, cluster
 	}
 
 	// Verify Cluster already exists
-	_, err := v.GetCluster(ctx, provider, cluster)
+	_, err = v.GetCluster(ctx, provider, cluster)
 	if err != nil {
-		return []ClusterLabel{}, err
+		return []ClusterLabel{}, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []ClusterLabel{}, err
+		return []ClusterLabel{}, "", err
 	}
 
 	resp := make([]ClusterLabel, 0)
@@ -607,12 +746,12 @@ func (v *ClusterClient) GetClusterLabels(ctx context.Context, provider, cluster
 		cp := ClusterLabel{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []ClusterLabel{}, err
+			return []ClusterLabel{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteClusterLabel ... Delete the Cluster Label from database
@@ -718,6 +857,18 @@ func (v *ClusterClient) GetClusterKvPairsValue(ctx context.Context, provider, cl
 
 // GetAllClusterKvPairs returns the Cluster Kv Pairs for corresponding provider and cluster
 func (v *ClusterClient) GetAllClusterKvPairs(ctx context.Context, provider, cluster string) ([]ClusterKvPairs, error) {
+	res, _, err := v.ListClusterKvPairs(ctx, provider, cluster, db.FindOptions{})
+	return res, err
+}
+
+// ListClusterKvPairs returns a page of the Cluster Kv Pairs of the cluster,
+// filtered and sorted on the clusterKvPairsSelectors, and the token of the next page
+func (v *ClusterClient) ListClusterKvPairs(ctx context.Context, provider, cluster string, opts db.FindOptions) ([]ClusterKvPairs, string, error) {
+	opts, err := clusterKvPairsSelectors.Resolve(opts)
+	if err != nil {
+		return []ClusterKvPairs{}, "", err
+	}
+
 	//Construct key and tag to select the entry
 	key := ClusterKvPairsKey{
 		ClusterProviderName: provider,
@@ -726,14 +877,14 @@ func (v *ClusterClient) GetAllClusterKvPairs(ctx context.Context, provider, clus
 	}
 
 	// Verify Cluster exists
-	_, err := v.GetCluster(ctx, provider, cluster)
+	_, err = v.GetCluster(ctx, provider, cluster)
 	if err != nil {
-		return []ClusterKvPairs{}, err
+		return []ClusterKvPairs{}, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []ClusterKvPairs{}, err
+		return []ClusterKvPairs{}, "", err
 	}
 
 	resp := make([]ClusterKvPairs, 0)
@@ -741,12 +892,12 @@ func (v *ClusterClient) GetAllClusterKvPairs(ctx context.Context, provider, clus
 		cp := ClusterKvPairs{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []ClusterKvPairs{}, err
+			return []ClusterKvPairs{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteClusterKvPairs the  ClusterKvPairs from database
@@ -824,3 +975,16 @@ func (v *ClusterClient) GetAllClusterSyncObjects(ctx context.Context, provider s
 	// Get from rysn db
 	return ccc.GetAllClusterSyncObjects(ctx, provider)
 }
+
+// ListClusterSyncObjects returns a page of the Cluster Sync Objects for
+// corresponding provider and the token of the next page
+func (v *ClusterClient) ListClusterSyncObjects(ctx context.Context, provider string, opts db.FindOptions) ([]mtypes.ClusterSyncObjects, string, error) {
+	// Verify Cluster Provider exists
+	_, err := v.GetClusterProvider(ctx, provider)
+	if err != nil {
+		return []mtypes.ClusterSyncObjects{}, "", err
+	}
+	ccc := rsync.NewCloudConfigClient()
+	// Get from rysn db
+	return ccc.ListClusterSyncObjects(ctx, provider, opts)
+}
@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/lease"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// The health of a cluster
+const (
+	ClusterHealthHealthy     = "Healthy"
+	ClusterHealthDegraded    = "Degraded"
+	ClusterHealthUnreachable = "Unreachable"
+	ClusterHealthUnknown     = "Unknown"
+)
+
+// HealthLabelPrefix is the prefix of the label CLM sets on the probed clusters,
+// e.g. "health.unreachable"
+const HealthLabelPrefix = "health."
+
+// probeTimeout bounds the requests made to the cluster API server by a probe
+const probeTimeout = 10 * time.Second
+
+// ClusterStatus is the health and capacity of a cluster, as last probed by CLM
+type ClusterStatus struct {
+	Health             string           `json:"health"`
+	Reachable          bool             `json:"reachable"`
+	Message            string           `json:"message,omitempty"`
+	KubernetesVersion  string           `json:"kubernetesVersion,omitempty"`
+	NodeCount          int              `json:"nodeCount"`
+	ReadyNodeCount     int              `json:"readyNodeCount"`
+	Allocatable        ClusterResources `json:"allocatable"`
+	LastProbeTime      time.Time        `json:"lastProbeTime"`
+	LastTransitionTime time.Time        `json:"lastTransitionTime"`
+}
+
+// ClusterResources is the total allocatable CPU and memory of the cluster nodes
+type ClusterResources struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+// probeCluster queries the API server of the cluster with the kubeconfig
+var probeCluster = func(ctx context.Context, kubeconfig []byte) (ClusterStatus, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return ClusterStatus{}, pkgerrors.Wrap(err, "Invalid kubeconfig")
+	}
+	config.Timeout = probeTimeout
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return ClusterStatus{}, err
+	}
+
+	version, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return ClusterStatus{}, err
+	}
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return ClusterStatus{}, err
+	}
+
+	s := ClusterStatus{Reachable: true, KubernetesVersion: version.GitVersion, NodeCount: len(nodes.Items)}
+	var cpu, memory resource.Quantity
+	for _, n := range nodes.Items {
+		for _, c := range n.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+				s.ReadyNodeCount++
+			}
+		}
+		cpu.Add(n.Status.Allocatable[corev1.ResourceCPU])
+		memory.Add(n.Status.Allocatable[corev1.ResourceMemory])
+	}
+	s.Allocatable = ClusterResources{CPU: cpu.String(), Memory: memory.String()}
+	return s, nil
+}
+
+// GetClusterStatus returns the last probed status of the cluster
+func (v *ClusterClient) GetClusterStatus(ctx context.Context, provider, name string) (ClusterStatus, error) {
+	//Construct key and tag to select the entry
+	key := ClusterKey{
+		ClusterProviderName: provider,
+		ClusterName:         name,
+	}
+
+	// Verify Cluster exists
+	_, err := v.GetCluster(ctx, provider, name)
+	if err != nil {
+		return ClusterStatus{}, err
+	}
+
+	value, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagStatus)
+	if err != nil {
+		return ClusterStatus{}, err
+	} else if len(value) == 0 || value[0] == nil {
+		return ClusterStatus{}, pkgerrors.New("Cluster status not found")
+	}
+
+	s := ClusterStatus{}
+	err = db.DBconn.Unmarshal(value[0], &s)
+	if err != nil {
+		return ClusterStatus{}, err
+	}
+	return s, nil
+}
+
+// ProbeCluster probes the cluster, stores its status and sets its health label.
+// GitOps clusters and clusters without a kubeconfig are not probed, their
+// health is Unknown.
+func (v *ClusterClient) ProbeCluster(ctx context.Context, provider, name string) (ClusterStatus, error) {
+	c, err := v.GetCluster(ctx, provider, name)
+	if err != nil {
+		return ClusterStatus{}, err
+	}
+
+	var s ClusterStatus
+	var kubeconfig []byte
+	if c.Spec.Props.GitOpsType == "" {
+		content, err := v.GetClusterContent(ctx, provider, name)
+		if err != nil {
+			return ClusterStatus{}, err
+		}
+		kubeconfig, err = base64.StdEncoding.DecodeString(content.Kubeconfig)
+		if err != nil {
+			return ClusterStatus{}, pkgerrors.Wrap(err, "Error decoding the kubeconfig of cluster "+name)
+		}
+	}
+
+	if len(kubeconfig) == 0 {
+		s = ClusterStatus{Health: ClusterHealthUnknown, Message: "Cluster has no kubeconfig to probe"}
+	} else {
+		s, err = probeCluster(ctx, kubeconfig)
+		switch {
+		case err != nil:
+			s = ClusterStatus{Health: ClusterHealthUnreachable, Message: err.Error()}
+		case s.ReadyNodeCount < s.NodeCount:
+			s.Health = ClusterHealthDegraded
+		default:
+			s.Health = ClusterHealthHealthy
+		}
+	}
+
+	s.LastProbeTime = time.Now()
+	s.LastTransitionTime = s.LastProbeTime
+	if prev, err := v.GetClusterStatus(ctx, provider, name); err == nil && prev.Health == s.Health {
+		s.LastTransitionTime = prev.LastTransitionTime
+	}
+
+	//Construct key and tag to select the entry
+	key := ClusterKey{
+		ClusterProviderName: provider,
+		ClusterName:         name,
+	}
+	// the status and the label are upserted, so they are written only while
+	// the cluster exists and isn't being deleted, and removed if it was
+	// deleted meanwhile
+	if _, err := v.checkClusterProbed(ctx, key); err != nil {
+		return ClusterStatus{}, err
+	}
+	err = db.DBconn.Insert(ctx, v.db.storeName, key, nil, v.db.tagStatus, s)
+	if err != nil {
+		return ClusterStatus{}, pkgerrors.Wrap(err, "Creating DB Entry")
+	}
+
+	err = v.setHealthLabel(ctx, provider, name, s.Health)
+	if err != nil {
+		return ClusterStatus{}, pkgerrors.Wrap(err, "Error setting the health label of cluster "+name)
+	}
+
+	if exists, err := v.checkClusterProbed(ctx, key); err != nil {
+		if exists {
+			// the cluster being deleted removes its health
+			v.removeClusterHealth(ctx, provider, name)
+		} else {
+			v.setHealthLabel(ctx, provider, name, ClusterHealthUnknown)
+			db.DBconn.Remove(ctx, v.db.storeName, key)
+		}
+		return ClusterStatus{}, err
+	}
+	return s, nil
+}
+
+// checkClusterProbed returns an error if the cluster was deleted or is being
+// deleted, and tells if the cluster exists
+func (v *ClusterClient) checkClusterProbed(ctx context.Context, key ClusterKey) (bool, error) {
+	value, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	if err != nil {
+		return false, err
+	}
+	if len(value) == 0 {
+		return false, pkgerrors.New("Cluster not found")
+	}
+	value, err = db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagDelete)
+	if err != nil {
+		return true, err
+	}
+	if len(value) > 0 && value[0] != nil {
+		return true, pkgerrors.New("Cluster is being deleted")
+	}
+	return true, nil
+}
+
+// removeClusterHealth removes the health label and the status of the cluster
+func (v *ClusterClient) removeClusterHealth(ctx context.Context, provider, name string) error {
+	err := v.setHealthLabel(ctx, provider, name, ClusterHealthUnknown)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error removing the health label of cluster "+name)
+	}
+	key := ClusterKey{
+		ClusterProviderName: provider,
+		ClusterName:         name,
+	}
+	status, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagStatus)
+	if err != nil || len(status) == 0 || status[0] == nil {
+		return err
+	}
+	return db.DBconn.RemoveTag(ctx, v.db.storeName, key, v.db.tagStatus)
+}
+
+// setHealthLabel replaces the health label of the cluster by the label of the
+// health, the cluster has no health label when its health is Unknown
+func (v *ClusterClient) setHealthLabel(ctx context.Context, provider, name, health string) error {
+	labels, err := v.GetClusterLabels(ctx, provider, name)
+	if err != nil {
+		return err
+	}
+
+	label := ""
+	if health != ClusterHealthUnknown {
+		label = HealthLabelPrefix + strings.ToLower(health)
+	}
+	found := false
+	for _, l := range labels {
+		switch {
+		case l.LabelName == label:
+			found = true
+		case strings.HasPrefix(l.LabelName, HealthLabelPrefix):
+			err = v.DeleteClusterLabel(ctx, provider, name, l.LabelName)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if label == "" || found {
+		return nil
+	}
+	_, err = v.CreateClusterLabel(ctx, provider, name, ClusterLabel{LabelName: label}, true)
+	return err
+}
+
+// probeLeaseDuration is the number of probe intervals after which another
+// replica of CLM takes the probes over from a replica that stopped
+const probeLeaseDuration = 3
+
+// RunHealthProbes probes all the clusters of all the cluster providers every
+// interval, with at most workers probes at a time, until the context is done.
+// Only the replica of CLM holding the lease of the probes runs them.
+func (v *ClusterClient) RunHealthProbes(ctx context.Context, interval time.Duration, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	l := lease.New("clm/probes", probeLeaseDuration*interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		owned, err := l.Acquire(ctx)
+		if err != nil {
+			log.Error("Error acquiring the lease of the cluster probes", log.Fields{"error": err})
+		}
+		if owned {
+			v.probeClusters(ctx, l, workers)
+		}
+		select {
+		case <-ctx.Done():
+			l.Release(context.Background())
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeClusters probes the clusters with a pool of workers, and stops when
+// the replica loses the lease, renewed as the clusters are probed
+func (v *ClusterClient) probeClusters(ctx context.Context, l *lease.Lease, workers int) {
+	providers, err := v.GetClusterProviders(ctx)
+	if err != nil {
+		log.Error("Error getting the cluster providers to probe", log.Fields{"error": err})
+		return
+	}
+
+	type probe struct {
+		provider, cluster string
+	}
+	probes := make(chan probe)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range probes {
+				s, err := v.ProbeCluster(ctx, p.provider, p.cluster)
+				if err != nil {
+					log.Error("Error probing cluster", log.Fields{"clusterProvider": p.provider, "cluster": p.cluster, "error": err})
+					continue
+				}
+				if s.Health != ClusterHealthHealthy && s.Health != ClusterHealthUnknown {
+					log.Warn("Cluster is not healthy", log.Fields{"clusterProvider": p.provider, "cluster": p.cluster, "health": s.Health, "message": s.Message})
+				}
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(probes)
+
+	renewed := time.Now()
+	for _, p := range providers {
+		clusters, err := v.GetClusters(ctx, p.Metadata.Name)
+		if err != nil {
+			log.Error("Error getting the clusters to probe", log.Fields{"clusterProvider": p.Metadata.Name, "error": err})
+			continue
+		}
+		for _, c := range clusters {
+			if time.Since(renewed) > probeTimeout {
+				owned, err := l.Acquire(ctx)
+				if err != nil || !owned {
+					log.Error("Lost the lease of the cluster probes", log.Fields{"error": err})
+					return
+				}
+				renewed = time.Now()
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case probes <- probe{provider: p.Metadata.Name, cluster: c.Metadata.Name}:
+			}
+		}
+	}
+}
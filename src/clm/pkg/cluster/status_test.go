@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	mtypes "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
+)
+
+// newStore sets a bolt store with the referential schema of the orchestrator,
+// which enforces the parent child constraints like MongoDB
+func newStore(t *testing.T) {
+	config.GetConfiguration().DatabasePath = t.TempDir()
+
+	// the store reads the schema from the directory of the orchestrator
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if err := os.Chdir("../../../orchestrator"); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	defer os.Chdir(wd)
+	ctx := context.Background()
+	store, err := db.NewBoltStore(ctx, "test", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	for i := 0; ; i++ {
+		segments, err := store.Find(ctx, "resources", db.DbSchemaKey{}, "segment")
+		if err == nil && len(segments) > 0 {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("Expected the schema registered, got %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	db.DBconn = store
+}
+
+func TestDeleteProbedCluster(t *testing.T) {
+	newStore(t)
+	probe := probeCluster
+	defer func() { probeCluster = probe }()
+	probeCluster = func(ctx context.Context, kubeconfig []byte) (ClusterStatus, error) {
+		return ClusterStatus{}, errors.New("connection refused")
+	}
+
+	ctx := context.Background()
+	c := NewClusterClient()
+	_, err := c.CreateClusterProvider(ctx, ClusterProvider{Metadata: mtypes.Metadata{Name: "p1"}}, false)
+	if err != nil {
+		t.Fatalf("CreateClusterProvider returned an error (%s)", err)
+	}
+	kubeconfig := base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nkind: Config\n"))
+	_, err = c.CreateCluster(ctx, "p1", Cluster{Metadata: mtypes.Metadata{Name: "c1"}}, ClusterContent{Kubeconfig: kubeconfig})
+	if err != nil {
+		t.Fatalf("CreateCluster returned an error (%s)", err)
+	}
+
+	s, err := c.ProbeCluster(ctx, "p1", "c1")
+	if err != nil || s.Health != ClusterHealthUnreachable {
+		t.Fatalf("ProbeCluster returned (%v, %v)", s, err)
+	}
+	if _, err := c.GetClusterLabel(ctx, "p1", "c1", HealthLabelPrefix+"unreachable"); err != nil {
+		t.Fatalf("Expected the health label, got %s", err)
+	}
+
+	if err := c.DeleteCluster(ctx, "p1", "c1"); err != nil {
+		t.Fatalf("DeleteCluster of a probed cluster returned an error (%s)", err)
+	}
+	if _, err := c.GetCluster(ctx, "p1", "c1"); err == nil {
+		t.Fatalf("Expected the cluster deleted")
+	}
+	if _, err := c.GetClusterLabel(ctx, "p1", "c1", HealthLabelPrefix+"unreachable"); err == nil {
+		t.Fatalf("Expected the health label deleted")
+	}
+}
+
+func TestProbeDeletingCluster(t *testing.T) {
+	newStore(t)
+	probe := probeCluster
+	defer func() { probeCluster = probe }()
+	probeCluster = func(ctx context.Context, kubeconfig []byte) (ClusterStatus, error) {
+		return ClusterStatus{}, errors.New("connection refused")
+	}
+
+	ctx := context.Background()
+	c := NewClusterClient()
+	_, err := c.CreateClusterProvider(ctx, ClusterProvider{Metadata: mtypes.Metadata{Name: "p1"}}, false)
+	if err != nil {
+		t.Fatalf("CreateClusterProvider returned an error (%s)", err)
+	}
+	kubeconfig := base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nkind: Config\n"))
+	_, err = c.CreateCluster(ctx, "p1", Cluster{Metadata: mtypes.Metadata{Name: "c1"}}, ClusterContent{Kubeconfig: kubeconfig})
+	if err != nil {
+		t.Fatalf("CreateCluster returned an error (%s)", err)
+	}
+
+	// the cluster is being deleted, the probe doesn't set its health
+	key := ClusterKey{ClusterProviderName: "p1", ClusterName: "c1"}
+	if err := db.DBconn.Insert(ctx, c.db.storeName, key, nil, c.db.tagDelete, true); err != nil {
+		t.Fatalf("Insert returned an error (%s)", err)
+	}
+	if _, err := c.ProbeCluster(ctx, "p1", "c1"); err == nil || !strings.Contains(err.Error(), "being deleted") {
+		t.Fatalf("ProbeCluster of a cluster being deleted returned (%v)", err)
+	}
+	if labels, err := c.GetClusterLabels(ctx, "p1", "c1"); err != nil || len(labels) != 0 {
+		t.Fatalf("Expected no health label, got (%v, %v)", labels, err)
+	}
+	if _, err := c.GetClusterStatus(ctx, "p1", "c1"); err == nil {
+		t.Fatalf("Expected no status")
+	}
+}
@@ -19,10 +19,21 @@ type ControllerManager interface {
 	CreateController(ctx context.Context, ms clmModel.Controller, mayExist bool) (clmModel.Controller, error)
 	GetController(ctx context.Context, name string) (clmModel.Controller, error)
 	GetControllers(ctx context.Context) ([]clmModel.Controller, error)
+	ListControllers(ctx context.Context, opts db.FindOptions) ([]clmModel.Controller, string, error)
 	InitControllers(ctx context.Context)
 	DeleteController(ctx context.Context, name string) error
 }
 
+// controllerSelectors are the fields a list of Controllers can be filtered and sorted on
+var controllerSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": clmModel.Controller{},
+	},
+}
+
 // ControllerClient implements the Manager
 // It will also be used to maintain some localized state
 type ControllerClient struct {
@@ -101,6 +112,17 @@ func (mc *ControllerClient) GetController(ctx context.Context, name string) (clm
 
 // GetControllers returns all the  Controllers that are registered
 func (mc *ControllerClient) GetControllers(ctx context.Context) ([]clmModel.Controller, error) {
+	res, _, err := mc.ListControllers(ctx, db.FindOptions{})
+	return res, err
+}
+
+// ListControllers returns a page of the Controllers that are registered,
+// filtered and sorted on the controllerSelectors, and the token of the next page
+func (mc *ControllerClient) ListControllers(ctx context.Context, opts db.FindOptions) ([]clmModel.Controller, string, error) {
+	opts, err := controllerSelectors.Resolve(opts)
+	if err != nil {
+		return []clmModel.Controller{}, "", err
+	}
 
 	//Construct the composite key to select the entry
 	key := clmModel.ControllerKey{
@@ -109,22 +131,22 @@ func (mc *ControllerClient) GetControllers(ctx context.Context) ([]clmModel.Cont
 	}
 
 	var resp []clmModel.Controller
-	values, err := db.DBconn.Find(ctx, mc.collectionName, key, mc.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, mc.collectionName, key, mc.tagMeta, opts)
 	if err != nil {
-		return []clmModel.Controller{}, err
+		return []clmModel.Controller{}, "", err
 	}
 
 	for _, value := range values {
 		microserv := clmModel.Controller{}
 		err = db.DBconn.Unmarshal(value, &microserv)
 		if err != nil {
-			return []clmModel.Controller{}, err
+			return []clmModel.Controller{}, "", err
 		}
 
 		resp = append(resp, microserv)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteController the  Controller from database
@@ -14,3 +14,8 @@ var ClusterGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Name: "emco_cluster",
 	Help: "Count of Clusters",
 }, []string{"name", "cluster_provider"})
+
+var ClusterReachableGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "emco_cluster_reachable",
+	Help: "Reachability of Clusters, as last probed",
+}, []string{"name", "cluster_provider"})
@@ -54,6 +54,15 @@ func do(ctx context.Context) {
 		}
 		for _, cl := range clusters {
 			ClusterGauge.WithLabelValues(cl.Metadata.Name, clp.Metadata.Name).Set(1)
+			s, err := client.GetClusterStatus(ctx, clp.Metadata.Name, cl.Metadata.Name)
+			if err != nil || s.Health == cluster.ClusterHealthUnknown {
+				continue
+			}
+			reachable := 0.0
+			if s.Reachable {
+				reachable = 1
+			}
+			ClusterReachableGauge.WithLabelValues(cl.Metadata.Name, clp.Metadata.Name).Set(reachable)
 		}
 	}
 }
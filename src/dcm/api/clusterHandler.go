@@ -12,6 +12,8 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/dcm/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 )
@@ -93,6 +95,7 @@ func (h clusterHandler) getAllHandler(w http.ResponseWriter, r *http.Request) {
 	logicalCloud := vars["logicalCloud"]
 	var ret interface{}
 	var err error
+	var next string
 
 	// Check logical cloud exists and is valid
 	_, err = module.NewLogicalCloudClient().Get(ctx, project, logicalCloud)
@@ -102,13 +105,21 @@ func (h clusterHandler) getAllHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ret, err = h.client.GetAllClusters(ctx, project, logicalCloud)
+	var opts db.FindOptions
+	opts, err = apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ret, next, err = h.client.ListClusters(ctx, project, logicalCloud, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, apiErr.Message, apiErr.Status)
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -363,7 +363,7 @@ var _ = Describe("ClusterHandler", func() {
 	DescribeTable("Get List Cluster tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.clClient.On("GetAllClusters", mock.Anything, "test-project", "test-lc").Return(t.mockVals, t.mockError)
+			t.clClient.On("ListClusters", mock.Anything, "test-project", "test-lc", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/logical-clouds/test-lc/cluster-references", nil)
@@ -361,7 +361,7 @@ var _ = Describe("KeyValueHandler", func() {
 	DescribeTable("Get List KeyValue tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.kvClient.On("GetAllKVPairs", mock.Anything, "test-project", "test-lc").Return(t.mockVals, t.mockError)
+			t.kvClient.On("ListKVPairs", mock.Anything, "test-project", "test-lc", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/logical-clouds/test-lc/kv-pairs", nil)
@@ -14,6 +14,7 @@ import (
 	dcm "gitlab.com/project-emco/core/emco-base/src/dcm/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	orch "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -98,15 +99,22 @@ func (h logicalCloudHandler) getAllHandler(w http.ResponseWriter, r *http.Reques
 	vars := mux.Vars(r)
 	project := vars["project"]
 	var ret interface{}
-	var err error
 
-	ret, err = h.client.GetAll(ctx, project)
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ret, next, err := h.client.List(ctx, project, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, apiErr.Message, apiErr.Status)
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -379,7 +379,7 @@ var _ = Describe("LogicalCloudHandler", func() {
 	DescribeTable("List LogicalCloud tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.lcClient.On("GetAll", mock.Anything, "test-project").Return(t.mockVals, t.mockError)
+			t.lcClient.On("List", mock.Anything, "test-project", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/logical-clouds", nil)
@@ -608,7 +608,7 @@ var _ = Describe("LogicalCloudHandler", func() {
 			lcStatus: status.LogicalCloudStatus{
 				Project:      "test-project",
 				LogicalCloud: "testlogicalcloud",
-				StatusResult: status.StatusResult{"logical-cloud", state.StateInfo{}, "", "", "", nil, nil, nil, nil, nil, nil},
+				StatusResult: status.StatusResult{Name: "logical-cloud", State: state.StateInfo{}},
 				// StatusContextId: "",
 				// Actions:         nil,
 			},
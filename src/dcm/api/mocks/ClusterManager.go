@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	module "gitlab.com/project-emco/core/emco-base/src/orchestrator/common"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // ClusterManager is an autogenerated mock type for the ClusterManager type
@@ -114,6 +115,36 @@ func (_m *ClusterManager) GetClusterConfig(ctx context.Context, project string,
 	return r0, r1
 }
 
+// ListClusters provides a mock function with given fields: project, logicalCloud, opts
+func (_m *ClusterManager) ListClusters(ctx context.Context, project string, logicalCloud string, opts db.FindOptions) ([]module.Cluster, string, error) {
+	ret := _m.Called(ctx, project, logicalCloud, opts)
+
+	var r0 []module.Cluster
+	if rf, ok := ret.Get(0).(func(string, string, db.FindOptions) []module.Cluster); ok {
+		r0 = rf(project, logicalCloud, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.Cluster)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, db.FindOptions) string); ok {
+		r1 = rf(project, logicalCloud, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, db.FindOptions) error); ok {
+		r2 = rf(project, logicalCloud, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateCluster provides a mock function with given fields: project, logicalCloud, name, c
 func (_m *ClusterManager) UpdateCluster(ctx context.Context, project string, logicalCloud string, name string, c module.Cluster) (module.Cluster, error) {
 	ret := _m.Called(ctx, project, logicalCloud, name, c)
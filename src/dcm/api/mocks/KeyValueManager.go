@@ -6,6 +6,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"gitlab.com/project-emco/core/emco-base/src/dcm/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // KeyValueManager is an autogenerated mock type for the KeyValueManager type
@@ -92,6 +93,36 @@ func (_m *KeyValueManager) GetKVPair(ctx context.Context, project string, logica
 	return r0, r1
 }
 
+// ListKVPairs provides a mock function with given fields: project, logicalCloud, opts
+func (_m *KeyValueManager) ListKVPairs(ctx context.Context, project string, logicalCloud string, opts db.FindOptions) ([]module.KeyValue, string, error) {
+	ret := _m.Called(ctx, project, logicalCloud, opts)
+
+	var r0 []module.KeyValue
+	if rf, ok := ret.Get(0).(func(string, string, db.FindOptions) []module.KeyValue); ok {
+		r0 = rf(project, logicalCloud, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.KeyValue)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, db.FindOptions) string); ok {
+		r1 = rf(project, logicalCloud, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, db.FindOptions) error); ok {
+		r2 = rf(project, logicalCloud, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateKVPair provides a mock function with given fields: project, logicalCloud, name, c
 func (_m *KeyValueManager) UpdateKVPair(ctx context.Context, project string, logicalCloud string, name string, c module.KeyValue) (module.KeyValue, error) {
 	ret := _m.Called(ctx, project, logicalCloud, name, c)
@@ -6,6 +6,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	module "gitlab.com/project-emco/core/emco-base/src/orchestrator/common"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/status"
 )
@@ -115,6 +116,36 @@ func (_m *LogicalCloudManager) GetAll(ctx context.Context, project string) ([]mo
 	return r0, r1
 }
 
+// List provides a mock function with given fields: project, opts
+func (_m *LogicalCloudManager) List(ctx context.Context, project string, opts db.FindOptions) ([]module.LogicalCloud, string, error) {
+	ret := _m.Called(ctx, project, opts)
+
+	var r0 []module.LogicalCloud
+	if rf, ok := ret.Get(0).(func(string, db.FindOptions) []module.LogicalCloud); ok {
+		r0 = rf(project, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.LogicalCloud)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, db.FindOptions) string); ok {
+		r1 = rf(project, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, db.FindOptions) error); ok {
+		r2 = rf(project, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetState provides a mock function with given fields: p, lc
 func (_m *LogicalCloudManager) GetState(ctx context.Context, p string, lc string) (state.StateInfo, error) {
 	ret := _m.Called(ctx, p, lc)
@@ -6,6 +6,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	module "gitlab.com/project-emco/core/emco-base/src/dcm/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // UserPermissionManager is an autogenerated mock type for the UserPermissionManager type
@@ -92,6 +93,36 @@ func (_m *UserPermissionManager) GetUserPerm(ctx context.Context, project string
 	return r0, r1
 }
 
+// ListUserPerms provides a mock function with given fields: project, logicalCloud, opts
+func (_m *UserPermissionManager) ListUserPerms(ctx context.Context, project string, logicalCloud string, opts db.FindOptions) ([]module.UserPermission, string, error) {
+	ret := _m.Called(ctx, project, logicalCloud, opts)
+
+	var r0 []module.UserPermission
+	if rf, ok := ret.Get(0).(func(string, string, db.FindOptions) []module.UserPermission); ok {
+		r0 = rf(project, logicalCloud, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.UserPermission)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, db.FindOptions) string); ok {
+		r1 = rf(project, logicalCloud, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, db.FindOptions) error); ok {
+		r2 = rf(project, logicalCloud, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateUserPerm provides a mock function with given fields: project, logicalCloud, name, c
 func (_m *UserPermissionManager) UpdateUserPerm(ctx context.Context, project string, logicalCloud string, name string, c module.UserPermission) (module.UserPermission, error) {
 	ret := _m.Called(ctx, project, logicalCloud, name, c)
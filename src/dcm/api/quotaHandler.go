@@ -11,6 +11,8 @@ import (
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/dcm/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 )
@@ -83,14 +85,23 @@ func (h quotaHandler) getAllHandler(w http.ResponseWriter, r *http.Request) {
 	logicalCloud := vars["logicalCloud"]
 	var ret interface{}
 	var err error
+	var next string
 
-	ret, err = h.client.GetAllQuotas(ctx, project, logicalCloud)
+	var opts db.FindOptions
+	opts, err = apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ret, next, err = h.client.ListQuotas(ctx, project, logicalCloud, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, apiErr.Message, apiErr.Status)
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -411,7 +411,7 @@ var _ = Describe("QuotaHandler", func() {
 	DescribeTable("Get List Quota tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.quotaClient.On("GetAllQuotas", mock.Anything, "test-project", "test-lc").Return(t.mockVals, t.mockError)
+			t.quotaClient.On("ListQuotas", mock.Anything, "test-project", "test-lc", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/logical-clouds/test-lc/cluster-quotas", nil)
@@ -351,7 +351,7 @@ var _ = Describe("UserPermissionHandler", func() {
 	DescribeTable("Get List UserPermission tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.upClient.On("GetAllUserPerms", mock.Anything, "test-project", "test-lc").Return(t.mockVals, t.mockError)
+			t.upClient.On("ListUserPerms", mock.Anything, "test-project", "test-lc", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/logical-clouds/test-lc/user-permissions", nil)
@@ -1222,7 +1222,7 @@ func IsGitOpsCluster(ctx context.Context, clustername string) (bool, error) {
 		log.Debug("Error getting GitOps config", log.Fields{"err": err})
 		return false, nil
 	}
-	if gc.Config.Props.GitOpsType == "fluxcd" || gc.Config.Props.GitOpsType == "azureArcV2" || gc.Config.Props.GitOpsType == "anthos" {
+	if gc.Config.Props.GitOpsType == "fluxcd" || gc.Config.Props.GitOpsType == "azureArcV2" || gc.Config.Props.GitOpsType == "anthos" || gc.Config.Props.GitOpsType == "argocd" {
 		return true, nil
 	} else {
 		log.Info("GitOps Type not supported:", log.Fields{"GitOpsType": gc.Config.Props.GitOpsType})
@@ -27,11 +27,24 @@ type ClusterManager interface {
 	CreateCluster(ctx context.Context, project, logicalCloud string, c common.Cluster) (common.Cluster, error)
 	GetCluster(ctx context.Context, project, logicalCloud, name string) (common.Cluster, error)
 	GetAllClusters(ctx context.Context, project, logicalCloud string) ([]common.Cluster, error)
+	ListClusters(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]common.Cluster, string, error)
 	DeleteCluster(ctx context.Context, project, logicalCloud, name string) error
 	UpdateCluster(ctx context.Context, project, logicalCloud, name string, c common.Cluster) (common.Cluster, error)
 	GetClusterConfig(ctx context.Context, project, logicalcloud, name string) (string, error)
 }
 
+// clusterSelectors are the fields a list of cluster references can be filtered and sorted on
+var clusterSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":            "data.metadata.name",
+		"clusterProvider": "data.spec.clusterProvider",
+		"cluster":         "data.spec.cluster",
+	},
+	TagTypes: map[string]interface{}{
+		"data": common.Cluster{},
+	},
+}
+
 // ClusterClient implements the ClusterManager
 // It will also be used to maintain some localized state
 type ClusterClient struct {
@@ -126,31 +139,45 @@ func (v *ClusterClient) GetCluster(ctx context.Context, project, logicalCloud, c
 
 // GetAll returns all cluster references in the logical cloud
 func (v *ClusterClient) GetAllClusters(ctx context.Context, project, logicalCloud string) ([]common.Cluster, error) {
+	res, _, err := v.ListClusters(ctx, project, logicalCloud, db.FindOptions{})
+	if err != nil {
+		return []common.Cluster{}, err
+	}
+	if len(res) == 0 {
+		return []common.Cluster{}, pkgerrors.New("No Cluster References associated")
+	}
+	return res, nil
+}
+
+// ListClusters returns a page of the cluster references of the logical cloud,
+// filtered and sorted on the clusterSelectors, and the token of the next page
+func (v *ClusterClient) ListClusters(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]common.Cluster, string, error) {
+	opts, err := clusterSelectors.Resolve(opts)
+	if err != nil {
+		return []common.Cluster{}, "", err
+	}
+
 	//Construct the composite key to select clusters
 	key := common.ClusterKey{
 		Project:          project,
 		LogicalCloudName: logicalCloud,
 		ClusterReference: "",
 	}
-	var resp []common.Cluster
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []common.Cluster{}, err
-	}
-	if len(values) == 0 {
-		return []common.Cluster{}, pkgerrors.New("No Cluster References associated")
+		return []common.Cluster{}, "", err
 	}
-
+	resp := []common.Cluster{}
 	for _, value := range values {
 		cl := common.Cluster{}
 		err = db.DBconn.Unmarshal(value, &cl)
 		if err != nil {
-			return []common.Cluster{}, err
+			return []common.Cluster{}, "", err
 		}
 		resp = append(resp, cl)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the Cluster reference entry from database
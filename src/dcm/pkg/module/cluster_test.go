@@ -131,6 +131,18 @@ var _ = Describe("Cluster", func() {
 				clusters, err := client.GetAllClusters(ctx, "project", "logicalcloud")
 				Expect(len(clusters)).To(Equal(0))
 			})
+			It("list when nothing matches should return an empty page", func() {
+				ctx := context.Background()
+				clusters, next, err := client.ListClusters(ctx, "project", "logicalcloud", db.FindOptions{Filter: map[string]string{"name": "testcluster"}})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(clusters).To(BeEmpty())
+				Expect(next).To(BeEmpty())
+			})
+			It("get-all when nothing exists should fail", func() {
+				ctx := context.Background()
+				_, err := client.GetAllClusters(ctx, "project", "logicalcloud")
+				Expect(err).To(MatchError("No Cluster References associated"))
+			})
 			It("delete when nothing exists should fail", func() {
 				ctx := context.Background()
 				err := client.DeleteCluster(ctx, "project", "logicalcloud", "testcluster")
@@ -42,10 +42,21 @@ type KeyValueManager interface {
 	CreateKVPair(ctx context.Context, project, logicalCloud string, c KeyValue) (KeyValue, error)
 	GetKVPair(ctx context.Context, project, logicalCloud, name string) (KeyValue, error)
 	GetAllKVPairs(ctx context.Context, project, logicalCloud string) ([]KeyValue, error)
+	ListKVPairs(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]KeyValue, string, error)
 	DeleteKVPair(ctx context.Context, project, logicalCloud, name string) error
 	UpdateKVPair(ctx context.Context, project, logicalCloud, name string, c KeyValue) (KeyValue, error)
 }
 
+// keyValueSelectors are the fields a list of KeyValues can be filtered and sorted on
+var keyValueSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": KeyValue{},
+	},
+}
+
 // KeyValueClient implements the KeyValueManager
 // It will also be used to maintain some localized state
 type KeyValueClient struct {
@@ -119,6 +130,17 @@ func (v *KeyValueClient) GetKVPair(ctx context.Context, project, logicalCloud, k
 
 // Get All lists all key value pairs
 func (v *KeyValueClient) GetAllKVPairs(ctx context.Context, project, logicalCloud string) ([]KeyValue, error) {
+	res, _, err := v.ListKVPairs(ctx, project, logicalCloud, db.FindOptions{})
+	return res, err
+}
+
+// ListKVPairs returns a page of the key value pairs of the logical cloud,
+// filtered and sorted on the keyValueSelectors, and the token of the next page
+func (v *KeyValueClient) ListKVPairs(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]KeyValue, string, error) {
+	opts, err := keyValueSelectors.Resolve(opts)
+	if err != nil {
+		return []KeyValue{}, "", err
+	}
 
 	//Construct the composite key to select the entry
 	key := KeyValueKey{
@@ -127,21 +149,21 @@ func (v *KeyValueClient) GetAllKVPairs(ctx context.Context, project, logicalClou
 		KeyValueName:     "",
 	}
 	var resp []KeyValue
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []KeyValue{}, err
+		return []KeyValue{}, "", err
 	}
 
 	for _, value := range values {
 		kv := KeyValue{}
 		err = db.DBconn.Unmarshal(value, &kv)
 		if err != nil {
-			return []KeyValue{}, err
+			return []KeyValue{}, "", err
 		}
 		resp = append(resp, kv)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the Key Value entry from database
@@ -29,6 +29,7 @@ type LogicalCloudManager interface {
 	Create(ctx context.Context, project string, c common.LogicalCloud) (common.LogicalCloud, error)
 	Get(ctx context.Context, project, name string) (common.LogicalCloud, error)
 	GetAll(ctx context.Context, project string) ([]common.LogicalCloud, error)
+	List(ctx context.Context, project string, opts db.FindOptions) ([]common.LogicalCloud, string, error)
 	GetState(ctx context.Context, p string, lc string) (state.StateInfo, error)
 	Delete(ctx context.Context, project, name string) error
 	GenericStatus(ctx context.Context, project, name, qStatusInstance, qType, qOutput string, fClusters, fResources []string) (status.StatusResult, error)
@@ -39,6 +40,20 @@ type LogicalCloudManager interface {
 	UpdateInstantiation(ctx context.Context, project, name string, c common.LogicalCloud) (common.LogicalCloud, error)
 }
 
+// logicalCloudSelectors are the fields a list of Logical Clouds can be filtered and sorted on
+var logicalCloudSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":      "data.metadata.name",
+		"namespace": "data.spec.namespace",
+		"level":     "data.spec.level",
+		"state":     "stateInfo.actions.-1.state",
+	},
+	TagTypes: map[string]interface{}{
+		"data":      common.LogicalCloud{},
+		"stateInfo": state.StateInfo{},
+	},
+}
+
 // LogicalCloudClient implements the LogicalCloudManager
 // It will also be used to maintain some localized state
 type LogicalCloudClient struct {
@@ -155,6 +170,13 @@ func (v *LogicalCloudClient) Get(ctx context.Context, project, logicalCloudName
 
 // GetAll returns Logical Clouds in the project
 func (v *LogicalCloudClient) GetAll(ctx context.Context, project string) ([]common.LogicalCloud, error) {
+	resp, _, err := v.List(ctx, project, db.FindOptions{})
+	return resp, err
+}
+
+// List returns a page of the Logical Clouds in the project, filtered and sorted
+// on the logicalCloudSelectors, and the token of the next page
+func (v *LogicalCloudClient) List(ctx context.Context, project string, opts db.FindOptions) ([]common.LogicalCloud, string, error) {
 
 	//Construct the composite key to select the entry
 	key := common.LogicalCloudKey{
@@ -162,22 +184,27 @@ func (v *LogicalCloudClient) GetAll(ctx context.Context, project string) ([]comm
 		LogicalCloudName: "",
 	}
 
+	opts, err := logicalCloudSelectors.Resolve(opts)
+	if err != nil {
+		return []common.LogicalCloud{}, "", err
+	}
+
 	var resp []common.LogicalCloud
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []common.LogicalCloud{}, err
+		return []common.LogicalCloud{}, "", err
 	}
 
 	for _, value := range values {
 		lc := common.LogicalCloud{}
 		err = db.DBconn.Unmarshal(value, &lc)
 		if err != nil {
-			return []common.LogicalCloud{}, err
+			return []common.LogicalCloud{}, "", err
 		}
 		resp = append(resp, lc)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // GetState returns the LogicalCloud StateInfo with a given logical cloud name and project
@@ -65,10 +65,21 @@ type QuotaManager interface {
 	CreateQuota(ctx context.Context, project, logicalCloud string, c Quota) (Quota, error)
 	GetQuota(ctx context.Context, project, logicalCloud, name string) (Quota, error)
 	GetAllQuotas(ctx context.Context, project, logicalCloud string) ([]Quota, error)
+	ListQuotas(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]Quota, string, error)
 	DeleteQuota(ctx context.Context, project, logicalCloud, name string) error
 	UpdateQuota(ctx context.Context, project, logicalCloud, name string, c Quota) (Quota, error)
 }
 
+// quotaSelectors are the fields a list of Quotas can be filtered and sorted on
+var quotaSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": Quota{},
+	},
+}
+
 // QuotaClient implements the QuotaManager
 // It will also be used to maintain some localized state
 type QuotaClient struct {
@@ -151,6 +162,18 @@ func (v *QuotaClient) GetQuota(ctx context.Context, project, logicalCloud, quota
 
 // GetAll returns all cluster quotas in the logical cloud
 func (v *QuotaClient) GetAllQuotas(ctx context.Context, project, logicalCloud string) ([]Quota, error) {
+	res, _, err := v.ListQuotas(ctx, project, logicalCloud, db.FindOptions{})
+	return res, err
+}
+
+// ListQuotas returns a page of the quotas of the logical cloud, filtered and
+// sorted on the quotaSelectors, and the token of the next page
+func (v *QuotaClient) ListQuotas(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]Quota, string, error) {
+	opts, err := quotaSelectors.Resolve(opts)
+	if err != nil {
+		return []Quota{}, "", err
+	}
+
 	//Construct the composite key to select the entry
 	key := QuotaKey{
 		Project:          project,
@@ -158,21 +181,21 @@ func (v *QuotaClient) GetAllQuotas(ctx context.Context, project, logicalCloud st
 		QuotaName:        "",
 	}
 	var resp []Quota
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []Quota{}, err
+		return []Quota{}, "", err
 	}
 
 	for _, value := range values {
 		q := Quota{}
 		err = db.DBconn.Unmarshal(value, &q)
 		if err != nil {
-			return []Quota{}, err
+			return []Quota{}, "", err
 		}
 		resp = append(resp, q)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the Quota entry from database
@@ -44,10 +44,22 @@ type UserPermissionManager interface {
 	CreateUserPerm(ctx context.Context, project, logicalCloud string, c UserPermission) (UserPermission, error)
 	GetUserPerm(ctx context.Context, project, logicalCloud, name string) (UserPermission, error)
 	GetAllUserPerms(ctx context.Context, project, logicalCloud string) ([]UserPermission, error)
+	ListUserPerms(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]UserPermission, string, error)
 	DeleteUserPerm(ctx context.Context, project, logicalCloud, name string) error
 	UpdateUserPerm(ctx context.Context, project, logicalCloud, name string, c UserPermission) (UserPermission, error)
 }
 
+// userPermissionSelectors are the fields a list of UserPermissions can be filtered and sorted on
+var userPermissionSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":      "data.metadata.name",
+		"namespace": "data.spec.namespace",
+	},
+	TagTypes: map[string]interface{}{
+		"data": UserPermission{},
+	},
+}
+
 // UserPermissionClient implements the UserPermissionManager
 // It will also be used to maintain some localized state
 type UserPermissionClient struct {
@@ -122,6 +134,18 @@ func (v *UserPermissionClient) GetUserPerm(ctx context.Context, project, logical
 
 // GetAll lists all user permissions
 func (v *UserPermissionClient) GetAllUserPerms(ctx context.Context, project, logicalCloud string) ([]UserPermission, error) {
+	res, _, err := v.ListUserPerms(ctx, project, logicalCloud, db.FindOptions{})
+	return res, err
+}
+
+// ListUserPerms returns a page of the user permissions of the logical cloud,
+// filtered and sorted on the userPermissionSelectors, and the token of the next page
+func (v *UserPermissionClient) ListUserPerms(ctx context.Context, project, logicalCloud string, opts db.FindOptions) ([]UserPermission, string, error) {
+	opts, err := userPermissionSelectors.Resolve(opts)
+	if err != nil {
+		return []UserPermission{}, "", err
+	}
+
 	//Construct the composite key to select the entry
 	key := UserPermissionKey{
 		Project:            project,
@@ -129,20 +153,20 @@ func (v *UserPermissionClient) GetAllUserPerms(ctx context.Context, project, log
 		UserPermissionName: "",
 	}
 	var resp []UserPermission
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []UserPermission{}, err
+		return []UserPermission{}, "", err
 	}
 
 	for _, value := range values {
 		up := UserPermission{}
 		err = db.DBconn.Unmarshal(value, &up)
 		if err != nil {
-			return []UserPermission{}, err
+			return []UserPermission{}, "", err
 		}
 		resp = append(resp, up)
 	}
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the User Permission entry from database
@@ -10,6 +10,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	controller "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/controller"
@@ -126,10 +128,18 @@ func (h controllerHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 	name := vars["dtcController"]
 	var ret interface{}
 	var err error
+	var next string
 
 	// handle the get all controllers case
 	if len(name) == 0 {
-		ret, err = h.client.GetControllers(ctx)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListControllers(ctx, opts)
 	} else {
 		ret, err = h.client.GetController(ctx, name)
 	}
@@ -140,6 +150,7 @@ func (h controllerHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
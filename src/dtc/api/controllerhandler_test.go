@@ -463,7 +463,7 @@ var _ = Describe("Controllerhandler", func() {
 		func(t testCase) {
 			// set up client mock responses
 
-			t.client.On("GetControllers", mock.Anything).Return(t.mockVals, t.mockError)
+			t.client.On("ListControllers", mock.Anything, mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/dtc-controllers", nil)
@@ -12,6 +12,8 @@ import (
 	pkgerrors "github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/dtc/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	orcmod "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -187,9 +189,17 @@ func (h inboundclientsaccessintentHandler) getHandler(w http.ResponseWriter, r *
 
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetClientsAccessInboundIntents(ctx, project, compositeApp, compositeAppVersion, deploymentIntentGroupName, trafficIntentGroupName, inboundIntentName, inboundClientIntentName)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListClientsAccessInboundIntents(ctx, project, compositeApp, compositeAppVersion, deploymentIntentGroupName, trafficIntentGroupName, inboundIntentName, inboundClientIntentName, opts)
 	} else {
 		ret, err = h.client.GetClientsAccessInboundIntent(ctx, name, project, compositeApp, compositeAppVersion, deploymentIntentGroupName, trafficIntentGroupName, inboundIntentName, inboundClientIntentName)
 	}
@@ -200,6 +210,7 @@ func (h inboundclientsaccessintentHandler) getHandler(w http.ResponseWriter, r *
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
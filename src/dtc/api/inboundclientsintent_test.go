@@ -599,7 +599,7 @@ var _ = Describe("Inboundclientsintenthandler", func() {
 		func(t testCase) {
 			// set up client mock responses
 
-			t.client.On("GetClientsInboundIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "testtrafficgroupintent", "testinboundintentname").Return(t.mockVals, t.mockError)
+			t.client.On("ListClientsInboundIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "testtrafficgroupintent", "testinboundintentname", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/traffic-group-intents/testtrafficgroupintent/inbound-intents/testinboundintentname/clients", nil)
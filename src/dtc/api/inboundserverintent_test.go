@@ -684,7 +684,7 @@ var _ = Describe("Inboundserverintenthandler", func() {
 		func(t testCase) {
 			// set up client mock responses
 
-			t.client.On("GetServerInboundIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "testtrafficgroupintent").Return(t.mockVals, t.mockError)
+			t.client.On("ListServerInboundIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "testtrafficgroupintent", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/traffic-group-intents/testtrafficgroupintent/inbound-intents", nil)
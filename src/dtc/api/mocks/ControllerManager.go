@@ -6,6 +6,7 @@ import (
 	controller "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/controller"
 	mock "github.com/stretchr/testify/mock"
 	"context"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // ControllerManager is an autogenerated mock type for the ControllerManager type
@@ -92,6 +93,36 @@ func (_m *ControllerManager) GetControllers(ctx context.Context) ([]controller.C
 	return r0, r1
 }
 
+// ListControllers provides a mock function with given fields: opts
+func (_m *ControllerManager) ListControllers(ctx context.Context, opts db.FindOptions) ([]controller.Controller, string, error) {
+	ret := _m.Called(ctx, opts)
+
+	var r0 []controller.Controller
+	if rf, ok := ret.Get(0).(func(db.FindOptions) []controller.Controller); ok {
+		r0 = rf(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]controller.Controller)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(db.FindOptions) string); ok {
+		r1 = rf(opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(db.FindOptions) error); ok {
+		r2 = rf(opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // InitControllers provides a mock function with given fields:
 func (_m *ControllerManager) InitControllers(ctx context.Context) {
 	_m.Called(ctx)
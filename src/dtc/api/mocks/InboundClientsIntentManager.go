@@ -6,6 +6,7 @@ import (
 	"context"
 	module "gitlab.com/project-emco/core/emco-base/src/dtc/pkg/module"
 	mock "github.com/stretchr/testify/mock"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // InboundClientsIntentManager is an autogenerated mock type for the InboundClientsIntentManager type
@@ -91,3 +92,33 @@ func (_m *InboundClientsIntentManager) GetClientsInboundIntents(ctx context.Cont
 
 	return r0, r1
 }
+
+// ListClientsInboundIntents provides a mock function with given fields: project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName, opts
+func (_m *InboundClientsIntentManager) ListClientsInboundIntents(ctx context.Context, project string, compositeapp string, compositeappversion string, deploymentIntentGroupName string, trafficintentgroupname string, inboundIntentName string, opts db.FindOptions) ([]module.InboundClientsIntent, string, error) {
+	ret := _m.Called(ctx, project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName, opts)
+
+	var r0 []module.InboundClientsIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, string, db.FindOptions) []module.InboundClientsIntent); ok {
+		r0 = rf(project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.InboundClientsIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	module "gitlab.com/project-emco/core/emco-base/src/dtc/pkg/module"
 	mock "github.com/stretchr/testify/mock"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // InboundServerIntentManager is an autogenerated mock type for the InboundServerIntentManager type
@@ -91,3 +92,33 @@ func (_m *InboundServerIntentManager) GetServerInboundIntents(ctx context.Contex
 
 	return r0, r1
 }
+
+// ListServerInboundIntents provides a mock function with given fields: project, compositeapp, compositeappversion, dig, intentName, opts
+func (_m *InboundServerIntentManager) ListServerInboundIntents(ctx context.Context, project string, compositeapp string, compositeappversion string, dig string, intentName string, opts db.FindOptions) ([]module.InboundServerIntent, string, error) {
+	ret := _m.Called(ctx, project, compositeapp, compositeappversion, dig, intentName, opts)
+
+	var r0 []module.InboundServerIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, db.FindOptions) []module.InboundServerIntent); ok {
+		r0 = rf(project, compositeapp, compositeappversion, dig, intentName, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.InboundServerIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, compositeapp, compositeappversion, dig, intentName, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, compositeapp, compositeappversion, dig, intentName, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	module "gitlab.com/project-emco/core/emco-base/src/dtc/pkg/module"
 	mock "github.com/stretchr/testify/mock"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // TrafficGroupIntentManager is an autogenerated mock type for the TrafficGroupIntentManager type
@@ -91,3 +92,33 @@ func (_m *TrafficGroupIntentManager) GetTrafficGroupIntents(ctx context.Context,
 
 	return r0, r1
 }
+
+// ListTrafficGroupIntents provides a mock function with given fields: project, compositeapp, compositeappversion, dig, opts
+func (_m *TrafficGroupIntentManager) ListTrafficGroupIntents(ctx context.Context, project string, compositeapp string, compositeappversion string, dig string, opts db.FindOptions) ([]module.TrafficGroupIntent, string, error) {
+	ret := _m.Called(ctx, project, compositeapp, compositeappversion, dig, opts)
+
+	var r0 []module.TrafficGroupIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, db.FindOptions) []module.TrafficGroupIntent); ok {
+		r0 = rf(project, compositeapp, compositeappversion, dig, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.TrafficGroupIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, compositeapp, compositeappversion, dig, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, compositeapp, compositeappversion, dig, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
@@ -369,7 +369,7 @@ var _ = Describe("Trafficgroupintenthandler", func() {
 		func(t testCase) {
 			// set up client mock responses
 
-			t.client.On("GetTrafficGroupIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig").Return(t.mockVals, t.mockError)
+			t.client.On("ListTrafficGroupIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/traffic-group-intents", nil)
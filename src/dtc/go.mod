@@ -125,6 +125,7 @@ require (
 	gitlab.com/project-emco/core/emco-base/src/clm v0.0.0-00010101000000-000000000000 // indirect
 	gitlab.com/project-emco/core/emco-base/src/monitor v0.0.0-00010101000000-000000000000 // indirect
 	gitlab.com/project-emco/core/emco-base/src/rsync v0.0.0-00010101000000-000000000000 // indirect
+	go.etcd.io/bbolt v1.3.6 // indirect
 	go.etcd.io/etcd/api/v3 v3.5.5 // indirect
 	go.etcd.io/etcd/client/pkg/v3 v3.5.5 // indirect
 	go.etcd.io/etcd/client/v3 v3.5.5 // indirect
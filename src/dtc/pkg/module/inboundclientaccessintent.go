@@ -24,10 +24,22 @@ type InboundClientsAccessIntentSpec struct {
 type InboundClientsAccessIntentManager interface {
 	CreateClientsAccessInboundIntent(ctx context.Context, tci InboundClientsAccessIntent, project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupName, inboundintentname, inboundclientsintentname string, exists bool) (InboundClientsAccessIntent, error)
 	GetClientsAccessInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundintentname, inboundclientsintentname string) ([]InboundClientsAccessIntent, error)
+	ListClientsAccessInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundintentname, inboundclientsintentname string, opts db.FindOptions) ([]InboundClientsAccessIntent, string, error)
 	GetClientsAccessInboundIntent(ctx context.Context, name, project, compositeapp, compositeappversion, deploymentintentGroupName, trafficintentgroupname, inboundintentname, inboundclientsintentname string) (InboundClientsAccessIntent, error)
 	DeleteClientsAccessInboundIntent(ctx context.Context, name, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname, inboundclientsintentname string) error
 }
 
+// inboundClientsAccessIntentSelectors are the fields a list of InboundClientsAccessIntents can be filtered and sorted on
+var inboundClientsAccessIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":   "data.metadata.name",
+		"action": "data.spec.action",
+	},
+	TagTypes: map[string]interface{}{
+		"data": InboundClientsAccessIntent{},
+	},
+}
+
 type InboundClientsAccessIntentDbClient struct {
 	db ClientDbInfo
 }
@@ -119,6 +131,18 @@ func (v *InboundClientsAccessIntentDbClient) GetClientsAccessInboundIntent(ctx c
 
 // GetClientsAccessInboundIntents returns all of the InboundClientsAccessIntent for corresponding name
 func (v *InboundClientsAccessIntentDbClient) GetClientsAccessInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname, inboundclientsintentname string) ([]InboundClientsAccessIntent, error) {
+	res, _, err := v.ListClientsAccessInboundIntents(ctx, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname, inboundclientsintentname, db.FindOptions{})
+	return res, err
+}
+
+// ListClientsAccessInboundIntents returns a page of the InboundClientsAccessIntents
+// of the InboundClientsIntent, filtered and sorted on the
+// inboundClientsAccessIntentSelectors, and the token of the next page
+func (v *InboundClientsAccessIntentDbClient) ListClientsAccessInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname, inboundclientsintentname string, opts db.FindOptions) ([]InboundClientsAccessIntent, string, error) {
+	opts, err := inboundClientsAccessIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []InboundClientsAccessIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := InboundClientsAccessIntentKey{
@@ -133,21 +157,21 @@ func (v *InboundClientsAccessIntentDbClient) GetClientsAccessInboundIntents(ctx
 	}
 
 	var resp []InboundClientsAccessIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []InboundClientsAccessIntent{}, err
+		return []InboundClientsAccessIntent{}, "", err
 	}
 
 	for _, value := range values {
 		icai := InboundClientsAccessIntent{}
 		err = db.DBconn.Unmarshal(value, &icai)
 		if err != nil {
-			return []InboundClientsAccessIntent{}, err
+			return []InboundClientsAccessIntent{}, "", err
 		}
 		resp = append(resp, icai)
 	}
 
-	return resp, nil
+	return resp, next, nil
 
 }
 
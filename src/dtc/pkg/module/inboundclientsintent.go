@@ -26,10 +26,24 @@ type InboundClientsIntentSpec struct {
 type InboundClientsIntentManager interface {
 	CreateClientsInboundIntent(ctx context.Context, tci InboundClientsIntent, project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficIntentGroupName, inboundIntentName string, exists bool) (InboundClientsIntent, error)
 	GetClientsInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName string) ([]InboundClientsIntent, error)
+	ListClientsInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName string, opts db.FindOptions) ([]InboundClientsIntent, string, error)
 	GetClientsInboundIntent(ctx context.Context, name, project, compositeapp, compositeappversion, deploymentIntentGroupName, trafficintentgroupname, inboundIntentName string) (InboundClientsIntent, error)
 	DeleteClientsInboundIntent(ctx context.Context, name, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname string) error
 }
 
+// inboundClientsIntentSelectors are the fields a list of InboundClientsIntents can be filtered and sorted on
+var inboundClientsIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":        "data.metadata.name",
+		"app":         "data.spec.app",
+		"appLabel":    "data.spec.appLabel",
+		"serviceName": "data.spec.serviceName",
+	},
+	TagTypes: map[string]interface{}{
+		"data": InboundClientsIntent{},
+	},
+}
+
 type InboundClientsIntentDbClient struct {
 	db ClientDbInfo
 }
@@ -118,6 +132,18 @@ func (v *InboundClientsIntentDbClient) GetClientsInboundIntent(ctx context.Conte
 
 // GetClientsInboundIntents returns all of the InboundClientsIntent for corresponding name
 func (v *InboundClientsIntentDbClient) GetClientsInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname string) ([]InboundClientsIntent, error) {
+	res, _, err := v.ListClientsInboundIntents(ctx, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname, db.FindOptions{})
+	return res, err
+}
+
+// ListClientsInboundIntents returns a page of the InboundClientsIntents of the
+// InboundServerIntent, filtered and sorted on the inboundClientsIntentSelectors,
+// and the token of the next page
+func (v *InboundClientsIntentDbClient) ListClientsInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, inboundserverintentname string, opts db.FindOptions) ([]InboundClientsIntent, string, error) {
+	opts, err := inboundClientsIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []InboundClientsIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := InboundClientsIntentKey{
@@ -131,21 +157,21 @@ func (v *InboundClientsIntentDbClient) GetClientsInboundIntents(ctx context.Cont
 	}
 
 	var resp []InboundClientsIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []InboundClientsIntent{}, err
+		return []InboundClientsIntent{}, "", err
 	}
 
 	for _, value := range values {
 		ici := InboundClientsIntent{}
 		err = db.DBconn.Unmarshal(value, &ici)
 		if err != nil {
-			return []InboundClientsIntent{}, err
+			return []InboundClientsIntent{}, "", err
 		}
 		resp = append(resp, ici)
 	}
 
-	return resp, nil
+	return resp, next, nil
 
 }
 
@@ -48,9 +48,25 @@ type InboundServerIntentManager interface {
 	GetServerInboundIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig, trafficintentgroupname string) (InboundServerIntent, error)
 
 	GetServerInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, intentName string) ([]InboundServerIntent, error)
+	ListServerInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, intentName string, opts db.FindOptions) ([]InboundServerIntent, string, error)
 	DeleteServerInboundIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig, trafficintentgroupname string) error
 }
 
+// inboundServerIntentSelectors are the fields a list of InboundServerIntents can be filtered and sorted on
+var inboundServerIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":        "data.metadata.name",
+		"app":         "data.spec.app",
+		"appLabel":    "data.spec.appLabel",
+		"serviceName": "data.spec.serviceName",
+		"protocol":    "data.spec.protocol",
+		"serviceMesh": "data.spec.serviceMesh",
+	},
+	TagTypes: map[string]interface{}{
+		"data": InboundServerIntent{},
+	},
+}
+
 type InboundServerIntentDbClient struct {
 	db ClientDbInfo
 }
@@ -135,6 +151,18 @@ func (v *InboundServerIntentDbClient) GetServerInboundIntent(ctx context.Context
 
 // GetServerInboundIntents returns all of the ServerInboundIntents
 func (v *InboundServerIntentDbClient) GetServerInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname string) ([]InboundServerIntent, error) {
+	res, _, err := v.ListServerInboundIntents(ctx, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname, db.FindOptions{})
+	return res, err
+}
+
+// ListServerInboundIntents returns a page of the InboundServerIntents of the
+// TrafficGroupIntent, filtered and sorted on the inboundServerIntentSelectors, and
+// the token of the next page
+func (v *InboundServerIntentDbClient) ListServerInboundIntents(ctx context.Context, project, compositeapp, compositeappversion, deploymentintentgroupname, trafficintentgroupname string, opts db.FindOptions) ([]InboundServerIntent, string, error) {
+	opts, err := inboundServerIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []InboundServerIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := InboundServerIntentKey{
@@ -147,21 +175,21 @@ func (v *InboundServerIntentDbClient) GetServerInboundIntents(ctx context.Contex
 	}
 
 	var resp []InboundServerIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []InboundServerIntent{}, err
+		return []InboundServerIntent{}, "", err
 	}
 
 	for _, value := range values {
 		is := InboundServerIntent{}
 		err = db.DBconn.Unmarshal(value, &is)
 		if err != nil {
-			return []InboundServerIntent{}, err
+			return []InboundServerIntent{}, "", err
 		}
 		resp = append(resp, is)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  ServerInboundIntents from database
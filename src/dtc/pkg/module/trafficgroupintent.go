@@ -19,9 +19,20 @@ type TrafficGroupIntentManager interface {
 
 	GetTrafficGroupIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig string) (TrafficGroupIntent, error)
 	GetTrafficGroupIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string) ([]TrafficGroupIntent, error)
+	ListTrafficGroupIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string, opts db.FindOptions) ([]TrafficGroupIntent, string, error)
 	DeleteTrafficGroupIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig string) error
 }
 
+// trafficGroupIntentSelectors are the fields a list of TrafficGroupIntents can be filtered and sorted on
+var trafficGroupIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": TrafficGroupIntent{},
+	},
+}
+
 type TrafficGroupIntentDbClient struct {
 	db ClientDbInfo
 }
@@ -104,6 +115,18 @@ func (v *TrafficGroupIntentDbClient) GetTrafficGroupIntent(ctx context.Context,
 
 // GetTrafficGroupIntents returns all of the TrafficGroupIntents
 func (v *TrafficGroupIntentDbClient) GetTrafficGroupIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string) ([]TrafficGroupIntent, error) {
+	res, _, err := v.ListTrafficGroupIntents(ctx, project, compositeapp, compositeappversion, dig, db.FindOptions{})
+	return res, err
+}
+
+// ListTrafficGroupIntents returns a page of the TrafficGroupIntents of the deployment
+// intent group, filtered and sorted on the trafficGroupIntentSelectors, and the
+// token of the next page
+func (v *TrafficGroupIntentDbClient) ListTrafficGroupIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string, opts db.FindOptions) ([]TrafficGroupIntent, string, error) {
+	opts, err := trafficGroupIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []TrafficGroupIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := TrafficGroupIntentKey{
@@ -115,21 +138,21 @@ func (v *TrafficGroupIntentDbClient) GetTrafficGroupIntents(ctx context.Context,
 	}
 
 	var resp []TrafficGroupIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []TrafficGroupIntent{}, err
+		return []TrafficGroupIntent{}, "", err
 	}
 
 	for _, value := range values {
 		tgi := TrafficGroupIntent{}
 		err = db.DBconn.Unmarshal(value, &tgi)
 		if err != nil {
-			return []TrafficGroupIntent{}, err
+			return []TrafficGroupIntent{}, "", err
 		}
 		resp = append(resp, tgi)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  TrafficGroupIntent from database
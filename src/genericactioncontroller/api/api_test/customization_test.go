@@ -19,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/api"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 )
 
@@ -109,6 +110,12 @@ func (m *mockCustomizationManager) GetAllCustomization(ctx context.Context,
 	return customizations, nil
 }
 
+func (m *mockCustomizationManager) ListCustomizations(ctx context.Context,
+	project, compositeApp, version, deploymentIntentGroup, intent, resource string, opts db.FindOptions) ([]module.Customization, string, error) {
+	items, err := m.GetAllCustomization(ctx, project, compositeApp, version, deploymentIntentGroup, intent, resource)
+	return items, "", err
+}
+
 func (m *mockCustomizationManager) GetCustomization(ctx context.Context,
 	customization, project, compositeApp, version, deploymentIntentGroup, intent, resource string) (module.Customization, error) {
 
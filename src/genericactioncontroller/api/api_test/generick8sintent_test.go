@@ -18,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/api"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 )
 
@@ -91,6 +92,11 @@ func (m *mockGenericK8sIntentManager) GetAllGenericK8sIntents(ctx context.Contex
 	return intents, nil
 }
 
+func (m *mockGenericK8sIntentManager) ListGenericK8sIntents(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup string, opts db.FindOptions) ([]module.GenericK8sIntent, string, error) {
+	items, err := m.GetAllGenericK8sIntents(ctx, project, compositeApp, compositeAppVersion, deploymentIntentGroup)
+	return items, "", err
+}
+
 func (m *mockGenericK8sIntentManager) GetGenericK8sIntent(ctx context.Context, intent, project, compositeApp, compositeAppVersion, deploymentIntentGroup string) (module.GenericK8sIntent, error) {
 
 	if m.Err != nil {
@@ -19,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/api"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 )
 
@@ -116,6 +117,11 @@ func (m *mockResourceManager) GetAllResources(ctx context.Context, project, comp
 	return resources, nil
 }
 
+func (m *mockResourceManager) ListResources(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent string, opts db.FindOptions) ([]module.Resource, string, error) {
+	items, err := m.GetAllResources(ctx, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent)
+	return items, "", err
+}
+
 func (m *mockResourceManager) DeleteResource(ctx context.Context, resource, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent string) error {
 	if m.Err != nil {
 		return m.Err
@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 )
 
@@ -58,13 +59,20 @@ func (h customizationHandler) handleCustomizationGet(w http.ResponseWriter, r *h
 	ctx := r.Context()
 	vars := _cVars(mux.Vars(r))
 	if len(vars.customization) == 0 {
-		customizations, err := h.client.GetAllCustomization(ctx, vars.project, vars.compositeApp,
-			vars.version, vars.deploymentIntentGroup, vars.intent, vars.resource)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		customizations, next, err := h.client.ListCustomizations(ctx, vars.project, vars.compositeApp,
+			vars.version, vars.deploymentIntentGroup, vars.intent, vars.resource, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(mux.Vars(r), err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
 			return
 		}
+		apilist.SetContinue(w, next)
 		sendResponse(w, customizations, http.StatusOK)
 		return
 	}
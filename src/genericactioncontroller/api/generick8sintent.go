@@ -9,6 +9,8 @@ import (
 
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 
 	"github.com/gorilla/mux"
@@ -54,12 +56,20 @@ func (h genericK8sIntentHandler) handleGenericK8sIntentGet(w http.ResponseWriter
 	var (
 		genericK8sIntent interface{}
 		err              error
+		next             string
 	)
 
 	vars := _gkiVars(mux.Vars(r))
 	if len(vars.intent) == 0 {
-		genericK8sIntent, err = h.client.GetAllGenericK8sIntents(ctx, vars.project, vars.compositeApp,
-			vars.version, vars.deploymentIntentGroup)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			logutils.Error(err.Error(), logutils.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		genericK8sIntent, next, err = h.client.ListGenericK8sIntents(ctx, vars.project, vars.compositeApp,
+			vars.version, vars.deploymentIntentGroup, opts)
 	} else {
 		genericK8sIntent, err = h.client.GetGenericK8sIntent(ctx, vars.intent, vars.project,
 			vars.compositeApp, vars.version, vars.deploymentIntentGroup)
@@ -71,6 +81,7 @@ func (h genericK8sIntentHandler) handleGenericK8sIntentGet(w http.ResponseWriter
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	sendResponse(w, genericK8sIntent, http.StatusOK)
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/genericactioncontroller/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 )
 
@@ -60,13 +61,20 @@ func (h resourceHandler) handleResourceGet(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	vars := _rVars(mux.Vars(r))
 	if len(vars.resource) == 0 {
-		resources, err := h.client.GetAllResources(ctx, vars.project, vars.compositeApp, vars.version,
-			vars.deploymentIntentGroup, vars.intent)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resources, next, err := h.client.ListResources(ctx, vars.project, vars.compositeApp, vars.version,
+			vars.deploymentIntentGroup, vars.intent, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(mux.Vars(r), err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
 			return
 		}
+		apilist.SetContinue(w, next)
 
 		if resources == nil {
 			sendResponse(w, resources, http.StatusNotFound)
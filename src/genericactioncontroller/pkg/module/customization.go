@@ -79,6 +79,22 @@ type CustomizationKey struct {
 	Resource              string `json:"genericResource"`
 }
 
+// customizationSelectors are the fields a list of Customizations can be filtered and sorted on
+var customizationSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":            "data.metadata.name",
+		"clusterSpecific": "data.spec.clusterSpecific",
+		"scope":           "data.spec.clusterInfo.scope",
+		"clusterProvider": "data.spec.clusterInfo.clusterProvider",
+		"cluster":         "data.spec.clusterInfo.cluster",
+		"clusterLabel":    "data.spec.clusterInfo.clusterLabel",
+		"mode":            "data.spec.clusterInfo.mode",
+	},
+	TagTypes: map[string]interface{}{
+		"data": Customization{},
+	},
+}
+
 // CustomizationClient holds the client properties
 type CustomizationClient struct {
 	db ClientDbInfo
@@ -111,6 +127,7 @@ type CustomizationManager interface {
 		failIfExists bool) (Customization, bool, error)
 	DeleteCustomization(ctx context.Context, customization, project, compositeApp, version, deploymentIntentGroup, intent, resource string) error
 	GetAllCustomization(ctx context.Context, project, compositeApp, version, deploymentIntentGroup, intent, resource string) ([]Customization, error)
+	ListCustomizations(ctx context.Context, project, compositeApp, version, deploymentIntentGroup, intent, resource string, opts db.FindOptions) ([]Customization, string, error)
 	GetCustomization(ctx context.Context, customization, project, compositeApp, version, deploymentIntentGroup, intent, resource string) (Customization, error)
 	GetCustomizationContent(ctx context.Context, customization, project, compositeApp, version, deploymentIntentGroup, intent, resource string) (CustomizationContent, error)
 }
@@ -193,6 +210,19 @@ func (cc *CustomizationClient) GetCustomization(ctx context.Context,
 // GetAllCustomization returns all the Customizations for an Intent and Resource
 func (cc *CustomizationClient) GetAllCustomization(ctx context.Context,
 	project, compositeApp, version, deploymentIntentGroup, intent, resource string) ([]Customization, error) {
+	res, _, err := cc.ListCustomizations(ctx, project, compositeApp, version, deploymentIntentGroup, intent, resource, db.FindOptions{})
+	return res, err
+}
+
+// ListCustomizations returns a page of the Customizations for an Intent and
+// Resource, filtered and sorted on the customizationSelectors, and the token of
+// the next page
+func (cc *CustomizationClient) ListCustomizations(ctx context.Context,
+	project, compositeApp, version, deploymentIntentGroup, intent, resource string, opts db.FindOptions) ([]Customization, string, error) {
+	opts, err := customizationSelectors.Resolve(opts)
+	if err != nil {
+		return []Customization{}, "", err
+	}
 
 	key := CustomizationKey{
 		Customization:         "",
@@ -204,21 +234,21 @@ func (cc *CustomizationClient) GetAllCustomization(ctx context.Context,
 		Resource:              resource,
 	}
 
-	values, err := db.DBconn.Find(ctx, cc.db.storeName, key, cc.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, cc.db.storeName, key, cc.db.tagMeta, opts)
 	if err != nil {
-		return []Customization{}, err
+		return []Customization{}, "", err
 	}
 
 	var customizations []Customization
 	for _, value := range values {
 		c := Customization{}
 		if err = db.DBconn.Unmarshal(value, &c); err != nil {
-			return []Customization{}, err
+			return []Customization{}, "", err
 		}
 		customizations = append(customizations, c)
 	}
 
-	return customizations, nil
+	return customizations, next, nil
 }
 
 // GetCustomizationContent returns the content of the Customization files
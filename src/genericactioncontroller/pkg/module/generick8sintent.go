@@ -28,6 +28,16 @@ type GenericK8sIntentKey struct {
 	DeploymentIntentGroup string `json:"deploymentIntentGroup"`
 }
 
+// genericK8sIntentSelectors are the fields a list of GenericK8sIntents can be filtered and sorted on
+var genericK8sIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": GenericK8sIntent{},
+	},
+}
+
 // GenericK8sIntentClient holds the client properties
 type GenericK8sIntentClient struct {
 	db ClientDbInfo
@@ -59,6 +69,7 @@ type GenericK8sIntentManager interface {
 		failIfExists bool) (GenericK8sIntent, bool, error)
 	DeleteGenericK8sIntent(ctx context.Context, intent, project, compositeApp, compositeAppVersion, deploymentIntentGroup string) error
 	GetAllGenericK8sIntents(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup string) ([]GenericK8sIntent, error)
+	ListGenericK8sIntents(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup string, opts db.FindOptions) ([]GenericK8sIntent, string, error)
 	GetGenericK8sIntent(ctx context.Context, intent, project, compositeApp, compositeAppVersion, deploymentIntentGroup string) (GenericK8sIntent, error)
 }
 
@@ -129,6 +140,18 @@ func (g *GenericK8sIntentClient) GetGenericK8sIntent(ctx context.Context, intent
 // GetAllGenericK8sIntents returns all the GenericK8sIntents
 func (g *GenericK8sIntentClient) GetAllGenericK8sIntents(ctx context.Context, project, compositeApp, compositeAppVersion,
 	deploymentIntentGroup string) ([]GenericK8sIntent, error) {
+	res, _, err := g.ListGenericK8sIntents(ctx, project, compositeApp, compositeAppVersion, deploymentIntentGroup, db.FindOptions{})
+	return res, err
+}
+
+// ListGenericK8sIntents returns a page of the GenericK8sIntents, filtered and
+// sorted on the genericK8sIntentSelectors, and the token of the next page
+func (g *GenericK8sIntentClient) ListGenericK8sIntents(ctx context.Context, project, compositeApp, compositeAppVersion,
+	deploymentIntentGroup string, opts db.FindOptions) ([]GenericK8sIntent, string, error) {
+	opts, err := genericK8sIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []GenericK8sIntent{}, "", err
+	}
 
 	key := GenericK8sIntentKey{
 		GenericK8sIntent:      "",
@@ -138,21 +161,21 @@ func (g *GenericK8sIntentClient) GetAllGenericK8sIntents(ctx context.Context, pr
 		DeploymentIntentGroup: deploymentIntentGroup,
 	}
 
-	values, err := db.DBconn.Find(ctx, g.db.storeName, key, g.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, g.db.storeName, key, g.db.tagMeta, opts)
 	if err != nil {
-		return []GenericK8sIntent{}, err
+		return []GenericK8sIntent{}, "", err
 	}
 
 	var intents []GenericK8sIntent
 	for _, value := range values {
 		gki := GenericK8sIntent{}
 		if err = db.DBconn.Unmarshal(value, &gki); err != nil {
-			return []GenericK8sIntent{}, err
+			return []GenericK8sIntent{}, "", err
 		}
 		intents = append(intents, gki)
 	}
 
-	return intents, nil
+	return intents, next, nil
 }
 
 // DeleteGenericK8sIntent deletes a given GenericK8sIntent
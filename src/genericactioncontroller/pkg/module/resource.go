@@ -48,6 +48,18 @@ type ResourceKey struct {
 	GenericK8sIntent      string `json:"genericK8sIntent"`
 }
 
+// resourceSelectors are the fields a list of Resources can be filtered and sorted on
+var resourceSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":      "data.metadata.name",
+		"app":       "data.spec.app",
+		"newObject": "data.spec.newObject",
+	},
+	TagTypes: map[string]interface{}{
+		"data": Resource{},
+	},
+}
+
 // ResourceClient holds the client properties
 type ResourceClient struct {
 	db ClientDbInfo
@@ -80,6 +92,7 @@ type ResourceManager interface {
 		failIfExists bool) (Resource, bool, error)
 	DeleteResource(ctx context.Context, resource, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent string) error
 	GetAllResources(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent string) ([]Resource, error)
+	ListResources(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent string, opts db.FindOptions) ([]Resource, string, error)
 	GetResource(ctx context.Context, resource, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent string) (Resource, error)
 	GetResourceContent(ctx context.Context, resource, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent string) (ResourceContent, error)
 }
@@ -158,6 +171,18 @@ func (rc *ResourceClient) GetResource(ctx context.Context, resource, project, co
 // GetAllResources returns all the Resources for an Intent
 func (rc *ResourceClient) GetAllResources(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup,
 	genericK8sIntent string) ([]Resource, error) {
+	res, _, err := rc.ListResources(ctx, project, compositeApp, compositeAppVersion, deploymentIntentGroup, genericK8sIntent, db.FindOptions{})
+	return res, err
+}
+
+// ListResources returns a page of the Resources for an Intent, filtered and
+// sorted on the resourceSelectors, and the token of the next page
+func (rc *ResourceClient) ListResources(ctx context.Context, project, compositeApp, compositeAppVersion, deploymentIntentGroup,
+	genericK8sIntent string, opts db.FindOptions) ([]Resource, string, error) {
+	opts, err := resourceSelectors.Resolve(opts)
+	if err != nil {
+		return []Resource{}, "", err
+	}
 
 	key := ResourceKey{
 		Resource:              "",
@@ -168,21 +193,21 @@ func (rc *ResourceClient) GetAllResources(ctx context.Context, project, composit
 		GenericK8sIntent:      genericK8sIntent,
 	}
 
-	values, err := db.DBconn.Find(ctx, rc.db.storeName, key, rc.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, rc.db.storeName, key, rc.db.tagMeta, opts)
 	if err != nil {
-		return []Resource{}, err
+		return []Resource{}, "", err
 	}
 
 	var resources []Resource
 	for _, value := range values {
 		r := Resource{}
 		if err = db.DBconn.Unmarshal(value, &r); err != nil {
-			return []Resource{}, err
+			return []Resource{}, "", err
 		}
 		resources = append(resources, r)
 	}
 
-	return resources, nil
+	return resources, next, nil
 }
 
 // GetResourceContent returns the content of the Resource template
@@ -13,6 +13,8 @@ import (
 	pkgerrors "github.com/pkg/errors"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 
@@ -136,8 +138,16 @@ func (h HpaPlacementIntentHandler) getHpaConsumerHandler(w http.ResponseWriter,
 	log.Info(":: getHpaConsumerHandler .. Req ::", log.Fields{"project": p, "composite-app": ca, "composite-app-ver": v, "dep-group": di, "intent-name": name})
 
 	var consumers interface{}
+	var next string
 	if len(name) == 0 {
-		consumers, err = h.client.GetAllConsumers(ctx, p, ca, v, di, i)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: getHpaConsumerHandler .. Invalid list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		consumers, next, err = h.client.ListConsumers(ctx, p, ca, v, di, i, opts)
 	} else {
 		consumers, _, err = h.client.GetConsumer(ctx, name, p, ca, v, di, i)
 	}
@@ -148,6 +158,7 @@ func (h HpaPlacementIntentHandler) getHpaConsumerHandler(w http.ResponseWriter,
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(consumers)
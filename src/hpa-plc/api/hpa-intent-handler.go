@@ -13,6 +13,8 @@ import (
 	pkgerrors "github.com/pkg/errors"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 
@@ -136,8 +138,16 @@ func (h HpaPlacementIntentHandler) getHpaIntentHandler(w http.ResponseWriter, r
 	log.Info(":: getHpaIntentHandler .. Req ::", log.Fields{"project": p, "composite-app": ca, "composite-app-ver": v, "dep-group": di, "intent-name": name})
 
 	var intents interface{}
+	var next string
 	if len(name) == 0 {
-		intents, err = h.client.GetAllIntents(ctx, p, ca, v, di)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: getHpaIntentHandler .. Invalid list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		intents, next, err = h.client.ListIntents(ctx, p, ca, v, di, opts)
 	} else {
 		intents, _, err = h.client.GetIntent(ctx, name, p, ca, v, di)
 	}
@@ -148,6 +158,7 @@ func (h HpaPlacementIntentHandler) getHpaIntentHandler(w http.ResponseWriter, r
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(intents)
@@ -15,6 +15,8 @@ import (
 	pkgerrors "github.com/pkg/errors"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 
@@ -144,8 +146,16 @@ func (h HpaPlacementIntentHandler) getHpaResourceHandler(w http.ResponseWriter,
 	log.Info(":: getHpaResourceHandler .. Req ::", log.Fields{"project": p, "composite-app": ca, "composite-app-ver": v, "dep-group": di, "intent-name": name})
 
 	var resources interface{}
+	var next string
 	if len(name) == 0 {
-		resources, err = h.client.GetAllResources(ctx, p, ca, v, di, i, cn)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: getHpaResourceHandler .. Invalid list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resources, next, err = h.client.ListResources(ctx, p, ca, v, di, i, cn, opts)
 	} else {
 		resources, _, err = h.client.GetResource(ctx, name, p, ca, v, di, i, cn)
 	}
@@ -156,6 +166,7 @@ func (h HpaPlacementIntentHandler) getHpaResourceHandler(w http.ResponseWriter,
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(resources)
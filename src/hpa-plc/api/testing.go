@@ -11,6 +11,7 @@ import (
 	"github.com/gorilla/mux"
 
 	hpaModel "gitlab.com/project-emco/core/emco-base/src/hpa-plc/pkg/model"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 func executeRequest(request *http.Request, router *mux.Router) *http.Response {
@@ -55,6 +56,11 @@ func (m *mockIntentManager) GetAllIntents(ctx context.Context, p string, ca stri
 	return m.Items, nil
 }
 
+func (m *mockIntentManager) ListIntents(ctx context.Context, p string, ca string, v string, di string, opts db.FindOptions) ([]hpaModel.DeploymentHpaIntent, string, error) {
+	items, err := m.GetAllIntents(ctx, p, ca, v, di)
+	return items, "", err
+}
+
 func (m *mockIntentManager) GetAllIntentsByApp(ctx context.Context, app string, p string, ca string, v string, di string) ([]hpaModel.DeploymentHpaIntent, error) {
 
 	if m.Err != nil {
@@ -100,6 +106,11 @@ func (m *mockIntentManager) GetAllConsumers(ctx context.Context, p, ca, v, di, i
 	return m.ConsumerItems, nil
 }
 
+func (m *mockIntentManager) ListConsumers(ctx context.Context, p, ca, v, di, i string, opts db.FindOptions) ([]hpaModel.HpaResourceConsumer, string, error) {
+	items, err := m.GetAllConsumers(ctx, p, ca, v, di, i)
+	return items, "", err
+}
+
 func (m *mockIntentManager) GetConsumerByName(ctx context.Context, cn, p, ca, v, di, i string) (hpaModel.HpaResourceConsumer, error) {
 	if m.Err != nil {
 		return hpaModel.HpaResourceConsumer{}, m.Err
@@ -137,6 +148,11 @@ func (m *mockIntentManager) GetAllResources(ctx context.Context, p, ca, v, di, i
 
 }
 
+func (m *mockIntentManager) ListResources(ctx context.Context, p, ca, v, di, i, cn string, opts db.FindOptions) ([]hpaModel.HpaResourceRequirement, string, error) {
+	items, err := m.GetAllResources(ctx, p, ca, v, di, i, cn)
+	return items, "", err
+}
+
 func (m *mockIntentManager) GetResourceByName(ctx context.Context, rn, p, ca, v, di, i, cn string) (hpaModel.HpaResourceRequirement, error) {
 	if m.Err != nil {
 		return hpaModel.HpaResourceRequirement{}, m.Err
@@ -86,6 +86,20 @@ GetAllConsumers ... takes in projectName, CompositeAppName, CompositeAppVersion,
 DeploymentIntentName . It returns ListOfConsumers.
 */
 func (c HpaPlacementClient) GetAllConsumers(ctx context.Context, p, ca, v, di, i string) ([]hpaModel.HpaResourceConsumer, error) {
+	res, _, err := c.ListConsumers(ctx, p, ca, v, di, i, db.FindOptions{})
+	return res, err
+}
+
+/*
+ListConsumers ... takes in projectName, CompositeAppName, CompositeAppVersion, DeploymentGroup,
+DeploymentIntentName and the list options. It returns a page of the consumers,
+filtered and sorted on the hpaConsumerSelectors, and the token of the next page.
+*/
+func (c HpaPlacementClient) ListConsumers(ctx context.Context, p, ca, v, di, i string, opts db.FindOptions) ([]hpaModel.HpaResourceConsumer, string, error) {
+	opts, err := hpaConsumerSelectors.Resolve(opts)
+	if err != nil {
+		return []hpaModel.HpaResourceConsumer{}, "", err
+	}
 
 	dbKey := HpaConsumerKey{
 		ConsumerName:          "",
@@ -96,12 +110,12 @@ func (c HpaPlacementClient) GetAllConsumers(ctx context.Context, p, ca, v, di, i
 		DeploymentIntentGroup: di,
 	}
 
-	result, err := db.DBconn.Find(ctx, c.db.StoreName, dbKey, c.db.TagMetaData)
+	result, next, err := db.DBconn.FindWithOptions(ctx, c.db.StoreName, dbKey, c.db.TagMetaData, opts)
 	if err != nil {
-		log.Error("GetAllConsumers ... DB Error .. Get HpaConsumers db error", log.Fields{"hpaIntent": i})
-		return []hpaModel.HpaResourceConsumer{}, err
+		log.Error("ListConsumers ... DB Error .. Get HpaConsumers db error", log.Fields{"hpaIntent": i})
+		return []hpaModel.HpaResourceConsumer{}, "", err
 	}
-	log.Info("GetAllConsumers ... db result", log.Fields{"StoreName": c.db.StoreName, "key": dbKey, "project": p, "compositeApp": ca, "compositeAppVersion": v, "deploymentIntentGroup": di})
+	log.Info("ListConsumers ... db result", log.Fields{"StoreName": c.db.StoreName, "key": dbKey, "project": p, "compositeApp": ca, "compositeAppVersion": v, "deploymentIntentGroup": di})
 
 	var listOfMapOfConsumers []hpaModel.HpaResourceConsumer
 	for i := range result {
@@ -109,14 +123,14 @@ func (c HpaPlacementClient) GetAllConsumers(ctx context.Context, p, ca, v, di, i
 		if result[i] != nil {
 			err = db.DBconn.Unmarshal(result[i], &a)
 			if err != nil {
-				log.Error("GetAllConsumers ... Unmarshalling Consumer error.", log.Fields{"index": i, "hpaConsumer": result[i], "err": err})
-				return []hpaModel.HpaResourceConsumer{}, err
+				log.Error("ListConsumers ... Unmarshalling Consumer error.", log.Fields{"index": i, "hpaConsumer": result[i], "err": err})
+				return []hpaModel.HpaResourceConsumer{}, "", err
 			}
 			listOfMapOfConsumers = append(listOfMapOfConsumers, a)
 		}
 	}
 
-	return listOfMapOfConsumers, nil
+	return listOfMapOfConsumers, next, nil
 }
 
 /*
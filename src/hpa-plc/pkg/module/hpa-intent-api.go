@@ -85,6 +85,20 @@ GetAllIntents takes in projectName, CompositeAppName, CompositeAppVersion,
 DeploymentIntentName . It returns ListOfIntents.
 */
 func (c HpaPlacementClient) GetAllIntents(ctx context.Context, p string, ca string, v string, di string) ([]hpaModel.DeploymentHpaIntent, error) {
+	res, _, err := c.ListIntents(ctx, p, ca, v, di, db.FindOptions{})
+	return res, err
+}
+
+/*
+ListIntents takes in projectName, CompositeAppName, CompositeAppVersion,
+DeploymentIntentName and the list options. It returns a page of the intents,
+filtered and sorted on the hpaIntentSelectors, and the token of the next page.
+*/
+func (c HpaPlacementClient) ListIntents(ctx context.Context, p string, ca string, v string, di string, opts db.FindOptions) ([]hpaModel.DeploymentHpaIntent, string, error) {
+	opts, err := hpaIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []hpaModel.DeploymentHpaIntent{}, "", err
+	}
 
 	dbKey := HpaIntentKey{
 		IntentName:            "",
@@ -94,12 +108,12 @@ func (c HpaPlacementClient) GetAllIntents(ctx context.Context, p string, ca stri
 		DeploymentIntentGroup: di,
 	}
 
-	result, err := db.DBconn.Find(ctx, c.db.StoreName, dbKey, c.db.TagMetaData)
+	result, next, err := db.DBconn.FindWithOptions(ctx, c.db.StoreName, dbKey, c.db.TagMetaData, opts)
 	if err != nil {
-		log.Error("GetAllIntents ... DB Error .. Get HpaIntents db error", log.Fields{"StoreName": c.db.StoreName, "project": p, "compositeApp": ca, "compositeAppVersion": v, "deploymentIntentGroup": di, "len_result": len(result), "err": err})
-		return []hpaModel.DeploymentHpaIntent{}, err
+		log.Error("ListIntents ... DB Error .. Get HpaIntents db error", log.Fields{"StoreName": c.db.StoreName, "project": p, "compositeApp": ca, "compositeAppVersion": v, "deploymentIntentGroup": di, "len_result": len(result), "err": err})
+		return []hpaModel.DeploymentHpaIntent{}, "", err
 	}
-	log.Info("GetAllIntents ... db result", log.Fields{"StoreName": c.db.StoreName, "key": dbKey, "project": p, "compositeApp": ca, "compositeAppVersion": v, "deploymentIntentGroup": di})
+	log.Info("ListIntents ... db result", log.Fields{"StoreName": c.db.StoreName, "key": dbKey, "project": p, "compositeApp": ca, "compositeAppVersion": v, "deploymentIntentGroup": di})
 
 	var listOfIntents []hpaModel.DeploymentHpaIntent
 	for i := range result {
@@ -107,13 +121,13 @@ func (c HpaPlacementClient) GetAllIntents(ctx context.Context, p string, ca stri
 		if result[i] != nil {
 			err = db.DBconn.Unmarshal(result[i], &a)
 			if err != nil {
-				log.Error("GetAllIntents ... Unmarshalling HpaIntents error", log.Fields{"deploymentgroup": di})
-				return []hpaModel.DeploymentHpaIntent{}, err
+				log.Error("ListIntents ... Unmarshalling HpaIntents error", log.Fields{"deploymentgroup": di})
+				return []hpaModel.DeploymentHpaIntent{}, "", err
 			}
 			listOfIntents = append(listOfIntents, a)
 		}
 	}
-	return listOfIntents, nil
+	return listOfIntents, next, nil
 }
 
 /*
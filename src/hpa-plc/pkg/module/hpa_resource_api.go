@@ -86,6 +86,21 @@ func (c *HpaPlacementClient) GetResource(ctx context.Context, rn string, p strin
  DeploymentIntentName, ConsumerName . It returns ListOfResources.
 */
 func (c HpaPlacementClient) GetAllResources(ctx context.Context, p, ca, v, di, i, cn string) ([]hpaModel.HpaResourceRequirement, error) {
+	res, _, err := c.ListResources(ctx, p, ca, v, di, i, cn, db.FindOptions{})
+	return res, err
+}
+
+/*
+ListResources ... takes in projectName, CompositeAppName, CompositeAppVersion, DeploymentGroup,
+DeploymentIntentName, ConsumerName and the list options. It returns a page of
+the resources, filtered and sorted on the hpaResourceSelectors, and the token
+of the next page.
+*/
+func (c HpaPlacementClient) ListResources(ctx context.Context, p, ca, v, di, i, cn string, opts db.FindOptions) ([]hpaModel.HpaResourceRequirement, string, error) {
+	opts, err := hpaResourceSelectors.Resolve(opts)
+	if err != nil {
+		return []hpaModel.HpaResourceRequirement{}, "", err
+	}
 
 	dbKey := HpaResourceKey{
 		ResourceName:          "",
@@ -97,12 +112,12 @@ func (c HpaPlacementClient) GetAllResources(ctx context.Context, p, ca, v, di, i
 		DeploymentIntentGroup: di,
 	}
 
-	result, err := db.DBconn.Find(ctx, c.db.StoreName, dbKey, c.db.TagMetaData)
+	result, next, err := db.DBconn.FindWithOptions(ctx, c.db.StoreName, dbKey, c.db.TagMetaData, opts)
 	if err != nil {
-		log.Error("GetAllResources ... DB Error .. Get HpaResources db error", log.Fields{"hpaConsumer": cn})
-		return []hpaModel.HpaResourceRequirement{}, err
+		log.Error("ListResources ... DB Error .. Get HpaResources db error", log.Fields{"hpaConsumer": cn})
+		return []hpaModel.HpaResourceRequirement{}, "", err
 	}
-	log.Info("GetAllResources ... db result", log.Fields{"StoreName": c.db.StoreName, "key": dbKey, "project": p, "compositeApp": ca, "compositeAppVersion": v, "dep-group": di, "hpaConsumer": cn})
+	log.Info("ListResources ... db result", log.Fields{"StoreName": c.db.StoreName, "key": dbKey, "project": p, "compositeApp": ca, "compositeAppVersion": v, "dep-group": di, "hpaConsumer": cn})
 
 	var listOfMapOfResources []hpaModel.HpaResourceRequirement
 	for i := range result {
@@ -110,13 +125,13 @@ func (c HpaPlacementClient) GetAllResources(ctx context.Context, p, ca, v, di, i
 		if result[i] != nil {
 			err = db.DBconn.Unmarshal(result[i], &a)
 			if err != nil {
-				log.Error("GetAllResources ... Unmarshalling Resources error", log.Fields{"hpaConsumer": cn})
-				return []hpaModel.HpaResourceRequirement{}, err
+				log.Error("ListResources ... Unmarshalling Resources error", log.Fields{"hpaConsumer": cn})
+				return []hpaModel.HpaResourceRequirement{}, "", err
 			}
 			listOfMapOfResources = append(listOfMapOfResources, a)
 		}
 	}
-	return listOfMapOfResources, nil
+	return listOfMapOfResources, next, nil
 }
 
 /*
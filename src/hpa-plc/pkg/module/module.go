@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 
 	hpaModel "gitlab.com/project-emco/core/emco-base/src/hpa-plc/pkg/model"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // HpaPlacementManager .. Manager is an interface exposing the HpaPlacementIntent functionality
@@ -16,6 +17,7 @@ type HpaPlacementManager interface {
 	AddIntent(ctx context.Context, a hpaModel.DeploymentHpaIntent, p string, ca string, v string, di string, exists bool) (hpaModel.DeploymentHpaIntent, error)
 	GetIntent(ctx context.Context, i string, p string, ca string, v string, di string) (hpaModel.DeploymentHpaIntent, bool, error)
 	GetAllIntents(ctx context.Context, p, ca, v, di string) ([]hpaModel.DeploymentHpaIntent, error)
+	ListIntents(ctx context.Context, p, ca, v, di string, opts db.FindOptions) ([]hpaModel.DeploymentHpaIntent, string, error)
 	GetAllIntentsByApp(ctx context.Context, app, p, ca, v, di string) ([]hpaModel.DeploymentHpaIntent, error)
 	GetIntentByName(ctx context.Context, i, p, ca, v, di string) (hpaModel.DeploymentHpaIntent, error)
 	DeleteIntent(ctx context.Context, i string, p string, ca string, v string, di string) error
@@ -24,6 +26,7 @@ type HpaPlacementManager interface {
 	AddConsumer(ctx context.Context, a hpaModel.HpaResourceConsumer, p string, ca string, v string, di string, i string, exists bool) (hpaModel.HpaResourceConsumer, error)
 	GetConsumer(ctx context.Context, cn string, p string, ca string, v string, di string, i string) (hpaModel.HpaResourceConsumer, bool, error)
 	GetAllConsumers(ctx context.Context, p, ca, v, di, i string) ([]hpaModel.HpaResourceConsumer, error)
+	ListConsumers(ctx context.Context, p, ca, v, di, i string, opts db.FindOptions) ([]hpaModel.HpaResourceConsumer, string, error)
 	GetConsumerByName(ctx context.Context, cn, p, ca, v, di, i string) (hpaModel.HpaResourceConsumer, error)
 	DeleteConsumer(ctx context.Context, cn, p string, ca string, v string, di string, i string) error
 
@@ -31,10 +34,45 @@ type HpaPlacementManager interface {
 	AddResource(ctx context.Context, a hpaModel.HpaResourceRequirement, p string, ca string, v string, di string, i string, cn string, exists bool) (hpaModel.HpaResourceRequirement, error)
 	GetResource(ctx context.Context, rn string, p string, ca string, v string, di string, i string, cn string) (hpaModel.HpaResourceRequirement, bool, error)
 	GetAllResources(ctx context.Context, p, ca, v, di, i, cn string) ([]hpaModel.HpaResourceRequirement, error)
+	ListResources(ctx context.Context, p, ca, v, di, i, cn string, opts db.FindOptions) ([]hpaModel.HpaResourceRequirement, string, error)
 	GetResourceByName(ctx context.Context, rn, p, ca, v, di, i, cn string) (hpaModel.HpaResourceRequirement, error)
 	DeleteResource(ctx context.Context, rn string, p string, ca string, v string, di string, i string, cn string) error
 }
 
+// hpaIntentSelectors are the fields a list of HPA intents can be filtered and sorted on
+var hpaIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+		"app":  "data.spec.app",
+	},
+	TagTypes: map[string]interface{}{
+		"data": hpaModel.DeploymentHpaIntent{},
+	},
+}
+
+// hpaConsumerSelectors are the fields a list of HPA consumers can be filtered and sorted on
+var hpaConsumerSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":       "data.metadata.name",
+		"apiVersion": "data.spec.apiVersion",
+		"kind":       "data.spec.kind",
+		"container":  "data.spec.container",
+	},
+	TagTypes: map[string]interface{}{
+		"data": hpaModel.HpaResourceConsumer{},
+	},
+}
+
+// hpaResourceSelectors are the fields a list of HPA resources can be filtered and sorted on
+var hpaResourceSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": hpaModel.HpaResourceRequirement{},
+	},
+}
+
 // HpaPlacementClient implements the HpaPlacementManager interface
 type HpaPlacementClient struct {
 	db hpaModel.ClientDBInfo
@@ -12,6 +12,8 @@ import (
 	netintents "gitlab.com/project-emco/core/emco-base/src/ncm/pkg/networkintents"
 	nettypes "gitlab.com/project-emco/core/emco-base/src/ncm/pkg/networkintents/types"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 
@@ -187,9 +189,17 @@ func (h networkHandler) getNetworkHandler(w http.ResponseWriter, r *http.Request
 	name := vars["network"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetNetworks(ctx, clusterProvider, cluster)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListNetworks(ctx, clusterProvider, cluster, opts)
 	} else {
 		ret, err = h.client.GetNetwork(ctx, name, clusterProvider, cluster)
 	}
@@ -200,6 +210,7 @@ func (h networkHandler) getNetworkHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -52,9 +52,21 @@ type NetworkManager interface {
 	CreateNetwork(ctx context.Context, pr Network, clusterProvider, cluster string, exists bool) (Network, error)
 	GetNetwork(ctx context.Context, name, clusterProvider, cluster string) (Network, error)
 	GetNetworks(ctx context.Context, clusterProvider, cluster string) ([]Network, error)
+	ListNetworks(ctx context.Context, clusterProvider, cluster string, opts db.FindOptions) ([]Network, string, error)
 	DeleteNetwork(ctx context.Context, name, clusterProvider, cluster string) error
 }
 
+// networkSelectors are the fields a list of Networks can be filtered and sorted on
+var networkSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":    "data.metadata.name",
+		"cniType": "data.spec.cniType",
+	},
+	TagTypes: map[string]interface{}{
+		"data": Network{},
+	},
+}
+
 // NetworkClient implements the Manager
 // It will also be used to maintain some localized state
 type NetworkClient struct {
@@ -154,6 +166,17 @@ func (v *NetworkClient) GetNetwork(ctx context.Context, name, clusterProvider, c
 
 // GetNetworkList returns all of the Network for corresponding name
 func (v *NetworkClient) GetNetworks(ctx context.Context, clusterProvider, cluster string) ([]Network, error) {
+	res, _, err := v.ListNetworks(ctx, clusterProvider, cluster, db.FindOptions{})
+	return res, err
+}
+
+// ListNetworks returns a page of the Networks of the cluster, filtered and
+// sorted on the networkSelectors, and the token of the next page
+func (v *NetworkClient) ListNetworks(ctx context.Context, clusterProvider, cluster string, opts db.FindOptions) ([]Network, string, error) {
+	opts, err := networkSelectors.Resolve(opts)
+	if err != nil {
+		return []Network{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := NetworkKey{
@@ -163,21 +186,21 @@ func (v *NetworkClient) GetNetworks(ctx context.Context, clusterProvider, cluste
 	}
 
 	var resp []Network
-	values, err := db.DBconn.Find(ctx, v.db.StoreName, key, v.db.TagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.StoreName, key, v.db.TagMeta, opts)
 	if err != nil {
-		return []Network{}, err
+		return []Network{}, "", err
 	}
 
 	for _, value := range values {
 		cp := Network{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []Network{}, err
+			return []Network{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  Network from database
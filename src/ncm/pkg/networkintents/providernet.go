@@ -54,9 +54,22 @@ type ProviderNetManager interface {
 	CreateProviderNet(ctx context.Context, pr ProviderNet, clusterProvider, cluster string, exists bool) (ProviderNet, error)
 	GetProviderNet(ctx context.Context, name, clusterProvider, cluster string) (ProviderNet, error)
 	GetProviderNets(ctx context.Context, clusterProvider, cluster string) ([]ProviderNet, error)
+	ListProviderNets(ctx context.Context, clusterProvider, cluster string, opts db.FindOptions) ([]ProviderNet, string, error)
 	DeleteProviderNet(ctx context.Context, name, clusterProvider, cluster string) error
 }
 
+// providerNetSelectors are the fields a list of ProviderNets can be filtered and sorted on
+var providerNetSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":            "data.metadata.name",
+		"cniType":         "data.spec.cniType",
+		"providerNetType": "data.spec.providerNetType",
+	},
+	TagTypes: map[string]interface{}{
+		"data": ProviderNet{},
+	},
+}
+
 // ProviderNetClient implements the Manager
 // It will also be used to maintain some localized state
 type ProviderNetClient struct {
@@ -156,6 +169,17 @@ func (v *ProviderNetClient) GetProviderNet(ctx context.Context, name, clusterPro
 
 // GetProviderNetList returns all of the ProviderNet for corresponding name
 func (v *ProviderNetClient) GetProviderNets(ctx context.Context, clusterProvider, cluster string) ([]ProviderNet, error) {
+	res, _, err := v.ListProviderNets(ctx, clusterProvider, cluster, db.FindOptions{})
+	return res, err
+}
+
+// ListProviderNets returns a page of the ProviderNets of the cluster, filtered
+// and sorted on the providerNetSelectors, and the token of the next page
+func (v *ProviderNetClient) ListProviderNets(ctx context.Context, clusterProvider, cluster string, opts db.FindOptions) ([]ProviderNet, string, error) {
+	opts, err := providerNetSelectors.Resolve(opts)
+	if err != nil {
+		return []ProviderNet{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := ProviderNetKey{
@@ -165,21 +189,21 @@ func (v *ProviderNetClient) GetProviderNets(ctx context.Context, clusterProvider
 	}
 
 	var resp []ProviderNet
-	values, err := db.DBconn.Find(ctx, v.db.StoreName, key, v.db.TagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.StoreName, key, v.db.TagMeta, opts)
 	if err != nil {
-		return []ProviderNet{}, err
+		return []ProviderNet{}, "", err
 	}
 
 	for _, value := range values {
 		cp := ProviderNet{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []ProviderNet{}, err
+			return []ProviderNet{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  ProviderNet from database
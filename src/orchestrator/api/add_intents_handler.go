@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -135,13 +136,21 @@ func (h intentHandler) getAllIntentsHandler(w http.ResponseWriter, r *http.Reque
 	v := vars["compositeAppVersion"]
 	di := vars["deploymentIntentGroup"]
 
-	mapOfIntents, err := h.client.GetAllIntents(ctx, p, ca, v, di)
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mapOfIntents, next, err := h.client.ListIntents(ctx, p, ca, v, di, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, apiErr.Message, apiErr.Status)
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(mapOfIntents)
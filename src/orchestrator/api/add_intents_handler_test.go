@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 )
 
@@ -65,6 +66,11 @@ func (im *mockIntentManager) GetAllIntents(ctx context.Context, project, composi
 	return moduleLib.ListOfIntents{}, nil
 }
 
+func (im *mockIntentManager) ListIntents(ctx context.Context, project, compositeApp, version, deploymentIntentGroup string, opts db.FindOptions) (moduleLib.ListOfIntents, string, error) {
+	items, err := im.GetAllIntents(ctx, project, compositeApp, version, deploymentIntentGroup)
+	return items, "", err
+}
+
 func (im *mockIntentManager) AddIntent(ctx context.Context, intent moduleLib.Intent, project, compositeApp, version, deploymentIntentGroup string, failIfExists bool) (moduleLib.Intent, bool, error) {
 	iExists := false
 	index := 0
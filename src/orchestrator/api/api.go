@@ -53,6 +53,7 @@ func NewRouter(projectClient moduleLib.ProjectManager,
 	v2Router.HandleFunc("/projects/{project}", projHandler.getHandler).Methods("GET")
 	v2Router.HandleFunc("/projects", projHandler.getHandler).Methods("GET")
 	v2Router.HandleFunc("/projects/{project}", projHandler.deleteHandler).Methods("DELETE")
+	v2Router.HandleFunc("/projects/{project}/watch", projHandler.watchHandler).Methods("GET")
 
 	//setting routes for compositeApp
 	if compositeAppClient == nil {
@@ -189,6 +190,8 @@ func NewRouter(projectClient moduleLib.ProjectManager,
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/approve", instantiationHandler.approveHandler).Methods("POST")
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/terminate", instantiationHandler.terminateHandler).Methods("POST")
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/instantiate", instantiationHandler.instantiateHandler).Methods("POST")
+	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/plan", instantiationHandler.planHandler).Methods("POST")
+	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/scheduled", instantiationHandler.cancelScheduledHandler).Methods("DELETE")
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/stop", instantiationHandler.stopHandler).Methods("POST")
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/status", instantiationHandler.statusHandler).Methods("GET")
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/status",
@@ -213,6 +216,7 @@ func NewRouter(projectClient moduleLib.ProjectManager,
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/migrate", updateHandler.migrateHandler).Methods("POST")
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/update", updateHandler.updateHandler).Methods("POST")
 	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/rollback", updateHandler.rollbackHandler).Methods("POST")
+	v2Router.HandleFunc("/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/rollout", updateHandler.rolloutStatusHandler).Methods("GET")
 
 	if appDependencyClient == nil {
 		appDependencyClient = moduleClient.AppDependency
@@ -243,5 +247,19 @@ func NewRouter(projectClient moduleLib.ProjectManager,
 	v2Router.HandleFunc("/projects/{project}/services/{service}/terminate-apps", serviceHandler.terminateServiceDIGsHandler).Methods(http.MethodPost)
 	v2Router.HandleFunc("/projects/{project}/services/{service}/status", serviceHandler.serviceStatusHandler).Methods(http.MethodGet)
 
+	auditHandler := auditHandler{}
+	v2Router.HandleFunc("/audit", auditHandler.listHandler).Methods(http.MethodGet)
+
+	bundleHandler := bundleHandler{}
+	v2Router.HandleFunc("/projects/{project}/export", bundleHandler.exportHandler).Methods(http.MethodGet)
+	v2Router.HandleFunc("/projects/{project}/import", bundleHandler.importHandler).Methods(http.MethodPost)
+
+	encryptionHandler := encryptionHandler{}
+	v2Router.HandleFunc("/encryption/reencrypt", encryptionHandler.reencryptHandler).Methods(http.MethodPost)
+
+	backupHandler := backupHandler{}
+	v2Router.HandleFunc("/backups", backupHandler.createHandler).Methods(http.MethodPost)
+	v2Router.HandleFunc("/backups", backupHandler.getAllHandler).Methods(http.MethodGet)
+
 	return router
 }
@@ -42,6 +42,18 @@ var apiErrors = []apierror.APIError{
 	{ID: "not allowed to use DIG from a different project", Message: "", Status: http.StatusBadRequest},
 	{ID: "invalid digId", Message: "", Status: http.StatusUnprocessableEntity},
 	{ID: "not found in service", Message: "", Status: http.StatusNotFound},
+	{ID: "DeploymentIntentGroup rollout not found", Message: "DeploymentIntentGroup rollout not found", Status: http.StatusNotFound},
+	{ID: "DeploymentIntentGroup has a rollout in progress", Message: "DeploymentIntentGroup has a rollout in progress", Status: http.StatusConflict},
+	{ID: "Invalid rollout", Message: "Invalid rollout strategy", Status: http.StatusBadRequest},
+	{ID: "DeploymentIntentGroup has a scheduled operation", Message: "DeploymentIntentGroup has a scheduled operation", Status: http.StatusConflict},
+	{ID: "DeploymentIntentGroup has no scheduled operation", Message: "DeploymentIntentGroup has no scheduled operation", Status: http.StatusNotFound},
+	{ID: "maintenance windows of the DeploymentIntentGroup don't open", Message: "The maintenance windows of the DeploymentIntentGroup don't open in the next year", Status: http.StatusConflict},
+	{ID: "Invalid maintenance window", Message: "Invalid maintenance window", Status: http.StatusBadRequest},
+	{ID: "Invalid placement intent", Message: "Invalid placement intent", Status: http.StatusBadRequest},
+	{ID: "Not enough topology domains for spread", Message: "Not enough topology domains for spread", Status: http.StatusConflict},
+	{ID: "Not enough clusters for weighted", Message: "Not enough clusters for weighted", Status: http.StatusConflict},
+	{ID: "Invalid bundle", Message: "Invalid bundle", Status: http.StatusBadRequest},
+	{ID: "doesn't support the export and the import", Message: "The database doesn't support the export and the import", Status: http.StatusNotImplemented},
 }
 
 var lcErrors = []apierror.APIError{
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -203,13 +204,21 @@ func (h appIntentHandler) getAllAppIntentsHandler(w http.ResponseWriter, r *http
 	i := vars["genericPlacementIntent"]
 	digName := vars["deploymentIntentGroup"]
 
-	applicationsAndClusterInfo, err := h.client.GetAllAppIntents(ctx, p, ca, v, i, digName)
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	applicationsAndClusterInfo, next, err := h.client.ListAppIntents(ctx, p, ca, v, i, digName, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, apiErr.Message, apiErr.Status)
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(applicationsAndClusterInfo)
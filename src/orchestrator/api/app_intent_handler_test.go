@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	gpic "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/gpic"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 )
@@ -117,6 +118,11 @@ func (aim *mockAppIntentManager) GetAllAppIntents(ctx context.Context, project,
 	return []moduleLib.AppIntent{}, nil
 }
 
+func (aim *mockAppIntentManager) ListAppIntents(ctx context.Context, project, compositeApp, version, genericPlacementIntent, deploymentIntentGroup string, opts db.FindOptions) ([]moduleLib.AppIntent, string, error) {
+	items, err := aim.GetAllAppIntents(ctx, project, compositeApp, version, genericPlacementIntent, deploymentIntentGroup)
+	return items, "", err
+}
+
 func init() {
 	appIntentJSONFile = "../json-schemas/generic-placement-intent-app.json"
 }
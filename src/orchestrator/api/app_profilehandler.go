@@ -15,6 +15,7 @@ import (
 	"net/textproto"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -159,7 +160,14 @@ func (h appProfileHandler) getAppProfileHandler(w http.ResponseWriter, r *http.R
 	if len(name) == 0 && len(appName) == 0 {
 		var retList []moduleLib.AppProfile
 
-		ret, err := h.client.GetAppProfiles(ctx, project, compositeApp, compositeAppVersion, compositeProfile)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ret, next, err := h.client.ListAppProfiles(ctx, project, compositeApp, compositeAppVersion, compositeProfile, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
@@ -170,6 +178,7 @@ func (h appProfileHandler) getAppProfileHandler(w http.ResponseWriter, r *http.R
 			retList = append(retList, ap)
 		}
 
+		apilist.SetContinue(w, next)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		err = json.NewEncoder(w).Encode(retList)
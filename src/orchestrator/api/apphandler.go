@@ -15,6 +15,7 @@ import (
 	"net/textproto"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -140,7 +141,14 @@ func (h appHandler) getAppHandler(w http.ResponseWriter, r *http.Request) {
 	if len(name) == 0 {
 		var retList []moduleLib.App
 
-		ret, err := h.client.GetApps(ctx, projectName, compositeAppName, compositeAppVersion)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ret, next, err := h.client.ListApps(ctx, projectName, compositeAppName, compositeAppVersion, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
@@ -151,6 +159,7 @@ func (h appHandler) getAppHandler(w http.ResponseWriter, r *http.Request) {
 			retList = append(retList, moduleLib.App{Metadata: app.Metadata})
 		}
 
+		apilist.SetContinue(w, next)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		err = json.NewEncoder(w).Encode(retList)
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/audit"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// auditHandler lists the audit records of all the services
+type auditHandler struct{}
+
+// auditQuery reads the query of a list of audit records from the query
+// parameters of the request:
+//
+//	project: the project of the resources
+//	resource: comma separated variable=value of the route of the resources,
+//	  e.g. resource=compositeApp=ca,deploymentIntentGroup=dig
+//	since, until: the RFC 3339 times the records are received from and until
+//
+// and the list options, see apilist.FindOptions, whose fields are time,
+// service, subject, method and route.
+func auditQuery(r *http.Request) (audit.Query, error) {
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		return audit.Query{}, err
+	}
+	if _, err := audit.Selectors.Resolve(opts); err != nil {
+		return audit.Query{}, err
+	}
+	q := audit.Query{Options: opts, Resource: make(map[string]string)}
+
+	params := r.URL.Query()
+	if project := params.Get("project"); project != "" {
+		q.Resource["project"] = project
+	}
+	for _, res := range params["resource"] {
+		for _, selector := range strings.Split(res, ",") {
+			kv := strings.SplitN(selector, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return audit.Query{}, pkgerrors.Errorf("Invalid resource selector: %s", selector)
+			}
+			q.Resource[kv[0]] = kv[1]
+		}
+	}
+	if q.From, err = queryTime(params.Get("since")); err != nil {
+		return audit.Query{}, err
+	}
+	if q.To, err = queryTime(params.Get("until")); err != nil {
+		return audit.Query{}, err
+	}
+	return q, nil
+}
+
+func queryTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, pkgerrors.Errorf("Invalid time: %s", s)
+	}
+	return t, nil
+}
+
+// listHandler handles GET of the audit records
+func (h auditHandler) listHandler(w http.ResponseWriter, r *http.Request) {
+	q, err := auditQuery(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entries, next, err := audit.List(r.Context(), q)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	apilist.SetContinue(w, next)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		log.Error(err.Error(), log.Fields{})
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/backup"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// backupHandler takes and lists the backups of the databases
+type backupHandler struct{}
+
+// createHandler handles POST to take a backup of the databases now
+func (h backupHandler) createHandler(w http.ResponseWriter, r *http.Request) {
+	t, err := backup.NewTarget(config.GetConfiguration().BackupTarget)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	m, err := backup.Backup(r.Context(), t)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(m); err != nil {
+		log.Error(err.Error(), log.Fields{})
+	}
+}
+
+// getAllHandler handles GET of the backups of the target, the oldest first
+func (h backupHandler) getAllHandler(w http.ResponseWriter, r *http.Request) {
+	t, err := backup.NewTarget(config.GetConfiguration().BackupTarget)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	backups, err := backup.List(r.Context(), t)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(backups); err != nil {
+		log.Error(err.Error(), log.Fields{})
+	}
+}
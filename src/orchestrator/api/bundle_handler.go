@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/bundle"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// bundleHandler exports and imports the configuration of the projects
+type bundleHandler struct{}
+
+// exportWriter writes the headers of the bundle with its first bytes, so
+// that the errors found before the bundle is written are still reported
+// with their status
+type exportWriter struct {
+	w       http.ResponseWriter
+	project string
+	written bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.written {
+		e.written = true
+		e.w.Header().Set("Content-Type", "application/gzip")
+		e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", e.project+".tgz"))
+		e.w.WriteHeader(http.StatusOK)
+	}
+	return e.w.Write(p)
+}
+
+// exportHandler handles GET of the bundle of a project, a gzipped tar
+// archive streamed to the client
+func (h bundleHandler) exportHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	project := vars["project"]
+
+	ew := &exportWriter{w: w, project: project}
+	if _, err := bundle.Export(r.Context(), project, ew); err != nil {
+		if ew.written {
+			// The status is sent, the client gets a truncated archive
+			log.Error("Error streaming the bundle", log.Fields{"project": project, "error": err.Error()})
+			return
+		}
+		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
+		http.Error(w, apiErr.Message, apiErr.Status)
+	}
+}
+
+// importHandler handles POST of a bundle to create the project. This is a
+// multipart handler, the metadata holds the import options, if any:
+// curl -X POST http://localhost:9015/v2/projects/newProject/import \
+// -F "metadata={\"rename\":{\"logicalCloud\":{\"lc1\":\"lc2\"}}};type=application/json" \
+// -F file=@/pathToBundle
+func (h bundleHandler) importHandler(w http.ResponseWriter, r *http.Request) {
+	var opts bundle.ImportOptions
+	vars := mux.Vars(r)
+
+	err := r.ParseMultipartForm(maxMemory)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if metadata := r.FormValue("metadata"); metadata != "" {
+		if err := json.Unmarshal([]byte(metadata), &opts); err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, "Unable to process the bundle", http.StatusUnprocessableEntity)
+		return
+	}
+	defer file.Close()
+
+	result, err := bundle.Import(r.Context(), file, vars["project"], opts)
+	if err != nil {
+		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
+		http.Error(w, apiErr.Message, apiErr.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Error(err.Error(), log.Fields{})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -103,7 +104,14 @@ func (h compositeProfileHandler) getHandler(w http.ResponseWriter, r *http.Reque
 	if len(cProfName) == 0 {
 		var retList []moduleLib.CompositeProfile
 
-		ret, err := h.client.GetCompositeProfiles(ctx, projectName, compositeAppName, version)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ret, next, err := h.client.ListCompositeProfiles(ctx, projectName, compositeAppName, version, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
@@ -114,6 +122,7 @@ func (h compositeProfileHandler) getHandler(w http.ResponseWriter, r *http.Reque
 			retList = append(retList, moduleLib.CompositeProfile{Metadata: cl.Metadata})
 		}
 
+		apilist.SetContinue(w, next)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		err = json.NewEncoder(w).Encode(retList)
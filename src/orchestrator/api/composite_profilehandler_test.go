@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"testing"
 
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 )
 
@@ -53,6 +54,12 @@ func (m *mockCompositeProfileManager) GetCompositeProfiles(ctx context.Context,
 	return m.Items, nil
 }
 
+func (m *mockCompositeProfileManager) ListCompositeProfiles(ctx context.Context, projectName string,
+	compositeAppName string, version string, opts db.FindOptions) ([]moduleLib.CompositeProfile, string, error) {
+	items, err := m.GetCompositeProfiles(ctx, projectName, compositeAppName, version)
+	return items, "", err
+}
+
 func (m *mockCompositeProfileManager) DeleteCompositeProfile(ctx context.Context, name string, projectName string,
 	compositeAppName string, version string) error {
 	return m.Err
@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"testing"
 
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/controller"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 
@@ -53,6 +54,11 @@ func (m *mockControllerManager) GetControllers(ctx context.Context) ([]controlle
 	return m.Items, nil
 }
 
+func (m *mockControllerManager) ListControllers(ctx context.Context, opts db.FindOptions) ([]controller.Controller, string, error) {
+	items, err := m.GetControllers(ctx)
+	return items, "", err
+}
+
 func (m *mockControllerManager) DeleteController(ctx context.Context, name string) error {
 	return m.Err
 }
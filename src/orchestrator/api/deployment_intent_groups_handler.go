@@ -10,7 +10,9 @@ import (
 	"github.com/google/uuid"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/schedule"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 
@@ -51,6 +53,12 @@ func (h deploymentIntentGroupHandler) createDeploymentIntentGroupHandler(w http.
 		return
 	}
 
+	if err := schedule.ValidateWindows(d.Spec.MaintenanceWindows); err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	projectName := vars["project"]
@@ -140,13 +148,21 @@ func (h deploymentIntentGroupHandler) getAllDeploymentIntentGroupsHandler(w http
 	ca := vars["compositeApp"]
 	v := vars["compositeAppVersion"]
 
-	diList, err := h.client.GetAllDeploymentIntentGroups(ctx, p, ca, v)
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	diList, next, err := h.client.ListDeploymentIntentGroups(ctx, p, ca, v, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, apiErr.Message, apiErr.Status)
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(diList)
@@ -204,6 +220,12 @@ func (h deploymentIntentGroupHandler) putDeploymentIntentGroupHandler(w http.Res
 		return
 	}
 
+	if err := schedule.ValidateWindows(dig.Spec.MaintenanceWindows); err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	deploymentIntentGroup, digExists, err := h.client.CreateDeploymentIntentGroup(ctx, dig, p, ca, v, false)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, dig, apiErrors)
@@ -15,6 +15,8 @@ import (
 	"testing"
 
 	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
 )
@@ -52,6 +54,19 @@ func (digm *mockDeploymentIntentGroupManager) GetAllDeploymentIntentGroups(ctx c
 	return []moduleLib.DeploymentIntentGroup{}, nil
 }
 
+func (digm *mockDeploymentIntentGroupManager) ListDeploymentIntentGroups(ctx context.Context, project, compositeApp, version string, opts db.FindOptions) ([]moduleLib.DeploymentIntentGroup, string, error) {
+	items, err := digm.GetAllDeploymentIntentGroups(ctx, project, compositeApp, version)
+	if err != nil {
+		return items, "", err
+	}
+
+	if opts.Limit > 0 && int64(len(items)) > opts.Limit {
+		return items[:opts.Limit], "next", nil
+	}
+
+	return items, "", nil
+}
+
 func (digm *mockDeploymentIntentGroupManager) CreateDeploymentIntentGroup(ctx context.Context, d moduleLib.DeploymentIntentGroup, project, compositeApp, version string, failIfExists bool) (moduleLib.DeploymentIntentGroup, bool, error) {
 	digExists := false
 	index := 0
@@ -273,6 +288,8 @@ func TestGetDeploymentIntentGroupHandler(t *testing.T) {
 func TestGetAllDeploymentIntentGroupsHandler(t *testing.T) {
 	testCases := []struct {
 		err, label string
+		query      string
+		next       string
 		client     *mockDeploymentIntentGroupManager
 		code       int
 		result     []moduleLib.DeploymentIntentGroup
@@ -367,6 +384,47 @@ func TestGetAllDeploymentIntentGroupsHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			label: "Get First Page Of DeploymentIntentGroups",
+			query: "?limit=1&sort=name",
+			next:  "next",
+			code:  http.StatusOK,
+			result: []moduleLib.DeploymentIntentGroup{
+				{
+					MetaData: moduleLib.DepMetaData{
+						Name: "testDeploymentIntentGroup_1",
+					},
+				},
+			},
+			client: &mockDeploymentIntentGroupManager{
+				Items: []moduleLib.DeploymentIntentGroup{
+					{
+						MetaData: moduleLib.DepMetaData{
+							Name: "testDeploymentIntentGroup_1",
+						},
+					},
+					{
+						MetaData: moduleLib.DepMetaData{
+							Name: "testDeploymentIntentGroup_2",
+						},
+					},
+				},
+			},
+		},
+		{
+			label:  "Get DeploymentIntentGroups With Invalid Limit",
+			query:  "?limit=-1",
+			code:   http.StatusBadRequest,
+			err:    "Invalid limit",
+			client: &mockDeploymentIntentGroupManager{},
+		},
+		{
+			label:  "Get DeploymentIntentGroups With Invalid Filter",
+			query:  "?filter=state",
+			code:   http.StatusBadRequest,
+			err:    "Invalid filter field",
+			client: &mockDeploymentIntentGroupManager{},
+		},
 		{
 			label:  "Get All DeploymentIntentGroups Not Exists",
 			code:   http.StatusOK,
@@ -379,12 +437,23 @@ func TestGetAllDeploymentIntentGroupsHandler(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.label, func(t *testing.T) {
-			request := httptest.NewRequest("GET", "/v2/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups", nil)
+			request := httptest.NewRequest("GET", "/v2/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups"+test.query, nil)
 			resp := executeRequest(request, NewRouter(nil, nil, nil, nil, nil, nil, test.client, nil, nil, nil, nil, nil))
 			if resp.StatusCode != test.code {
 				t.Fatalf("getAllDeploymentIntentGroupsHandler returned an unexpected status. Expected %d; Got: %d", test.code, resp.StatusCode)
 			}
 
+			if next := resp.Header.Get(apilist.ContinueHeader); next != test.next {
+				t.Fatalf("getAllDeploymentIntentGroupsHandler returned an unexpected continue token. Expected %q; Got: %q", test.next, next)
+			}
+
+			if test.err != "" {
+				body, _ := io.ReadAll(resp.Body)
+				if !strings.Contains(string(body), test.err) {
+					t.Fatalf("getAllDeploymentIntentGroupsHandler returned an unexpected error. Expected %s; Got: %s", test.err, string(body))
+				}
+			}
+
 			if resp.StatusCode == http.StatusOK {
 				dig := []moduleLib.DeploymentIntentGroup{}
 				json.NewDecoder(resp.Body).Decode(&dig)
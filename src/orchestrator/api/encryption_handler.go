@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// encryptionHandler manages the encryption of the secrets in the database
+type encryptionHandler struct{}
+
+// reencryptHandler handles POST to encrypt the secrets in the database
+// again with the current key encryption key, e.g. after a key rotation
+func (h encryptionHandler) reencryptHandler(w http.ResponseWriter, r *http.Request) {
+	result, err := db.Reencrypt(r.Context())
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Error(err.Error(), log.Fields{})
+	}
+}
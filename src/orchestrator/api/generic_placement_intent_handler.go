@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 
@@ -143,12 +144,20 @@ func (h genericPlacementIntentHandler) getAllGenericPlacementIntentsHandler(w ht
 	v := vars["compositeAppVersion"]
 	digName := vars["deploymentIntentGroup"]
 
-	gpList, err := h.client.GetAllGenericPlacementIntents(ctx, p, ca, v, digName)
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	gpList, next, err := h.client.ListGenericPlacementIntents(ctx, p, ca, v, digName, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, apiErr.Message, apiErr.Status)
 		return
 	}
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(gpList)
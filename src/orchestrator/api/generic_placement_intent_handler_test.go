@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 )
 
@@ -50,6 +51,11 @@ func (gpim *mockGenericPlacementIntentManager) GetAllGenericPlacementIntents(ctx
 	return []moduleLib.GenericPlacementIntent{}, nil
 }
 
+func (gpim *mockGenericPlacementIntentManager) ListGenericPlacementIntents(ctx context.Context, p string, ca string, v string, digName string, opts db.FindOptions) ([]moduleLib.GenericPlacementIntent, string, error) {
+	items, err := gpim.GetAllGenericPlacementIntents(ctx, p, ca, v, digName)
+	return items, "", err
+}
+
 func (gpim *mockGenericPlacementIntentManager) CreateGenericPlacementIntent(ctx context.Context, g moduleLib.GenericPlacementIntent, p string, ca string, v string, digName string, failIfExists bool) (moduleLib.GenericPlacementIntent, bool, error) {
 	gpiExists := false
 	index := 0
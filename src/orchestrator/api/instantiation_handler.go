@@ -96,6 +96,62 @@ func (h instantiationHandler) instantiateHandler(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusAccepted)
 }
 
+func (h instantiationHandler) planHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	p := vars["project"]
+	ca := vars["compositeApp"]
+	v := vars["compositeAppVersion"]
+	di := vars["deploymentIntentGroup"]
+
+	plan, iErr := h.client.Plan(ctx, p, ca, v, di)
+	if iErr != nil {
+		log.Error(":: Error plan handler ::", log.Fields{"Error": iErr.Error(), "project": p, "compositeApp": ca, "compositeAppVer": v, "depGroup": di})
+		apiErr := apierror.HandleLogicalCloudErrors(vars, iErr, lcErrors)
+		if (apiErr == apierror.APIError{}) {
+			// There are no logical cloud error(s). Check for api specific error(s)
+			apiErr = apierror.HandleErrors(vars, iErr, nil, apiErrors)
+		}
+		if apiErr.Status == http.StatusInternalServerError {
+			http.Error(w, pkgerrors.Cause(iErr).Error(), apiErr.Status)
+		} else {
+			http.Error(w, apiErr.Message, apiErr.Status)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err := json.NewEncoder(w).Encode(plan)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h instantiationHandler) cancelScheduledHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	p := vars["project"]
+	ca := vars["compositeApp"]
+	v := vars["compositeAppVersion"]
+	di := vars["deploymentIntentGroup"]
+
+	iErr := h.client.CancelScheduled(ctx, p, ca, v, di)
+	if iErr != nil {
+		log.Error(":: Error cancel scheduled handler ::", log.Fields{"Error": iErr.Error(), "project": p, "compositeApp": ca, "compositeAppVer": v, "depGroup": di})
+		apiErr := apierror.HandleErrors(vars, iErr, nil, apiErrors)
+		if apiErr.Status == http.StatusInternalServerError {
+			http.Error(w, pkgerrors.Cause(iErr).Error(), apiErr.Status)
+		} else {
+			http.Error(w, apiErr.Message, apiErr.Status)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h instantiationHandler) terminateHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -186,7 +242,7 @@ func (h instantiationHandler) statusHandler(w http.ResponseWriter, r *http.Reque
 	}
 	if t, found := qParams["status"]; found {
 		queryType = t[0]
-		if queryType != "ready" && queryType != "deployed" {
+		if queryType != "ready" && queryType != "deployed" && queryType != "drift" && queryType != "prune" {
 			log.Error("Invalid query status", log.Fields{})
 			http.Error(w, "Invalid query status", http.StatusBadRequest)
 			return
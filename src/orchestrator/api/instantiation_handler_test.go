@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
+)
+
+func (m mockInstantiationManager) Plan(ctx context.Context, p string, ca string, v string, di string) (moduleLib.DeploymentPlan, error) {
+	if m.Err != nil {
+		return moduleLib.DeploymentPlan{}, m.Err
+	}
+
+	return moduleLib.DeploymentPlan{
+		Project:               p,
+		CompositeApp:          ca,
+		CompositeAppVersion:   v,
+		DeploymentIntentGroup: di,
+		Operation:             moduleLib.PlanOperationUpdate,
+		Summary:               map[string]int{"Modified": 1},
+		Apps: []moduleLib.PlanApp{{
+			Name: "app1",
+			Clusters: []moduleLib.PlanCluster{{
+				Cluster: "provider1+cluster1",
+				Resources: []moduleLib.PlanResource{{
+					Name:   "r1+Deployment",
+					Change: moduleLib.PlanChangeEnum.Modified,
+				}},
+			}},
+		}},
+	}, nil
+}
+
+func (m mockInstantiationManager) CancelScheduled(ctx context.Context, p string, ca string, v string, di string) error {
+	return m.Err
+}
+
+func Test_instantiationHandler_plan(t *testing.T) {
+	testCases := []struct {
+		label        string
+		expectedCode int
+		iClient      mockInstantiationManager
+	}{
+		{
+			label:        "Plan DIG",
+			expectedCode: http.StatusOK,
+			iClient:      mockInstantiationManager{},
+		},
+		{
+			label:        "Plan Missing DIG",
+			expectedCode: http.StatusNotFound,
+			iClient: mockInstantiationManager{
+				Err: pkgerrors.New("DeploymentIntentGroup not found"),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/v2/projects/p/composite-apps/ca/v1/deployment-intent-groups/dig1/plan", nil)
+			resp := executeRequest(request, NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCase.iClient, nil))
+
+			//Check returned code
+			if resp.StatusCode != testCase.expectedCode {
+				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
+			}
+
+			if resp.StatusCode == http.StatusOK {
+				got := moduleLib.DeploymentPlan{}
+				json.NewDecoder(resp.Body).Decode(&got)
+				if got.DeploymentIntentGroup != "dig1" || got.Operation != moduleLib.PlanOperationUpdate || len(got.Apps) != 1 {
+					t.Errorf("planHandler returned unexpected body: got %v", got)
+				}
+			}
+		})
+	}
+}
+
+func Test_instantiationHandler_cancelScheduled(t *testing.T) {
+	testCases := []struct {
+		label        string
+		expectedCode int
+		iClient      mockInstantiationManager
+	}{
+		{
+			label:        "Cancel Scheduled Operation",
+			expectedCode: http.StatusNoContent,
+			iClient:      mockInstantiationManager{},
+		},
+		{
+			label:        "Cancel Missing Scheduled Operation",
+			expectedCode: http.StatusNotFound,
+			iClient: mockInstantiationManager{
+				Err: pkgerrors.New("DeploymentIntentGroup has no scheduled operation"),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			request := httptest.NewRequest("DELETE", "/v2/projects/p/composite-apps/ca/v1/deployment-intent-groups/dig1/scheduled", nil)
+			resp := executeRequest(request, NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCase.iClient, nil))
+
+			//Check returned code
+			if resp.StatusCode != testCase.expectedCode {
+				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
+			}
+		})
+	}
+}
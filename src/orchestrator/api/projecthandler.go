@@ -10,6 +10,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apiwatch"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -130,7 +132,14 @@ func (h projectHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 	if len(name) == 0 {
 		var pList []moduleLib.Project
 
-		projects, err := h.client.GetAllProjects(ctx)
+		opts, err := apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		projects, next, err := h.client.ListProjects(ctx, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
@@ -141,6 +150,7 @@ func (h projectHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 			pList = append(pList, moduleLib.Project{MetaData: p.MetaData})
 		}
 
+		apilist.SetContinue(w, next)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		err = json.NewEncoder(w).Encode(pList)
@@ -192,3 +202,19 @@ func (h projectHandler) deleteHandler(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// watchHandler streams the changes to the Project and its resources
+func (h projectHandler) watchHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	name := vars["project"]
+
+	events, err := h.client.WatchProject(ctx, name)
+	if err != nil {
+		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
+		http.Error(w, apiErr.Message, apiErr.Status)
+		return
+	}
+
+	apiwatch.StreamEvents(w, events)
+}
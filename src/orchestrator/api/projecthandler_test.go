@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"testing"
 
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 
 	pkgerrors "github.com/pkg/errors"
@@ -24,8 +25,9 @@ import (
 type mockProjectManager struct {
 	// Items and err will be used to customize each test
 	// via a localized instantiation of mockProjectManager
-	Items []moduleLib.Project
-	Err   error
+	Items  []moduleLib.Project
+	Events []db.WatchEvent
+	Err    error
 }
 
 func (m *mockProjectManager) CreateProject(ctx context.Context, inp moduleLib.Project, exists bool) (moduleLib.Project, error) {
@@ -52,6 +54,23 @@ func (m *mockProjectManager) GetAllProjects(ctx context.Context) ([]moduleLib.Pr
 	return []moduleLib.Project{}, m.Err
 }
 
+func (m *mockProjectManager) ListProjects(ctx context.Context, opts db.FindOptions) ([]moduleLib.Project, string, error) {
+	return []moduleLib.Project{}, "", m.Err
+}
+
+func (m *mockProjectManager) WatchProject(ctx context.Context, name string) (<-chan db.WatchEvent, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	events := make(chan db.WatchEvent, len(m.Events))
+	for _, e := range m.Events {
+		events <- e
+	}
+	close(events)
+	return events, nil
+}
+
 func init() {
 	projectJSONFile = "../json-schemas/metadata.json"
 }
@@ -347,3 +366,67 @@ func TestProjectDeleteHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectWatchHandler(t *testing.T) {
+	testCases := []struct {
+		label         string
+		expected      []db.WatchEvent
+		name          string
+		expectedCode  int
+		projectClient *mockProjectManager
+	}{
+		{
+			label:        "Watch Project",
+			expectedCode: http.StatusOK,
+			expected: []db.WatchEvent{
+				{Type: db.WatchEventInsert, Key: map[string]string{"project": "testProject", "compositeApp": "testApp", "compositeAppVersion": "v1"}},
+				{Type: db.WatchEventDelete, Key: map[string]string{"project": "testProject", "compositeApp": "testApp", "compositeAppVersion": "v1"}},
+			},
+			name: "testProject",
+			projectClient: &mockProjectManager{
+				Events: []db.WatchEvent{
+					{Type: db.WatchEventInsert, Key: map[string]string{"project": "testProject", "compositeApp": "testApp", "compositeAppVersion": "v1"}},
+					{Type: db.WatchEventDelete, Key: map[string]string{"project": "testProject", "compositeApp": "testApp", "compositeAppVersion": "v1"}},
+				},
+			},
+		},
+		{
+			label:        "Watch Non-Existing Project",
+			expectedCode: http.StatusNotFound,
+			name:         "nonexistingproject",
+			projectClient: &mockProjectManager{
+				Err: pkgerrors.New("Project not found"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/v2/projects/"+testCase.name+"/watch", nil)
+			resp := executeRequest(request, NewRouter(testCase.projectClient, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
+
+			//Check returned code
+			if resp.StatusCode != testCase.expectedCode {
+				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
+			}
+
+			//Check returned events only if statusOK
+			if resp.StatusCode == http.StatusOK {
+				var got []db.WatchEvent
+				dec := json.NewDecoder(resp.Body)
+				for dec.More() {
+					e := db.WatchEvent{}
+					if err := dec.Decode(&e); err != nil {
+						t.Fatalf("Error decoding watch event: %s", err)
+					}
+					got = append(got, e)
+				}
+
+				if reflect.DeepEqual(testCase.expected, got) == false {
+					t.Errorf("watchHandler returned unexpected events: got %v;"+
+						" expected %v", got, testCase.expected)
+				}
+			}
+		})
+	}
+}
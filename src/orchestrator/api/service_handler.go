@@ -11,6 +11,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
@@ -79,7 +80,14 @@ func (h serviceHandler) getAllServicesHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	project := vars["project"]
-	serviceList, err := h.client.GetAllServices(ctx, project)
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	serviceList, next, err := h.client.ListServices(ctx, project, opts)
 	if err != nil {
 		apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 		http.Error(w, err.Error(), apiErr.Status)
@@ -87,6 +95,7 @@ func (h serviceHandler) getAllServicesHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(serviceList)
@@ -92,7 +92,7 @@ func (h updateHandler) updateHandler(w http.ResponseWriter, r *http.Request) {
 	v := vars["compositeAppVersion"]
 	di := vars["deploymentIntentGroup"]
 
-	revisionID, iErr := h.client.Update(ctx, p, ca, v, di)
+	revisionID, scheduled, iErr := h.client.Update(ctx, p, ca, v, di)
 	if iErr != nil {
 		log.Error(":: Error update handler ::", log.Fields{"Error": iErr.Error(), "project": p, "compositeApp": ca, "compositeAppVer": v,
 			"depGroup": di})
@@ -110,9 +110,14 @@ func (h updateHandler) updateHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Info("updateHandler ... end ", log.Fields{"project": p, "compositeApp": ca, "compositeAppVer": v,
 		"depGroup": di, "returnValue": iErr})
+	// the revision of a scheduled update is unknown until it is executed
+	var resp interface{} = revisionID
+	if scheduled != nil {
+		resp = scheduled
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
-	err := json.NewEncoder(w).Encode(revisionID)
+	err := json.NewEncoder(w).Encode(resp)
 	if err != nil {
 		log.Error(err.Error(), log.Fields{})
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -215,3 +220,31 @@ func (h updateHandler) cloneDeploymentIntentGroup(w http.ResponseWriter, r *http
 		return
 	}
 }
+
+func (h updateHandler) rolloutStatusHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	p := vars["project"]
+	ca := vars["compositeApp"]
+	v := vars["compositeAppVersion"]
+	di := vars["deploymentIntentGroup"]
+
+	rs, iErr := h.client.GetRolloutStatus(ctx, p, ca, v, di)
+	if iErr != nil {
+		log.Error(":: Error rollout status handler ::", log.Fields{"Error": iErr.Error(), "project": p, "compositeApp": ca, "compositeAppVer": v,
+			"depGroup": di})
+		apiErr := apierror.HandleErrors(vars, iErr, nil, apiErrors)
+		http.Error(w, apiErr.Message, apiErr.Status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err := json.NewEncoder(w).Encode(rs)
+	if err != nil {
+		log.Error(err.Error(), log.Fields{})
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
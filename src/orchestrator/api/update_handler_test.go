@@ -6,12 +6,17 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	pkgerrors "github.com/pkg/errors"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
 )
 
 // Creating an embedded interface via anonymous variable
@@ -21,7 +26,8 @@ type mockInstantiationManager struct {
 	// Items and err will be used to customize each test
 	// via a localized instantiation of mockInstantiationManager
 	moduleLib.InstantiationClient
-	Err error
+	Err       error
+	Scheduled *state.ScheduledEntry
 }
 
 func (m mockInstantiationManager) Migrate(ctx context.Context, p string, ca string, v string, tCav string, di string, tDi string) error {
@@ -32,12 +38,15 @@ func (m mockInstantiationManager) Migrate(ctx context.Context, p string, ca stri
 	return nil
 }
 
-func (m mockInstantiationManager) Update(ctx context.Context, p string, ca string, v string, di string) (int64, error) {
+func (m mockInstantiationManager) Update(ctx context.Context, p string, ca string, v string, di string) (int64, *state.ScheduledEntry, error) {
 	if m.Err != nil {
-		return -1, m.Err
+		return -1, nil, m.Err
+	}
+	if m.Scheduled != nil {
+		return -1, m.Scheduled, nil
 	}
 
-	return 0, nil
+	return 1, nil, nil
 }
 
 func (m mockInstantiationManager) Rollback(ctx context.Context, p string, ca string, v string, di string, rbRev string) error {
@@ -56,6 +65,21 @@ func (m mockInstantiationManager) CloneDig(ctx context.Context, p, ca, v, di str
 	return nil, nil
 }
 
+func (m mockInstantiationManager) GetRolloutStatus(ctx context.Context, p string, ca string, v string, di string) (moduleLib.RolloutStatus, error) {
+	if m.Err != nil {
+		return moduleLib.RolloutStatus{}, m.Err
+	}
+
+	return moduleLib.RolloutStatus{
+		Source: moduleLib.RolloutSource{DeploymentIntentGroup: "test1", CompositeAppVersion: "v1"},
+		State:  moduleLib.RolloutStateEnum.Running,
+		Waves: []moduleLib.RolloutWave{
+			{Clusters: []string{"provider1+cluster1"}, State: moduleLib.RolloutWaveStateEnum.Ready},
+			{Clusters: []string{"provider1+cluster2"}, State: moduleLib.RolloutWaveStateEnum.Pending},
+		},
+	}, nil
+}
+
 func init() {
 	migrateJSONFile = "../json-schemas/migrate.json"
 	rollbackJSONFile = "../json-schemas/rollback.json"
@@ -130,17 +154,33 @@ func Test_updateHandler_migrate(t *testing.T) {
 }
 
 func Test_updateHandler_update(t *testing.T) {
+	notBefore := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
 	testCases := []struct {
 		label        string
 		reader       io.Reader
 		expectedCode int
+		expected     string
 		uClient      mockInstantiationManager
 	}{
 		{
 			label:        "Update DIG",
 			expectedCode: http.StatusAccepted,
+			expected:     "1",
 			uClient:      mockInstantiationManager{},
 		},
+		{
+			label:        "Update DIG in the next maintenance window",
+			expectedCode: http.StatusAccepted,
+			expected:     `{"state":"Scheduled","operation":"update","time":"2029-12-31T00:00:00Z","notBefore":"2030-01-01T00:00:00Z"}`,
+			uClient: mockInstantiationManager{
+				Scheduled: &state.ScheduledEntry{
+					State:     state.StateEnum.Scheduled,
+					Operation: moduleLib.ScheduledOperationUpdate,
+					TimeStamp: notBefore.Add(-24 * time.Hour),
+					NotBefore: notBefore,
+				},
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.label, func(t *testing.T) {
@@ -151,6 +191,10 @@ func Test_updateHandler_update(t *testing.T) {
 			if resp.StatusCode != testCase.expectedCode {
 				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
 			}
+			body, _ := io.ReadAll(resp.Body)
+			if got := strings.TrimSpace(string(body)); got != testCase.expected {
+				t.Fatalf("Expected %s; Got: %s", testCase.expected, got)
+			}
 		})
 	}
 }
@@ -205,3 +249,45 @@ func Test_updateHandler_rollback(t *testing.T) {
 	}
 
 }
+
+func Test_updateHandler_rolloutStatus(t *testing.T) {
+	testCases := []struct {
+		label         string
+		expectedCode  int
+		expectedState moduleLib.RolloutState
+		uClient       mockInstantiationManager
+	}{
+		{
+			label:         "Get DIG Rollout Status",
+			expectedCode:  http.StatusOK,
+			expectedState: moduleLib.RolloutStateEnum.Running,
+			uClient:       mockInstantiationManager{},
+		},
+		{
+			label:        "Get Missing DIG Rollout Status",
+			expectedCode: http.StatusNotFound,
+			uClient: mockInstantiationManager{
+				Err: pkgerrors.New("DeploymentIntentGroup rollout not found"),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/v2/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}/rollout", nil)
+			resp := executeRequest(request, NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCase.uClient, nil))
+
+			//Check returned code
+			if resp.StatusCode != testCase.expectedCode {
+				t.Fatalf("Expected %d; Got: %d", testCase.expectedCode, resp.StatusCode)
+			}
+
+			if resp.StatusCode == http.StatusOK {
+				got := moduleLib.RolloutStatus{}
+				json.NewDecoder(resp.Body).Decode(&got)
+				if got.State != testCase.expectedState || len(got.Waves) != 2 {
+					t.Errorf("rolloutStatusHandler returned unexpected body: got %v", got)
+				}
+			}
+		})
+	}
+}
@@ -5,26 +5,71 @@ package main
 
 import (
 	"context"
+	"flag"
 	"math/rand"
 	"os"
 	"os/signal"
 	"time"
 
+	pkgerrors "github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/api"
 	register "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/grpc"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/backup"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 	contextDb "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/rpc"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/metrics"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/controller"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/statusnotify"
 )
 
+// restoreBackup restores the backup with the name, the latest backup if the
+// name is latest, or the latest backup taken at or before the time. Nothing
+// is restored in databases which aren't empty, e.g. once the backup is
+// restored, so that the orchestrator restarts with the restore options.
+func restoreBackup(ctx context.Context, name, at string) error {
+	ok, err := backup.Restorable(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		log.Warn("The databases aren't empty, the backup isn't restored", log.Fields{"backup": name, "time": at})
+		return nil
+	}
+	t, err := backup.NewTarget(config.GetConfiguration().BackupTarget)
+	if err != nil {
+		return err
+	}
+	if at != "" {
+		tm, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "Invalid restore time %s", at)
+		}
+		name, err = backup.Select(ctx, t, tm)
+		if err != nil {
+			return err
+		}
+	} else if name == "latest" {
+		name, err = backup.Select(ctx, t, time.Time{})
+		if err != nil {
+			return err
+		}
+	}
+	_, err = backup.Restore(ctx, t, name)
+	return err
+}
+
 func main() {
 	rand.Seed(time.Now().UnixNano())
 
+	restore := flag.String("restore", "", "name of the backup, or latest, restored before starting if the databases are empty")
+	restoreTime := flag.String("restore-time", "", "restore the latest backup taken at or before this RFC 3339 time before starting if the databases are empty")
+	flag.Parse()
+
 	ctx := context.Background()
 
 	err := db.InitializeDatabaseConnection(ctx, "emco")
@@ -38,6 +83,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *restore != "" || *restoreTime != "" {
+		err = restoreBackup(ctx, *restore, *restoreTime)
+		if err != nil {
+			log.Error("Unable to restore the backup", log.Fields{"Error": err})
+			os.Exit(1)
+		}
+	}
+
 	grpcServer, err := register.NewGrpcServer("orchestrator", "ORCHESTRATOR_NAME", 9016,
 		register.RegisterStatusNotifyService, statusnotify.StartStatusNotifyServer())
 	if err != nil {
@@ -66,6 +119,15 @@ func main() {
 
 	controller.NewControllerClient("resources", "data", "orchestrator").InitControllers(ctx)
 
+	// execute the operations scheduled in the maintenance windows
+	go module.NewInstantiationClient().RunScheduledOperations(ctx)
+
+	// resume the rollouts left running by the replicas that stopped
+	go module.NewInstantiationClient().RunRollouts(ctx)
+
+	// take the backups of the databases at the configured interval
+	go backup.RunBackups(ctx)
+
 	connectionsClose := make(chan struct{})
 	go func() {
 		c := make(chan os.Signal, 1)
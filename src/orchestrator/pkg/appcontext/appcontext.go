@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/rtcontext"
 )
@@ -89,18 +90,63 @@ var ClusterReadyStatusEnum = &clusterStatuses{
 // appcontext /meta handle of a Composite App or Logical Cloud, may have.
 // Note: only some of these fields will be used in each for each of the types above:
 type CompositeAppMeta struct {
-	Project               string   `json:"Project"`
-	CompositeApp          string   `json:"CompositeApp"`
-	Version               string   `json:"Version"`
-	Release               string   `json:"Release"`
-	DeploymentIntentGroup string   `json:"DeploymentIntentGroup"`
-	Namespace             string   `json:"Namespace"`
-	Level                 string   `json:"Level"`
-	ChildContextIDs       []string `json:"ChildContextIDs"`
-	Services              []string `json:"services"`
-	LogicalCloud          string   `json:"LogicalCloud"`
-	LogicalCloudNamespace string   `json:"LogicalCloudNamespace"`
-	LogicalCloudLevel     string   `json:"LogicalCloudLevel"`
+	Project               string          `json:"Project"`
+	CompositeApp          string          `json:"CompositeApp"`
+	Version               string          `json:"Version"`
+	Release               string          `json:"Release"`
+	DeploymentIntentGroup string          `json:"DeploymentIntentGroup"`
+	Namespace             string          `json:"Namespace"`
+	Level                 string          `json:"Level"`
+	ChildContextIDs       []string        `json:"ChildContextIDs"`
+	Services              []string        `json:"services"`
+	LogicalCloud          string          `json:"LogicalCloud"`
+	LogicalCloudNamespace string          `json:"LogicalCloudNamespace"`
+	LogicalCloudLevel     string          `json:"LogicalCloudLevel"`
+	DriftDetection        *DriftDetection `json:"DriftDetection,omitempty"`
+	RetryPolicy           *RetryPolicy    `json:"RetryPolicy,omitempty"`
+	// Revision of the DeploymentIntentGroup deployed by the context
+	Revision int64 `json:"Revision,omitempty"`
+	// ForceConflicts makes the server-side apply of the resources take the
+	// ownership of the fields managed by other field managers
+	ForceConflicts bool `json:"ForceConflicts,omitempty"`
+	// PruneOrphans makes rsync prune the orphaned resources of the
+	// composite app on the clusters after an update
+	PruneOrphans *PruneOrphans `json:"PruneOrphans,omitempty"`
+}
+
+// DriftDetection configures the periodic comparison, by rsync, of the
+// resources of the composite app with the resources on the clusters
+type DriftDetection struct {
+	// IntervalSeconds is the time between two comparisons
+	IntervalSeconds int `json:"intervalSeconds"`
+	// SelfHeal re-applies the drifted and missing resources
+	SelfHeal bool `json:"selfHeal,omitempty"`
+}
+
+// PruneOrphans configures the pruning, by rsync, of the resources labeled
+// with the deployment of the composite app that aren't in the appcontext
+// anymore after an update
+type PruneOrphans struct {
+	// DryRun only reports the orphaned resources, without deleting them
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// RetryPolicy configures how rsync retries the failed operations on the
+// clusters. The fields that aren't set take the rsync configuration.
+type RetryPolicy struct {
+	// InitialDelaySeconds is the delay before the first retry
+	InitialDelaySeconds float64 `json:"initialDelaySeconds,omitempty"`
+	// MaxDelaySeconds caps the delay between two retries
+	MaxDelaySeconds float64 `json:"maxDelaySeconds,omitempty"`
+	// Multiplier grows the delay after each retry
+	Multiplier float64 `json:"multiplier,omitempty"`
+	// Jitter randomizes each delay by up to this fraction of it
+	Jitter float64 `json:"jitter,omitempty"`
+	// MaxAttempts is the number of attempts of an operation on a resource
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// RetryOn lists the classes of errors that are retried, the other
+	// errors fail at once
+	RetryOn []string `json:"retryOn,omitempty"`
 }
 
 // Init app context
@@ -637,6 +683,24 @@ func (ac *AppContext) UpdateValue(ctx context.Context, handle interface{}, value
 	return ac.rtc.RtcUpdateValue(ctx, handle, value)
 }
 
+// GetValueWithRevision returns the value and the revision for a given handle.
+// The revision is 0 if the handle doesn't exist.
+func (ac *AppContext) GetValueWithRevision(ctx context.Context, handle interface{}) (interface{}, int64, error) {
+	var v interface{}
+	rev, err := ac.rtc.RtcGetValueWithRevision(ctx, handle, &v)
+	if err != nil {
+		return nil, 0, err
+	}
+	return v, rev, nil
+}
+
+// UpdateValueIfRevision updates the value with the given handle only if it was
+// not modified since the revision was read. contextdb.ErrRevisionMismatch is
+// returned otherwise. Any additional puts are committed in the same transaction.
+func (ac *AppContext) UpdateValueIfRevision(ctx context.Context, handle interface{}, value interface{}, revision int64, puts ...contextdb.TxnPut) error {
+	return ac.rtc.RtcUpdateValueIfRevision(ctx, handle, value, revision, puts...)
+}
+
 // Return all the handles under the composite app
 func (ac *AppContext) GetAllHandles(ctx context.Context, handle interface{}) ([]interface{}, error) {
 	hs, err := ac.rtc.RtcGetHandles(ctx, handle)
@@ -696,7 +760,61 @@ func (ac *AppContext) GetCompositeAppMeta(ctx context.Context) (CompositeAppMeta
 	// user-intended level of logical cloud, not level of app itself (which a logical cloud can be):
 	lclevel := fmt.Sprintf("%v", datamap["LogicalCloudLevel"])
 
+	var drift *DriftDetection
+	if dd, ok := datamap["DriftDetection"].(map[string]interface{}); ok {
+		drift = &DriftDetection{}
+		if i, ok := dd["intervalSeconds"].(float64); ok {
+			drift.IntervalSeconds = int(i)
+		}
+		drift.SelfHeal, _ = dd["selfHeal"].(bool)
+	}
+
+	var retry *RetryPolicy
+	if rp, ok := datamap["RetryPolicy"].(map[string]interface{}); ok {
+		retry = &RetryPolicy{}
+		retry.InitialDelaySeconds, _ = rp["initialDelaySeconds"].(float64)
+		retry.MaxDelaySeconds, _ = rp["maxDelaySeconds"].(float64)
+		retry.Multiplier, _ = rp["multiplier"].(float64)
+		retry.Jitter, _ = rp["jitter"].(float64)
+		if i, ok := rp["maxAttempts"].(float64); ok {
+			retry.MaxAttempts = int(i)
+		}
+		if on, ok := rp["retryOn"].([]interface{}); ok {
+			for _, c := range on {
+				retry.RetryOn = append(retry.RetryOn, fmt.Sprintf("%v", c))
+			}
+		}
+	}
+
+	var revision int64
+	if r, ok := datamap["Revision"].(float64); ok {
+		revision = int64(r)
+	}
+	forceConflicts, _ := datamap["ForceConflicts"].(bool)
+
+	var prune *PruneOrphans
+	if po, ok := datamap["PruneOrphans"].(map[string]interface{}); ok {
+		prune = &PruneOrphans{}
+		prune.DryRun, _ = po["dryRun"].(bool)
+	}
+
 	return CompositeAppMeta{Project: p, CompositeApp: ca, Version: v, Release: rn, DeploymentIntentGroup: dig,
 		Namespace: namespace, Level: level, ChildContextIDs: childCtxs, LogicalCloud: lc, LogicalCloudNamespace: lcn,
-		LogicalCloudLevel: lclevel, Services: services}, nil
+		LogicalCloudLevel: lclevel, Services: services, DriftDetection: drift, RetryPolicy: retry, Revision: revision,
+		ForceConflicts: forceConflicts, PruneOrphans: prune}, nil
+}
+
+// SetCompositeAppRevision sets the revision of the DeploymentIntentGroup
+// deployed by the context in the meta data of the composite app
+func (ac *AppContext) SetCompositeAppRevision(ctx context.Context, revision int64) error {
+	mi, err := ac.rtcObj.RtcGetMeta(ctx)
+	if err != nil {
+		return pkgerrors.Errorf("Failed to get compositeApp meta")
+	}
+	datamap, ok := mi.(map[string]interface{})
+	if !ok {
+		return pkgerrors.Errorf("Failed to cast meta interface to compositeApp meta")
+	}
+	datamap["Revision"] = revision
+	return ac.rtc.RtcAddMeta(ctx, datamap)
 }
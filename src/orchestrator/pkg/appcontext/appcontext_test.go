@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
 )
 
 // Mock run time context
@@ -139,6 +140,14 @@ func (c *MockRunTimeContext) RtcUpdateValue(ctx context.Context, handle interfac
 	return c.Err
 }
 
+func (c *MockRunTimeContext) RtcGetValueWithRevision(ctx context.Context, handle interface{}, value interface{}) (int64, error) {
+	return 0, c.RtcGetValue(ctx, handle, value)
+}
+
+func (c *MockRunTimeContext) RtcUpdateValueIfRevision(ctx context.Context, handle interface{}, value interface{}, revision int64, puts ...contextdb.TxnPut) error {
+	return c.RtcUpdateValue(ctx, handle, value)
+}
+
 func TestCreateCompositeApp(t *testing.T) {
 	var ac = AppContext{}
 	testCases := []struct {
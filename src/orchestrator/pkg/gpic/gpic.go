@@ -50,11 +50,15 @@ type ClusterWithLabel struct {
 	ClusterLabel string
 }
 
-// IntentStruc consists of AllOfArray and AnyOfArray
+// IntentStruc consists of AllOfArray and AnyOfArray, and of the selectors
+// resolved by the orchestrator: SpreadArray, WeightedArray and ExcludeArray
 type IntentStruc struct {
-	Selector   ClusterSelector `json:"selector,omitempty"`
-	AllOfArray []AllOf         `json:"allOf,omitempty"`
-	AnyOfArray []AnyOf         `json:"anyOf,omitempty"`
+	Selector      ClusterSelector `json:"selector,omitempty"`
+	AllOfArray    []AllOf         `json:"allOf,omitempty"`
+	AnyOfArray    []AnyOf         `json:"anyOf,omitempty"`
+	SpreadArray   []Spread        `json:"spread,omitempty"`
+	WeightedArray []Weighted      `json:"weighted,omitempty"`
+	ExcludeArray  []Exclude       `json:"exclude,omitempty"`
 }
 
 // AllOf consists if ProviderName, ClusterName, ClusterLabelName and AnyOfArray. Any of them can be empty
@@ -105,6 +109,12 @@ var intentResolverHelper = func(pn, cn, cln string, clusters []ClusterWithName)
 
 // IntentResolver shall help to resolve the given intent into 2 lists of clusters where the app need to be deployed.
 func IntentResolver(intent IntentStruc) (ClusterList, error) {
+	return resolveIntent(intent, nil, nil)
+}
+
+// resolveIntent resolves the intent among the allowed clusters, all of them if
+// allowed is nil. placements has the clusters of the apps excluded by the intent.
+func resolveIntent(intent IntentStruc, allowed clusterSet, placements map[string][]ClusterWithName) (ClusterList, error) {
 	var mc []ClusterWithName
 	var mClusters []ClusterGroup
 	var err error
@@ -136,6 +146,51 @@ func IntentResolver(intent IntentStruc) (ClusterList, error) {
 			oClusters = append(oClusters, eachOptionalCluster)
 		}
 	}
+
+	excluded, err := excludedClusters(intent, placements)
+	if err != nil {
+		return ClusterList{}, pkgerrors.Wrap(err, "Error resolving the excluded clusters")
+	}
+	mClusters = filterGroups(mClusters, excluded)
+	oClusters = filterGroups(oClusters, excluded)
+
+	// the spread and weighted selectors add mandatory clusters, other than the
+	// excluded clusters and the mandatory clusters already selected
+	selected := make(clusterSet, len(excluded)+len(mClusters))
+	for c := range excluded {
+		selected[c] = true
+	}
+	for _, g := range mClusters {
+		for _, c := range g.Clusters {
+			selected[c] = true
+		}
+	}
+	var sClusters []ClusterWithName
+	for _, eachSpread := range intent.SpreadArray {
+		clusters, err := resolveSpread(eachSpread, allowed, selected)
+		if err != nil {
+			return ClusterList{}, err
+		}
+		sClusters = append(sClusters, clusters...)
+		for _, c := range clusters {
+			selected[c] = true
+		}
+	}
+	for _, eachWeighted := range intent.WeightedArray {
+		clusters, err := resolveWeighted(eachWeighted, allowed, selected)
+		if err != nil {
+			return ClusterList{}, err
+		}
+		sClusters = append(sClusters, clusters...)
+		for _, c := range clusters {
+			selected[c] = true
+		}
+	}
+	for _, c := range sClusters {
+		index++
+		mClusters = append(mClusters, ClusterGroup{Clusters: []ClusterWithName{c}, GroupNumber: strconv.Itoa(index)})
+	}
+
 	clusterList := ClusterList{MandatoryClusters: mClusters, OptionalClusters: oClusters}
 	return clusterList, nil
 }
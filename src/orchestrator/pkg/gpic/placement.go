@@ -0,0 +1,319 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package gpic
+
+/*
+ This file implements the placement selectors resolved by the orchestrator
+ itself: spread, weighted and exclude.
+*/
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"gitlab.com/project-emco/core/emco-base/src/clm/pkg/cluster"
+)
+
+// Spread places the app on Replicas clusters of the provider, each in a
+// different topology domain. The domain of a cluster is the value of its label
+// "<topologyKey>.<value>", e.g. "region.us-east" for the topologyKey "region".
+type Spread struct {
+	ProviderName     string `json:"clusterProvider"`
+	ClusterLabelName string `json:"clusterLabel,omitempty"`
+	TopologyKey      string `json:"topologyKey"`
+	Replicas         int    `json:"replicas"`
+}
+
+// Weighted places the app on the Replicas clusters of the provider with the
+// highest score. The score of a cluster is the sum of the weights of the
+// preferences whose label it has.
+type Weighted struct {
+	ProviderName     string       `json:"clusterProvider"`
+	ClusterLabelName string       `json:"clusterLabel,omitempty"`
+	Preferences      []Preference `json:"preferences"`
+	Replicas         int          `json:"replicas"`
+}
+
+// Preference is the weight of a cluster label, negative to avoid the clusters
+type Preference struct {
+	ClusterLabelName string `json:"clusterLabel"`
+	Weight           int    `json:"weight"`
+}
+
+// Exclude removes clusters from the placement of the app: the clusters of a
+// provider, by name or by label, or the clusters another app may be placed on
+type Exclude struct {
+	ProviderName     string `json:"clusterProvider,omitempty"`
+	ClusterName      string `json:"cluster,omitempty"`
+	ClusterLabelName string `json:"clusterLabel,omitempty"`
+	AppName          string `json:"app,omitempty"`
+}
+
+// providerClusters returns the clusters of the provider and their labels
+var providerClusters = func(pn string) ([]cluster.ClusterWithLabels, error) {
+	return cluster.NewClusterClient().GetAllClustersAndLabels(context.Background(), pn)
+}
+
+// ValidateIntent checks the spread, weighted and exclude selectors of the intent
+func ValidateIntent(intent IntentStruc) error {
+	for _, s := range intent.SpreadArray {
+		if s.ProviderName == "" {
+			return fmt.Errorf("\"clusterProvider\" is required in spread")
+		}
+		if s.TopologyKey == "" {
+			return fmt.Errorf("\"topologyKey\" is required in spread")
+		}
+		if s.Replicas < 1 {
+			return fmt.Errorf("invalid \"replicas\" in spread: %d", s.Replicas)
+		}
+	}
+	for _, w := range intent.WeightedArray {
+		if w.ProviderName == "" {
+			return fmt.Errorf("\"clusterProvider\" is required in weighted")
+		}
+		if w.Replicas < 1 {
+			return fmt.Errorf("invalid \"replicas\" in weighted: %d", w.Replicas)
+		}
+		if len(w.Preferences) == 0 {
+			return fmt.Errorf("\"preferences\" are required in weighted")
+		}
+		for _, p := range w.Preferences {
+			if p.ClusterLabelName == "" {
+				return fmt.Errorf("\"clusterLabel\" is required in preference")
+			}
+		}
+	}
+	for _, e := range intent.ExcludeArray {
+		if (e.AppName == "") == (e.ProviderName == "") {
+			return fmt.Errorf("one of \"app\" or \"clusterProvider\" is required in exclude")
+		}
+		if e.ClusterName != "" && e.ClusterLabelName != "" {
+			return fmt.Errorf("only one of \"cluster\" or \"clusterLabel\" is allowed in exclude")
+		}
+	}
+	return nil
+}
+
+// clusterSet is a set of clusters, a cluster with no name stands for all the
+// clusters of the provider
+type clusterSet map[ClusterWithName]bool
+
+func (s clusterSet) has(c ClusterWithName) bool {
+	return s[c] || s[ClusterWithName{ProviderName: c.ProviderName}]
+}
+
+// excludedClusters returns the clusters excluded by the intent
+func excludedClusters(intent IntentStruc, placements map[string][]ClusterWithName) (clusterSet, error) {
+	excluded := make(clusterSet)
+	for _, e := range intent.ExcludeArray {
+		switch {
+		case e.AppName != "":
+			for _, c := range placements[e.AppName] {
+				excluded[c] = true
+			}
+		case e.ClusterName == "" && e.ClusterLabelName == "":
+			excluded[ClusterWithName{ProviderName: e.ProviderName}] = true
+		default:
+			clusters, err := intentResolverHelper(e.ProviderName, e.ClusterName, e.ClusterLabelName, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range clusters {
+				excluded[c] = true
+			}
+		}
+	}
+	return excluded, nil
+}
+
+// candidateClusters returns the clusters of the provider that have the label,
+// if any, are allowed and aren't excluded, sorted by name
+func candidateClusters(pn, label string, allowed, excluded clusterSet) ([]cluster.ClusterWithLabels, error) {
+	clusters, err := providerClusters(pn)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error getting the clusters of cluster provider "+pn)
+	}
+	var candidates []cluster.ClusterWithLabels
+	for _, c := range clusters {
+		cn := ClusterWithName{pn, c.Metadata.Name}
+		if excluded.has(cn) || (allowed != nil && !allowed.has(cn)) {
+			continue
+		}
+		if label != "" && !hasLabel(c, label) {
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Metadata.Name < candidates[j].Metadata.Name
+	})
+	return candidates, nil
+}
+
+func hasLabel(c cluster.ClusterWithLabels, label string) bool {
+	for _, l := range c.Labels {
+		if l.LabelName == label {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSpread returns the first cluster of each topology domain, in the
+// order of the domains, until there are enough replicas
+func resolveSpread(s Spread, allowed, excluded clusterSet) ([]ClusterWithName, error) {
+	candidates, err := candidateClusters(s.ProviderName, s.ClusterLabelName, allowed, excluded)
+	if err != nil {
+		return nil, err
+	}
+	prefix := s.TopologyKey + "."
+	domains := make(map[string]ClusterWithName)
+	for _, c := range candidates {
+		for _, l := range c.Labels {
+			if !strings.HasPrefix(l.LabelName, prefix) {
+				continue
+			}
+			d := strings.TrimPrefix(l.LabelName, prefix)
+			if _, ok := domains[d]; !ok {
+				domains[d] = ClusterWithName{s.ProviderName, c.Metadata.Name}
+			}
+			break
+		}
+	}
+	if len(domains) < s.Replicas {
+		return nil, pkgerrors.Errorf("Not enough topology domains for spread: %d %s domains found for %d replicas", len(domains), s.TopologyKey, s.Replicas)
+	}
+
+	names := make([]string, 0, len(domains))
+	for d := range domains {
+		names = append(names, d)
+	}
+	sort.Strings(names)
+	clusters := make([]ClusterWithName, 0, s.Replicas)
+	for _, d := range names[:s.Replicas] {
+		clusters = append(clusters, domains[d])
+	}
+	return clusters, nil
+}
+
+// resolveWeighted returns the clusters with the highest score, the clusters
+// with the same score are sorted by name
+func resolveWeighted(w Weighted, allowed, excluded clusterSet) ([]ClusterWithName, error) {
+	candidates, err := candidateClusters(w.ProviderName, w.ClusterLabelName, allowed, excluded)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) < w.Replicas {
+		return nil, pkgerrors.Errorf("Not enough clusters for weighted: %d clusters found for %d replicas", len(candidates), w.Replicas)
+	}
+
+	scores := make(map[string]int, len(candidates))
+	for _, c := range candidates {
+		for _, p := range w.Preferences {
+			if hasLabel(c, p.ClusterLabelName) {
+				scores[c.Metadata.Name] += p.Weight
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].Metadata.Name] > scores[candidates[j].Metadata.Name]
+	})
+	clusters := make([]ClusterWithName, 0, w.Replicas)
+	for _, c := range candidates[:w.Replicas] {
+		clusters = append(clusters, ClusterWithName{w.ProviderName, c.Metadata.Name})
+	}
+	return clusters, nil
+}
+
+// filterGroups removes the excluded clusters from the groups, and the groups
+// left without clusters
+func filterGroups(groups []ClusterGroup, excluded clusterSet) []ClusterGroup {
+	if len(excluded) == 0 {
+		return groups
+	}
+	var filtered []ClusterGroup
+	for _, g := range groups {
+		var clusters []ClusterWithName
+		for _, c := range g.Clusters {
+			if !excluded.has(c) {
+				clusters = append(clusters, c)
+			}
+		}
+		if len(clusters) > 0 {
+			filtered = append(filtered, ClusterGroup{Clusters: clusters, GroupNumber: g.GroupNumber})
+		}
+	}
+	return filtered
+}
+
+/*
+ResolveIntents resolves the intents of the apps of a composite app. The
+clusters of the spread and weighted selectors are chosen among the allowed
+clusters, all the clusters of their provider if allowed is nil. The clusters
+an exclude selector removes for another app are all the clusters that app may
+be placed on, without its own app exclusions.
+*/
+func ResolveIntents(intents map[string]IntentStruc, allowed []ClusterWithName) (map[string]ClusterList, error) {
+	var allowedSet clusterSet
+	if allowed != nil {
+		allowedSet = make(clusterSet, len(allowed))
+		for _, c := range allowed {
+			allowedSet[c] = true
+		}
+	}
+
+	apps := make([]string, 0, len(intents))
+	for app := range intents {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+
+	// resolve the intents without the app exclusions first
+	resolved := make(map[string]ClusterList, len(intents))
+	placements := make(map[string][]ClusterWithName, len(intents))
+	for _, app := range apps {
+		intent := intents[app]
+		var excludes []Exclude
+		for _, e := range intent.ExcludeArray {
+			if e.AppName == "" {
+				excludes = append(excludes, e)
+			}
+		}
+		intent.ExcludeArray = excludes
+		l, err := resolveIntent(intent, allowedSet, nil)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "Error resolving the intent of app %s", app)
+		}
+		resolved[app] = l
+		for _, g := range append(append([]ClusterGroup{}, l.MandatoryClusters...), l.OptionalClusters...) {
+			placements[app] = append(placements[app], g.Clusters...)
+		}
+	}
+
+	// then the intents with app exclusions
+	for _, app := range apps {
+		if !hasAppExclusion(intents[app]) {
+			continue
+		}
+		l, err := resolveIntent(intents[app], allowedSet, placements)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "Error resolving the intent of app %s", app)
+		}
+		resolved[app] = l
+	}
+	return resolved, nil
+}
+
+func hasAppExclusion(intent IntentStruc) bool {
+	for _, e := range intent.ExcludeArray {
+		if e.AppName != "" {
+			return true
+		}
+	}
+	return false
+}
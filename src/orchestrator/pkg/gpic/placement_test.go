@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package gpic
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gitlab.com/project-emco/core/emco-base/src/clm/pkg/cluster"
+	mtypes "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
+)
+
+func mockPlacementClusters() {
+	labels := map[string][]string{
+		"edge1": {"region.east", "gpu"},
+		"edge2": {"region.east"},
+		"edge3": {"region.west", "ssd"},
+		"edge4": {"region.west", "gpu", "ssd"},
+		"edge5": {"region.north"},
+		"edge6": {},
+	}
+	providerClusters = func(pn string) ([]cluster.ClusterWithLabels, error) {
+		var clusters []cluster.ClusterWithLabels
+		for name, ls := range labels {
+			c := cluster.ClusterWithLabels{Metadata: mtypes.Metadata{Name: name}}
+			for _, l := range ls {
+				c.Labels = append(c.Labels, cluster.ClusterLabel{LabelName: l})
+			}
+			clusters = append(clusters, c)
+		}
+		return clusters, nil
+	}
+	intentResolverHelper = func(pn, cn, cln string, clusters []ClusterWithName) ([]ClusterWithName, error) {
+		if cn != "" {
+			return append(clusters, ClusterWithName{pn, cn}), nil
+		}
+		for name, ls := range labels {
+			for _, l := range ls {
+				if l == cln {
+					clusters = append(clusters, ClusterWithName{pn, name})
+				}
+			}
+		}
+		return clusters, nil
+	}
+}
+
+func mandatoryClusterNames(l ClusterList) []string {
+	var names []string
+	for _, g := range l.MandatoryClusters {
+		for _, c := range g.Clusters {
+			names = append(names, c.ClusterName)
+		}
+	}
+	return names
+}
+
+func TestResolvePlacement(t *testing.T) {
+	mockPlacementClusters()
+
+	testCases := []struct {
+		label         string
+		intent        IntentStruc
+		allowed       clusterSet
+		expected      []string
+		expectedError string
+	}{
+		{
+			label:    "Spread across regions",
+			intent:   IntentStruc{SpreadArray: []Spread{{ProviderName: "aws", TopologyKey: "region", Replicas: 2}}},
+			expected: []string{"edge1", "edge5"},
+		},
+		{
+			label:    "Spread among allowed clusters",
+			intent:   IntentStruc{SpreadArray: []Spread{{ProviderName: "aws", TopologyKey: "region", Replicas: 2}}},
+			allowed:  clusterSet{{"aws", "edge2"}: true, {"aws", "edge3"}: true},
+			expected: []string{"edge2", "edge3"},
+		},
+		{
+			label:         "Spread with too many replicas",
+			intent:        IntentStruc{SpreadArray: []Spread{{ProviderName: "aws", TopologyKey: "region", Replicas: 4}}},
+			expectedError: "Not enough topology domains for spread",
+		},
+		{
+			label: "Weighted by labels",
+			intent: IntentStruc{WeightedArray: []Weighted{{ProviderName: "aws", Replicas: 2,
+				Preferences: []Preference{{ClusterLabelName: "gpu", Weight: 10}, {ClusterLabelName: "ssd", Weight: 5}}}}},
+			expected: []string{"edge4", "edge1"},
+		},
+		{
+			label: "Weighted with negative weight",
+			intent: IntentStruc{WeightedArray: []Weighted{{ProviderName: "aws", ClusterLabelName: "region.west", Replicas: 1,
+				Preferences: []Preference{{ClusterLabelName: "gpu", Weight: -1}}}}},
+			expected: []string{"edge3"},
+		},
+		{
+			label: "Exclude from allOf and spread",
+			intent: IntentStruc{
+				AllOfArray:   []AllOf{{ProviderName: "aws", ClusterName: "edge1"}, {ProviderName: "aws", ClusterName: "edge6"}},
+				SpreadArray:  []Spread{{ProviderName: "aws", TopologyKey: "region", Replicas: 2}},
+				ExcludeArray: []Exclude{{ProviderName: "aws", ClusterName: "edge6"}, {ProviderName: "aws", ClusterLabelName: "region.north"}},
+			},
+			// edge1 is already placed, the spread chooses other clusters
+			expected: []string{"edge1", "edge2", "edge3"},
+		},
+		{
+			label: "Exclude a provider",
+			intent: IntentStruc{
+				AllOfArray:   []AllOf{{ProviderName: "aws", ClusterName: "edge1"}, {ProviderName: "azure", ClusterName: "edge1"}},
+				ExcludeArray: []Exclude{{ProviderName: "azure"}},
+			},
+			expected: []string{"edge1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			l, err := resolveIntent(testCase.intent, testCase.allowed, nil)
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Fatalf("resolveIntent returned (%v), expected %s", err, testCase.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveIntent returned an error (%s)", err)
+			}
+			if got := mandatoryClusterNames(l); !reflect.DeepEqual(got, testCase.expected) {
+				t.Fatalf("resolveIntent returned %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestResolveIntents(t *testing.T) {
+	mockPlacementClusters()
+
+	intents := map[string]IntentStruc{
+		"db": {
+			AnyOfArray: []AnyOf{{ProviderName: "aws", ClusterName: "edge1"}, {ProviderName: "aws", ClusterName: "edge2"}},
+		},
+		"cache": {
+			SpreadArray:  []Spread{{ProviderName: "aws", TopologyKey: "region", Replicas: 2}},
+			ExcludeArray: []Exclude{{AppName: "db"}},
+		},
+	}
+	resolved, err := ResolveIntents(intents, nil)
+	if err != nil {
+		t.Fatalf("ResolveIntents returned an error (%s)", err)
+	}
+	if got := mandatoryClusterNames(resolved["cache"]); !reflect.DeepEqual(got, []string{"edge5", "edge3"}) {
+		t.Fatalf("ResolveIntents placed cache on %v", got)
+	}
+	if len(resolved["db"].OptionalClusters) != 2 || len(resolved["db"].MandatoryClusters) != 0 {
+		t.Fatalf("ResolveIntents placed db on %v", resolved["db"])
+	}
+}
+
+func TestValidateIntent(t *testing.T) {
+	for _, intent := range []IntentStruc{
+		{SpreadArray: []Spread{{ProviderName: "aws", Replicas: 1}}},
+		{SpreadArray: []Spread{{ProviderName: "aws", TopologyKey: "region"}}},
+		{WeightedArray: []Weighted{{ProviderName: "aws", Replicas: 1}}},
+		{ExcludeArray: []Exclude{{}}},
+		{ExcludeArray: []Exclude{{AppName: "app1", ProviderName: "aws"}}},
+		{ExcludeArray: []Exclude{{ProviderName: "aws", ClusterName: "c1", ClusterLabelName: "east"}}},
+	} {
+		if err := ValidateIntent(intent); err == nil {
+			t.Fatalf("ValidateIntent of %+v returned no error", intent)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+// Package apiauth authenticates the requests to the REST APIs of the EMCO
+// services with OIDC/JWT bearer tokens, and authorizes them with the roles
+// granted to the groups or roles of the users in their tokens.
+package apiauth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+type principalKey struct{}
+type subjectKey struct{}
+
+// binding is a role granted to the value of the roles claim
+type binding struct {
+	claim    string
+	role     Role
+	projects []string
+}
+
+// Middleware authenticates and authorizes the requests
+type Middleware struct {
+	verifier   *verifier
+	rolesClaim string
+	bindings   []binding
+	exempt     map[string]bool
+}
+
+// New returns the middleware of the authentication configuration
+func New(cfg *config.Configuration) (*Middleware, error) {
+	var keys *keySet
+	switch {
+	case cfg.AuthJwksFile != "":
+		var err error
+		if keys, err = newFileKeySet(cfg.AuthJwksFile); err != nil {
+			return nil, err
+		}
+	case cfg.AuthIssuer != "":
+		keys = newIssuerKeySet(cfg.AuthIssuer)
+	default:
+		return nil, pkgerrors.New("Authentication requires an auth-issuer or an auth-jwks-file")
+	}
+	m := &Middleware{
+		verifier:   &verifier{keys: keys, issuer: cfg.AuthIssuer, audience: cfg.AuthAudience, now: time.Now},
+		rolesClaim: cfg.AuthRolesClaim,
+		exempt:     make(map[string]bool),
+	}
+	for _, b := range cfg.AuthRoleBindings {
+		role, err := parseRole(b.Role)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "Invalid role binding of %s", b.Claim)
+		}
+		m.bindings = append(m.bindings, binding{claim: b.Claim, role: role, projects: b.Projects})
+	}
+	for _, p := range cfg.AuthExemptPaths {
+		m.exempt[p] = true
+	}
+	return m, nil
+}
+
+// Handler requires a valid bearer token granting the role required by the
+// request, and adds the user to the context of the request
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.exempt[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := bearerToken(r)
+		if token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="emco"`)
+			http.Error(w, "Missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		claims, err := m.verifier.verify(r.Context(), token)
+		if err != nil {
+			log.Warn("Invalid bearer token", log.Fields{"method": r.Method, "path": r.URL.Path, "error": err})
+			w.Header().Set("WWW-Authenticate", `Bearer realm="emco", error="invalid_token"`)
+			http.Error(w, "Invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		p := m.principal(claims)
+		SetSubject(r.Context(), p.Subject)
+		if err := authorize(p, r.Method, r.URL.Path); err != nil {
+			log.Warn("Request denied", log.Fields{"subject": p.Subject, "method": r.Method, "path": r.URL.Path, "error": err})
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), p)))
+	})
+}
+
+// principal returns the user of the claims with the roles bound to the
+// values of its roles claim
+func (m *Middleware) principal(claims Claims) Principal {
+	p := Principal{ProjectRoles: make(map[string]Role)}
+	p.Subject, _ = claims["sub"].(string)
+	for _, v := range claimValues(claims, m.rolesClaim) {
+		for _, b := range m.bindings {
+			if b.claim != v {
+				continue
+			}
+			if len(b.projects) == 0 {
+				if b.role > p.Role {
+					p.Role = b.role
+				}
+				continue
+			}
+			for _, project := range b.projects {
+				if b.role > p.ProjectRoles[project] {
+					p.ProjectRoles[project] = b.role
+				}
+			}
+		}
+	}
+	return p
+}
+
+// claimValues returns the values of the claim, a string or a list of
+// strings, at the dotted path, e.g. realm_access.roles
+func claimValues(claims Claims, path string) []string {
+	var v interface{} = map[string]interface{}(claims)
+	for _, name := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	switch c := v.(type) {
+	case string:
+		return []string{c}
+	case []interface{}:
+		var values []string
+		for _, e := range c {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if len(h) < 7 || !strings.EqualFold(h[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(h[7:])
+}
+
+// NewContext returns the context with the authenticated user
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// NewSubjectContext returns the context of a request where the middleware
+// sets the subject of the user once the token is verified, even if the
+// request is then denied, for the middlewares running before it, e.g. the
+// audit
+func NewSubjectContext(ctx context.Context) (context.Context, *string) {
+	s := new(string)
+	return context.WithValue(ctx, subjectKey{}, s), s
+}
+
+// SetSubject sets the subject of the user in the context returned by
+// NewSubjectContext, if the context has one
+func SetSubject(ctx context.Context, subject string) {
+	if s, ok := ctx.Value(subjectKey{}).(*string); ok {
+		*s = subject
+	}
+}
+
+// FromContext returns the authenticated user of the request, if the
+// authentication is enabled
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
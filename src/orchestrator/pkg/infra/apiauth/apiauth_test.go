@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apiauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes())}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{"kty": "EC", "kid": kid, "crv": "P-256", "x": b64(key.X.Bytes()), "y": b64(key.Y.Bytes())}
+}
+
+// sign returns a token with the claims signed with the key
+func sign(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	h, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	c, _ := json.Marshal(claims)
+	input := b64(h) + "." + b64(c)
+	digest := sha256.Sum256([]byte(input))
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return input + "." + b64(sig)
+}
+
+func newTestMiddleware(t *testing.T, cfg *config.Configuration, jwks ...map[string]string) *Middleware {
+	data, _ := json.Marshal(map[string]interface{}{"keys": jwks})
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	cfg.AuthJwksFile = file
+	m, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	return m
+}
+
+func serve(m *Middleware, method, path, token string) (*httptest.ResponseRecorder, Principal) {
+	var p Principal
+	h := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, _ = FromContext(r.Context())
+	}))
+	r := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w, p
+}
+
+func TestMiddleware(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	cfg := &config.Configuration{
+		AuthIssuer:     "https://idp.example.com",
+		AuthAudience:   "emco",
+		AuthRolesClaim: "realm_access.roles",
+		AuthRoleBindings: []config.AuthRoleBinding{
+			{Claim: "emco-admins", Role: "admin"},
+			{Claim: "team-a", Role: "operator", Projects: []string{"proj1"}},
+			{Claim: "auditors", Role: "viewer"},
+		},
+		AuthExemptPaths: []string{"/metrics"},
+	}
+	m := newTestMiddleware(t, cfg, rsaJWK("rsa1", rsaKey), ecJWK("ec1", ecKey))
+
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := func(sub string, roles ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"sub": sub, "iss": "https://idp.example.com", "aud": []string{"account", "emco"}, "exp": exp,
+			"realm_access": map[string]interface{}{"roles": roles},
+		}
+	}
+	operator := sign(t, "RS256", "rsa1", rsaKey, claims("user1", "team-a", "auditors"))
+	admin := sign(t, "ES256", "ec1", ecKey, claims("admin1", "emco-admins"))
+
+	tests := []struct {
+		name, method, path, token string
+		status                    int
+	}{
+		{"no token", http.MethodGet, "/v2/projects/proj1", "", http.StatusUnauthorized},
+		{"exempt path", http.MethodGet, "/metrics", "", http.StatusOK},
+		{"operator reads its project", http.MethodGet, "/v2/projects/proj1/composite-apps", operator, http.StatusOK},
+		{"operator instantiates in its project", http.MethodPost, "/v2/projects/proj1/composite-apps/ca/v1/deployment-intent-groups/dig/instantiate", operator, http.StatusOK},
+		{"operator deletes its project", http.MethodDelete, "/v2/projects/proj1", operator, http.StatusForbidden},
+		{"operator changes another project", http.MethodPost, "/v2/projects/proj2/composite-apps", operator, http.StatusForbidden},
+		{"viewer reads another project", http.MethodGet, "/v2/projects/proj2/composite-apps", operator, http.StatusOK},
+		{"operator reads the controllers", http.MethodGet, "/v2/controllers", operator, http.StatusForbidden},
+		{"admin adds a cluster provider", http.MethodPost, "/v2/cluster-providers", admin, http.StatusOK},
+		{"admin creates a project", http.MethodPost, "/v2/projects", admin, http.StatusOK},
+		{"wrong key", http.MethodGet, "/v2/projects/proj1", sign(t, "RS256", "ec1", rsaKey, claims("user1", "team-a")), http.StatusUnauthorized},
+		{"expired", http.MethodGet, "/v2/projects/proj1", sign(t, "RS256", "rsa1", rsaKey, map[string]interface{}{"iss": "https://idp.example.com", "aud": "emco", "exp": time.Now().Add(-time.Hour).Unix()}), http.StatusUnauthorized},
+		{"other audience", http.MethodGet, "/v2/projects/proj1", sign(t, "RS256", "rsa1", rsaKey, map[string]interface{}{"iss": "https://idp.example.com", "aud": "other", "exp": exp}), http.StatusUnauthorized},
+		{"other issuer", http.MethodGet, "/v2/projects/proj1", sign(t, "RS256", "rsa1", rsaKey, map[string]interface{}{"iss": "https://other.example.com", "aud": "emco", "exp": exp}), http.StatusUnauthorized},
+		{"tampered", http.MethodGet, "/v2/projects/proj1", operator[:len(operator)-4] + "AAAA", http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w, _ := serve(m, tc.method, tc.path, tc.token)
+			if w.Code != tc.status {
+				t.Errorf("Expected status %d, got %d %s", tc.status, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	_, p := serve(m, http.MethodGet, "/v2/projects/proj1", operator)
+	if p.Subject != "user1" || p.Role != RoleViewer || p.RoleIn("proj1") != RoleOperator {
+		t.Errorf("Unexpected user %+v", p)
+	}
+}
+
+func TestIssuerKeys(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/keys"})
+		case "/keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{rsaJWK("k1", key)}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	m, err := New(&config.Configuration{
+		AuthIssuer:       server.URL,
+		AuthRolesClaim:   "groups",
+		AuthRoleBindings: []config.AuthRoleBinding{{Claim: "emco-admins", Role: "admin"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	token := sign(t, "RS256", "k1", key, map[string]interface{}{"iss": server.URL, "exp": time.Now().Add(time.Hour).Unix(), "groups": "emco-admins"})
+	if w, _ := serve(m, http.MethodDelete, "/v2/controllers/rsync", token); w.Code != http.StatusOK {
+		t.Errorf("Expected the admin to be allowed, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := New(&config.Configuration{}); err == nil {
+		t.Errorf("Expected an error without keys")
+	}
+	if _, err := New(&config.Configuration{AuthJwksFile: filepath.Join(os.TempDir(), "missing-jwks.json")}); err == nil {
+		t.Errorf("Expected an error for a missing JWKS file")
+	}
+	_, err := New(&config.Configuration{AuthIssuer: "https://idp.example.com", AuthRoleBindings: []config.AuthRoleBinding{{Claim: "g", Role: "owner"}}})
+	if err == nil {
+		t.Errorf("Expected an error for an unknown role")
+	}
+}
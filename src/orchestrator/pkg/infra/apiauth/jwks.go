@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apiauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// The keys of the issuer are fetched again for an unknown key at most once
+// per refreshInterval
+const refreshInterval = time.Minute
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// parseJWKS returns the signing keys of the JWKS by key ID
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, pkgerrors.Wrap(err, "Invalid JWKS")
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			log.Warn("Skipping a key of the JWKS", log.Fields{"kid": k.Kid, "error": err})
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, pkgerrors.New("No signing key in the JWKS")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, pkgerrors.Errorf("Unsupported curve %s", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, pkgerrors.New("Point not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, pkgerrors.Errorf("Unsupported key type %s", k.Kty)
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, pkgerrors.New("Invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// keySet holds the keys of a local JWKS file or, discovered from the OIDC
+// configuration of the issuer, the keys of the issuer
+type keySet struct {
+	issuer string
+	client *http.Client
+
+	mutex     sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// newFileKeySet returns the keys of the JWKS file
+func newFileKeySet(file string) (*keySet, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error reading the JWKS file")
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	return &keySet{keys: keys}, nil
+}
+
+// newIssuerKeySet returns the keys of the issuer, fetched on first use
+func newIssuerKeySet(issuer string) *keySet {
+	return &keySet{issuer: issuer, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// key returns the key with the ID, or the only key if the token has no key ID
+func (s *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	// The issuer may have rotated its keys
+	if s.issuer == "" || time.Since(s.fetchedAt) < refreshInterval {
+		return nil, pkgerrors.Errorf("Unknown signing key %s", kid)
+	}
+	s.fetchedAt = time.Now()
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.keys = keys
+	if key, ok := s.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, pkgerrors.Errorf("Unknown signing key %s", kid)
+}
+
+func (s *keySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if key, ok := s.keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// fetch reads the keys from the jwks_uri of the OIDC configuration of the
+// issuer
+func (s *keySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	var discovery struct {
+		JwksURI string `json:"jwks_uri"`
+	}
+	data, err := s.get(ctx, strings.TrimSuffix(s.issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error discovering the OIDC configuration")
+	}
+	if err := json.Unmarshal(data, &discovery); err != nil || discovery.JwksURI == "" {
+		return nil, pkgerrors.New("Invalid OIDC configuration")
+	}
+	data, err = s.get(ctx, discovery.JwksURI)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error fetching the JWKS")
+	}
+	log.Info("Fetched the keys of the issuer", log.Fields{"issuer": s.issuer, "jwks": discovery.JwksURI})
+	return parseJWKS(data)
+}
+
+func (s *keySet) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, pkgerrors.Errorf("%s returned %s", url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apiauth
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Role is a role of a user, in a project or in all of EMCO. Each role has
+// the permissions of the lower roles.
+type Role int
+
+const (
+	// RoleNone grants nothing
+	RoleNone Role = iota
+	// RoleViewer reads the resources
+	RoleViewer
+	// RoleOperator also creates, updates, deletes and operates the
+	// resources of a project
+	RoleOperator
+	// RoleAdmin also manages the projects, the controllers and the
+	// cluster providers, and reads the audit records
+	RoleAdmin
+)
+
+var roleNames = map[string]Role{
+	"viewer":   RoleViewer,
+	"operator": RoleOperator,
+	"admin":    RoleAdmin,
+}
+
+func (r Role) String() string {
+	for name, role := range roleNames {
+		if role == r {
+			return name
+		}
+	}
+	return "none"
+}
+
+// parseRole returns the role of the name
+func parseRole(name string) (Role, error) {
+	r, ok := roleNames[name]
+	if !ok {
+		return RoleNone, pkgerrors.Errorf("Unknown role %s", name)
+	}
+	return r, nil
+}
+
+// Principal is the authenticated user of a request
+type Principal struct {
+	Subject string
+	// Role in all of EMCO
+	Role Role
+	// Roles in the projects
+	ProjectRoles map[string]Role
+}
+
+// RoleIn returns the role of the user in the project
+func (p Principal) RoleIn(project string) Role {
+	if r := p.ProjectRoles[project]; r > p.Role {
+		return r
+	}
+	return p.Role
+}
+
+// Resources reserved to the admins of EMCO
+var adminResources = map[string]bool{
+	"controllers":       true,
+	"cluster-providers": true,
+	"audit":             true,
+	"encryption":        true,
+	"backups":           true,
+}
+
+// Resources of a project reserved to its admins: its bundle holds its
+// secrets in the clear, and the import creates the project
+var projectAdminResources = map[string]bool{
+	"export": true,
+	"import": true,
+}
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// requiredRole returns the project of the request, empty for the resources
+// outside the projects, and the role it requires in the project:
+//   - the resources of a project are read by its viewers and changed by its
+//     operators, the project itself is changed, exported and imported by
+//     its admins
+//   - the controllers, the cluster providers, the audit records, the
+//     encryption and the backups are reserved to the admins
+//   - the other resources, e.g. the list of the projects, are read by the
+//     viewers and changed by the admins
+func requiredRole(method, path string) (string, Role) {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segs) > 0 && versionSegment.MatchString(segs[0]) {
+		segs = segs[1:]
+	}
+	read := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+	switch {
+	case len(segs) > 0 && adminResources[segs[0]]:
+		return "", RoleAdmin
+	case len(segs) >= 2 && segs[0] == "projects" && segs[1] != "":
+		if len(segs) == 3 && projectAdminResources[segs[2]] {
+			return segs[1], RoleAdmin
+		}
+		if read {
+			return segs[1], RoleViewer
+		}
+		if len(segs) == 2 {
+			return segs[1], RoleAdmin
+		}
+		return segs[1], RoleOperator
+	case read:
+		return "", RoleViewer
+	}
+	return "", RoleAdmin
+}
+
+// authorize returns an error if the user doesn't have the role required by
+// the request
+func authorize(p Principal, method, path string) error {
+	project, role := requiredRole(method, path)
+	if p.RoleIn(project) >= role {
+		return nil
+	}
+	if project != "" {
+		return pkgerrors.Errorf("%s requires the role %s in the project %s", method, role, project)
+	}
+	return pkgerrors.Errorf("%s requires the role %s", method, role)
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apiauth
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRequiredRole(t *testing.T) {
+	tests := []struct {
+		method, path, project string
+		role                  Role
+	}{
+		{http.MethodGet, "/v2/projects", "", RoleViewer},
+		{http.MethodPost, "/v2/projects", "", RoleAdmin},
+		{http.MethodGet, "/v2/projects/proj1", "proj1", RoleViewer},
+		{http.MethodPut, "/v2/projects/proj1", "proj1", RoleAdmin},
+		{http.MethodDelete, "/v2/projects/proj1/", "proj1", RoleAdmin},
+		{http.MethodPost, "/v2/projects/proj1/logical-clouds/lc1/instantiate", "proj1", RoleOperator},
+		{http.MethodGet, "/v2/projects/proj1/composite-apps/ca/v1/deployment-intent-groups/dig/status", "proj1", RoleViewer},
+		{http.MethodGet, "/v2/projects/proj1/export", "proj1", RoleAdmin},
+		{http.MethodPost, "/v2/projects/proj2/import", "proj2", RoleAdmin},
+		{http.MethodGet, "/v2/controllers", "", RoleAdmin},
+		{http.MethodGet, "/v2/cluster-providers/p1/clusters", "", RoleAdmin},
+		{http.MethodGet, "/v2/audit", "", RoleAdmin},
+		{http.MethodPost, "/v2/encryption/reencrypt", "", RoleAdmin},
+		{http.MethodGet, "/v2/backups", "", RoleAdmin},
+		{http.MethodPost, "/v2/dtc-controllers", "", RoleAdmin},
+		{http.MethodGet, "/v2/dtc-controllers", "", RoleViewer},
+	}
+	for _, tc := range tests {
+		project, role := requiredRole(tc.method, tc.path)
+		if project != tc.project || role != tc.role {
+			t.Errorf("%s %s: expected %s in %q, got %s in %q", tc.method, tc.path, tc.role, tc.project, role, project)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	p := Principal{Role: RoleViewer, ProjectRoles: map[string]Role{"proj1": RoleAdmin}}
+	if err := authorize(p, http.MethodDelete, "/v2/projects/proj1"); err != nil {
+		t.Errorf("Expected the project admin to delete the project, got %s", err)
+	}
+	if err := authorize(p, http.MethodPost, "/v2/projects/proj2/composite-apps"); err == nil {
+		t.Errorf("Expected the viewer not to change another project")
+	}
+	if err := authorize(p, http.MethodPost, "/v2/controllers"); err == nil {
+		t.Errorf("Expected a project admin not to add controllers")
+	}
+}
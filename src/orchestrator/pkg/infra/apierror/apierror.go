@@ -27,6 +27,14 @@ var dbErrors = []APIError{
 	{ID: "db Insert referential schema missing", Message: "Cannot perform requested operation. The requested resource is not defined in the referential schema", Status: http.StatusConflict},
 }
 
+// listErrors are the errors of the filtering, sorting and paging options of the list requests
+var listErrors = []APIError{
+	{ID: "Invalid filter field", Message: "Invalid filter field", Status: http.StatusBadRequest},
+	{ID: "Invalid sort field", Message: "Invalid sort field", Status: http.StatusBadRequest},
+	{ID: "Invalid limit", Message: "Invalid limit", Status: http.StatusBadRequest},
+	{ID: "Invalid continue token", Message: "Invalid continue token", Status: http.StatusBadRequest},
+}
+
 // shared list the errors a controller can get from a dependent controller
 // for example, DTC calls the orchestrator to check the status of a deployment intent group
 // and the orchestrator returns `DeploymentIntentGroup not found`
@@ -58,6 +66,13 @@ func HandleErrors(params map[string]string, err error, mod interface{}, apiErr [
 		}
 	}
 
+	// list options errors
+	for _, e := range listErrors {
+		if strings.Contains(err.Error(), e.ID) {
+			return e
+		}
+	}
+
 	// api specific errors
 	for _, e := range apiErr {
 		if strings.Contains(err.Error(), e.ID) {
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apilist
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+// ContinueHeader is the response header holding the token of the next page of a list
+const ContinueHeader = "X-Continue-Token"
+
+// FindOptions reads the options of a list request from its query parameters:
+//
+//	limit: the maximum number of resources returned
+//	continue: the token of the page, returned in the ContinueHeader of the previous page
+//	sort: the field to sort by, in descending order if prefixed with '-'
+//	filter: comma separated field=value selectors, e.g. filter=state=Instantiated,logicalCloud=lc1
+//
+// The fields are the names of the field selectors of the resource, not their path.
+func FindOptions(r *http.Request) (db.FindOptions, error) {
+	return parseFindOptions(r)
+}
+
+// parseFindOptions reads the options of a list request from its query parameters
+func parseFindOptions(r *http.Request) (db.FindOptions, error) {
+	q := r.URL.Query()
+	opts := db.FindOptions{
+		Continue: q.Get("continue"),
+		Sort:     q.Get("sort"),
+	}
+
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.ParseInt(l, 10, 64)
+		if err != nil {
+			return db.FindOptions{}, pkgerrors.Errorf("Invalid limit: %s", l)
+		}
+		opts.Limit = limit
+	}
+
+	for _, f := range q["filter"] {
+		for _, selector := range strings.Split(f, ",") {
+			kv := strings.SplitN(selector, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return db.FindOptions{}, pkgerrors.Errorf("Invalid filter field: %s", selector)
+			}
+			if opts.Filter == nil {
+				opts.Filter = make(map[string]string)
+			}
+			opts.Filter[kv[0]] = kv[1]
+		}
+	}
+
+	return opts, opts.Validate()
+}
+
+// SetContinue sets the token of the next page of a list in the response, if any
+func SetContinue(w http.ResponseWriter, next string) {
+	if next != "" {
+		w.Header().Set(ContinueHeader, next)
+	}
+}
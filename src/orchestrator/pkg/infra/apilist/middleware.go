@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apilist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// listStateKey is the key of the listState in the context of a request
+type listStateKey struct{}
+
+// listState records whether the handler of a request opted in to the
+// in-memory filtering, sorting and paging of its list
+type listState struct {
+	inMemory bool
+}
+
+// InMemory opts the handler of the request in to the filtering, sorting
+// and paging of its list by the Middleware, for the lists that can't be
+// read with db.FindWithOptions. It must be called before the response is
+// written.
+func InMemory(r *http.Request) {
+	if s, ok := r.Context().Value(listStateKey{}).(*listState); ok {
+		s.inMemory = true
+	}
+}
+
+// hasListOptions returns whether the request has any of the list query parameters
+func hasListOptions(r *http.Request) bool {
+	q := r.URL.Query()
+	for _, p := range []string{"limit", "continue", "sort", "filter"} {
+		if _, ok := q[p]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldPath returns the json path of a field of the options in a resource.
+// The fields are the dotted json paths of the resources, with "name"
+// standing for the name in the metadata.
+func fieldPath(name string) string {
+	if name == "name" {
+		return "metadata.name"
+	}
+	return name
+}
+
+// resolve converts the options using the names of the fields to options
+// using the json paths of the fields in the resources
+func resolve(opts db.FindOptions) db.FindOptions {
+	resolved := db.FindOptions{
+		Limit:    opts.Limit,
+		Continue: opts.Continue,
+	}
+	if len(opts.Filter) > 0 {
+		resolved.Filter = make(map[string]string, len(opts.Filter))
+	}
+	for name, value := range opts.Filter {
+		resolved.Filter[fieldPath(name)] = value
+	}
+	if opts.Sort != "" {
+		if opts.Sort[0] == '-' {
+			resolved.Sort = "-" + fieldPath(opts.Sort[1:])
+		} else {
+			resolved.Sort = fieldPath(opts.Sort)
+		}
+	}
+	return resolved
+}
+
+// listWriter passes the response of a handler through, unless the handler
+// opted in to the in-memory processing of its list before writing it, in
+// which case it holds it
+type listWriter struct {
+	http.ResponseWriter
+	state    *listState
+	written  bool
+	buffered bool
+	header   http.Header
+	status   int
+	body     bytes.Buffer
+}
+
+// hold tells whether the response is held
+func (b *listWriter) hold() bool {
+	if !b.buffered && !b.written && b.state.inMemory {
+		b.buffered = true
+		b.header = b.ResponseWriter.Header().Clone()
+		b.status = http.StatusOK
+	}
+	return b.buffered
+}
+
+func (b *listWriter) Header() http.Header {
+	if b.hold() {
+		return b.header
+	}
+	return b.ResponseWriter.Header()
+}
+
+func (b *listWriter) Write(p []byte) (int, error) {
+	if b.hold() {
+		return b.body.Write(p)
+	}
+	b.written = true
+	return b.ResponseWriter.Write(p)
+}
+
+func (b *listWriter) WriteHeader(status int) {
+	if b.hold() {
+		b.status = status
+		return
+	}
+	b.written = true
+	b.ResponseWriter.WriteHeader(status)
+}
+
+// Flush sends the data written so far, unless the response is held
+func (b *listWriter) Flush() {
+	if b.buffered {
+		return
+	}
+	if f, ok := b.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// writeTo writes the held response, with the body if it isn't nil
+func (b *listWriter) writeTo(w http.ResponseWriter, body []byte) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	if body == nil {
+		body = b.body.Bytes()
+	} else {
+		w.Header().Del("Content-Length")
+	}
+	w.WriteHeader(b.status)
+	w.Write(body)
+}
+
+// Middleware filters, sorts and pages in memory the lists returned by the
+// handlers that opted in with InMemory, as db.ApplyFindOptions does. The
+// responses of the other handlers are passed through: the handlers of the
+// lists read in the database read the list options with FindOptions. The
+// fields of the options of the lists processed in memory are the dotted json
+// paths of the resources, e.g. filter=spec.clusterProvider=p1, with "name"
+// standing for metadata.name.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !hasListOptions(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		state := &listState{}
+		b := &listWriter{ResponseWriter: w, state: state}
+		next.ServeHTTP(b, r.WithContext(context.WithValue(r.Context(), listStateKey{}, state)))
+		if !b.buffered {
+			return
+		}
+
+		var values []json.RawMessage
+		if b.status != http.StatusOK || json.Unmarshal(b.body.Bytes(), &values) != nil {
+			// the response isn't a list
+			b.writeTo(w, nil)
+			return
+		}
+
+		opts, err := parseFindOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		items := make([][]byte, len(values))
+		for i, v := range values {
+			items[i] = v
+		}
+		items, continueToken, err := db.ApplyFindOptions(items, resolve(opts))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		values = make([]json.RawMessage, len(items))
+		for i, item := range items {
+			values[i] = item
+		}
+		body, err := json.Marshal(values)
+		if err != nil {
+			log.Error("Error encoding the list", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		SetContinue(w, continueToken)
+		b.writeTo(w, append(body, '\n'))
+	})
+}
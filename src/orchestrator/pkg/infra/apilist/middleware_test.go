@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apilist
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testList = `[
+	{"metadata":{"name":"c"},"spec":{"clusterProvider":"p1"}},
+	{"metadata":{"name":"a"},"spec":{"clusterProvider":"p2"}},
+	{"metadata":{"name":"b"},"spec":{"clusterProvider":"p1","nodes":3}}
+]`
+
+func testNames(t *testing.T, body string) []string {
+	var list []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(body), &list); err != nil {
+		t.Fatalf("Unexpected response %s", body)
+	}
+	names := []string{}
+	for _, r := range list {
+		names = append(names, r.Metadata.Name)
+	}
+	return names
+}
+
+func TestMiddleware(t *testing.T) {
+	list := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		InMemory(r)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testList))
+	})
+	handled := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := FindOptions(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(testList))
+	})
+	notOptedIn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testList))
+	})
+
+	testCases := []struct {
+		label        string
+		handler      http.Handler
+		query        string
+		expectedCode int
+		expected     string
+		next         bool
+	}{
+		{
+			label:        "No options",
+			handler:      list,
+			expectedCode: http.StatusOK,
+			expected:     "c,a,b",
+		},
+		{
+			label:        "Filter by path",
+			handler:      list,
+			query:        "?filter=spec.clusterProvider=p1",
+			expectedCode: http.StatusOK,
+			expected:     "b,c",
+		},
+		{
+			label:        "Filter on a number",
+			handler:      list,
+			query:        "?filter=spec.nodes=3",
+			expectedCode: http.StatusOK,
+			expected:     "",
+		},
+		{
+			label:        "Sort by name and page",
+			handler:      list,
+			query:        "?sort=-name&limit=2",
+			expectedCode: http.StatusOK,
+			expected:     "c,b",
+			next:         true,
+		},
+		{
+			label:        "Invalid limit",
+			handler:      list,
+			query:        "?limit=x",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			label:        "Options read by the handler",
+			handler:      handled,
+			query:        "?limit=1",
+			expectedCode: http.StatusOK,
+			expected:     "c,a,b",
+		},
+		{
+			label:        "Not opted in",
+			handler:      notOptedIn,
+			query:        "?limit=1",
+			expectedCode: http.StatusOK,
+			expected:     "c,a,b",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Middleware(testCase.handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/items"+testCase.query, nil))
+			if w.Code != testCase.expectedCode {
+				t.Fatalf("Expected the code %d, got %d %s", testCase.expectedCode, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			if names := strings.Join(testNames(t, w.Body.String()), ","); names != testCase.expected {
+				t.Errorf("Expected %s, got %s", testCase.expected, names)
+			}
+			if (w.Header().Get(ContinueHeader) != "") != testCase.next {
+				t.Errorf("Unexpected continue token %q", w.Header().Get(ContinueHeader))
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package apiwatch
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// StreamEvents writes the watch events to the response as newline delimited
+// JSON objects, flushing each event, until the events channel is closed.
+// The channel is closed when the context of the watch, usually the request
+// context, is done.
+func StreamEvents(w http.ResponseWriter, events <-chan db.WatchEvent) {
+	flusher, _ := w.(http.Flusher)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	enc := json.NewEncoder(w)
+	for ev := range events {
+		if err := enc.Encode(ev); err != nil {
+			log.Error("Error writing watch event", log.Fields{"error": err.Error()})
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
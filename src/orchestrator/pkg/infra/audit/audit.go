@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+// Package audit records the changes made through the REST APIs of the EMCO
+// services: who sent each POST, PUT, PATCH or DELETE request, when, to which
+// route and resource, and with which result.
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+const (
+	// collection holds the records of all the services
+	collection = "audit"
+	tagEntry   = "entry"
+	// number of records deleted at a time by the retention
+	retentionBatch = 500
+)
+
+// retentionInterval is the time between two deletions of the expired records
+var retentionInterval = time.Hour
+
+// Entry is the record of a request
+type Entry struct {
+	ID string `json:"id"`
+	// Time the request was received, in UTC
+	Time time.Time `json:"time"`
+	// Service that served the request, e.g. orchestrator or clm
+	Service string `json:"service"`
+	// Subject of the authenticated user, empty without authentication
+	Subject string `json:"subject,omitempty"`
+	Method  string `json:"method"`
+	// Route is the template of the path, e.g. /v2/projects/{project}
+	Route string `json:"route"`
+	Path  string `json:"path"`
+	// Resource is the key of the resource, the variables of the route
+	Resource map[string]string `json:"resource,omitempty"`
+	// Digest is the SHA-256 of the body of the request, hex encoded
+	Digest string `json:"digest,omitempty"`
+	// Status is the HTTP status code of the response
+	Status int `json:"status"`
+}
+
+// EntryKey is the key of the records in the database
+type EntryKey struct {
+	Entry string `json:"auditEntry"`
+}
+
+// Selectors are the fields the records can be filtered and sorted by
+var Selectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"time":    tagEntry + ".time",
+		"service": tagEntry + ".service",
+		"subject": tagEntry + ".subject",
+		"method":  tagEntry + ".method",
+		"route":   tagEntry + ".route",
+	},
+	TagTypes: map[string]interface{}{
+		tagEntry: Entry{},
+	},
+}
+
+// Query selects the records returned by List
+type Query struct {
+	// Resource selects the records whose resource has these variables,
+	// e.g. project and deploymentIntentGroup
+	Resource map[string]string
+	// From and To bound the time of the records, see db.TimeRange
+	From, To time.Time
+	// Options filter, sort and page the records using the Selectors
+	Options db.FindOptions
+}
+
+// List returns a page of the records selected by the query, the most recent
+// first unless the options sort them otherwise, and the continue token of
+// the next page, if any
+func List(ctx context.Context, q Query) ([]Entry, string, error) {
+	opts := q.Options
+	if opts.Sort == "" {
+		opts.Sort = "-time"
+	}
+	resolved, err := Selectors.Resolve(opts)
+	if err != nil {
+		return nil, "", err
+	}
+	for name, value := range q.Resource {
+		if resolved.Filter == nil {
+			resolved.Filter = make(map[string]string)
+		}
+		resolved.Filter[tagEntry+".resource."+name] = value
+	}
+	if !q.From.IsZero() || !q.To.IsZero() {
+		resolved.TimeRanges = map[string]db.TimeRange{
+			Selectors.Paths["time"]: {From: q.From, To: q.To},
+		}
+	}
+
+	values, next, err := db.DBconn.FindWithOptions(ctx, collection, EntryKey{}, tagEntry, resolved)
+	if err != nil {
+		return nil, "", pkgerrors.Wrap(err, "Error listing the audit records")
+	}
+	entries := make([]Entry, 0, len(values))
+	for _, value := range values {
+		var e Entry
+		if err := db.DBconn.Unmarshal(value, &e); err != nil {
+			return nil, "", pkgerrors.Wrap(err, "Error unmarshalling an audit record")
+		}
+		entries = append(entries, e)
+	}
+	return entries, next, nil
+}
+
+// Recorder stores the records in the database and, optionally, appends them
+// to a file
+type Recorder struct {
+	service string
+	mutex   sync.Mutex
+	file    *os.File
+}
+
+// NewRecorder returns the recorder of the service. The records are also
+// appended to the file, one json object per line, if its name isn't empty.
+func NewRecorder(service, file string) (*Recorder, error) {
+	r := &Recorder{service: service}
+	if file != "" {
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "Error opening the audit file")
+		}
+		r.file = f
+	}
+	return r, nil
+}
+
+// Record stores the record, setting its ID and service
+func (r *Recorder) Record(ctx context.Context, e Entry) error {
+	e.Service = r.service
+	e.ID = newID(e.Time)
+
+	// The record is written to the file even if the database fails
+	err := db.DBconn.Insert(ctx, collection, EntryKey{Entry: e.ID}, nil, tagEntry, e)
+	if err != nil {
+		err = pkgerrors.Wrap(err, "Error storing the audit record")
+	}
+	if r.file != nil {
+		if werr := r.write(e); werr != nil && err == nil {
+			err = pkgerrors.Wrap(werr, "Error writing the audit record")
+		}
+	}
+	return err
+}
+
+func (r *Recorder) write(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	_, err = r.file.Write(append(line, '\n'))
+	return err
+}
+
+// RunRetention deletes the records of the service older than the retention,
+// every retentionInterval, until the context is done. The deletion is
+// idempotent, so the replicas of the service may run it at the same time.
+func (r *Recorder) RunRetention(ctx context.Context, retention time.Duration) {
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+	for {
+		n, err := r.deleteExpired(ctx, time.Now().UTC().Add(-retention))
+		if err != nil {
+			log.Error("Error deleting the expired audit records", log.Fields{"service": r.service, "error": err.Error()})
+		} else if n > 0 {
+			log.Info("Deleted the expired audit records", log.Fields{"service": r.service, "records": n})
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deleteExpired deletes the records of the service received before the time
+// and returns their number
+func (r *Recorder) deleteExpired(ctx context.Context, before time.Time) (int, error) {
+	opts := db.FindOptions{
+		Filter:     map[string]string{Selectors.Paths["service"]: r.service},
+		TimeRanges: map[string]db.TimeRange{Selectors.Paths["time"]: {To: before}},
+		Limit:      retentionBatch,
+		TagTypes:   Selectors.TagTypes,
+	}
+	deleted := 0
+	for {
+		values, _, err := db.DBconn.FindWithOptions(ctx, collection, EntryKey{}, tagEntry, opts)
+		if err != nil {
+			return deleted, pkgerrors.Wrap(err, "Error listing the expired audit records")
+		}
+		for _, value := range values {
+			var e Entry
+			if err := db.DBconn.Unmarshal(value, &e); err != nil {
+				return deleted, pkgerrors.Wrap(err, "Error unmarshalling an audit record")
+			}
+			if err := db.DBconn.RemoveAll(ctx, collection, EntryKey{Entry: e.ID}); err != nil {
+				return deleted, pkgerrors.Wrap(err, "Error deleting an audit record")
+			}
+			deleted++
+		}
+		if len(values) < retentionBatch {
+			return deleted, nil
+		}
+	}
+}
+
+// Close closes the file of the recorder, if any
+func (r *Recorder) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+// newID returns a unique ID of a record received at the time
+func newID(t time.Time) string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return strconv.FormatInt(t.UnixNano(), 36) + "-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apiauth"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+)
+
+const digRoute = "/v2/projects/{project}/composite-apps/{compositeApp}/{compositeAppVersion}/deployment-intent-groups/{deploymentIntentGroup}"
+
+func newTestRouter(t *testing.T, r *Recorder) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(r.Middleware)
+	// stands for the authentication middleware
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if sub := req.Header.Get("X-Test-Subject"); sub != "" {
+				apiauth.SetSubject(req.Context(), sub)
+				if sub == "mallory" {
+					http.Error(w, "Denied", http.StatusForbidden)
+					return
+				}
+				req = req.WithContext(apiauth.NewContext(req.Context(), apiauth.Principal{Subject: sub}))
+			}
+			next.ServeHTTP(w, req)
+		})
+	})
+	handler := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if req.Method == http.MethodPost && len(body) == 0 {
+				t.Errorf("Expected the body to be passed to the handler")
+			}
+			w.WriteHeader(status)
+		}
+	}
+	router.HandleFunc(digRoute+"/instantiate", handler(http.StatusAccepted)).Methods(http.MethodPost)
+	router.HandleFunc(digRoute+"/terminate", handler(http.StatusConflict)).Methods(http.MethodPost)
+	router.HandleFunc(digRoute, handler(http.StatusOK)).Methods(http.MethodGet)
+	router.HandleFunc("/v2/cluster-providers/{clusterProvider}/clusters/{cluster}", handler(http.StatusNoContent)).Methods(http.MethodDelete)
+	return router
+}
+
+func send(router http.Handler, method, path, subject, body string) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if subject != "" {
+		req.Header.Set("X-Test-Subject", subject)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), req)
+}
+
+func TestMiddleware(t *testing.T) {
+	db.DBconn = &db.NewMockDB{}
+	file := filepath.Join(t.TempDir(), "audit.log")
+	r, err := NewRecorder("orchestrator", file)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	defer r.Close()
+	router := newTestRouter(t, r)
+
+	start := time.Now().UTC()
+	send(router, http.MethodPost, "/v2/projects/p1/composite-apps/ca/v1/deployment-intent-groups/dig1/instantiate", "alice", `{"x":1}`)
+	send(router, http.MethodGet, "/v2/projects/p1/composite-apps/ca/v1/deployment-intent-groups/dig1", "alice", "")
+	send(router, http.MethodPost, "/v2/projects/p2/composite-apps/ca/v1/deployment-intent-groups/dig1/terminate", "bob", `{}`)
+	send(router, http.MethodDelete, "/v2/cluster-providers/cp/clusters/c1", "", "")
+	send(router, http.MethodPost, "/v2/projects/p3/composite-apps/ca/v1/deployment-intent-groups/dig1/instantiate", "mallory", `{}`)
+
+	entries, _, err := List(context.Background(), Query{})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 records, got %+v", entries)
+	}
+
+	entries, _, err = List(context.Background(), Query{Resource: map[string]string{"project": "p1", "deploymentIntentGroup": "dig1"}})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected the record of p1, got %+v %v", entries, err)
+	}
+	e := entries[0]
+	digest := sha256.Sum256([]byte(`{"x":1}`))
+	if e.Subject != "alice" || e.Service != "orchestrator" || e.Method != http.MethodPost ||
+		e.Route != digRoute+"/instantiate" || e.Status != http.StatusAccepted ||
+		e.Digest != hex.EncodeToString(digest[:]) || e.Time.Before(start) || e.ID == "" {
+		t.Errorf("Unexpected record %+v", e)
+	}
+
+	entries, _, err = List(context.Background(), Query{Options: db.FindOptions{Filter: map[string]string{"subject": "bob"}}})
+	if err != nil || len(entries) != 1 || entries[0].Status != http.StatusConflict {
+		t.Errorf("Expected the failed termination of bob, got %+v %v", entries, err)
+	}
+
+	entries, _, err = List(context.Background(), Query{Options: db.FindOptions{Filter: map[string]string{"subject": "mallory"}}})
+	if err != nil || len(entries) != 1 || entries[0].Status != http.StatusForbidden {
+		t.Errorf("Expected the denied instantiation of mallory, got %+v %v", entries, err)
+	}
+
+	entries, _, err = List(context.Background(), Query{From: time.Now().Add(time.Minute)})
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no record in the future, got %+v %v", entries, err)
+	}
+	entries, next, err := List(context.Background(), Query{From: start, Options: db.FindOptions{Limit: 2}})
+	if err != nil || len(entries) != 2 || next == "" {
+		t.Errorf("Expected a first page of 2 records, got %+v %q %v", entries, next, err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	defer f.Close()
+	var lines []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("Invalid line %s", scanner.Text())
+		}
+		lines = append(lines, e)
+	}
+	if len(lines) != 4 || lines[2].Resource["cluster"] != "c1" || lines[2].Status != http.StatusNoContent {
+		t.Errorf("Unexpected audit file %+v", lines)
+	}
+}
+
+func TestListInvalid(t *testing.T) {
+	db.DBconn = &db.NewMockDB{}
+	if _, _, err := List(context.Background(), Query{Options: db.FindOptions{Filter: map[string]string{"digest": "x"}}}); err == nil {
+		t.Errorf("Expected an error for an unknown field")
+	}
+}
+
+func TestRetention(t *testing.T) {
+	db.DBconn = &db.NewMockDB{}
+	r, _ := NewRecorder("orchestrator", "")
+	other, _ := NewRecorder("clm", "")
+	now := time.Now().UTC()
+	for _, e := range []struct {
+		r   *Recorder
+		age time.Duration
+	}{{r, 48 * time.Hour}, {r, 2 * time.Hour}, {other, 48 * time.Hour}} {
+		if err := e.r.Record(context.Background(), Entry{Time: now.Add(-e.age), Method: http.MethodPost}); err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+	}
+
+	n, err := r.deleteExpired(context.Background(), now.Add(-24*time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("Expected the expired record of the service deleted, got %d %v", n, err)
+	}
+	entries, _, err := List(context.Background(), Query{})
+	if err != nil || len(entries) != 2 || entries[0].Service != "orchestrator" || entries[1].Service != "clm" {
+		t.Errorf("Expected the recent record and the record of the other service kept, got %+v %v", entries, err)
+	}
+}
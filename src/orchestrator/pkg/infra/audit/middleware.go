@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apiauth"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// audited are the methods of the requests that are recorded
+var audited = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// statusWriter keeps the status code of the response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// recordTimeout bounds the time of recording a request, which isn't canceled
+// with the request
+var recordTimeout = 10 * time.Second
+
+// Middleware records the requests that change the resources once they are
+// served. It must precede the authentication middleware to also record the
+// requests it rejects, the authentication sets the subject of the record.
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !audited[req.Method] {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		e := Entry{
+			Time:     time.Now().UTC(),
+			Method:   req.Method,
+			Path:     req.URL.Path,
+			Resource: mux.Vars(req),
+		}
+		if route := mux.CurrentRoute(req); route != nil {
+			e.Route, _ = route.GetPathTemplate()
+		}
+		if p, ok := apiauth.FromContext(req.Context()); ok {
+			e.Subject = p.Subject
+		}
+		if req.Body != nil {
+			body, err := ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				log.Error("Error reading the body of the request", log.Fields{"method": req.Method, "path": req.URL.Path, "error": err})
+				http.Error(w, "Error reading the body of the request", http.StatusBadRequest)
+				return
+			}
+			if len(body) > 0 {
+				digest := sha256.Sum256(body)
+				e.Digest = hex.EncodeToString(digest[:])
+			}
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		ctx, subject := apiauth.NewSubjectContext(req.Context())
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, req.WithContext(ctx))
+		e.Status = sw.status
+		if e.Status == 0 {
+			e.Status = http.StatusOK
+		}
+		if *subject != "" {
+			e.Subject = *subject
+		}
+
+		// The request is recorded even if the client went away
+		ctx, cancel := context.WithTimeout(context.Background(), recordTimeout)
+		defer cancel()
+		if err := r.Record(ctx, e); err != nil {
+			log.Error("Error recording the request", log.Fields{"method": e.Method, "path": e.Path, "subject": e.Subject, "error": err})
+		}
+	})
+}
@@ -0,0 +1,699 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package backup
+
+/*
+This package backs up the database of EMCO, i.e. all the collections of all
+the services, and the context database, i.e. the AppContexts, to a local
+directory or to an S3-compatible storage, and restores a backup in the empty
+databases of a new instance of EMCO. The database is read first, then the
+context database, so that the AppContexts referred to by the database are in
+the backup. A backup is a gzipped tar archive named after the time it was
+taken, which the restore selects the backup of a point in time with. The
+documents are written to the archive in chunks, and the archive to a
+temporary file, so that a backup is never held in memory and the database
+isn't read for as long as the upload lasts.
+*/
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/lease"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+const (
+	// Version is the version of the backup format
+	Version = "v1"
+
+	namePrefix     = "emco-"
+	nameSuffix     = ".tgz"
+	nameTimeFormat = "20060102T150405Z"
+
+	manifestFile   = "manifest.json"
+	contextDir     = "contextdb/"
+	collectionsDir = "collections/"
+	// chunkSize is the size of the documents written in a file of the
+	// archive, above which the next documents are written in another file
+	chunkSize = 1 << 20
+
+	activeContexts  = "/activecontext/"
+	runtimeContexts = "/context/"
+	// eventQueue is the level of the queue of the events of an AppContext
+	// processed by rsync
+	eventQueue = "rsync/AppContextEventQueue/"
+	// restoreKey records the backup being restored, so that the restore
+	// interrupted by a stop of the orchestrator is resumed when it starts
+	restoreKey = "/restore/"
+
+	// checkInterval is the interval the replica holding the lease of the
+	// backups checks if a backup is due
+	checkInterval = time.Minute
+	leaseDuration = time.Minute
+)
+
+// ownershipPrefixes are the keys of the replicas of rsync sharing the
+// AppContexts and the leases of the replicas of the orchestrator, which
+// belong to the replicas of the backed up instance
+var ownershipPrefixes = []string{"/rsyncreplica/", "/rsynclease/", "/lease/"}
+
+// ErrNotEmpty is returned by Restore if the databases aren't empty
+var ErrNotEmpty = pkgerrors.New("The databases aren't empty")
+
+// Manifest describes a backup
+type Manifest struct {
+	Version string    `json:"version"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	// Format is the document format of the database, the backup can only
+	// be restored in a database of the same format
+	Format string `json:"format"`
+	// Snapshot is true if the collections were read from a single point in
+	// time of the database
+	Snapshot bool `json:"snapshot"`
+	// Collections counts the documents of each collection
+	Collections map[string]int `json:"collections"`
+	ContextKeys int            `json:"contextKeys"`
+}
+
+// Info identifies a backup of a target
+type Info struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
+
+// RestoreResult counts what a restore restored
+type RestoreResult struct {
+	Name        string `json:"name"`
+	Documents   int    `json:"documents"`
+	ContextKeys int    `json:"contextKeys"`
+	// ActiveContexts is the number of AppContexts with pending events,
+	// resumed by rsync when it starts
+	ActiveContexts int `json:"activeContexts"`
+}
+
+// snapshotters returns the stores of the database and the context database
+func snapshotters() (db.Snapshotter, contextdb.Snapshotter, error) {
+	ss, ok := db.DBconn.(db.Snapshotter)
+	if !ok {
+		return nil, nil, pkgerrors.Errorf("The database %T doesn't support the backups", db.DBconn)
+	}
+	cs, ok := contextdb.Db.(contextdb.Snapshotter)
+	if !ok {
+		return nil, nil, pkgerrors.Errorf("The context database %T doesn't support the backups", contextdb.Db)
+	}
+	return ss, cs, nil
+}
+
+// Backup takes a backup of the databases and stores it in the target. The
+// oldest backups are deleted beyond the configured retention.
+func Backup(ctx context.Context, t Target) (Manifest, error) {
+	ss, cs, err := snapshotters()
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	f, err := os.CreateTemp("", "emco-backup-")
+	if err != nil {
+		return Manifest{}, pkgerrors.Wrap(err, "Error creating the backup file")
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	created := time.Now().UTC().Truncate(time.Second)
+	m := Manifest{
+		Version:     Version,
+		Name:        namePrefix + created.Format(nameTimeFormat) + nameSuffix,
+		Created:     created,
+		Format:      ss.DocumentFormat(),
+		Collections: make(map[string]int),
+	}
+	aw := newArchiveWriter(f, created)
+	m.Snapshot, err = ss.Snapshot(ctx, func(coll string, doc json.RawMessage) error {
+		m.Collections[coll]++
+		return aw.add(collectionsDir+coll+"/", doc)
+	})
+	if err != nil {
+		return Manifest{}, pkgerrors.Wrap(err, "Error reading the database")
+	}
+	kvs, err := cs.Snapshot(ctx, "/")
+	if err != nil {
+		return Manifest{}, pkgerrors.Wrap(err, "Error reading the context database")
+	}
+	for _, kv := range kvs {
+		doc, err := json.Marshal(kv)
+		if err != nil {
+			return Manifest{}, pkgerrors.Wrapf(err, "Error Marshalling the context key %s", kv.Key)
+		}
+		if err := aw.add(contextDir, doc); err != nil {
+			return Manifest{}, err
+		}
+	}
+	m.ContextKeys = len(kvs)
+	if err := aw.close(m); err != nil {
+		return Manifest{}, err
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return Manifest{}, pkgerrors.Wrap(err, "Error reading the backup file")
+	}
+	if err := t.Put(ctx, m.Name, f, size); err != nil {
+		return Manifest{}, err
+	}
+	log.Info("Backed up the databases", log.Fields{"backup": m.Name, "snapshot": m.Snapshot, "contextKeys": m.ContextKeys, "size": size})
+
+	if retention := config.GetConfiguration().BackupRetention; retention > 0 {
+		prune(ctx, t, retention)
+	}
+	return m, nil
+}
+
+// prune deletes the oldest backups of the target beyond the retention
+func prune(ctx context.Context, t Target, retention int) {
+	backups, err := List(ctx, t)
+	if err != nil {
+		log.Error("Error listing the backups to delete", log.Fields{"error": err})
+		return
+	}
+	for i := 0; i < len(backups)-retention; i++ {
+		if err := t.Delete(ctx, backups[i].Name); err != nil {
+			log.Error("Error deleting a backup", log.Fields{"backup": backups[i].Name, "error": err})
+		}
+	}
+}
+
+// List returns the backups of the target, the oldest first
+func List(ctx context.Context, t Target) ([]Info, error) {
+	names, err := t.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]Info, 0, len(names))
+	for _, name := range names {
+		if created, ok := backupTime(name); ok {
+			backups = append(backups, Info{Name: name, Created: created})
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Created.Before(backups[j].Created)
+	})
+	return backups, nil
+}
+
+// Select returns the name of the latest backup of the target taken at or
+// before the time, or of the latest backup if the time is zero
+func Select(ctx context.Context, t Target, at time.Time) (string, error) {
+	backups, err := List(ctx, t)
+	if err != nil {
+		return "", err
+	}
+	for i := len(backups) - 1; i >= 0; i-- {
+		if at.IsZero() || !backups[i].Created.After(at) {
+			return backups[i].Name, nil
+		}
+	}
+	return "", pkgerrors.New("Backup not found")
+}
+
+// isBackupName returns true if the name is the name of a backup
+func isBackupName(name string) bool {
+	_, ok := backupTime(name)
+	return ok
+}
+
+// backupTime returns the time a backup was taken from its name
+func backupTime(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, namePrefix) || !strings.HasSuffix(name, nameSuffix) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(nameTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, namePrefix), nameSuffix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Restorable returns true if the databases are empty, i.e. no collection
+// and no context key holds data, or if a restore was interrupted
+func Restorable(ctx context.Context) (bool, error) {
+	ss, cs, err := snapshotters()
+	if err != nil {
+		return false, err
+	}
+	interrupted, err := restoring(ctx)
+	if err != nil || interrupted != "" {
+		return interrupted != "", err
+	}
+	return isEmpty(ctx, ss, cs)
+}
+
+// Restore restores the backup of the target in the databases, which must
+// be empty, or else ErrNotEmpty is returned. Nothing is written before the
+// backup is read, and the databases are emptied if the restore fails, or,
+// if the restore is interrupted, by the next restore. The keys of the
+// replicas of rsync aren't restored, and the AppContexts with pending events
+// are recorded as active, so that rsync resumes them when it starts.
+func Restore(ctx context.Context, t Target, name string) (RestoreResult, error) {
+	ss, cs, err := snapshotters()
+	if err != nil {
+		return RestoreResult{}, err
+	}
+
+	interrupted, err := restoring(ctx)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+	if interrupted == "" {
+		empty, err := isEmpty(ctx, ss, cs)
+		if err != nil {
+			return RestoreResult{}, err
+		}
+		if !empty {
+			return RestoreResult{}, ErrNotEmpty
+		}
+	}
+
+	r, err := t.Get(ctx, name)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+	defer r.Close()
+	m, collections, kvs, err := readArchive(r)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+	if m.Format != ss.DocumentFormat() {
+		return RestoreResult{}, pkgerrors.Errorf("Invalid backup: the backup of a %s database can't be restored in a %s database", m.Format, ss.DocumentFormat())
+	}
+
+	if interrupted != "" {
+		log.Warn("Deleting the data of an interrupted restore", log.Fields{"backup": interrupted})
+		if err := clear(ctx, ss, cs); err != nil {
+			return RestoreResult{}, err
+		}
+	}
+	if err := contextdb.Db.Put(ctx, restoreKey, m.Name); err != nil {
+		return RestoreResult{}, pkgerrors.Wrap(err, "Error recording the restore")
+	}
+	result, err := restore(ctx, ss, m, collections, kvs)
+	if err != nil {
+		log.Error("Error restoring the backup, deleting the restored data", log.Fields{"backup": m.Name, "error": err})
+		if cerr := clear(ctx, ss, cs); cerr != nil {
+			log.Error("Error deleting the restored data", log.Fields{"backup": m.Name, "error": cerr})
+		}
+		return RestoreResult{}, err
+	}
+	if err := contextdb.Db.Delete(ctx, restoreKey); err != nil {
+		return result, pkgerrors.Wrap(err, "Error recording the end of the restore")
+	}
+
+	log.Info("Restored the databases", log.Fields{"backup": m.Name, "documents": result.Documents,
+		"contextKeys": result.ContextKeys, "activeContexts": result.ActiveContexts})
+	return result, nil
+}
+
+// restore writes the collections and the keys of the context database
+func restore(ctx context.Context, ss db.Snapshotter, m Manifest, collections map[string][]json.RawMessage, kvs []contextdb.KeyValue) (RestoreResult, error) {
+	result := RestoreResult{Name: m.Name}
+	colls := make([]string, 0, len(collections))
+	for coll := range collections {
+		colls = append(colls, coll)
+	}
+	sort.Strings(colls)
+	for _, coll := range colls {
+		if err := ss.RestoreCollection(ctx, coll, collections[coll]); err != nil {
+			return result, err
+		}
+		result.Documents += len(collections[coll])
+	}
+
+	active := make(map[string]bool)
+	for _, kv := range kvs {
+		if isOwnership(kv.Key) {
+			continue
+		}
+		if err := contextdb.Db.Put(ctx, kv.Key, kv.Value); err != nil {
+			return result, pkgerrors.Wrapf(err, "Error restoring the context key %s", kv.Key)
+		}
+		result.ContextKeys++
+		if strings.HasPrefix(kv.Key, activeContexts) {
+			active[strings.TrimSuffix(strings.TrimPrefix(kv.Key, activeContexts), "/")] = true
+		}
+	}
+	for _, acID := range pendingContexts(kvs) {
+		if active[acID] {
+			continue
+		}
+		if err := recordActiveContext(ctx, acID); err != nil {
+			return result, err
+		}
+		active[acID] = true
+	}
+	result.ActiveContexts = len(active)
+	return result, nil
+}
+
+// restoring returns the name of the backup whose restore was interrupted,
+// if any
+func restoring(ctx context.Context) (string, error) {
+	var name string
+	rev, err := contextdb.Db.GetWithRevision(ctx, restoreKey, &name)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Error reading the context database")
+	}
+	if rev == 0 {
+		return "", nil
+	}
+	return name, nil
+}
+
+// isEmpty returns true if the database and the context database are empty.
+// The keys of the replicas, written as soon as they start, are ignored.
+func isEmpty(ctx context.Context, ss db.Snapshotter, cs contextdb.Snapshotter) (bool, error) {
+	empty, err := ss.Empty(ctx)
+	if err != nil || !empty {
+		return false, err
+	}
+	kvs, err := cs.Snapshot(ctx, "/")
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "Error reading the context database")
+	}
+	for _, kv := range kvs {
+		if !isOwnership(kv.Key) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// clear deletes the data of the databases, the keys of the context database
+// last, so that the restore is still recorded if the database can't be
+// emptied. The keys of the running replicas are kept.
+func clear(ctx context.Context, ss db.Snapshotter, cs contextdb.Snapshotter) error {
+	if err := ss.Clear(ctx); err != nil {
+		return err
+	}
+	kvs, err := cs.Snapshot(ctx, "/")
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error reading the context database")
+	}
+	// the keys are deleted by top level prefix, e.g. "/context/"
+	deleted := map[string]bool{restoreKey: true}
+	for _, kv := range kvs {
+		prefix := "/" + strings.SplitN(strings.TrimPrefix(kv.Key, "/"), "/", 2)[0] + "/"
+		if isOwnership(prefix) || deleted[prefix] {
+			continue
+		}
+		deleted[prefix] = true
+		if err := contextdb.Db.DeleteAll(ctx, prefix); err != nil {
+			return pkgerrors.Wrap(err, "Error deleting the keys of the context database")
+		}
+	}
+	if err := contextdb.Db.DeleteAll(ctx, restoreKey); err != nil {
+		return pkgerrors.Wrap(err, "Error deleting the keys of the context database")
+	}
+	return nil
+}
+
+// isOwnership returns true if the key is a key of a replica of rsync
+func isOwnership(key string) bool {
+	for _, p := range ownershipPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingContexts returns the AppContexts whose event queue holds pending
+// events, i.e. events rsync didn't process yet
+func pendingContexts(kvs []contextdb.KeyValue) []string {
+	var acIDs []string
+	for _, kv := range kvs {
+		segs := strings.Split(kv.Key, "/")
+		if !strings.HasPrefix(kv.Key, runtimeContexts) || len(segs) != 6 || segs[3]+"/"+segs[4]+"/" != eventQueue {
+			continue
+		}
+		var q struct {
+			AcQueue []struct {
+				Status string `json:"status"`
+			}
+		}
+		if err := json.Unmarshal(kv.Value, &q); err != nil {
+			log.Warn("Invalid event queue of an AppContext", log.Fields{"key": kv.Key, "error": err})
+			continue
+		}
+		for _, e := range q.AcQueue {
+			if e.Status == "Pending" {
+				acIDs = append(acIDs, segs[2])
+				break
+			}
+		}
+	}
+	return acIDs
+}
+
+// recordActiveContext records the AppContext as active like rsync does
+// when an event is queued, unless it is already recorded
+func recordActiveContext(ctx context.Context, acID string) error {
+	err := contextdb.Db.PutIfRevision(ctx, activeContexts+acID+"/", acID, 0)
+	if err != nil && err != contextdb.ErrRevisionMismatch {
+		return pkgerrors.Wrapf(err, "Error recording the active context %s", acID)
+	}
+	return nil
+}
+
+// RunBackups takes a backup at the configured interval, until the context
+// is done. It returns at once if the interval isn't configured. Only the
+// replica holding the lease of the backups takes them, once the latest
+// backup of the target is older than the interval.
+func RunBackups(ctx context.Context) {
+	c := config.GetConfiguration()
+	if c.BackupInterval <= 0 {
+		return
+	}
+	t, err := NewTarget(c.BackupTarget)
+	if err != nil {
+		log.Error("Unable to take the backups", log.Fields{"error": err})
+		return
+	}
+	interval := time.Duration(c.BackupInterval) * time.Minute
+	l := lease.New("backup", leaseDuration)
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			owned, err := l.Acquire(ctx)
+			if err != nil {
+				log.Error("Error acquiring the lease of the backups", log.Fields{"error": err})
+			}
+			if !owned {
+				continue
+			}
+			l.Hold(ctx, func(ctx context.Context) {
+				if !due(ctx, t, interval, time.Now()) {
+					return
+				}
+				if _, err := Backup(ctx, t); err != nil {
+					log.Error("Error taking a backup", log.Fields{"error": err})
+				}
+			})
+		}
+	}
+}
+
+// due returns true if the latest backup of the target is older than the
+// interval at the time, or if the target has no backup
+func due(ctx context.Context, t Target, interval time.Duration, now time.Time) bool {
+	backups, err := List(ctx, t)
+	if err != nil {
+		log.Error("Error listing the backups", log.Fields{"error": err})
+		return false
+	}
+	return len(backups) == 0 || now.Sub(backups[len(backups)-1].Created) >= interval
+}
+
+// archiveWriter writes the gzipped tar archive of a backup: the documents in
+// files of up to chunkSize bytes, then the manifest
+type archiveWriter struct {
+	gw      *gzip.Writer
+	tw      *tar.Writer
+	created time.Time
+	// the directory, the documents and the size of the current file
+	dir  string
+	docs []json.RawMessage
+	size int
+	// the number of files written by directory
+	files map[string]int
+}
+
+func newArchiveWriter(w io.Writer, created time.Time) *archiveWriter {
+	gw := gzip.NewWriter(w)
+	return &archiveWriter{
+		gw:      gw,
+		tw:      tar.NewWriter(gw),
+		created: created,
+		files:   make(map[string]int),
+	}
+}
+
+// add adds the document to the current file of the directory
+func (a *archiveWriter) add(dir string, doc json.RawMessage) error {
+	if dir != a.dir {
+		if err := a.flush(); err != nil {
+			return err
+		}
+		a.dir = dir
+	}
+	a.docs = append(a.docs, doc)
+	a.size += len(doc)
+	if a.size >= chunkSize {
+		return a.flush()
+	}
+	return nil
+}
+
+// flush writes the documents of the current file
+func (a *archiveWriter) flush() error {
+	if len(a.docs) == 0 {
+		return nil
+	}
+	name := fmt.Sprintf("%s%06d.json", a.dir, a.files[a.dir])
+	if err := a.write(name, a.docs); err != nil {
+		return err
+	}
+	a.files[a.dir]++
+	a.docs, a.size = nil, 0
+	return nil
+}
+
+// write writes the JSON of the value in the file
+func (a *archiveWriter) write(name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "Error Marshalling %s", name)
+	}
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: a.created,
+	}
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the backup")
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the backup")
+	}
+	return nil
+}
+
+// close writes the last documents and the manifest, and closes the archive
+func (a *archiveWriter) close(m Manifest) error {
+	if err := a.flush(); err != nil {
+		return err
+	}
+	if err := a.write(manifestFile, m); err != nil {
+		return err
+	}
+	if err := a.tw.Close(); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the backup")
+	}
+	if err := a.gw.Close(); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the backup")
+	}
+	return nil
+}
+
+// readArchive reads the manifest, the documents of each collection and the
+// keys of the context database of a backup, and checks that the backup has
+// all the documents and the keys counted by its manifest
+func readArchive(r io.Reader) (Manifest, map[string][]json.RawMessage, []contextdb.KeyValue, error) {
+	var m Manifest
+	var kvs []contextdb.KeyValue
+	collections := make(map[string][]json.RawMessage)
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return m, nil, nil, pkgerrors.Wrap(err, "Invalid backup")
+	}
+	defer gr.Close()
+
+	hasManifest := false
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return m, nil, nil, pkgerrors.Wrap(err, "Invalid backup")
+		}
+		var v interface{}
+		var docs []json.RawMessage
+		var chunk []contextdb.KeyValue
+		dir := path.Dir(hdr.Name) + "/"
+		coll := strings.TrimSuffix(strings.TrimPrefix(dir, collectionsDir), "/")
+		switch {
+		case hdr.Name == manifestFile:
+			v = &m
+		case dir == contextDir:
+			v = &chunk
+		case strings.HasPrefix(dir, collectionsDir) && coll != "":
+			v = &docs
+		default:
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return m, nil, nil, pkgerrors.Wrap(err, "Invalid backup")
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			return m, nil, nil, pkgerrors.Wrapf(err, "Invalid backup: invalid %s", hdr.Name)
+		}
+		switch v {
+		case &m:
+			hasManifest = true
+		case &chunk:
+			kvs = append(kvs, chunk...)
+		case &docs:
+			collections[coll] = append(collections[coll], docs...)
+		}
+	}
+
+	if !hasManifest {
+		return m, nil, nil, pkgerrors.New("Invalid backup: the manifest is missing")
+	}
+	if m.Version != Version {
+		return m, nil, nil, pkgerrors.Errorf("Invalid backup: unsupported version %s", m.Version)
+	}
+	complete := len(kvs) == m.ContextKeys && len(collections) <= len(m.Collections)
+	for coll, n := range m.Collections {
+		complete = complete && len(collections[coll]) == n
+	}
+	if !complete {
+		return m, nil, nil, pkgerrors.New("Invalid backup: documents or context keys are missing")
+	}
+	return m, collections, kvs, nil
+}
@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	bolt "go.etcd.io/bbolt"
+)
+
+type projectKey struct {
+	Project string `json:"project"`
+}
+
+type entryKey struct {
+	Entry string `json:"entry"`
+}
+
+type queue struct {
+	AcQueue []map[string]string
+}
+
+// newStores opens empty databases and context databases
+func newStores(t *testing.T) {
+	dir := t.TempDir()
+	bdb, err := bolt.Open(filepath.Join(dir, "emco.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	t.Cleanup(func() { bdb.Close() })
+	db.DBconn, err = db.NewBoltStore(context.Background(), "emco", bdb)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	contextdb.Db, err = contextdb.NewBoltClient(contextdb.BoltConfig{
+		Path:    filepath.Join(dir, "contextdb.db"),
+		Timeout: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+}
+
+func put(t *testing.T, key string, value interface{}) {
+	if err := contextdb.Db.Put(context.Background(), key, value); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	target := dirTarget{dir: t.TempDir()}
+	newStores(t)
+
+	ds := db.DBconn.(db.DocumentStore)
+	err := ds.InsertDocument(ctx, "resources", db.Document{Fields: map[string]string{"project": "p1"}, KeyId: "{project}",
+		Tags: map[string]json.RawMessage{"data": json.RawMessage(`{"name":"p1"}`)}})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	// the documents of the collection are written in several files
+	content := json.RawMessage(`"` + strings.Repeat("a", chunkSize/2) + `"`)
+	for _, app := range []string{"a1", "a2", "a3"} {
+		err = ds.InsertDocument(ctx, "resources", db.Document{Fields: map[string]string{"project": "p1", "app": app}, KeyId: "{app,project}",
+			Tags: map[string]json.RawMessage{"appcontent": content}})
+		if err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+	}
+	err = ds.InsertDocument(ctx, "audit", db.Document{Fields: map[string]string{"entry": "e1"}, KeyId: "{entry}",
+		Tags: map[string]json.RawMessage{"entry": json.RawMessage(`{"method":"POST"}`)}})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	// the context 1 has a pending event, 2 is done and 3 is active
+	put(t, "/context/1/", "1")
+	put(t, "/context/1/rsync/AppContextEventQueue/", queue{AcQueue: []map[string]string{{"event": "Instantiate", "status": "Pending"}}})
+	put(t, "/context/2/", "2")
+	put(t, "/context/2/rsync/AppContextEventQueue/", queue{AcQueue: []map[string]string{{"event": "Instantiate", "status": "Done"}}})
+	put(t, "/context/3/", "3")
+	put(t, "/activecontext/3/", "3")
+	put(t, "/rsynclease/3/", map[string]string{"owner": "replica1"})
+
+	m, err := Backup(ctx, target)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if m.Format != "bolt" || !m.Snapshot || m.Collections["resources"] != 4 || m.Collections["audit"] != 1 || m.ContextKeys != 7 {
+		t.Fatalf("Unexpected backup %+v", m)
+	}
+
+	name, err := Select(ctx, target, time.Time{})
+	if err != nil || name != m.Name {
+		t.Fatalf("Expected the backup %s, got %s %v", m.Name, name, err)
+	}
+	if _, err := Select(ctx, target, m.Created.Add(-time.Second)); err == nil || err.Error() != "Backup not found" {
+		t.Errorf("Expected no backup before %s, got %v", m.Created, err)
+	}
+	if name, err := Select(ctx, target, m.Created.Add(time.Hour)); err != nil || name != m.Name {
+		t.Errorf("Expected the backup %s, got %s %v", m.Name, name, err)
+	}
+
+	if ok, err := Restorable(ctx); err != nil || ok {
+		t.Errorf("Expected the non-empty databases not restorable, got %v %v", ok, err)
+	}
+	if _, err := Restore(ctx, target, m.Name); err != ErrNotEmpty {
+		t.Errorf("Expected the restore in non-empty databases to fail, got %v", err)
+	}
+
+	newStores(t)
+	// the schema registered by the orchestrator when it starts
+	err = ds.InsertDocument(ctx, "resources", db.Document{Fields: map[string]string{"SegmentId": "s1"}, KeyId: "{SegmentId}",
+		Tags: map[string]json.RawMessage{"segment": json.RawMessage(`{"name":"emco-base"}`)}})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	// the keys of the replicas of the new instance
+	put(t, "/lease/x/", map[string]string{"owner": "orchestrator1"})
+	put(t, "/rsyncreplica/x/", map[string]string{"address": "rsync1"})
+	if ok, err := Restorable(ctx); err != nil || !ok {
+		t.Errorf("Expected the empty databases restorable, got %v %v", ok, err)
+	}
+	result, err := Restore(ctx, target, m.Name)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if result.Documents != 5 || result.ContextKeys != 6 || result.ActiveContexts != 2 {
+		t.Errorf("Unexpected restore %+v", result)
+	}
+
+	if _, err := Restore(ctx, target, m.Name); err != ErrNotEmpty {
+		t.Errorf("Expected the restored databases not restored again, got %v", err)
+	}
+
+	values, err := db.DBconn.Find(ctx, "resources", projectKey{Project: "p1"}, "data")
+	if err != nil || len(values) != 1 || !strings.Contains(string(values[0]), "p1") {
+		t.Errorf("Expected the project restored, got %s %v", values, err)
+	}
+	docs, err := db.DBconn.(db.DocumentStore).FindDocuments(ctx, "audit", entryKey{Entry: "e1"})
+	if err != nil || len(docs) != 1 || string(docs[0].Tags["entry"]) != `{"method":"POST"}` {
+		t.Errorf("Expected the audit entry restored, got %+v %v", docs, err)
+	}
+	keys, err := contextdb.Db.GetAllKeys(ctx, "/activecontext/")
+	if err != nil || len(keys) != 2 || keys[0] != "/activecontext/1/" || keys[1] != "/activecontext/3/" {
+		t.Errorf("Expected the contexts 1 and 3 active, got %v %v", keys, err)
+	}
+	if _, err := contextdb.Db.GetAllKeys(ctx, "/rsynclease/"); err == nil {
+		t.Errorf("Expected no lease restored")
+	}
+	if _, err := contextdb.Db.GetAllKeys(ctx, "/lease/x/"); err != nil {
+		t.Errorf("Expected the lease of the replica kept, got %v", err)
+	}
+	var q queue
+	if err := contextdb.Db.Get(ctx, "/context/2/rsync/AppContextEventQueue/", &q); err != nil || q.AcQueue[0]["status"] != "Done" {
+		t.Errorf("Expected the queue of the context 2 restored, got %+v %v", q, err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	target := dirTarget{dir: t.TempDir()}
+	for _, name := range []string{"emco-20221001T100000Z.tgz", "emco-20221003T100000Z.tgz", "emco-20221002T100000Z.tgz", "other.tgz"} {
+		if err := target.Put(ctx, name, strings.NewReader("backup"), 6); err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+	}
+
+	prune(ctx, target, 2)
+	backups, err := List(ctx, target)
+	if err != nil || len(backups) != 2 || backups[0].Name != "emco-20221002T100000Z.tgz" || backups[1].Name != "emco-20221003T100000Z.tgz" {
+		t.Errorf("Expected the two latest backups, got %+v %v", backups, err)
+	}
+
+	name, err := Select(ctx, target, time.Date(2022, 10, 2, 12, 0, 0, 0, time.UTC))
+	if err != nil || name != "emco-20221002T100000Z.tgz" {
+		t.Errorf("Expected the backup of October 2, got %s %v", name, err)
+	}
+}
+
+func TestRestoreInvalid(t *testing.T) {
+	ctx := context.Background()
+	target := dirTarget{dir: t.TempDir()}
+	newStores(t)
+
+	if err := target.Put(ctx, "emco-20221001T100000Z.tgz", strings.NewReader("not a backup"), 12); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if _, err := Restore(ctx, target, "emco-20221001T100000Z.tgz"); err == nil || !strings.HasPrefix(err.Error(), "Invalid backup") {
+		t.Errorf("Expected an invalid backup, got %v", err)
+	}
+	if _, err := Restore(ctx, target, "emco-20221002T100000Z.tgz"); err == nil {
+		t.Errorf("Expected a missing backup")
+	}
+}
+
+// putBackup writes the backup of the documents of the collections
+func putBackup(t *testing.T, target Target, name string, collections map[string][]string) {
+	var buf bytes.Buffer
+	m := Manifest{Version: Version, Name: name, Format: "bolt", Collections: make(map[string]int)}
+	aw := newArchiveWriter(&buf, m.Created)
+	for _, coll := range []string{"a", "b"} {
+		for _, doc := range collections[coll] {
+			m.Collections[coll]++
+			if err := aw.add(collectionsDir+coll+"/", json.RawMessage(doc)); err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+		}
+	}
+	if err := aw.close(m); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if err := target.Put(context.Background(), name, &buf, int64(buf.Len())); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+}
+
+func TestRestoreRollback(t *testing.T) {
+	ctx := context.Background()
+	target := dirTarget{dir: t.TempDir()}
+	newStores(t)
+	doc := `{"id":"1","document":{"fields":{"entry":"e1"},"keyId":"{entry}","tags":{"entry":{}}}}`
+	// the lease of a running replica
+	put(t, "/lease/x/", map[string]string{"owner": "orchestrator1"})
+
+	// the second collection can't be restored
+	putBackup(t, target, "emco-20221001T100000Z.tgz", map[string][]string{"a": {doc}, "b": {`{"id":""}`}})
+	if _, err := Restore(ctx, target, "emco-20221001T100000Z.tgz"); err == nil || !strings.Contains(err.Error(), "Invalid document in the collection b") {
+		t.Fatalf("Expected an invalid document, got %v", err)
+	}
+	if ok, err := Restorable(ctx); err != nil || !ok {
+		t.Errorf("Expected the restore rolled back, got %v %v", ok, err)
+	}
+	if keys, err := contextdb.Db.GetAllKeys(ctx, "/"); err != nil || len(keys) != 1 || keys[0] != "/lease/x/" {
+		t.Errorf("Expected only the lease of the replica, got %v %v", keys, err)
+	}
+
+	// the restore interrupted by a stop is resumed
+	putBackup(t, target, "emco-20221002T100000Z.tgz", map[string][]string{"a": {doc}, "b": {doc}})
+	if err := db.DBconn.(db.Snapshotter).RestoreCollection(ctx, "a", []json.RawMessage{json.RawMessage(doc)}); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	put(t, restoreKey, "emco-20221002T100000Z.tgz")
+	put(t, "/context/1/", "1")
+	if ok, err := Restorable(ctx); err != nil || !ok {
+		t.Errorf("Expected the interrupted restore resumed, got %v %v", ok, err)
+	}
+	result, err := Restore(ctx, target, "emco-20221002T100000Z.tgz")
+	if err != nil || result.Documents != 2 || result.ContextKeys != 0 {
+		t.Fatalf("Unexpected restore %+v %v", result, err)
+	}
+	keys, err := contextdb.Db.GetAllKeys(ctx, "/")
+	if err != nil || len(keys) != 1 || keys[0] != "/lease/x/" {
+		t.Errorf("Expected the keys of the interrupted restore deleted, got %v %v", keys, err)
+	}
+	docs, err := db.DBconn.(db.DocumentStore).FindDocuments(ctx, "a", entryKey{Entry: "e1"})
+	if err != nil || len(docs) != 1 {
+		t.Errorf("Expected the document restored once, got %+v %v", docs, err)
+	}
+}
+
+func TestRestoreIncomplete(t *testing.T) {
+	ctx := context.Background()
+	target := dirTarget{dir: t.TempDir()}
+	newStores(t)
+
+	var buf bytes.Buffer
+	m := Manifest{Version: Version, Format: "bolt", Collections: map[string]int{"a": 2}}
+	aw := newArchiveWriter(&buf, m.Created)
+	if err := aw.add(collectionsDir+"a/", json.RawMessage(`{"id":"1","document":{}}`)); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if err := aw.close(m); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if err := target.Put(ctx, "emco-20221001T100000Z.tgz", &buf, int64(buf.Len())); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if _, err := Restore(ctx, target, "emco-20221001T100000Z.tgz"); err == nil || !strings.Contains(err.Error(), "documents or context keys are missing") {
+		t.Errorf("Expected an incomplete backup, got %v", err)
+	}
+}
+
+func TestDue(t *testing.T) {
+	ctx := context.Background()
+	target := dirTarget{dir: t.TempDir()}
+	now := time.Date(2022, 10, 1, 11, 0, 0, 0, time.UTC)
+	if !due(ctx, target, time.Hour, now) {
+		t.Errorf("Expected a backup due without backups")
+	}
+	if err := target.Put(ctx, "emco-20221001T103000Z.tgz", strings.NewReader("backup"), 6); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if due(ctx, target, time.Hour, now) {
+		t.Errorf("Expected no backup due")
+	}
+	if !due(ctx, target, time.Hour, now.Add(30*time.Minute)) {
+		t.Errorf("Expected a backup due")
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSignV4 checks the signature of the get-vanilla request of the AWS
+// Signature Version 4 test suite
+func TestSignV4(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	signV4(req, sha256Hex(nil), "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if auth := req.Header.Get("Authorization"); auth != expected {
+		t.Errorf("Expected the authorization %s, got %s", expected, auth)
+	}
+}
+
+// fakeS3 serves the objects of a bucket
+type fakeS3 struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+	if r.URL.Path == "/bucket" {
+		var keys []string
+		for k := range s.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprint(w, "<ListBucketResult><IsTruncated>false</IsTruncated>")
+		for _, k := range keys {
+			fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", k)
+		}
+		fmt.Fprint(w, "</ListBucketResult>")
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[key], _ = ioutil.ReadAll(r.Body)
+	case http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Target(t *testing.T) {
+	ctx := context.Background()
+	s3 := &fakeS3{objects: map[string][]byte{"emco/other.tgz": []byte("other")}}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	target, err := newS3Target(server.URL, "us-east-1", "bucket", "emco", "access", "secret")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if err := target.Put(ctx, "emco-20221001T100000Z.tgz", strings.NewReader("backup"), 6); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if string(s3.objects["emco/emco-20221001T100000Z.tgz"]) != "backup" {
+		t.Errorf("Expected the backup uploaded, got %v", s3.objects)
+	}
+
+	names, err := target.List(ctx)
+	if err != nil || len(names) != 1 || names[0] != "emco-20221001T100000Z.tgz" {
+		t.Errorf("Expected the backup listed, got %v %v", names, err)
+	}
+
+	r, err := target.Get(ctx, "emco-20221001T100000Z.tgz")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	data, _ := ioutil.ReadAll(r)
+	r.Close()
+	if string(data) != "backup" {
+		t.Errorf("Expected the backup downloaded, got %s", data)
+	}
+	if _, err := target.Get(ctx, "emco-20221002T100000Z.tgz"); err == nil || !strings.Contains(err.Error(), "NoSuchKey") {
+		t.Errorf("Expected a missing backup, got %v", err)
+	}
+
+	if err := target.Delete(ctx, "emco-20221001T100000Z.tgz"); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if _, ok := s3.objects["emco/emco-20221001T100000Z.tgz"]; ok {
+		t.Errorf("Expected the backup deleted")
+	}
+}
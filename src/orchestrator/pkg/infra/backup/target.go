@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package backup
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+)
+
+// Target stores the backups
+type Target interface {
+	// Put stores the backup with the name
+	Put(ctx context.Context, name string, r io.Reader, size int64) error
+	// Get returns the content of the backup with the name
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the names of the backups
+	List(ctx context.Context) ([]string, error)
+	// Delete deletes the backup with the name
+	Delete(ctx context.Context, name string) error
+}
+
+// NewTarget returns the target of the location, a local directory or an
+// S3-compatible bucket with an optional prefix, s3://bucket/prefix
+func NewTarget(location string) (Target, error) {
+	if location == "" {
+		return nil, pkgerrors.New("The backup target isn't configured")
+	}
+	if strings.HasPrefix(location, "s3://") {
+		path := strings.SplitN(strings.TrimPrefix(location, "s3://"), "/", 2)
+		bucket, prefix := path[0], ""
+		if len(path) == 2 {
+			prefix = path[1]
+		}
+		if bucket == "" {
+			return nil, pkgerrors.Errorf("Invalid backup target %s: the bucket is missing", location)
+		}
+		c := config.GetConfiguration()
+		return newS3Target(c.BackupS3Endpoint, c.BackupS3Region, bucket, prefix,
+			os.Getenv("BACKUP_S3_ACCESS_KEY"), os.Getenv("BACKUP_S3_SECRET_KEY"))
+	}
+	return dirTarget{dir: strings.TrimPrefix(location, "file://")}, nil
+}
+
+// dirTarget stores the backups as files of a local directory
+type dirTarget struct {
+	dir string
+}
+
+// Put writes the backup to a temporary file renamed once complete, so that
+// a partial backup is never listed
+func (t dirTarget) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return pkgerrors.Wrapf(err, "Error creating the backup directory %s", t.dir)
+	}
+	f, err := os.CreateTemp(t.dir, ".tmp-")
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error creating the backup file")
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return pkgerrors.Wrap(err, "Error writing the backup file")
+	}
+	if err := f.Close(); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the backup file")
+	}
+	if err := os.Rename(f.Name(), filepath.Join(t.dir, filepath.Base(name))); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the backup file")
+	}
+	return nil
+}
+
+// Get opens the file of the backup
+func (t dirTarget) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(t.dir, filepath.Base(name)))
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error opening the backup %s", name)
+	}
+	return f, nil
+}
+
+// List returns the names of the backup files of the directory
+func (t dirTarget) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(t.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error reading the backup directory %s", t.dir)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && isBackupName(e.Name()) {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// Delete removes the file of the backup
+func (t dirTarget) Delete(ctx context.Context, name string) error {
+	if err := os.Remove(filepath.Join(t.dir, filepath.Base(name))); err != nil {
+		return pkgerrors.Wrapf(err, "Error deleting the backup %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,654 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package bundle
+
+/*
+This package exports the configuration of a project as a bundle, a gzipped
+tar archive, and imports it in the same or in another EMCO. The bundle holds
+all the resources of the project stored by the services: the composite apps
+with their Helm charts and profiles, the DeploymentIntentGroups, the intents
+of all the controllers and the logical clouds. The state of the resources,
+e.g. the instantiations, isn't exported: the imported resources are created.
+*/
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/utils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
+)
+
+const (
+	// Version is the version of the bundle format
+	Version = "v1"
+
+	manifestFile  = "manifest.json"
+	resourcesFile = "resources.json"
+	// collection holds the resources of all the services
+	collection = "resources"
+	stateTag   = "stateInfo"
+)
+
+// runtimeTags are the tags of the state of the resources, which aren't exported
+var runtimeTags = map[string]bool{
+	stateTag:        true,
+	"rolloutStatus": true,
+}
+
+// Manifest describes a bundle
+type Manifest struct {
+	Version string `json:"version"`
+	// Format is the document format of the database the bundle is exported
+	// from, the bundle of a mongo database can be imported in a mongo or a
+	// bolt database, the bundle of a bolt database in a bolt database
+	Format    string    `json:"format"`
+	Project   string    `json:"project"`
+	Created   time.Time `json:"created"`
+	Resources int       `json:"resources"`
+}
+
+// Resource is a resource of a bundle
+type Resource struct {
+	db.Document
+	// Encrypted lists the values decrypted by the export, encrypted again by
+	// the import, by their dotted path starting with their tag
+	Encrypted []string `json:"encrypted,omitempty"`
+	// State is set for the resources with a lifecycle, which are imported
+	// in the Created state
+	State bool `json:"state,omitempty"`
+}
+
+// ImportOptions are the options of an import
+type ImportOptions struct {
+	// Rename maps the resource types, e.g. logicalCloud, to the new names of
+	// their resources by their names in the bundle. A resource is renamed in
+	// the keys of the resources, in its metadata and in the fields named
+	// after its type, e.g. the logicalCloud of a DeploymentIntentGroup.
+	Rename map[string]map[string]string `json:"rename,omitempty"`
+}
+
+// ImportResult is the result of an import
+type ImportResult struct {
+	Project   string `json:"project"`
+	Resources int    `json:"resources"`
+}
+
+// documentStore returns the database if it can export and import documents
+func documentStore() (db.DocumentStore, error) {
+	ds, ok := db.DBconn.(db.DocumentStore)
+	if !ok {
+		return nil, pkgerrors.Errorf("The database %T doesn't support the export and the import", db.DBconn)
+	}
+	return ds, nil
+}
+
+// Export writes the bundle of the project. The encrypted values are
+// decrypted, the bundle must be protected as the database itself.
+func Export(ctx context.Context, project string, w io.Writer) (Manifest, error) {
+	ds, err := documentStore()
+	if err != nil {
+		return Manifest{}, err
+	}
+	docs, err := ds.FindDocuments(ctx, collection, map[string]string{"project": project})
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	oe := utils.GetObjectEncryptor("emco")
+	found := false
+	resources := make([]Resource, 0, len(docs))
+	for _, doc := range docs {
+		if doc.KeyId == "{project}" {
+			found = true
+		}
+		res, err := exportResource(doc, oe)
+		if err != nil {
+			return Manifest{}, pkgerrors.Wrapf(err, "Error exporting the resource %v", doc.Key())
+		}
+		if len(res.Tags) > 0 {
+			resources = append(resources, res)
+		}
+	}
+	if !found {
+		return Manifest{}, pkgerrors.New("Project not found")
+	}
+	sortResources(resources)
+
+	m := Manifest{
+		Version:   Version,
+		Format:    ds.DocumentFormat(),
+		Project:   project,
+		Created:   time.Now().UTC(),
+		Resources: len(resources),
+	}
+	if err := writeArchive(w, m, resources); err != nil {
+		return Manifest{}, err
+	}
+	log.Info("Exported the project", log.Fields{"project": project, "resources": m.Resources})
+	return m, nil
+}
+
+// exportResource returns the resource of the document without its state,
+// with its values decrypted
+func exportResource(doc db.Document, oe utils.IObjectEncryptor) (Resource, error) {
+	res := Resource{Document: doc}
+	res.Tags = make(map[string]json.RawMessage)
+	for tag, raw := range doc.Tags {
+		if runtimeTags[tag] {
+			res.State = res.State || tag == stateTag
+			continue
+		}
+		if oe == nil {
+			res.Tags[tag] = raw
+			continue
+		}
+		v, err := decodeTag(raw)
+		if err != nil {
+			return res, err
+		}
+		v, changed, err := walkStrings(v, tag, "", func(field, path, s string) (string, error) {
+			m, err := oe.DecryptString(s)
+			if err != nil {
+				if utils.IsEncrypted(s) {
+					return "", pkgerrors.Wrapf(err, "Error decrypting the value %s of %s", path, tag)
+				}
+				// not encrypted
+				return s, nil
+			}
+			res.Encrypted = append(res.Encrypted, tagPath(tag, path))
+			return m, nil
+		})
+		if err != nil {
+			return res, err
+		}
+		if !changed {
+			res.Tags[tag] = raw
+			continue
+		}
+		if res.Tags[tag], err = json.Marshal(v); err != nil {
+			return res, pkgerrors.Wrapf(err, "Error Marshalling the tag %s", tag)
+		}
+	}
+	sort.Strings(res.Encrypted)
+	return res, nil
+}
+
+// Import creates the project and its resources from the bundle, renamed as
+// requested: project, if not empty, is the name of the project in place
+// of its name in the bundle. The project must not exist. The resources are
+// created in the order of their dependencies, and the ones created are
+// deleted if one can't be.
+func Import(ctx context.Context, r io.Reader, project string, opts ImportOptions) (ImportResult, error) {
+	ds, err := documentStore()
+	if err != nil {
+		return ImportResult{}, err
+	}
+	m, resources, err := readArchive(r)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	for i := range resources {
+		if resources[i].Document, err = db.ConvertDocument(resources[i].Document, m.Format, ds.DocumentFormat()); err != nil {
+			return ImportResult{}, pkgerrors.Wrapf(err, "Error importing the resource %v", resources[i].Key())
+		}
+	}
+
+	rename := make(map[string]map[string]string)
+	for t, names := range opts.Rename {
+		rename[t] = names
+	}
+	if project != "" && project != m.Project {
+		rename["project"] = map[string]string{m.Project: project}
+	}
+
+	oe := utils.GetObjectEncryptor("emco")
+	own := ownFields(resources)
+	target := ""
+	for i := range resources {
+		if err := importResource(&resources[i], own[resources[i].KeyId], rename, oe); err != nil {
+			return ImportResult{}, pkgerrors.Wrapf(err, "Error importing the resource %v", resources[i].Key())
+		}
+		if resources[i].KeyId == "{project}" {
+			target = resources[i].Fields["project"]
+		}
+	}
+	if target == "" {
+		return ImportResult{}, pkgerrors.New("Invalid bundle: the project is missing")
+	}
+	existing, err := ds.FindDocuments(ctx, collection, map[string]string{"project": target})
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if len(existing) > 0 {
+		return ImportResult{}, pkgerrors.Errorf("Project already exists: %s", target)
+	}
+	if err := validateResources(ctx, resources, own); err != nil {
+		return ImportResult{}, err
+	}
+
+	sortResources(resources)
+	for i, res := range resources {
+		if err := createResource(ctx, ds, res); err != nil {
+			log.Error("Error importing the project, deleting the imported resources", log.Fields{"project": target, "resource": res.Key(), "error": err})
+			for j := i; j >= 0; j-- {
+				if err := db.DBconn.RemoveAll(ctx, collection, resources[j].Key()); err != nil {
+					log.Warn("Error deleting an imported resource", log.Fields{"resource": resources[j].Key(), "error": err})
+				}
+			}
+			return ImportResult{}, pkgerrors.Wrapf(err, "Error importing the resource %v", res.Key())
+		}
+	}
+	log.Info("Imported the project", log.Fields{"project": target, "from": m.Project, "resources": len(resources)})
+	return ImportResult{Project: target, Resources: len(resources)}, nil
+}
+
+// importResource renames the resource and encrypts the values decrypted by
+// the export. own are the fields of the key naming the resource itself.
+func importResource(res *Resource, own []string, rename map[string]map[string]string, oe utils.IObjectEncryptor) error {
+	newName := func(t, name string) (string, bool) {
+		n, ok := rename[t][name]
+		return n, ok && n != ""
+	}
+	names := make(map[string]string)
+	for _, t := range own {
+		names[t] = res.Fields[t]
+	}
+	fields := make(map[string]string)
+	for k, v := range res.Fields {
+		if n, ok := newName(k, v); ok {
+			v = n
+		}
+		fields[k] = v
+	}
+	res.Fields = fields
+
+	for i, ref := range res.References {
+		key, err := referenceKey(ref.Key)
+		if err != nil {
+			return err
+		}
+		for k, v := range key {
+			if n, ok := newName(k, v); ok {
+				key[k] = n
+			}
+		}
+		res.References[i].Key = key
+	}
+
+	encrypted := make(map[string]bool)
+	for _, p := range res.Encrypted {
+		encrypted[p] = true
+	}
+	for tag, raw := range res.Tags {
+		v, err := decodeTag(raw)
+		if err != nil {
+			return err
+		}
+		v, changed, err := walkStrings(v, tag, "", func(field, path, s string) (string, error) {
+			if path == "metadata.name" {
+				for t, name := range names {
+					if n, ok := newName(t, s); ok && name == s {
+						s = n
+						break
+					}
+				}
+			} else {
+				for t := range rename {
+					if n, ok := newName(t, s); ok && strings.EqualFold(t, field) {
+						s = n
+						break
+					}
+				}
+			}
+			if encrypted[tagPath(tag, path)] && oe != nil {
+				return oe.EncryptString(s)
+			}
+			return s, nil
+		})
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		if res.Tags[tag], err = json.Marshal(v); err != nil {
+			return pkgerrors.Wrapf(err, "Error Marshalling the tag %s", tag)
+		}
+	}
+	return nil
+}
+
+// validateResources validates the resources as the API validates them: the
+// names of the resources and the names in their metadata, and the resources
+// they reference, which must be in the bundle or in the database
+func validateResources(ctx context.Context, resources []Resource, own map[string][]string) error {
+	keys := make(map[string]bool)
+	for _, res := range resources {
+		keys[keyString(res.Key())] = true
+	}
+	for _, res := range resources {
+		names := make(map[string]bool)
+		for _, f := range own[res.KeyId] {
+			name := res.Fields[f]
+			if errs := validation.IsValidName(name); len(errs) > 0 {
+				return pkgerrors.Errorf("Invalid bundle: invalid %s name %q of the resource %v: %s", f, name, res.Key(), strings.Join(errs, ", "))
+			}
+			names[name] = true
+		}
+
+		var data struct {
+			Metadata struct {
+				Name *string `json:"name"`
+			} `json:"metadata"`
+		}
+		if raw, ok := res.Tags["data"]; ok {
+			if err := json.Unmarshal(raw, &data); err != nil {
+				return pkgerrors.Wrapf(err, "Invalid bundle: invalid data of the resource %v", res.Key())
+			}
+		}
+		if data.Metadata.Name != nil && !names[*data.Metadata.Name] {
+			return pkgerrors.Errorf("Invalid bundle: the name %q in the metadata of the resource %v doesn't match its key", *data.Metadata.Name, res.Key())
+		}
+
+		for _, ref := range res.References {
+			key, err := referenceKey(ref.Key)
+			if err != nil {
+				return err
+			}
+			if keys[keyString(key)] {
+				continue
+			}
+			found, err := db.DBconn.Find(ctx, collection, key, "data")
+			if err != nil {
+				return pkgerrors.Wrapf(err, "Error finding the resource %v referenced by %v", key, res.Key())
+			}
+			if len(found) == 0 {
+				return pkgerrors.Errorf("Invalid bundle: the resource %v references the missing resource %v", res.Key(), key)
+			}
+		}
+	}
+	return nil
+}
+
+// keyString returns the string of a key, the same for the same fields
+func keyString(key map[string]string) string {
+	s, _ := json.Marshal(key)
+	return string(s)
+}
+
+// createResource stores the resource, with a new state if it has one. The
+// resource is verified as the database verifies the resources it inserts:
+// its parent must exist, and its references are the ones of the bundle and
+// the ones found in its data.
+func createResource(ctx context.Context, ds db.DocumentStore, res Resource) error {
+	refs, err := db.VerifyDocument(ctx, db.DBconn, collection, res.Document)
+	if err != nil {
+		return err
+	}
+	res.References = mergeReferences(res.References, refs)
+	if err := ds.InsertDocument(ctx, collection, res.Document); err != nil {
+		return err
+	}
+	if !res.State {
+		return nil
+	}
+	s := state.StateInfo{}
+	a := state.ActionEntry{
+		State:     state.StateEnum.Created,
+		ContextId: "",
+		TimeStamp: time.Now(),
+	}
+	s.Actions = append(s.Actions, a)
+	return db.DBconn.Insert(ctx, collection, res.Key(), nil, stateTag, s)
+}
+
+// mergeReferences returns the references without the duplicates
+func mergeReferences(refs, more []db.ReferenceEntry) []db.ReferenceEntry {
+	seen := make(map[string]bool)
+	merged := make([]db.ReferenceEntry, 0, len(refs)+len(more))
+	for _, list := range [][]db.ReferenceEntry{refs, more} {
+		for _, ref := range list {
+			key, err := referenceKey(ref.Key)
+			if err != nil {
+				continue
+			}
+			id := ref.KeyId + keyString(key)
+			if !seen[id] {
+				seen[id] = true
+				merged = append(merged, db.ReferenceEntry{Key: key, KeyId: ref.KeyId})
+			}
+		}
+	}
+	return merged
+}
+
+// ownFields returns the fields of the keys naming the resources themselves
+// by keyId, i.e. the fields not in the key of their parent: the largest key
+// of the bundle included in theirs
+func ownFields(resources []Resource) map[string][]string {
+	keys := make(map[string]map[string]string)
+	for _, res := range resources {
+		keys[res.KeyId] = res.Key()
+	}
+	own := make(map[string][]string)
+	for keyId, key := range keys {
+		var parent map[string]string
+		for _, k := range keys {
+			if len(k) < len(key) && len(k) > len(parent) && includes(key, k) {
+				parent = k
+			}
+		}
+		for f := range key {
+			if _, ok := parent[f]; !ok {
+				own[keyId] = append(own[keyId], f)
+			}
+		}
+	}
+	return own
+}
+
+// includes returns whether all the fields of the sub key are in the key
+func includes(key, sub map[string]string) bool {
+	for f := range sub {
+		if _, ok := key[f]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sortResources sorts the resources in the order of their dependencies:
+// the parents, with fewer key fields, first
+func sortResources(resources []Resource) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		ki, kj := resources[i].Key(), resources[j].Key()
+		if len(ki) != len(kj) {
+			return len(ki) < len(kj)
+		}
+		if resources[i].KeyId != resources[j].KeyId {
+			return resources[i].KeyId < resources[j].KeyId
+		}
+		si, _ := json.Marshal(ki)
+		sj, _ := json.Marshal(kj)
+		return string(si) < string(sj)
+	})
+}
+
+// referenceKey returns the key of a reference as a map
+func referenceKey(key db.Key) (map[string]string, error) {
+	var m map[string]string
+	data, err := json.Marshal(key)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error Marshalling the reference key")
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, pkgerrors.Wrap(err, "Invalid bundle: invalid reference key")
+	}
+	return m, nil
+}
+
+// decodeTag decodes the JSON value of a tag, keeping the numbers as they are
+func decodeTag(raw json.RawMessage) (interface{}, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, pkgerrors.Wrap(err, "Error Unmarshalling the tag")
+	}
+	return v, nil
+}
+
+// tagPath returns the dotted path of a value starting with its tag
+func tagPath(tag, path string) string {
+	if path == "" {
+		return tag
+	}
+	return tag + "." + path
+}
+
+// walkStrings replaces the string values of the decoded JSON value by the
+// values returned by fn, called with the name of their field and their
+// dotted path. The fields starting with $, the types of the extended JSON,
+// are skipped. It returns the value and whether it changed.
+func walkStrings(v interface{}, field, path string, fn func(field, path, s string) (string, error)) (interface{}, bool, error) {
+	child := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	changed := false
+	switch t := v.(type) {
+	case string:
+		s, err := fn(field, path, t)
+		return s, s != t, err
+	case map[string]interface{}:
+		for k, e := range t {
+			if strings.HasPrefix(k, "$") {
+				continue
+			}
+			n, c, err := walkStrings(e, k, child(k), fn)
+			if err != nil {
+				return v, false, err
+			}
+			t[k] = n
+			changed = changed || c
+		}
+	case []interface{}:
+		for i, e := range t {
+			n, c, err := walkStrings(e, field, child(strconv.Itoa(i)), fn)
+			if err != nil {
+				return v, false, err
+			}
+			t[i] = n
+			changed = changed || c
+		}
+	}
+	return v, changed, nil
+}
+
+// writeArchive writes the gzipped tar archive of the bundle
+func writeArchive(w io.Writer, m Manifest, resources []Resource) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	files := []struct {
+		name string
+		v    interface{}
+	}{
+		{manifestFile, m},
+		{resourcesFile, resources},
+	}
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.v, "", "  ")
+		if err != nil {
+			return pkgerrors.Wrapf(err, "Error Marshalling %s", f.name)
+		}
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: m.Created,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return pkgerrors.Wrap(err, "Error writing the bundle")
+		}
+		if _, err := tw.Write(data); err != nil {
+			return pkgerrors.Wrap(err, "Error writing the bundle")
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the bundle")
+	}
+	if err := gw.Close(); err != nil {
+		return pkgerrors.Wrap(err, "Error writing the bundle")
+	}
+	return nil
+}
+
+// readArchive reads the manifest and the resources of a bundle
+func readArchive(r io.Reader) (Manifest, []Resource, error) {
+	var m Manifest
+	var resources []Resource
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return m, nil, pkgerrors.Wrap(err, "Invalid bundle")
+	}
+	defer gr.Close()
+
+	files := make(map[string]bool)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return m, nil, pkgerrors.Wrap(err, "Invalid bundle")
+		}
+		var v interface{}
+		switch hdr.Name {
+		case manifestFile:
+			v = &m
+		case resourcesFile:
+			v = &resources
+		default:
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return m, nil, pkgerrors.Wrap(err, "Invalid bundle")
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			return m, nil, pkgerrors.Wrapf(err, "Invalid bundle: invalid %s", hdr.Name)
+		}
+		files[hdr.Name] = true
+	}
+
+	if !files[manifestFile] || !files[resourcesFile] {
+		return m, nil, pkgerrors.New("Invalid bundle: the manifest or the resources are missing")
+	}
+	if m.Version != Version {
+		return m, nil, pkgerrors.Errorf("Invalid bundle: unsupported version %s", m.Version)
+	}
+	for _, res := range resources {
+		if len(res.Key()) == 0 || len(res.Tags) == 0 {
+			return m, nil, pkgerrors.New("Invalid bundle: invalid resource")
+		}
+	}
+	return m, resources, nil
+}
@@ -0,0 +1,314 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/utils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
+	bolt "go.etcd.io/bbolt"
+)
+
+// newStore returns a store with the referential schema of the orchestrator
+func newStore(t *testing.T) db.DocumentStore {
+	bdb, err := bolt.Open(filepath.Join(t.TempDir(), "test.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	t.Cleanup(func() { bdb.Close() })
+
+	// the store reads the schema from the directory of the orchestrator
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if err := os.Chdir("../../.."); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	defer os.Chdir(wd)
+	ctx := context.Background()
+	store, err := db.NewBoltStore(ctx, "test", bdb)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	for i := 0; ; i++ {
+		segments, err := store.Find(ctx, collection, db.DbSchemaKey{}, "segment")
+		if err == nil && len(segments) > 0 {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("Expected the schema registered, got %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	db.DBconn = store
+	return store.(db.DocumentStore)
+}
+
+func insert(t *testing.T, ds db.DocumentStore, keyId string, fields map[string]string, refs []db.ReferenceEntry, tags map[string]interface{}) {
+	doc := db.Document{Fields: fields, KeyId: keyId, References: refs, Tags: make(map[string]json.RawMessage)}
+	for tag, v := range tags {
+		doc.Tags[tag], _ = json.Marshal(v)
+	}
+	if err := ds.InsertDocument(context.Background(), collection, doc); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+}
+
+// find reads the tag of the resource of the key
+func find(t *testing.T, key map[string]string, tag string, out interface{}) {
+	docs, err := db.DBconn.(db.DocumentStore).FindDocuments(context.Background(), collection, key)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	for _, doc := range docs {
+		if len(doc.Key()) == len(key) {
+			if err := json.Unmarshal(doc.Tags[tag], out); err != nil {
+				t.Fatalf("Unexpected %s of %v: %s", tag, key, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("Expected the resource %v", key)
+}
+
+func metadata(name string) map[string]interface{} {
+	return map[string]interface{}{"metadata": map[string]string{"name": name}}
+}
+
+func TestExportImport(t *testing.T) {
+	key := make([]byte, 32)
+	rand.Read(key)
+	os.Setenv("EMCO_DATA_KEYS", "k1="+base64.StdEncoding.EncodeToString(key))
+	defer os.Unsetenv("EMCO_DATA_KEYS")
+	oe := utils.GetObjectEncryptor("emco")
+	if oe == nil {
+		t.Fatalf("Expected an object encryptor")
+	}
+	secret, _ := oe.EncryptString("private key")
+
+	ctx := context.Background()
+	ds := newStore(t)
+	instantiated := state.StateInfo{Actions: []state.ActionEntry{{State: state.StateEnum.Instantiated, ContextId: "1234"}}}
+	insert(t, ds, "{project}", map[string]string{"project": "p1"}, nil, map[string]interface{}{"data": metadata("p1")})
+	insert(t, ds, "{project}", map[string]string{"project": "p2"}, nil, map[string]interface{}{"data": metadata("p2")})
+	ca := map[string]string{"project": "p1", "compositeApp": "ca", "compositeAppVersion": "v1"}
+	insert(t, ds, "{compositeApp,compositeAppVersion,project}", ca, nil, map[string]interface{}{"data": metadata("ca")})
+	insert(t, ds, "{app,compositeApp,compositeAppVersion,project}",
+		map[string]string{"project": "p1", "compositeApp": "ca", "compositeAppVersion": "v1", "app": "a1"}, nil,
+		map[string]interface{}{"data": metadata("a1"), "appcontent": map[string]string{"FileContent": "Y2hhcnQ="}})
+	lc := map[string]string{"project": "p1", "logicalCloud": "lc1"}
+	insert(t, ds, "{logicalCloud,project}", lc, nil, map[string]interface{}{
+		"data":       metadata("lc1"),
+		"privatekey": map[string]string{"keyvalue": secret},
+		"stateInfo":  instantiated,
+	})
+	dig := map[string]interface{}{
+		"metadata": map[string]string{"name": "dig1"},
+		"spec":     map[string]string{"logicalCloud": "lc1", "version": "v1"},
+	}
+	insert(t, ds, "{compositeApp,compositeAppVersion,deploymentIntentGroup,project}",
+		map[string]string{"project": "p1", "compositeApp": "ca", "compositeAppVersion": "v1", "deploymentIntentGroup": "dig1"},
+		[]db.ReferenceEntry{{Key: lc, KeyId: "{logicalCloud,project}"}},
+		map[string]interface{}{"data": dig, "stateInfo": instantiated, "rolloutStatus": map[string]string{"id": "r1"}})
+
+	var buf bytes.Buffer
+	m, err := Export(ctx, "p1", &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	_, resources, err := readArchive(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if m.Resources != 5 || len(resources) != 5 || m.Format != "bolt" || resources[0].KeyId != "{project}" {
+		t.Fatalf("Unexpected bundle %+v %+v", m, resources)
+	}
+	for _, res := range resources {
+		if _, ok := res.Tags["stateInfo"]; ok {
+			t.Errorf("Expected no state in the bundle %+v", res)
+		}
+		if res.KeyId == "{logicalCloud,project}" {
+			if !res.State || len(res.Encrypted) != 1 || res.Encrypted[0] != "privatekey.keyvalue" ||
+				!strings.Contains(string(res.Tags["privatekey"]), "private key") {
+				t.Errorf("Expected the private key decrypted in the bundle %+v", res)
+			}
+		}
+	}
+
+	if _, err := Import(ctx, bytes.NewReader(buf.Bytes()), "", ImportOptions{}); err == nil || !strings.Contains(err.Error(), "Project already exists") {
+		t.Errorf("Expected an existing project, got %v", err)
+	}
+
+	opts := ImportOptions{Rename: map[string]map[string]string{"logicalCloud": {"lc1": "lc2"}}}
+	result, err := Import(ctx, bytes.NewReader(buf.Bytes()), "p3", opts)
+	if err != nil || result.Project != "p3" || result.Resources != 5 {
+		t.Fatalf("Unexpected import %+v %v", result, err)
+	}
+
+	var p struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+	find(t, map[string]string{"project": "p3"}, "data", &p)
+	if p.Metadata["name"] != "p3" {
+		t.Errorf("Expected the project renamed, got %+v", p)
+	}
+	lc2 := map[string]string{"project": "p3", "logicalCloud": "lc2"}
+	find(t, lc2, "data", &p)
+	if p.Metadata["name"] != "lc2" {
+		t.Errorf("Expected the logical cloud renamed, got %+v", p)
+	}
+	var pk map[string]string
+	find(t, lc2, "privatekey", &pk)
+	if v, err := oe.DecryptString(pk["keyvalue"]); !utils.IsEncrypted(pk["keyvalue"]) || err != nil || v != "private key" {
+		t.Errorf("Expected the private key encrypted, got %+v %v", pk, err)
+	}
+
+	digKey := map[string]string{"project": "p3", "compositeApp": "ca", "compositeAppVersion": "v1", "deploymentIntentGroup": "dig1"}
+	var d struct {
+		Spec map[string]string `json:"spec"`
+	}
+	find(t, digKey, "data", &d)
+	if d.Spec["logicalCloud"] != "lc2" || d.Spec["version"] != "v1" {
+		t.Errorf("Expected the logical cloud of the DIG renamed, got %+v", d)
+	}
+	var s state.StateInfo
+	find(t, digKey, "stateInfo", &s)
+	if len(s.Actions) != 1 || s.Actions[0].State != state.StateEnum.Created {
+		t.Errorf("Expected the DIG created, got %+v", s)
+	}
+	docs, _ := ds.FindDocuments(ctx, collection, digKey)
+	if len(docs) != 1 || len(docs[0].References) != 1 {
+		t.Fatalf("Unexpected DIG %+v", docs)
+	}
+	if ref, _ := referenceKey(docs[0].References[0].Key); ref["project"] != "p3" || ref["logicalCloud"] != "lc2" {
+		t.Errorf("Expected the reference renamed, got %v", ref)
+	}
+	if _, ok := docs[0].Tags["rolloutStatus"]; ok {
+		t.Errorf("Expected no rollout status, got %+v", docs[0])
+	}
+
+	if _, err := Export(ctx, "missing", &bytes.Buffer{}); err == nil || err.Error() != "Project not found" {
+		t.Errorf("Expected a missing project, got %v", err)
+	}
+}
+
+// writeBundle returns the bundle of the resources of the project p1
+func writeBundle(t *testing.T, format string, resources ...Resource) *bytes.Buffer {
+	var buf bytes.Buffer
+	m := Manifest{Version: Version, Format: format, Project: "p1", Resources: len(resources)}
+	if err := writeArchive(&buf, m, resources); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	return &buf
+}
+
+func resource(keyId string, fields map[string]string, data string) Resource {
+	return Resource{Document: db.Document{Fields: fields, KeyId: keyId,
+		Tags: map[string]json.RawMessage{"data": json.RawMessage(data)}}}
+}
+
+func TestImportMongo(t *testing.T) {
+	ctx := context.Background()
+	newStore(t)
+	project := resource("{project}", map[string]string{"project": "p1"}, `{"metadata":{"name":"p1"}}`)
+	lc := resource("{logicalCloud,project}", map[string]string{"project": "p1", "logicalCloud": "lc1"},
+		`{"metadata":{"name":"lc1"},"spec":{"level":{"$numberInt":"1"},"size":{"$numberLong":"20"},"ratio":{"$numberDouble":"0.5"},`+
+			`"created":{"$date":{"$numberLong":"1664618400500"}},"cert":{"$binary":{"base64":"Y2VydA==","subType":"00"}}}}`)
+	if _, err := Import(ctx, writeBundle(t, "mongo", project, lc), "", ImportOptions{}); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	var data struct {
+		Spec struct {
+			Level   int       `json:"level"`
+			Size    int64     `json:"size"`
+			Ratio   float64   `json:"ratio"`
+			Created time.Time `json:"created"`
+			Cert    []byte    `json:"cert"`
+		} `json:"spec"`
+	}
+	find(t, map[string]string{"project": "p1", "logicalCloud": "lc1"}, "data", &data)
+	if data.Spec.Level != 1 || data.Spec.Size != 20 || data.Spec.Ratio != 0.5 || string(data.Spec.Cert) != "cert" ||
+		!data.Spec.Created.Equal(time.Date(2022, 10, 1, 10, 0, 0, 500000000, time.UTC)) {
+		t.Errorf("Expected the extended JSON converted, got %+v", data)
+	}
+
+	regex := resource("{project}", map[string]string{"project": "p2"}, `{"metadata":{"name":"p2"},"spec":{"match":{"$regularExpression":{"pattern":"a","options":""}}}}`)
+	if _, err := Import(ctx, writeBundle(t, "mongo", regex), "", ImportOptions{}); err == nil || !strings.Contains(err.Error(), "$regularExpression can't be encoded in JSON") {
+		t.Errorf("Expected an unconverted type, got %v", err)
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	newStore(t)
+	if _, err := Import(context.Background(), strings.NewReader("not a bundle"), "p1", ImportOptions{}); err == nil || !strings.HasPrefix(err.Error(), "Invalid bundle") {
+		t.Errorf("Expected an invalid bundle, got %v", err)
+	}
+
+	ctx := context.Background()
+	project := resource("{project}", map[string]string{"project": "p1"}, `{"metadata":{"name":"p1"}}`)
+	if _, err := Import(ctx, writeBundle(t, "unknown", project), "", ImportOptions{}); err == nil || !strings.Contains(err.Error(), "unknown database can't be converted") {
+		t.Errorf("Expected a format mismatch, got %v", err)
+	}
+
+	caFields := map[string]string{"project": "p1", "compositeApp": "ca", "compositeAppVersion": "v1"}
+	ca := resource("{compositeApp,compositeAppVersion,project}", caFields, `{"metadata":{"name":"ca"}}`)
+	tests := []struct {
+		name      string
+		resources []Resource
+		err       string
+	}{
+		{
+			name: "invalid name",
+			resources: []Resource{project, resource("{logicalCloud,project}", map[string]string{"project": "p1", "logicalCloud": "lc_"},
+				`{"metadata":{"name":"lc_"}}`)},
+			err: "Invalid bundle: invalid logicalCloud name",
+		},
+		{
+			name: "metadata",
+			resources: []Resource{project, resource("{logicalCloud,project}", map[string]string{"project": "p1", "logicalCloud": "lc1"},
+				`{"metadata":{"name":"lc2"}}`)},
+			err: "doesn't match its key",
+		},
+		{
+			name: "reference",
+			resources: []Resource{project, ca, func() Resource {
+				dig := resource("{compositeApp,compositeAppVersion,deploymentIntentGroup,project}",
+					map[string]string{"project": "p1", "compositeApp": "ca", "compositeAppVersion": "v1", "deploymentIntentGroup": "dig1"},
+					`{"metadata":{"name":"dig1"},"spec":{"logicalCloud":"lc1"}}`)
+				dig.References = []db.ReferenceEntry{{Key: map[string]string{"project": "p1", "logicalCloud": "lc1"}, KeyId: "{logicalCloud,project}"}}
+				return dig
+			}()},
+			err: "references the missing resource",
+		},
+		{
+			name: "parent",
+			resources: []Resource{project, resource("{app,compositeApp,compositeAppVersion,project}",
+				map[string]string{"project": "p1", "compositeApp": "ca", "compositeAppVersion": "v1", "app": "a1"}, `{"metadata":{"name":"a1"}}`)},
+			err: "Parent resource not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Import(ctx, writeBundle(t, "bolt", tt.resources...), "", ImportOptions{}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("Expected %q, got %v", tt.err, err)
+			}
+			if found, _ := db.DBconn.Find(ctx, collection, map[string]string{"project": "p1"}, "data"); len(found) != 0 {
+				t.Errorf("Expected nothing imported, got %d resources", len(found))
+			}
+		})
+	}
+}
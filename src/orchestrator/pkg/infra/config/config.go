@@ -20,7 +20,9 @@ type Configuration struct {
 	Password               string `json:"password"`
 	DatabaseIP             string `json:"database-ip"`
 	DatabaseType           string `json:"database-type"`
+	DatabasePath           string `json:"database-path"`
 	PluginDir              string `json:"plugin-dir"`
+	ContextDatabaseType    string `json:"contextdb-type"`
 	EtcdIP                 string `json:"etcd-ip"`
 	EtcdCert               string `json:"etcd-cert"`
 	EtcdKey                string `json:"etcd-key"`
@@ -40,6 +42,14 @@ type Configuration struct {
 	MaxRetries             string `json:"max-retries"`
 	BackOff                int    `json:"db-schema-backoff"`
 	MaxBackOff             int    `json:"db-schema-max-backoff"`
+	MaxClusterOps          int    `json:"max-cluster-operations"`
+	MaxProviderOps         int    `json:"max-provider-operations"`
+	ClusterAPIQPS          int    `json:"cluster-api-qps"`
+	ClusterAPIBurst        int    `json:"cluster-api-burst"`
+	GitOpsCommitWindow     int    `json:"gitops-commit-window"`
+	ServerSideApply        bool   `json:"server-side-apply"`
+	RsyncSharding          bool   `json:"rsync-sharding"`
+	RsyncLeaseDuration     int    `json:"rsync-lease-duration"`
 
 	// EMCO-internal communication
 	//    wait time for a grpc connection to become ready, in milliseconds
@@ -52,9 +62,88 @@ type Configuration struct {
 	//    For now, we use a fixed timeout for all.
 	GrpcCallTimeout int `json:"grpc-call-timeout"`
 
+	// rsync retries of the operations on the clusters
+	//    delay before the first retry, in seconds
+	RetryInitialDelay float64 `json:"retry-initial-delay"`
+	//    max delay between two retries, in seconds
+	RetryMaxDelay float64 `json:"retry-max-delay"`
+	//    growth of the delay after each retry
+	RetryMultiplier float64 `json:"retry-multiplier"`
+	//    random fraction of each delay
+	RetryJitter float64 `json:"retry-jitter"`
+	//    attempts of an operation on a resource
+	RetryMaxAttempts int `json:"retry-max-attempts"`
+	//    classes of errors that are retried, the others fail at once
+	RetryOn []string `json:"retry-on"`
+
+	// Authentication and authorization of the REST APIs
+	//    require a valid bearer token and enforce the roles of its user
+	AuthEnabled bool `json:"auth-enabled"`
+	//    OIDC issuer of the tokens, its keys are discovered from the issuer
+	AuthIssuer string `json:"auth-issuer"`
+	//    local JWKS file with the keys of the tokens, instead of the discovery
+	AuthJwksFile string `json:"auth-jwks-file"`
+	//    audience the tokens must be issued for, not checked if empty
+	AuthAudience string `json:"auth-audience"`
+	//    claim with the groups or roles of the user, dotted for a nested claim
+	AuthRolesClaim string `json:"auth-roles-claim"`
+	//    roles of EMCO granted to the values of the claim
+	AuthRoleBindings []AuthRoleBinding `json:"auth-role-bindings"`
+	//    paths served without a token
+	AuthExemptPaths []string `json:"auth-exempt-paths"`
+
+	// Audit of the changes made through the REST APIs
+	//    record the POST, PUT, PATCH and DELETE requests in the database
+	AuditEnabled bool `json:"audit-enabled"`
+	//    file the records are also appended to, one json object per line
+	AuditFile string `json:"audit-file"`
+	//    days the records are kept in the database for, 0 keeps them all
+	AuditRetention int `json:"audit-retention"`
+
+	// Encryption of the secrets stored in the database, e.g. the kubeconfigs
+	//    source of the key encryption keys: env, file or plugin
+	EncryptionKeySource string `json:"encryption-key-source"`
+	//    json file with the keys, for the file source
+	EncryptionKeyFile string `json:"encryption-key-file"`
+	//    gRPC endpoint of the KMS plugin, for the plugin source, a unix
+	//    socket or a TCP endpoint with TLS
+	EncryptionPluginEndpoint string `json:"encryption-plugin-endpoint"`
+	//    CA certificate verifying the KMS plugin, for a TCP endpoint
+	EncryptionPluginCAFile string `json:"encryption-plugin-ca-file"`
+	//    client certificate and key presented to the KMS plugin, if any
+	EncryptionPluginCertFile string `json:"encryption-plugin-cert-file"`
+	EncryptionPluginKeyFile  string `json:"encryption-plugin-key-file"`
+
+	// Backups of the database and the context database of EMCO
+	//    directory, or s3://bucket/prefix, the backups are stored in
+	BackupTarget string `json:"backup-target"`
+	//    minutes between two backups taken by the orchestrator, 0 disables them
+	BackupInterval int `json:"backup-interval"`
+	//    number of backups kept, the older ones are deleted, 0 keeps them all
+	BackupRetention int `json:"backup-retention"`
+	//    endpoint of the S3-compatible storage, e.g. https://minio:9000
+	BackupS3Endpoint string `json:"backup-s3-endpoint"`
+	//    region of the S3-compatible storage
+	BackupS3Region string `json:"backup-s3-region"`
+
+	// Health probes of the clusters, by clm
+	//    seconds between two probes of all the clusters, 0 disables them
+	ClusterProbeInterval int `json:"cluster-probe-interval"`
+	//    number of clusters probed at the same time
+	ClusterProbeWorkers int `json:"cluster-probe-workers"`
+
 	// TODO: EMCO-K8s communication: Create similar time/timeout params
 }
 
+// AuthRoleBinding grants the role, viewer, operator or admin, to the users
+// with the value in their roles claim, in the projects or, without projects,
+// in all of EMCO
+type AuthRoleBinding struct {
+	Claim    string   `json:"claim"`
+	Role     string   `json:"role"`
+	Projects []string `json:"projects,omitempty"`
+}
+
 // Config is the structure that stores the configuration
 var gConfig *Configuration
 
@@ -95,7 +184,9 @@ func defaultConfiguration() *Configuration {
 		Password:               "",
 		DatabaseIP:             "127.0.0.1",
 		DatabaseType:           "mongo",
+		DatabasePath:           cwd, // directory for the embedded (bolt) database files
 		PluginDir:              cwd,
+		ContextDatabaseType:    "etcd",
 		EtcdIP:                 "127.0.0.1",
 		EtcdCert:               "",
 		EtcdKey:                "",
@@ -115,9 +206,35 @@ func defaultConfiguration() *Configuration {
 		MaxRetries:             "",     // rsync
 		BackOff:                5,      // default backoff time interval for ref schema
 		MaxBackOff:             60,     // max backoff time interval for ref schema
+		MaxClusterOps:          0,      // rsync, concurrent cluster operations, 0 is unlimited
+		MaxProviderOps:         0,      // rsync, concurrent cluster operations per cluster provider, 0 is unlimited
+		ClusterAPIQPS:          0,      // rsync, Kubernetes API calls per second per cluster, 0 is the client default
+		ClusterAPIBurst:        0,      // rsync, burst of Kubernetes API calls per cluster
+		GitOpsCommitWindow:     500,    // rsync, milliseconds the commits to a repository are batched for
+		ServerSideApply:        true,   // rsync, apply the resources with the server-side apply, or with the client-side apply
+		RsyncSharding:          false,  // rsync, share the AppContexts between the replicas of rsync
+		RsyncLeaseDuration:     30,     // rsync, seconds a replica owns its AppContexts for without renewing its lease
 		GrpcConnReadyTime:      1000,   // 1 second in milliseconds
 		GrpcConnTimeout:        1000,   // 1 second
 		GrpcCallTimeout:        10000,  // 10 seconds
+		RetryInitialDelay:      2,
+		RetryMaxDelay:          60,
+		RetryMultiplier:        2,
+		RetryJitter:            0.2,
+		RetryMaxAttempts:       5,
+		RetryOn:                []string{"ServerError", "Conflict", "Throttled", "Timeout"},
+		AuthEnabled:            false,
+		AuthRolesClaim:         "groups",
+		AuthExemptPaths:        []string{"/metrics"},
+		AuditEnabled:           false,
+		AuditFile:              "",
+		AuditRetention:         90,
+		EncryptionKeySource:    "env",
+		BackupInterval:         0,
+		BackupRetention:        0,
+		BackupS3Region:         "us-east-1",
+		ClusterProbeInterval:   60,
+		ClusterProbeWorkers:    10,
 	}
 }
 
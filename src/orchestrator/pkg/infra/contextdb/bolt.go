@@ -0,0 +1,356 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package contextdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// contextBucket is the single bucket holding all the context keys and
+// revisionBucket holds the revision of each of those keys
+var (
+	contextBucket  = []byte("contextdb")
+	revisionBucket = []byte("revisions")
+)
+
+// BoltConfig Configuration values needed for the Bolt Client
+type BoltConfig struct {
+	// Path of the database file, shared by all the EMCO services on the node
+	Path string
+	// Time to wait for the file lock held by another service
+	Timeout time.Duration
+}
+
+// BoltClient for an embedded bolt database file. The file is opened for
+// the duration of each operation only, so that several services (e.g. the
+// orchestrator and rsync) can share the same context database file.
+type BoltClient struct {
+	path    string
+	timeout time.Duration
+}
+
+// NewBoltClient function initializes the Bolt client
+func NewBoltClient(c BoltConfig) (ContextDb, error) {
+	if c.Path == "" {
+		return nil, pkgerrors.Errorf("Error creating bolt client: database path is empty")
+	}
+
+	b := &BoltClient{
+		path:    c.Path,
+		timeout: c.Timeout,
+	}
+
+	// Create the database file and the bucket
+	err := b.update(func(bucket, revs *bolt.Bucket) error {
+		return nil
+	})
+	if err != nil {
+		return nil, pkgerrors.Errorf("Error creating bolt client: %s", err.Error())
+	}
+
+	return b, nil
+}
+
+// view runs fn on the context and revision buckets in a read only transaction
+func (b *BoltClient) view(fn func(bucket, revs *bolt.Bucket) error) error {
+	db, err := bolt.Open(b.path, 0600, &bolt.Options{Timeout: b.timeout, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(contextBucket)
+		revs := tx.Bucket(revisionBucket)
+		if bucket == nil || revs == nil {
+			return pkgerrors.New("Context bucket doesn't exist")
+		}
+		return fn(bucket, revs)
+	})
+}
+
+// update runs fn on the context and revision buckets in a read-write transaction
+func (b *BoltClient) update(fn func(bucket, revs *bolt.Bucket) error) error {
+	db, err := bolt.Open(b.path, 0600, &bolt.Options{Timeout: b.timeout})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(contextBucket)
+		if err != nil {
+			return err
+		}
+		revs, err := tx.CreateBucketIfNotExists(revisionBucket)
+		if err != nil {
+			return err
+		}
+		return fn(bucket, revs)
+	})
+}
+
+// prefixKeys returns all the keys in the bucket with the prefix
+func prefixKeys(bucket *bolt.Bucket, prefix string) [][]byte {
+	var keys [][]byte
+	c := bucket.Cursor()
+	p := []byte(prefix)
+	for k, _ := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, _ = c.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	return keys
+}
+
+// revision returns the revision of the key, 0 if the key doesn't exist
+func revision(revs *bolt.Bucket, key []byte) int64 {
+	v := revs.Get(key)
+	if v == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(v))
+}
+
+// put stores the value and bumps the revision of the key. Revisions are
+// taken from the sequence of the revision bucket, so they are increasing
+// across all the keys like the etcd revisions.
+func put(bucket, revs *bolt.Bucket, key, value []byte) error {
+	seq, err := revs.NextSequence()
+	if err != nil {
+		return err
+	}
+	rev := make([]byte, 8)
+	binary.BigEndian.PutUint64(rev, seq)
+	if err := revs.Put(key, rev); err != nil {
+		return err
+	}
+	return bucket.Put(key, value)
+}
+
+// del removes the value and the revision of the key
+func del(bucket, revs *bolt.Bucket, key []byte) error {
+	if err := revs.Delete(key); err != nil {
+		return err
+	}
+	return bucket.Delete(key)
+}
+
+// Put values in Bolt DB
+func (b *BoltClient) Put(ctx context.Context, key string, value interface{}) error {
+	if key == "" {
+		return pkgerrors.Errorf("Key is null")
+	}
+	if value == nil {
+		return pkgerrors.Errorf("Value is nil")
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		return pkgerrors.Errorf("Json Marshal error: %s", err.Error())
+	}
+	err = b.update(func(bucket, revs *bolt.Bucket) error {
+		return put(bucket, revs, []byte(key), v)
+	})
+	if err != nil {
+		return pkgerrors.Errorf("Error creating bolt entry: %s", err.Error())
+	}
+	return nil
+}
+
+// Get values from Bolt DB and decodes from json
+func (b *BoltClient) Get(ctx context.Context, key string, value interface{}) error {
+	if key == "" {
+		return pkgerrors.Errorf("Key is null")
+	}
+	if value == nil {
+		return pkgerrors.Errorf("Value is nil")
+	}
+	var v []byte
+	err := b.view(func(bucket, revs *bolt.Bucket) error {
+		if val := bucket.Get([]byte(key)); val != nil {
+			v = append([]byte{}, val...)
+		}
+		return nil
+	})
+	if err != nil {
+		return pkgerrors.Errorf("Error getting bolt entry: %s", err.Error())
+	}
+	if v == nil {
+		return pkgerrors.Errorf("Key doesn't exist")
+	}
+	return json.Unmarshal(v, value)
+}
+
+// GetAllKeys values from Bolt DB
+func (b *BoltClient) GetAllKeys(ctx context.Context, key string) ([]string, error) {
+	var keys []string
+	err := b.view(func(bucket, revs *bolt.Bucket) error {
+		for _, k := range prefixKeys(bucket, key) {
+			keys = append(keys, string(k))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, pkgerrors.Errorf("Error getting bolt entry: %s", err.Error())
+	}
+	if len(keys) == 0 {
+		return nil, pkgerrors.Errorf("Key doesn't exist")
+	}
+	return keys, nil
+}
+
+// DeleteAll keys from Bolt DB
+func (b *BoltClient) DeleteAll(ctx context.Context, key string) error {
+	err := b.update(func(bucket, revs *bolt.Bucket) error {
+		for _, k := range prefixKeys(bucket, key) {
+			if err := del(bucket, revs, k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return pkgerrors.Errorf("Delete failed bolt entry: %s", err.Error())
+	}
+	return nil
+}
+
+// Delete values from Bolt DB
+func (b *BoltClient) Delete(ctx context.Context, key string) error {
+	err := b.update(func(bucket, revs *bolt.Bucket) error {
+		return del(bucket, revs, []byte(key))
+	})
+	if err != nil {
+		return pkgerrors.Errorf("Delete failed bolt entry: %s", err.Error())
+	}
+	return nil
+}
+
+// HealthCheck for checking health of the bolt database file
+func (b *BoltClient) HealthCheck() error {
+	return b.view(func(bucket, revs *bolt.Bucket) error {
+		return nil
+	})
+}
+
+// Put values in Bolt DB and check if already present
+func (b *BoltClient) PutWithCheck(ctx context.Context, key string, value interface{}) error {
+	if key == "" {
+		return pkgerrors.Errorf("Key is null")
+	}
+	if value == nil {
+		return pkgerrors.Errorf("Value is nil")
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		return pkgerrors.Errorf("Json Marshal error: %s", err.Error())
+	}
+	var exists bool
+	err = b.update(func(bucket, revs *bolt.Bucket) error {
+		exists = bucket.Get([]byte(key)) != nil
+		return put(bucket, revs, []byte(key), v)
+	})
+	if err != nil {
+		return pkgerrors.Errorf("Error creating bolt entry: %s", err.Error())
+	}
+	// Check if this key was already present
+	if exists {
+		return pkgerrors.Errorf("Key exists %v", key)
+	}
+	return nil
+}
+
+// GetWithRevision gets values from Bolt DB and returns the revision of the key
+func (b *BoltClient) GetWithRevision(ctx context.Context, key string, value interface{}) (int64, error) {
+	if key == "" {
+		return 0, pkgerrors.Errorf("Key is null")
+	}
+	if value == nil {
+		return 0, pkgerrors.Errorf("Value is nil")
+	}
+	var v []byte
+	var rev int64
+	err := b.view(func(bucket, revs *bolt.Bucket) error {
+		if val := bucket.Get([]byte(key)); val != nil {
+			v = append([]byte{}, val...)
+			rev = revision(revs, []byte(key))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, pkgerrors.Errorf("Error getting bolt entry: %s", err.Error())
+	}
+	if v == nil {
+		return 0, nil
+	}
+	err = json.Unmarshal(v, value)
+	if err != nil {
+		return 0, pkgerrors.Errorf("Json Unmarshal error: %s", err.Error())
+	}
+	return rev, nil
+}
+
+// PutIfRevision puts values in Bolt DB if the key was not modified since revision
+func (b *BoltClient) PutIfRevision(ctx context.Context, key string, value interface{}, revision int64) error {
+	return b.PutTxn(ctx, []TxnPut{{Key: key, Value: value, Revision: revision}})
+}
+
+// PutTxn puts all the values in Bolt DB in a single transaction
+func (b *BoltClient) PutTxn(ctx context.Context, puts []TxnPut) error {
+	values := make([][]byte, len(puts))
+	for i, p := range puts {
+		if p.Key == "" {
+			return pkgerrors.Errorf("Key is null")
+		}
+		if p.Value == nil {
+			return pkgerrors.Errorf("Value is nil")
+		}
+		v, err := json.Marshal(p.Value)
+		if err != nil {
+			return pkgerrors.Errorf("Json Marshal error: %s", err.Error())
+		}
+		values[i] = v
+	}
+	err := b.update(func(bucket, revs *bolt.Bucket) error {
+		for _, p := range puts {
+			if p.Revision != AnyRevision && revision(revs, []byte(p.Key)) != p.Revision {
+				return ErrRevisionMismatch
+			}
+		}
+		for i, p := range puts {
+			if err := put(bucket, revs, []byte(p.Key), values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == ErrRevisionMismatch {
+		return err
+	}
+	if err != nil {
+		return pkgerrors.Errorf("Error creating bolt entry: %s", err.Error())
+	}
+	return nil
+}
+
+// Snapshot gets all the keys with the prefix and their values in a single
+// read only transaction
+func (b *BoltClient) Snapshot(ctx context.Context, prefix string) ([]KeyValue, error) {
+	var kvs []KeyValue
+	err := b.view(func(bucket, revs *bolt.Bucket) error {
+		for _, k := range prefixKeys(bucket, prefix) {
+			kvs = append(kvs, KeyValue{Key: string(k), Value: append([]byte{}, bucket.Get(k)...)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, pkgerrors.Errorf("Error getting bolt entry: %s", err.Error())
+	}
+	return kvs, nil
+}
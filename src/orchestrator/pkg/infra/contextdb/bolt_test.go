@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package contextdb
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestBoltClient(t *testing.T) ContextDb {
+	cli, err := NewBoltClient(BoltConfig{
+		Path:    filepath.Join(t.TempDir(), "contextdb.db"),
+		Timeout: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewBoltClient returned an error (%s)", err)
+	}
+	return cli
+}
+
+func TestBoltPutGet(t *testing.T) {
+	ctx := context.Background()
+	cli := newTestBoltClient(t)
+
+	if err := cli.HealthCheck(); err != nil {
+		t.Fatalf("HealthCheck returned an error (%s)", err)
+	}
+
+	err := cli.Put(ctx, "", &testStruct{})
+	if err == nil || !strings.Contains(err.Error(), "Key is null") {
+		t.Fatalf("Put with empty key returned (%v)", err)
+	}
+
+	err = cli.Put(ctx, "test1", &testStruct{Name: "test", Num: 5})
+	if err != nil {
+		t.Fatalf("Put returned an error (%s)", err)
+	}
+
+	var v testStruct
+	err = cli.Get(ctx, "test1", &v)
+	if err != nil {
+		t.Fatalf("Get returned an error (%s)", err)
+	}
+	if v.Name != "test" || v.Num != 5 {
+		t.Fatalf("Get returned %v", v)
+	}
+
+	err = cli.Get(ctx, "test2", &v)
+	if err == nil || !strings.Contains(err.Error(), "Key doesn't exist") {
+		t.Fatalf("Get of missing key returned (%v)", err)
+	}
+}
+
+func TestBoltPutWithCheck(t *testing.T) {
+	ctx := context.Background()
+	cli := newTestBoltClient(t)
+
+	err := cli.PutWithCheck(ctx, "test1", "value1")
+	if err != nil {
+		t.Fatalf("PutWithCheck returned an error (%s)", err)
+	}
+	err = cli.PutWithCheck(ctx, "test1", "value2")
+	if err == nil || !strings.Contains(err.Error(), "Key exists") {
+		t.Fatalf("PutWithCheck of existing key returned (%v)", err)
+	}
+}
+
+func TestBoltPrefixKeys(t *testing.T) {
+	ctx := context.Background()
+	cli := newTestBoltClient(t)
+
+	for _, k := range []string{"/context/1/a", "/context/1/b", "/context/10/a", "/context/2/a"} {
+		if err := cli.Put(ctx, k, k); err != nil {
+			t.Fatalf("Put returned an error (%s)", err)
+		}
+	}
+
+	keys, err := cli.GetAllKeys(ctx, "/context/1/")
+	if err != nil {
+		t.Fatalf("GetAllKeys returned an error (%s)", err)
+	}
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"/context/1/a", "/context/1/b"}) {
+		t.Fatalf("GetAllKeys returned %v", keys)
+	}
+
+	if err := cli.Delete(ctx, "/context/2/a"); err != nil {
+		t.Fatalf("Delete returned an error (%s)", err)
+	}
+	if err := cli.DeleteAll(ctx, "/context/1"); err != nil {
+		t.Fatalf("DeleteAll returned an error (%s)", err)
+	}
+
+	_, err = cli.GetAllKeys(ctx, "/context/")
+	if err == nil || !strings.Contains(err.Error(), "Key doesn't exist") {
+		t.Fatalf("GetAllKeys after DeleteAll returned (%v)", err)
+	}
+}
+
+func TestBoltPutTxn(t *testing.T) {
+	ctx := context.Background()
+	cli := newTestBoltClient(t)
+
+	var v string
+	rev, err := cli.GetWithRevision(ctx, "test1", &v)
+	if err != nil || rev != 0 {
+		t.Fatalf("GetWithRevision of missing key returned (%d, %v)", rev, err)
+	}
+
+	err = cli.PutIfRevision(ctx, "test1", "value1", 0)
+	if err != nil {
+		t.Fatalf("PutIfRevision returned an error (%s)", err)
+	}
+	err = cli.PutIfRevision(ctx, "test1", "value2", 0)
+	if err != ErrRevisionMismatch {
+		t.Fatalf("PutIfRevision of existing key returned (%v)", err)
+	}
+
+	rev, err = cli.GetWithRevision(ctx, "test1", &v)
+	if err != nil || rev == 0 || v != "value1" {
+		t.Fatalf("GetWithRevision returned (%d, %s, %v)", rev, v, err)
+	}
+
+	// a stale revision fails the whole transaction
+	err = cli.PutTxn(ctx, []TxnPut{
+		{Key: "test2", Value: "value2", Revision: AnyRevision},
+		{Key: "test1", Value: "value3", Revision: rev - 1},
+	})
+	if err != ErrRevisionMismatch {
+		t.Fatalf("PutTxn with stale revision returned (%v)", err)
+	}
+	err = cli.Get(ctx, "test2", &v)
+	if err == nil {
+		t.Fatal("PutTxn with stale revision stored a value")
+	}
+
+	err = cli.PutTxn(ctx, []TxnPut{
+		{Key: "test2", Value: "value2", Revision: AnyRevision},
+		{Key: "test1", Value: "value3", Revision: rev},
+	})
+	if err != nil {
+		t.Fatalf("PutTxn returned an error (%s)", err)
+	}
+	newRev, err := cli.GetWithRevision(ctx, "test1", &v)
+	if err != nil || newRev <= rev || v != "value3" {
+		t.Fatalf("GetWithRevision returned (%d, %s, %v)", newRev, v, err)
+	}
+}
@@ -5,6 +5,8 @@ package contextdb
 
 import (
 	"context"
+	"path/filepath"
+	"time"
 
 	pkgerrors "github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
@@ -13,6 +15,22 @@ import (
 // Db interface used to talk a concrete Database connection
 var Db ContextDb
 
+// ErrRevisionMismatch is returned by PutIfRevision and PutTxn when a key
+// was modified since the expected revision was read
+var ErrRevisionMismatch = pkgerrors.New("Revision mismatch")
+
+// AnyRevision skips the revision check of a TxnPut
+const AnyRevision int64 = -1
+
+// TxnPut is a put that is part of a transaction. The put is only
+// applied if the revision of the key is the expected Revision.
+// A Revision of 0 means that the key must not exist.
+type TxnPut struct {
+	Key      string
+	Value    interface{}
+	Revision int64
+}
+
 // ContextDb is an interface for accessing the context database
 type ContextDb interface {
 	// Returns nil if db health is good
@@ -29,6 +47,12 @@ type ContextDb interface {
 	GetAllKeys(ctx context.Context, path string) ([]string, error)
 	// Put values in Etcd DB and check if already present
 	PutWithCheck(ctx context.Context, key string, value interface{}) error
+	// Gets Json Struct from db and the revision of the key (0 if the key doesn't exist)
+	GetWithRevision(ctx context.Context, key string, value interface{}) (int64, error)
+	// Puts Json Struct in db with key if the key is still at the revision
+	PutIfRevision(ctx context.Context, key string, value interface{}, revision int64) error
+	// Puts all the values in a single transaction if all the revisions match
+	PutTxn(ctx context.Context, puts []TxnPut) error
 }
 
 // createContextDBClient creates the DB client
@@ -46,6 +70,15 @@ func createContextDBClient(dbType string) error {
 		if err != nil {
 			pkgerrors.Wrap(err, "Etcd Client Initialization failed with error")
 		}
+	case "bolt":
+		c := BoltConfig{
+			Path:    filepath.Join(config.GetConfiguration().DatabasePath, "contextdb.db"),
+			Timeout: 10 * time.Second,
+		}
+		Db, err = NewBoltClient(c)
+		if err != nil {
+			return pkgerrors.Wrap(err, "Bolt Client Initialization failed with error")
+		}
 	default:
 		return pkgerrors.New(dbType + "DB not supported")
 	}
@@ -55,8 +88,7 @@ func createContextDBClient(dbType string) error {
 // InitializeContextDatabase sets up the connection to the
 // configured database to allow the application to talk to it.
 func InitializeContextDatabase() error {
-	// Only support Etcd for now
-	err := createContextDBClient("etcd")
+	err := createContextDBClient(config.GetConfiguration().ContextDatabaseType)
 	if err != nil {
 		return pkgerrors.Cause(err)
 	}
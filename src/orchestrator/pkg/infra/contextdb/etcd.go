@@ -35,6 +35,7 @@ type Etcd interface {
 	Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error)
 	Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error)
 	Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error)
+	Txn(ctx context.Context) clientv3.Txn
 }
 
 var getEtcd = func(e *EtcdClient) Etcd {
@@ -197,3 +198,86 @@ func (e *EtcdClient) PutWithCheck(ctx context.Context, key string, value interfa
 	}
 	return nil
 }
+
+// GetWithRevision gets values from Etcd DB and returns the modification revision of the key
+func (e *EtcdClient) GetWithRevision(ctx context.Context, key string, value interface{}) (int64, error) {
+	cli := getEtcd(e)
+	if cli == nil {
+		return 0, pkgerrors.Errorf("Etcd Client not initialized")
+	}
+	if key == "" {
+		return 0, pkgerrors.Errorf("Key is null")
+	}
+	if value == nil {
+		return 0, pkgerrors.Errorf("Value is nil")
+	}
+	getResp, err := cli.Get(ctx, key)
+	if err != nil {
+		return 0, pkgerrors.Errorf("Error getting etcd entry: %s", err.Error())
+	}
+	if getResp.Count == 0 {
+		return 0, nil
+	}
+	err = json.Unmarshal(getResp.Kvs[0].Value, value)
+	if err != nil {
+		return 0, pkgerrors.Errorf("Json Unmarshal error: %s", err.Error())
+	}
+	return getResp.Kvs[0].ModRevision, nil
+}
+
+// PutIfRevision puts values in Etcd DB if the key was not modified since revision
+func (e *EtcdClient) PutIfRevision(ctx context.Context, key string, value interface{}, revision int64) error {
+	return e.PutTxn(ctx, []TxnPut{{Key: key, Value: value, Revision: revision}})
+}
+
+// PutTxn puts all the values in Etcd DB in a single transaction
+func (e *EtcdClient) PutTxn(ctx context.Context, puts []TxnPut) error {
+	cli := getEtcd(e)
+	if cli == nil {
+		return pkgerrors.Errorf("Etcd Client not initialized")
+	}
+	var cmps []clientv3.Cmp
+	var ops []clientv3.Op
+	for _, p := range puts {
+		if p.Key == "" {
+			return pkgerrors.Errorf("Key is null")
+		}
+		if p.Value == nil {
+			return pkgerrors.Errorf("Value is nil")
+		}
+		v, err := json.Marshal(p.Value)
+		if err != nil {
+			return pkgerrors.Errorf("Json Marshal error: %s", err.Error())
+		}
+		if p.Revision != AnyRevision {
+			cmps = append(cmps, clientv3.Compare(clientv3.ModRevision(p.Key), "=", p.Revision))
+		}
+		ops = append(ops, clientv3.OpPut(p.Key, string(v)))
+	}
+	resp, err := cli.Txn(ctx).If(cmps...).Then(ops...).Commit()
+	if err != nil {
+		return pkgerrors.Errorf("Error creating etcd entry: %s", err.Error())
+	}
+	if !resp.Succeeded {
+		return ErrRevisionMismatch
+	}
+	return nil
+}
+
+// Snapshot gets all the keys with the prefix and their values in a single
+// request, i.e. from a single revision of Etcd DB
+func (e *EtcdClient) Snapshot(ctx context.Context, prefix string) ([]KeyValue, error) {
+	cli := getEtcd(e)
+	if cli == nil {
+		return nil, pkgerrors.Errorf("Etcd Client not initialized")
+	}
+	getResp, err := cli.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, pkgerrors.Errorf("Error getting etcd entry: %s", err.Error())
+	}
+	kvs := make([]KeyValue, 0, len(getResp.Kvs))
+	for _, kv := range getResp.Kvs {
+		kvs = append(kvs, KeyValue{Key: string(kv.Key), Value: kv.Value})
+	}
+	return kvs, nil
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	etcdserverpb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	mvccpb "go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
@@ -34,6 +35,7 @@ func (e *MockEtcdClient) Put(ctx context.Context, key, val string, opts ...clien
 	m.Key = []byte(key)
 	m.Value = []byte(val)
 	e.Count = e.Count + 1
+	m.ModRevision = e.Count
 	e.Kvs = append(e.Kvs, &m)
 	return &clientv3.PutResponse{}, e.Err
 }
@@ -51,6 +53,54 @@ func (e *MockEtcdClient) Delete(ctx context.Context, key string, opts ...clientv
 	return &clientv3.DeleteResponse{}, e.Err
 }
 
+// Txn function
+func (e *MockEtcdClient) Txn(ctx context.Context) clientv3.Txn {
+	return &mockTxn{e: e}
+}
+
+// mockTxn for mocking etcd transactions with ModRevision compares and puts
+type mockTxn struct {
+	e    *MockEtcdClient
+	cmps []clientv3.Cmp
+	ops  []clientv3.Op
+}
+
+func (t *mockTxn) If(cs ...clientv3.Cmp) clientv3.Txn {
+	t.cmps = cs
+	return t
+}
+
+func (t *mockTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	t.ops = ops
+	return t
+}
+
+func (t *mockTxn) Else(ops ...clientv3.Op) clientv3.Txn {
+	return t
+}
+
+func (t *mockTxn) Commit() (*clientv3.TxnResponse, error) {
+	if t.e.Err != nil {
+		return nil, t.e.Err
+	}
+	for _, c := range t.cmps {
+		var rev int64
+		for _, kv := range t.e.Kvs {
+			if string(kv.Key) == string(c.KeyBytes()) {
+				rev = kv.ModRevision
+			}
+		}
+		pc := etcdserverpb.Compare(c)
+		if rev != pc.GetModRevision() {
+			return &clientv3.TxnResponse{Succeeded: false}, nil
+		}
+	}
+	for _, op := range t.ops {
+		t.e.Put(context.Background(), string(op.KeyBytes()), string(op.ValueBytes()))
+	}
+	return &clientv3.TxnResponse{Succeeded: true}, nil
+}
+
 type testStruct struct {
 	Name string `json:"name"`
 	Num  int    `json:"num"`
@@ -272,3 +322,111 @@ func TestGetAll(t *testing.T) {
 		})
 	}
 }
+
+func TestPutIfRevision(t *testing.T) {
+	testCases := []struct {
+		label         string
+		mockEtcd      *MockEtcdClient
+		revision      int64
+		expectedError string
+	}{
+		{
+			label:    "Create Case",
+			mockEtcd: &MockEtcdClient{},
+			revision: 0,
+		},
+		{
+			label: "Update Case",
+			mockEtcd: &MockEtcdClient{Count: 1, Kvs: []*mvccpb.KeyValue{
+				{Key: []byte("test"), Value: []byte("\"test1\""), ModRevision: 1}}},
+			revision: 1,
+		},
+		{
+			label: "Revision mismatch",
+			mockEtcd: &MockEtcdClient{Count: 2, Kvs: []*mvccpb.KeyValue{
+				{Key: []byte("test"), Value: []byte("\"test1\""), ModRevision: 2}}},
+			revision:      1,
+			expectedError: "Revision mismatch",
+		},
+		{
+			label:         "Error creating etcd entry",
+			mockEtcd:      &MockEtcdClient{Err: pkgerrors.New("DB Error")},
+			expectedError: "Error creating etcd entry: DB Error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			ctx := context.Background()
+			cli, _ := NewEtcdClient(&clientv3.Client{}, EtcdConfig{})
+			getEtcd = func(e *EtcdClient) Etcd {
+				return testCase.mockEtcd
+			}
+			err := cli.PutIfRevision(ctx, "test", "test2", testCase.revision)
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("Method returned an un-expected (%s)", err)
+				}
+				if !strings.Contains(string(err.Error()), testCase.expectedError) {
+					t.Fatalf("Method returned an error (%s)", err)
+				}
+				return
+			}
+			if testCase.expectedError != "" {
+				t.Fatalf("Method didn't return an error (%s)", testCase.expectedError)
+			}
+			kv := testCase.mockEtcd.Kvs[len(testCase.mockEtcd.Kvs)-1]
+			if string(kv.Value) != "\"test2\"" || kv.ModRevision != testCase.mockEtcd.Count {
+				t.Fatalf("Method stored %s at revision %d", kv.Value, kv.ModRevision)
+			}
+		})
+	}
+}
+
+func TestGetWithRevision(t *testing.T) {
+	testCases := []struct {
+		label            string
+		mockEtcd         *MockEtcdClient
+		expectedRevision int64
+		expectedError    string
+	}{
+		{
+			label: "Success Case",
+			mockEtcd: &MockEtcdClient{Count: 1, Kvs: []*mvccpb.KeyValue{
+				{Key: []byte("test"), Value: []byte("\"test1\""), ModRevision: 7}}},
+			expectedRevision: 7,
+		},
+		{
+			label:            "Key doesn't exist",
+			mockEtcd:         &MockEtcdClient{},
+			expectedRevision: 0,
+		},
+		{
+			label:         "Error getting etcd entry",
+			mockEtcd:      &MockEtcdClient{Err: pkgerrors.New("DB Error")},
+			expectedError: "Error getting etcd entry: DB Error",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			ctx := context.Background()
+			cli, _ := NewEtcdClient(&clientv3.Client{}, EtcdConfig{})
+			getEtcd = func(e *EtcdClient) Etcd {
+				return testCase.mockEtcd
+			}
+			var s string
+			rev, err := cli.GetWithRevision(ctx, "test", &s)
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("Method returned an un-expected (%s)", err)
+				}
+				if !strings.Contains(string(err.Error()), testCase.expectedError) {
+					t.Fatalf("Method returned an error (%s)", err)
+				}
+				return
+			}
+			if rev != testCase.expectedRevision {
+				t.Fatalf("Method returned revision %d", rev)
+			}
+		})
+	}
+}
@@ -16,7 +16,18 @@ import (
 type MockConDb struct {
 	Items []sync.Map
 	sync.Mutex
-	Err error
+	Err       error
+	Revisions map[string]int64
+	revision  int64
+}
+
+// bumpRevision sets a new revision for the key, the lock must be held
+func (c *MockConDb) bumpRevision(key string) {
+	if c.Revisions == nil {
+		c.Revisions = make(map[string]int64)
+	}
+	c.revision++
+	c.Revisions[key] = c.revision
 }
 
 func (c *MockConDb) Put(ctx context.Context, key string, value interface{}) error {
@@ -35,6 +46,7 @@ func (c *MockConDb) Put(ctx context.Context, key string, value interface{}) erro
 	c.Lock()
 	defer c.Unlock()
 	c.Items = append(c.Items, d)
+	c.bumpRevision(key)
 	return c.Err
 }
 func (c *MockConDb) PutWithCheck(ctx context.Context, key string, value interface{}) error {
@@ -120,6 +132,7 @@ func (c *MockConDb) Delete(ctx context.Context, key string) error {
 			if k == key {
 				c.Items[i] = c.Items[len(c.Items)-1]
 				c.Items = c.Items[:len(c.Items)-1]
+				delete(c.Revisions, k)
 				return c.Err
 			}
 		}
@@ -140,8 +153,37 @@ func (c *MockConDb) DeleteAll(ctx context.Context, key string) error {
 			if ok {
 				c.Items[i] = c.Items[len(c.Items)-1]
 				c.Items = c.Items[:len(c.Items)-1]
+				delete(c.Revisions, k)
 			}
 		}
 	}
 	return c.Err
 }
+func (c *MockConDb) GetWithRevision(ctx context.Context, key string, value interface{}) (int64, error) {
+	c.Lock()
+	rev, ok := c.Revisions[key]
+	c.Unlock()
+	if !ok {
+		return 0, c.Err
+	}
+	return rev, c.Get(ctx, key, value)
+}
+func (c *MockConDb) PutIfRevision(ctx context.Context, key string, value interface{}, revision int64) error {
+	return c.PutTxn(ctx, []TxnPut{{Key: key, Value: value, Revision: revision}})
+}
+func (c *MockConDb) PutTxn(ctx context.Context, puts []TxnPut) error {
+	c.Lock()
+	for _, p := range puts {
+		if p.Revision != AnyRevision && c.Revisions[p.Key] != p.Revision {
+			c.Unlock()
+			return ErrRevisionMismatch
+		}
+	}
+	c.Unlock()
+	for _, p := range puts {
+		if err := c.Put(ctx, p.Key, p.Value); err != nil {
+			return err
+		}
+	}
+	return c.Err
+}
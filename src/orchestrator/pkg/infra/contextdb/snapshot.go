@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package contextdb
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// KeyValue is a key of the context database and its json value
+type KeyValue struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Snapshotter is implemented by the context databases that can read all the
+// keys with a prefix at once, e.g. to back them up
+type Snapshotter interface {
+	// Snapshot returns the keys with the prefix and their values, read
+	// from a single revision of the database
+	Snapshot(ctx context.Context, prefix string) ([]KeyValue, error)
+}
@@ -0,0 +1,880 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	utils "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltTimeout is the time to wait for the file lock held by another process
+const boltTimeout = 10 * time.Second
+
+// boltDBs are the database files opened by the process, by path, since a
+// file can't be opened twice
+var boltDBs = struct {
+	sync.Mutex
+	dbs map[string]*bolt.DB
+}{dbs: map[string]*bolt.DB{}}
+
+// BoltStore is an implementation of the db.Store interface backed by an
+// embedded bbolt database file. Each collection is a bucket and each
+// document is stored as a JSON encoded boltDocument under its key.
+// The file is kept open, and locked, by the process until it exits.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// boltDocument is the representation of a document in a BoltStore.
+// It mirrors the layout of the documents in the MongoStore: the key and
+// query fields are top level fields that can be filtered on, the keyId
+// identifies the type of the resource and each tag holds a JSON value.
+type boltDocument struct {
+	Fields     map[string]string          `json:"fields"`
+	KeyId      string                     `json:"keyId"`
+	Tags       map[string]json.RawMessage `json:"tags"`
+	References []ReferenceEntry           `json:"references,omitempty"`
+}
+
+// NewBoltStore opens (or creates) a bolt database file with the name provided
+// in the configured database-path. If store is not nil, it is used as is.
+func NewBoltStore(ctx context.Context, name string, store *bolt.DB) (Store, error) {
+	if store == nil {
+		dir := config.GetConfiguration().DatabasePath
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, pkgerrors.Wrapf(err, "Error creating the database directory %s", dir)
+		}
+
+		var err error
+		store, err = openBoltDB(filepath.Join(dir, name+".db"))
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "Error opening the bolt database %s", name)
+		}
+	}
+
+	boltStore := &BoltStore{
+		db: store,
+	}
+
+	go boltStore.ReadRefSchema(ctx)
+
+	return boltStore, nil
+}
+
+// openBoltDB opens the database file, or returns it if the process already
+// opened it. Another process holding the file fails the open after boltTimeout.
+func openBoltDB(path string) (*bolt.DB, error) {
+	boltDBs.Lock()
+	defer boltDBs.Unlock()
+	if db, ok := boltDBs.dbs[path]; ok {
+		return db, nil
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: boltTimeout})
+	if err != nil {
+		return nil, err
+	}
+	boltDBs.dbs[path] = db
+	return db, nil
+}
+
+// ReadRefSchema reads the Referential Schema Segment file and creates the refSchemaMap.
+func (b *BoltStore) ReadRefSchema(ctx context.Context) {
+	readRefSchema(ctx, b)
+}
+
+// HealthCheck verifies if the database is open and readable
+func (b *BoltStore) HealthCheck(ctx context.Context) error {
+	if b.db == nil {
+		return pkgerrors.New("Error getting database status: database is not open")
+	}
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return nil
+	})
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error getting database status")
+	}
+
+	return nil
+}
+
+// Unmarshal implements an unmarshaler for the json data that
+// is produced from the bolt database
+func (b *BoltStore) Unmarshal(inp []byte, out interface{}) error {
+	err := json.Unmarshal(inp, out)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "Error Unmarshalling json data to %T", out)
+	}
+
+	// Decrypt data if required
+	oe := utils.GetObjectEncryptor("emco")
+	if oe != nil {
+		_, err := oe.DecryptObject(out)
+		if err != nil {
+			log.Warn("Error to decrypt object", log.Fields{"error": err.Error()})
+		}
+	}
+	return nil
+}
+
+// keyToMap converts a key (or query) structure into a map of its elements
+func keyToMap(key interface{}) (map[string]string, error) {
+	var n map[string]string
+	st, err := json.Marshal(key)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error Marshalling key: %T %v", key, key)
+	}
+	err = json.Unmarshal(st, &n)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error Unmarshalling key to map. Key: %T %v", key, key)
+	}
+	return n, nil
+}
+
+// documentId returns the identifier of the document stored for the key.
+// json.Marshal sorts the map keys, so the identifier is stable.
+func documentId(key map[string]string) ([]byte, error) {
+	return json.Marshal(key)
+}
+
+// matchFields returns true if all the filter elements are present in the fields
+func matchFields(fields, filter map[string]string) bool {
+	for k, v := range filter {
+		if fv, ok := fields[k]; !ok || fv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// matchKey implements the key-as-filter semantics of Find and RemoveAll.
+// Elements with a value are matched exactly. If any element of the key is
+// empty, the document must also be of the same type (keyId) as the key.
+func matchKey(doc *boltDocument, key map[string]string, keyId string) bool {
+	wildcard := false
+	for k, v := range key {
+		if v == "" {
+			wildcard = true
+			continue
+		}
+		if fv, ok := doc.Fields[k]; !ok || fv != v {
+			return false
+		}
+	}
+	if wildcard && doc.KeyId != keyId {
+		return false
+	}
+	return true
+}
+
+// forEachDocument calls fn for every document in the collection bucket
+func forEachDocument(bucket *bolt.Bucket, fn func(id []byte, doc *boltDocument) error) error {
+	if bucket == nil {
+		return nil
+	}
+	return bucket.ForEach(func(id, v []byte) error {
+		doc := &boltDocument{}
+		if err := json.Unmarshal(v, doc); err != nil {
+			return pkgerrors.Wrapf(err, "Error Unmarshalling document %s", string(id))
+		}
+		return fn(id, doc)
+	})
+}
+
+// putDocument stores the document in the bucket under id
+func putDocument(bucket *bolt.Bucket, id []byte, doc *boltDocument) error {
+	v, err := json.Marshal(doc)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error Marshalling document")
+	}
+	return bucket.Put(id, v)
+}
+
+// getDocument returns the document stored in the bucket under id, or nil
+func getDocument(bucket *bolt.Bucket, id []byte) (*boltDocument, error) {
+	if bucket == nil {
+		return nil, nil
+	}
+	v := bucket.Get(id)
+	if v == nil {
+		return nil, nil
+	}
+	doc := &boltDocument{}
+	if err := json.Unmarshal(v, doc); err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error Unmarshalling document %s", string(id))
+	}
+	return doc, nil
+}
+
+// Insert is used to insert/add element to a document
+func (b *BoltStore) Insert(ctx context.Context, coll string, key Key, query interface{}, tag string, data interface{}) error {
+
+	if data == nil {
+		return pkgerrors.Errorf("db Insert error: No data to store")
+	}
+
+	if !validateParams(coll, key, tag) {
+		return pkgerrors.Errorf("db Insert error: Mandatory fields are missing. Collection: %s, Key: %T %v, Tag: %s", coll, key, key, tag)
+	}
+
+	rKey, err := keyToMap(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Insert error: Error finding filter with key %T %v", key, key)
+	}
+
+	id, err := documentId(rKey)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Insert error: Error creating document id with key %T %v", key, key)
+	}
+
+	// Create and add keyId tag
+	keyId, err := createKeyIdField(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Insert error: Error creating KeyID with key %T %v", key, key)
+	}
+
+	// Encrypt data if required
+	oe := utils.GetObjectEncryptor("emco")
+	if oe != nil {
+		var edata interface{}
+		if reflect.TypeOf(data).Kind() == reflect.Ptr {
+			// avoid changing data's field value during encryption
+			edata, err = oe.EncryptObject(reflect.ValueOf(data).Elem().Interface())
+		} else {
+			edata, err = oe.EncryptObject(data)
+		}
+
+		if err == nil {
+			data = edata
+		} else {
+			log.Warn("Error to encrypt object", log.Fields{"collection": coll, "tag": tag})
+		}
+	}
+
+	// verify references for Inserts with the "data" tag
+	var refs []ReferenceEntry
+	if tag == "data" {
+		refs, err = verifyReferences(ctx, b, coll, key, keyId, data)
+		if err != nil {
+			if strings.Contains(err.Error(), "Parent resource not found") {
+				// these errors should be handled separately, not as an internal server error
+				return pkgerrors.Wrapf(err, "db Insert parent resource not found")
+			}
+
+			if strings.Contains(err.Error(), "is not present in referential schema") {
+				// these errors should be handled separately, not as an internal server error
+				return pkgerrors.Wrapf(err, "db Insert referential schema missing")
+			}
+
+			return pkgerrors.Wrapf(err, "db Insert error: Error verifying the references. Collection: %s, Key: %T %v, KeyID: %s", coll, key, key, keyId)
+		}
+	}
+
+	value, err := json.Marshal(data)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Insert error: Error Marshalling data for tag %s", tag)
+	}
+
+	var fields map[string]string
+	if query != nil {
+		fields, err = keyToMap(query)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "db Insert error: Error updating filter with query %T %v", query, query)
+		}
+	}
+
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(coll))
+		if err != nil {
+			return err
+		}
+
+		doc, err := getDocument(bucket, id)
+		if err != nil {
+			return err
+		}
+		if doc == nil {
+			doc = &boltDocument{
+				Fields: make(map[string]string),
+				Tags:   make(map[string]json.RawMessage),
+			}
+		}
+
+		for k, v := range rKey {
+			doc.Fields[k] = v
+		}
+		for k, v := range fields {
+			doc.Fields[k] = v
+		}
+		doc.KeyId = keyId
+		doc.Tags[tag] = value
+		if tag == "data" {
+			doc.References = refs
+		}
+
+		return putDocument(bucket, id, doc)
+	})
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Insert error")
+	}
+
+	return nil
+}
+
+// Find method returns the data stored for this key and for this particular tag
+func (b *BoltStore) Find(ctx context.Context, coll string, key Key, tag string) ([][]byte, error) {
+	if !validateParams(coll, key, tag) {
+		return nil, pkgerrors.Errorf("db Find error: Mandatory fields are missing. Collection: %s, Key: %T %v, Tag: %s", coll, key, key, tag)
+	}
+
+	rKey, err := keyToMap(key)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "db Find error: Error finding filter with key %T %v", key, key)
+	}
+
+	keyId, err := createKeyIdField(key)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "db Find error: Error finding filter with key %T %v", key, key)
+	}
+
+	var result [][]byte
+	err = b.db.View(func(tx *bolt.Tx) error {
+		return forEachDocument(tx.Bucket([]byte(coll)), func(id []byte, doc *boltDocument) error {
+			if !matchKey(doc, rKey, keyId) {
+				return nil
+			}
+			value, ok := doc.Tags[tag]
+			if !ok {
+				return nil
+			}
+			result = append(result, tagValue(value))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "db Find error")
+	}
+
+	return result, nil
+}
+
+// tagValue returns the value of a tag as returned by Find.
+// String values are returned as is, like the MongoStore does.
+func tagValue(value json.RawMessage) []byte {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return []byte(s)
+	}
+	return []byte(value)
+}
+
+// FindWithOptions method returns a page of the data stored for this key and
+// for this particular tag, filtered and sorted using the options
+func (b *BoltStore) FindWithOptions(ctx context.Context, coll string, key Key, tag string, opts FindOptions) ([][]byte, string, error) {
+	if !validateParams(coll, key, tag) {
+		return nil, "", pkgerrors.Errorf("db Find error: Mandatory fields are missing. Collection: %s, Key: %T %v, Tag: %s", coll, key, key, tag)
+	}
+
+	rKey, err := keyToMap(key)
+	if err != nil {
+		return nil, "", pkgerrors.Wrapf(err, "db Find error: Error finding filter with key %T %v", key, key)
+	}
+
+	keyId, err := createKeyIdField(key)
+	if err != nil {
+		return nil, "", pkgerrors.Wrapf(err, "db Find error: Error finding filter with key %T %v", key, key)
+	}
+
+	var docs []findDocument
+	err = b.db.View(func(tx *bolt.Tx) error {
+		return forEachDocument(tx.Bucket([]byte(coll)), func(id []byte, doc *boltDocument) error {
+			if !matchKey(doc, rKey, keyId) {
+				return nil
+			}
+			if _, ok := doc.Tags[tag]; !ok {
+				return nil
+			}
+			tags := make(map[string][]byte, len(doc.Tags))
+			for t, v := range doc.Tags {
+				tags[t] = v
+			}
+			docs = append(docs, findDocument{id: string(id), tags: tags})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, "", pkgerrors.Wrap(err, "db Find error")
+	}
+
+	docs, next, err := applyFindOptions(docs, opts)
+	if err != nil {
+		return nil, "", pkgerrors.Wrap(err, "db Find error")
+	}
+
+	var result [][]byte
+	for _, doc := range docs {
+		result = append(result, tagValue(doc.tags[tag]))
+	}
+	return result, next, nil
+}
+
+// RemoveAll method to removes all the documet matching key
+func (b *BoltStore) RemoveAll(ctx context.Context, coll string, key Key) error {
+	if !validateParams(coll, key) {
+		return pkgerrors.Errorf("db Remove error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
+	}
+
+	rKey, err := keyToMap(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Remove error: Error finding filter with key %T %v", key, key)
+	}
+
+	keyId, err := createKeyIdField(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Remove error: Error finding filter with key %T %v", key, key)
+	}
+
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(coll))
+		var ids [][]byte
+		err := forEachDocument(bucket, func(id []byte, doc *boltDocument) error {
+			if matchKey(doc, rKey, keyId) {
+				ids = append(ids, append([]byte{}, id...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := bucket.Delete(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Remove error: Error deleting document(s) from database. Key: %T %v", key, key)
+	}
+	return nil
+}
+
+// Remove method to remove the documet by key if no child references
+func (b *BoltStore) Remove(ctx context.Context, coll string, key Key) error {
+	if !validateParams(coll, key) {
+		return pkgerrors.Errorf("db Remove error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
+	}
+
+	rKey, err := keyToMap(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Remove error: Error finding filter with key %T %v", key, key)
+	}
+
+	keyId, err := createKeyIdField(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Remove error: Error finding filter with key %T %v", key, key)
+	}
+
+	id, err := documentId(rKey)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db Remove error: Error creating document id with key %T %v", key, key)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(coll))
+
+		// search for child and referencing documents - assumes all children
+		// are part of the same collection
+		var count, refCount int
+		err := forEachDocument(bucket, func(_ []byte, doc *boltDocument) error {
+			if matchFields(doc.Fields, rKey) {
+				count++
+			}
+			for _, ref := range doc.References {
+				if ref.KeyId != keyId {
+					continue
+				}
+				refKey, err := keyToMap(ref.Key)
+				if err != nil {
+					return err
+				}
+				if matchFields(refKey, rKey) {
+					refCount++
+					break
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return pkgerrors.Wrap(err, "db Remove error")
+		}
+
+		if count == 0 {
+			return pkgerrors.Errorf("db Remove resource not found: The requested resource not found. Key: %T %v", key, key)
+		}
+
+		if count > 1 {
+			return pkgerrors.Errorf("db Remove parent child constraint: Cannot delete parent without deleting child references first. Key: %T %v", key, key)
+		}
+
+		if refCount > 0 {
+			return pkgerrors.Errorf("db Remove referential constraint: Cannot delete without deleting or updating referencing resources first. Key: %T %v", key, key)
+		}
+
+		// ok to delete the document
+		if err := bucket.Delete(id); err != nil {
+			return pkgerrors.Wrapf(err, "db Remove error: Error deleting document from database. Key: %T %v", key, key)
+		}
+		return nil
+	})
+}
+
+// RemoveTag is used to remove an element from a document
+func (b *BoltStore) RemoveTag(ctx context.Context, coll string, key Key, tag string) error {
+	rKey, err := keyToMap(key)
+	if err != nil {
+		return err
+	}
+
+	id, err := documentId(rKey)
+	if err != nil {
+		return err
+	}
+
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(coll))
+		doc, err := getDocument(bucket, id)
+		if err != nil || doc == nil {
+			return err
+		}
+		delete(doc.Tags, tag)
+		return putDocument(bucket, id, doc)
+	})
+	if err != nil {
+		return pkgerrors.Errorf("Error removing tag: %s", err.Error())
+	}
+
+	return nil
+}
+
+// Watch reports the changes to the document(s) matching the key prefix.
+// The database file is polled for changes.
+func (b *BoltStore) Watch(ctx context.Context, coll string, key Key) (<-chan WatchEvent, error) {
+	if !validateParams(coll, key) {
+		return nil, pkgerrors.Errorf("db Watch error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
+	}
+
+	prefix, err := watchPrefix(key)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "db Watch error: Error finding filter with key %T %v", key, key)
+	}
+
+	ch, err := pollWatch(ctx, func(ctx context.Context) (map[string]watchDocument, error) {
+		docs := make(map[string]watchDocument)
+		err := b.db.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket([]byte(coll))
+			if bucket == nil {
+				return nil
+			}
+			return bucket.ForEach(func(id, v []byte) error {
+				doc := &boltDocument{}
+				if err := json.Unmarshal(v, doc); err != nil {
+					return pkgerrors.Wrapf(err, "Error Unmarshalling document %s", string(id))
+				}
+				if !matchFields(doc.Fields, prefix) {
+					return nil
+				}
+				k := make(map[string]string)
+				for _, f := range keyIdFields(doc.KeyId) {
+					k[f] = doc.Fields[f]
+				}
+				docs[string(id)] = watchDocument{key: k, content: string(v)}
+				return nil
+			})
+		})
+		return docs, err
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "db Watch error")
+	}
+
+	return ch, nil
+}
+
+// Reencrypt encrypts the encrypted values of all the buckets again with the
+// current key encryption key, in a single transaction
+func (b *BoltStore) Reencrypt(ctx context.Context, oe utils.IObjectEncryptor) (ReencryptResult, error) {
+	var result ReencryptResult
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			changed := make(map[string]*boltDocument)
+			err := forEachDocument(bucket, func(id []byte, doc *boltDocument) error {
+				docChanged := false
+				for tag, raw := range doc.Tags {
+					var v interface{}
+					if err := json.Unmarshal(raw, &v); err != nil {
+						result.Failed++
+						continue
+					}
+					values, failed := reencryptValues(oe, v, "")
+					result.Failed += failed
+					if len(values) == 0 {
+						continue
+					}
+					if _, ok := v.(string); ok {
+						// the tag is a single value
+						v = values[0].new
+					} else {
+						for _, rv := range values {
+							setPath(v, rv.path, rv.new)
+						}
+					}
+					data, err := json.Marshal(v)
+					if err != nil {
+						return pkgerrors.Wrap(err, "Error Marshalling the re-encrypted values")
+					}
+					doc.Tags[tag] = data
+					result.Values += len(values)
+					docChanged = true
+				}
+				if docChanged {
+					changed[string(id)] = doc
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for id, doc := range changed {
+				if err := putDocument(bucket, []byte(id), doc); err != nil {
+					return err
+				}
+				result.Documents++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return ReencryptResult{}, pkgerrors.Wrap(err, "db Reencrypt error")
+	}
+	return result, nil
+}
+
+// DocumentFormat returns the encoding of the tags of the documents
+func (b *BoltStore) DocumentFormat() string {
+	return "bolt"
+}
+
+// FindDocuments returns the documents matching the key prefix
+func (b *BoltStore) FindDocuments(ctx context.Context, coll string, key Key) ([]Document, error) {
+	if !validateParams(coll, key) {
+		return nil, pkgerrors.Errorf("db FindDocuments error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
+	}
+
+	prefix, err := watchPrefix(key)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "db FindDocuments error: Error finding filter with key %T %v", key, key)
+	}
+
+	var docs []Document
+	err = b.db.View(func(tx *bolt.Tx) error {
+		return forEachDocument(tx.Bucket([]byte(coll)), func(id []byte, doc *boltDocument) error {
+			if matchFields(doc.Fields, prefix) {
+				docs = append(docs, Document{
+					Fields:     doc.Fields,
+					KeyId:      doc.KeyId,
+					References: doc.References,
+					Tags:       doc.Tags,
+				})
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "db FindDocuments error")
+	}
+
+	return docs, nil
+}
+
+// InsertDocument inserts the document, or updates the document with the same key
+func (b *BoltStore) InsertDocument(ctx context.Context, coll string, doc Document) error {
+	key := doc.Key()
+	if !validateParams(coll, key) || len(doc.Tags) == 0 {
+		return pkgerrors.Errorf("db InsertDocument error: Mandatory fields are missing. Collection: %s, Key: %v", coll, key)
+	}
+
+	id, err := documentId(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db InsertDocument error: Error creating document id with key %v", key)
+	}
+
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(coll))
+		if err != nil {
+			return err
+		}
+
+		d, err := getDocument(bucket, id)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			d = &boltDocument{
+				Fields: make(map[string]string),
+				Tags:   make(map[string]json.RawMessage),
+			}
+		}
+
+		for k, v := range doc.Fields {
+			d.Fields[k] = v
+		}
+		for tag, v := range doc.Tags {
+			d.Tags[tag] = v
+		}
+		d.KeyId = doc.KeyId
+		if _, ok := doc.Tags["data"]; ok {
+			d.References = doc.References
+		}
+
+		return putDocument(bucket, id, d)
+	})
+	if err != nil {
+		return pkgerrors.Wrap(err, "db InsertDocument error")
+	}
+
+	return nil
+}
+
+// boltRecord is a document of a BoltStore as returned by Snapshot: its id
+// and its JSON encoded boltDocument
+type boltRecord struct {
+	ID       string          `json:"id"`
+	Document json.RawMessage `json:"document"`
+}
+
+// Snapshot calls fn with all the documents of all the buckets, read in a
+// single transaction
+func (b *BoltStore) Snapshot(ctx context.Context, fn func(coll string, doc json.RawMessage) error) (bool, error) {
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			return forEachRecord(bucket, func(id, v []byte) error {
+				doc, err := json.Marshal(boltRecord{ID: string(id), Document: v})
+				if err != nil {
+					return pkgerrors.Wrapf(err, "Error Marshalling document %s", string(id))
+				}
+				return fn(string(name), doc)
+			})
+		})
+	})
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "db Snapshot error")
+	}
+	return true, nil
+}
+
+// errNotEmpty stops the iteration of Empty at the first document
+var errNotEmpty = pkgerrors.New("not empty")
+
+// Empty returns true if no bucket holds a document
+func (b *BoltStore) Empty(ctx context.Context) (bool, error) {
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			return forEachRecord(bucket, func(id, v []byte) error {
+				return errNotEmpty
+			})
+		})
+	})
+	if err == errNotEmpty {
+		return false, nil
+	}
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "db Empty error")
+	}
+	return true, nil
+}
+
+// RestoreCollection inserts the documents in the bucket, in a single transaction
+func (b *BoltStore) RestoreCollection(ctx context.Context, coll string, docs []json.RawMessage) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(coll))
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			var r boltRecord
+			if err := json.Unmarshal(doc, &r); err != nil {
+				return pkgerrors.Wrap(err, "Error Unmarshalling document")
+			}
+			if r.ID == "" || len(r.Document) == 0 {
+				return pkgerrors.Errorf("Invalid document in the collection %s", coll)
+			}
+			if err := bucket.Put([]byte(r.ID), r.Document); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return pkgerrors.Wrap(err, "db RestoreCollection error")
+	}
+	return nil
+}
+
+// Clear deletes the documents of all the buckets, in a single transaction
+func (b *BoltStore) Clear(ctx context.Context) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			var ids [][]byte
+			err := forEachRecord(bucket, func(id, v []byte) error {
+				ids = append(ids, append([]byte{}, id...))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := bucket.Delete(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return pkgerrors.Wrap(err, "db Clear error")
+	}
+	return nil
+}
+
+// forEachRecord calls fn with the id and the encoded document of each
+// document of the bucket but the segments of the referential schema
+func forEachRecord(bucket *bolt.Bucket, fn func(id, v []byte) error) error {
+	schema := schemaKeyId()
+	return bucket.ForEach(func(id, v []byte) error {
+		var doc struct {
+			KeyId string `json:"keyId"`
+		}
+		if err := json.Unmarshal(v, &doc); err != nil {
+			return pkgerrors.Wrapf(err, "Error Unmarshalling document %s", string(id))
+		}
+		if doc.KeyId == schema {
+			return nil
+		}
+		return fn(id, v)
+	})
+}
@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	utils "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+// testKeySource stores the data keys in the clear, with the ID of its
+// current key
+type testKeySource struct {
+	current string
+}
+
+func (s *testKeySource) KeyID(ctx context.Context) (string, error) {
+	return s.current, nil
+}
+
+func (s *testKeySource) Encrypt(ctx context.Context, plaintext []byte) (string, []byte, error) {
+	return s.current, plaintext, nil
+}
+
+func (s *testKeySource) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	return ciphertext, nil
+}
+
+type testProviderKey struct {
+	ClusterProvider string `json:"clusterProvider"`
+}
+
+type testClusterKey struct {
+	ClusterProvider string `json:"clusterProvider"`
+	Cluster         string `json:"cluster"`
+}
+
+type testClusterQuery struct {
+	Label string `json:"clusterLabel"`
+}
+
+type testResource struct {
+	Metadata map[string]string `json:"metadata"`
+	Spec     map[string]string `json:"spec"`
+}
+
+var _ = Describe("Bolt store",
+	func() {
+		var (
+			store *BoltStore
+			bdb   *bolt.DB
+			ctx   = context.Background()
+		)
+
+		BeforeEach(func() {
+			dir, err := ioutil.TempDir("", "bolt")
+			Expect(err).To(BeNil())
+			bdb, err = bolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+			Expect(err).To(BeNil())
+			store = &BoltStore{db: bdb}
+
+			refSchemaFile = wd + "/test-schemas/emco-base.yaml"
+			schema, err := readSchema()
+			Expect(err).To(BeNil())
+			waitForSchema, err := processSchema(ctx, store, schema)
+			Expect(err).To(BeNil())
+			Expect(waitForSchema).To(BeFalse())
+
+			err = store.Insert(ctx, "test", testProviderKey{"p1"}, nil, "data",
+				testResource{Metadata: map[string]string{"name": "p1"}})
+			Expect(err).To(BeNil())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(filepath.Dir(bdb.Path()))
+			bdb.Close()
+			resetSchema()
+			refSchemaFile = ""
+		})
+
+		It("registers the schema segment", func() {
+			segments, err := store.Find(ctx, "resources", DbSchemaKey{}, "segment")
+			validate(err, "")
+			Expect(len(segments)).To(Equal(1))
+		})
+
+		It("finds resources using the key as a filter", func() {
+			for _, c := range []string{"c1", "c2"} {
+				err := store.Insert(ctx, "test", testClusterKey{"p1", c}, nil, "data",
+					testResource{Metadata: map[string]string{"name": c}})
+				validate(err, "")
+			}
+
+			result, err := store.Find(ctx, "test", testClusterKey{"p1", "c1"}, "data")
+			validate(err, "")
+			Expect(len(result)).To(Equal(1))
+			r := testResource{}
+			validate(store.Unmarshal(result[0], &r), "")
+			Expect(r.Metadata["name"]).To(Equal("c1"))
+
+			result, err = store.Find(ctx, "test", testClusterKey{"p1", ""}, "data")
+			validate(err, "")
+			Expect(len(result)).To(Equal(2))
+
+			result, err = store.Find(ctx, "test", testClusterKey{"p2", ""}, "data")
+			validate(err, "")
+			Expect(len(result)).To(Equal(0))
+		})
+
+		It("stores additional tags and query fields", func() {
+			err := store.Insert(ctx, "test", testClusterKey{"p1", "c1"}, testClusterQuery{"edge"}, "data",
+				testResource{Metadata: map[string]string{"name": "c1"}})
+			validate(err, "")
+			err = store.Insert(ctx, "test", testClusterKey{"p1", "c1"}, nil, "status", "ready")
+			validate(err, "")
+
+			result, err := store.Find(ctx, "test", testClusterQuery{"edge"}, "status")
+			validate(err, "")
+			Expect(result).To(Equal([][]byte{[]byte("ready")}))
+
+			validate(store.RemoveTag(ctx, "test", testClusterKey{"p1", "c1"}, "status"), "")
+			result, err = store.Find(ctx, "test", testClusterKey{"p1", "c1"}, "status")
+			validate(err, "")
+			Expect(len(result)).To(Equal(0))
+		})
+
+		It("rejects resources without a parent", func() {
+			err := store.Insert(ctx, "test", testClusterKey{"p2", "c1"}, nil, "data",
+				testResource{Metadata: map[string]string{"name": "c1"}})
+			validate(err, "Parent resource not found")
+		})
+
+		It("enforces the parent child constraint on remove", func() {
+			err := store.Insert(ctx, "test", testClusterKey{"p1", "c1"}, nil, "data",
+				testResource{Metadata: map[string]string{"name": "c1"}})
+			validate(err, "")
+
+			validate(store.Remove(ctx, "test", testProviderKey{"p1"}), "parent child constraint")
+			validate(store.Remove(ctx, "test", testClusterKey{"p1", "c1"}), "")
+			validate(store.Remove(ctx, "test", testProviderKey{"p1"}), "")
+			validate(store.Remove(ctx, "test", testProviderKey{"p1"}), "resource not found")
+		})
+
+		It("removes all the matching resources", func() {
+			for _, c := range []string{"c1", "c2"} {
+				err := store.Insert(ctx, "test", testClusterKey{"p1", c}, nil, "data",
+					testResource{Metadata: map[string]string{"name": c}})
+				validate(err, "")
+			}
+
+			validate(store.RemoveAll(ctx, "test", testClusterKey{"p1", ""}), "")
+			result, err := store.Find(ctx, "test", testClusterKey{"p1", ""}, "data")
+			validate(err, "")
+			Expect(len(result)).To(Equal(0))
+
+			result, err = store.Find(ctx, "test", testProviderKey{"p1"}, "data")
+			validate(err, "")
+			Expect(len(result)).To(Equal(1))
+		})
+
+		It("filters, sorts and pages the resources", func() {
+			for _, c := range []string{"c3", "c1", "c2"} {
+				err := store.Insert(ctx, "test", testClusterKey{"p1", c}, nil, "data",
+					testResource{Metadata: map[string]string{"name": c}, Spec: map[string]string{"size": "s"}})
+				validate(err, "")
+			}
+			err := store.Insert(ctx, "test", testClusterKey{"p1", "c4"}, nil, "data",
+				testResource{Metadata: map[string]string{"name": "c4"}, Spec: map[string]string{"size": "l"}})
+			validate(err, "")
+
+			opts := FindOptions{Filter: map[string]string{"data.spec.size": "s"}, Sort: "-data.metadata.name", Limit: 2}
+			result, next, err := store.FindWithOptions(ctx, "test", testClusterKey{"p1", ""}, "data", opts)
+			validate(err, "")
+			Expect(len(result)).To(Equal(2))
+			Expect(next).NotTo(BeEmpty())
+			r := testResource{}
+			validate(store.Unmarshal(result[0], &r), "")
+			Expect(r.Metadata["name"]).To(Equal("c3"))
+
+			opts.Continue = next
+			result, next, err = store.FindWithOptions(ctx, "test", testClusterKey{"p1", ""}, "data", opts)
+			validate(err, "")
+			Expect(len(result)).To(Equal(1))
+			Expect(next).To(BeEmpty())
+			validate(store.Unmarshal(result[0], &r), "")
+			Expect(r.Metadata["name"]).To(Equal("c1"))
+		})
+
+		It("reports the changes to the watched documents", func() {
+			interval := watchPollInterval
+			watchPollInterval = 10 * time.Millisecond
+			defer func() { watchPollInterval = interval }()
+
+			wctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			events, err := store.Watch(wctx, "test", testClusterKey{"p1", ""})
+			validate(err, "")
+
+			err = store.Insert(ctx, "test", testClusterKey{"p1", "c1"}, nil, "data",
+				testResource{Metadata: map[string]string{"name": "c1"}})
+			validate(err, "")
+			Eventually(events).Should(Receive(Equal(WatchEvent{Type: WatchEventInsert,
+				Key: map[string]string{"clusterProvider": "p1", "cluster": "c1"}})))
+
+			err = store.Insert(ctx, "test", testClusterKey{"p1", "c1"}, nil, "status", "ready")
+			validate(err, "")
+			Eventually(events).Should(Receive(Equal(WatchEvent{Type: WatchEventUpdate,
+				Key: map[string]string{"clusterProvider": "p1", "cluster": "c1"}})))
+
+			validate(store.Remove(ctx, "test", testClusterKey{"p1", "c1"}), "")
+			Eventually(events).Should(Receive(Equal(WatchEvent{Type: WatchEventDelete,
+				Key: map[string]string{"clusterProvider": "p1", "cluster": "c1"}})))
+
+			cancel()
+			Eventually(events).Should(BeClosed())
+		})
+
+		It("re-encrypts the values with the current key", func() {
+			keys := &testKeySource{current: "k1"}
+			oe, err := utils.NewObjectEncryptor(keys, nil)
+			validate(err, "")
+			secret, err := oe.EncryptString("kubeconfig")
+			validate(err, "")
+			err = store.Insert(ctx, "test", testClusterKey{"p1", "c1"}, nil, "data",
+				testResource{Metadata: map[string]string{"name": "c1"}, Spec: map[string]string{"config": secret}})
+			validate(err, "")
+
+			keys.current = "k2"
+			_, err = oe.RefreshKey()
+			validate(err, "")
+			result, err := store.Reencrypt(ctx, oe)
+			validate(err, "")
+			Expect(result).To(Equal(ReencryptResult{Documents: 1, Values: 1}))
+
+			values, err := store.Find(ctx, "test", testClusterKey{"p1", "c1"}, "data")
+			validate(err, "")
+			r := testResource{}
+			validate(store.Unmarshal(values[0], &r), "")
+			Expect(r.Metadata["name"]).To(Equal("c1"))
+			Expect(r.Spec["config"]).To(HavePrefix("emco:v1:k2:"))
+			config, err := oe.DecryptString(r.Spec["config"])
+			validate(err, "")
+			Expect(config).To(Equal("kubeconfig"))
+
+			result, err = store.Reencrypt(ctx, oe)
+			validate(err, "")
+			Expect(result).To(Equal(ReencryptResult{}))
+		})
+
+		It("shares the database file between the stores of the process", func() {
+			path := filepath.Join(filepath.Dir(bdb.Path()), "shared.db")
+			db1, err := openBoltDB(path)
+			validate(err, "")
+			// opening the file again would wait for its lock
+			db2, err := openBoltDB(path)
+			validate(err, "")
+			Expect(db2).To(BeIdenticalTo(db1))
+			s1, s2 := &BoltStore{db: db1}, &BoltStore{db: db2}
+
+			err = s1.Insert(ctx, "test", testProviderKey{"p2"}, nil, "data",
+				testResource{Metadata: map[string]string{"name": "p2"}})
+			validate(err, "")
+			values, err := s2.Find(ctx, "test", testProviderKey{"p2"}, "data")
+			validate(err, "")
+			Expect(len(values)).To(Equal(1))
+			validate(s2.Remove(ctx, "test", testProviderKey{"p2"}), "")
+			values, err = s1.Find(ctx, "test", testProviderKey{"p2"}, "data")
+			validate(err, "")
+			Expect(len(values)).To(Equal(0))
+		})
+	})
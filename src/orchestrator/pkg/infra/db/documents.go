@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Document is a whole document of a collection: its fields, i.e. the
+// elements of its key and its query fields, the keyId of its key, its
+// references and its tags. The tags are encoded by the store, as canonical
+// extended JSON for the MongoStore and as JSON for the BoltStore, so that
+// they are written back as they were read.
+type Document struct {
+	Fields     map[string]string          `json:"fields"`
+	KeyId      string                     `json:"keyId"`
+	References []ReferenceEntry           `json:"references,omitempty"`
+	Tags       map[string]json.RawMessage `json:"tags"`
+}
+
+// Key returns the key of the document, i.e. its fields named in its keyId
+func (d Document) Key() map[string]string {
+	key := make(map[string]string)
+	for _, f := range keyIdFields(d.KeyId) {
+		key[f] = d.Fields[f]
+	}
+	return key
+}
+
+// DocumentStore is implemented by the stores that can read and write whole
+// documents, e.g. to export and import them
+type DocumentStore interface {
+	// DocumentFormat returns the encoding of the tags of the documents,
+	// the documents can only be inserted in a store of the same format,
+	// or once converted by ConvertDocument
+	DocumentFormat() string
+	// FindDocuments returns the documents whose key starts with the
+	// non-empty elements of the key
+	FindDocuments(ctx context.Context, coll string, key Key) ([]Document, error)
+	// InsertDocument inserts the document, or updates the fields and the
+	// tags of the document with the same key. The references of the
+	// document aren't verified.
+	InsertDocument(ctx context.Context, coll string, doc Document) error
+}
+
+// VerifyDocument verifies the document as the stores verify a resource on
+// the Insert of its "data" tag: its key must be in the referential schema
+// and its parent must exist in the store. It returns the references found
+// in its data.
+func VerifyDocument(ctx context.Context, s Store, coll string, doc Document) ([]ReferenceEntry, error) {
+	raw, ok := doc.Tags["data"]
+	if !ok {
+		return nil, pkgerrors.Errorf("The resource %v has no data", doc.Key())
+	}
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error Unmarshalling the data of %v", doc.Key())
+	}
+	return verifyReferences(ctx, s, coll, doc.Key(), doc.KeyId, data)
+}
+
+// ConvertDocument converts the tags of the document of a store of the format
+// from to the format to. The documents of a MongoStore are converted for a
+// BoltStore: the values of the extended JSON are converted to the values
+// encoded by encoding/json, e.g. the dates to RFC 3339 strings. Their fields
+// keep the lowercased names of the Go fields given by the BSON encoding,
+// which encoding/json matches regardless of their case. The documents of a
+// BoltStore can't be converted for a MongoStore, as their JSON doesn't keep
+// the BSON types of their values.
+func ConvertDocument(doc Document, from, to string) (Document, error) {
+	if from == to {
+		return doc, nil
+	}
+	if from != "mongo" || to != "bolt" {
+		return doc, pkgerrors.Errorf("The documents of a %s database can't be converted for a %s database", from, to)
+	}
+	tags := make(map[string]json.RawMessage)
+	for tag, raw := range doc.Tags {
+		var v interface{}
+		d := json.NewDecoder(bytes.NewReader(raw))
+		d.UseNumber()
+		if err := d.Decode(&v); err != nil {
+			return doc, pkgerrors.Wrapf(err, "Error Unmarshalling the tag %s", tag)
+		}
+		v, err := plainValue(v)
+		if err != nil {
+			return doc, pkgerrors.Wrapf(err, "Error converting the tag %s", tag)
+		}
+		if tags[tag], err = json.Marshal(v); err != nil {
+			return doc, pkgerrors.Wrapf(err, "Error Marshalling the tag %s", tag)
+		}
+	}
+	doc.Tags = tags
+	return doc, nil
+}
+
+// plainValue converts the values of the canonical or relaxed extended JSON
+// decoded in v to the values of encoding/json
+func plainValue(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 1 {
+			for k, e := range t {
+				if strings.HasPrefix(k, "$") {
+					return plainType(k, e)
+				}
+			}
+		}
+		for k, e := range t {
+			n, err := plainValue(e)
+			if err != nil {
+				return nil, err
+			}
+			t[k] = n
+		}
+	case []interface{}:
+		for i, e := range t {
+			n, err := plainValue(e)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = n
+		}
+	}
+	return v, nil
+}
+
+// plainType converts the value of the extended JSON type to its value in
+// encoding/json: the numbers, the dates, the binary data, i.e. the []byte,
+// and the object IDs
+func plainType(typ string, v interface{}) (interface{}, error) {
+	switch typ {
+	case "$numberInt", "$numberLong", "$numberDouble", "$numberDecimal":
+		s, ok := v.(string)
+		if !ok {
+			return nil, pkgerrors.Errorf("Invalid %s value %v", typ, v)
+		}
+		// e.g. NaN and Infinity aren't JSON numbers
+		var n json.Number
+		if err := json.Unmarshal([]byte(s), &n); err != nil {
+			return nil, pkgerrors.Errorf("The %s value %s can't be encoded in JSON", typ, s)
+		}
+		return n, nil
+	case "$date":
+		var t time.Time
+		switch d := v.(type) {
+		case string:
+			var err error
+			if t, err = time.Parse(time.RFC3339Nano, d); err != nil {
+				return nil, pkgerrors.Wrapf(err, "Invalid $date value %s", d)
+			}
+		case map[string]interface{}:
+			s, _ := d["$numberLong"].(string)
+			ms, err := strconv.ParseInt(s, 10, 64)
+			if err != nil || len(d) != 1 {
+				return nil, pkgerrors.Errorf("Invalid $date value %v", d)
+			}
+			t = time.Unix(ms/1000, ms%1000*int64(time.Millisecond))
+		default:
+			return nil, pkgerrors.Errorf("Invalid $date value %v", v)
+		}
+		return t.UTC().Format(time.RFC3339Nano), nil
+	case "$binary":
+		b, _ := v.(map[string]interface{})
+		s, ok := b["base64"].(string)
+		if !ok {
+			return nil, pkgerrors.Errorf("Invalid $binary value %v", v)
+		}
+		return s, nil
+	case "$oid":
+		s, ok := v.(string)
+		if !ok {
+			return nil, pkgerrors.Errorf("Invalid $oid value %v", v)
+		}
+		return s, nil
+	}
+	return nil, pkgerrors.Errorf("The extended JSON type %s can't be encoded in JSON", typ)
+}
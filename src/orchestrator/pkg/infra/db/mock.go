@@ -109,6 +109,30 @@ func (m *MockDB) Find(ctx context.Context, table string, key Key, tag string) ([
 	}
 }
 
+func (m *MockDB) FindWithOptions(ctx context.Context, table string, key Key, tag string, opts FindOptions) ([][]byte, string, error) {
+	values, err := m.Find(ctx, table, key, tag)
+	if err != nil {
+		return values, "", err
+	}
+
+	// only the fields of the tag can be used to filter and sort, and the
+	// values are their own id
+	docs := make([]findDocument, 0, len(values))
+	for _, v := range values {
+		docs = append(docs, findDocument{id: string(v), tags: map[string][]byte{tag: v}})
+	}
+	docs, next, err := applyFindOptions(docs, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var r [][]byte
+	for _, d := range docs {
+		r = append(r, d.tags[tag])
+	}
+	return r, next, nil
+}
+
 func (m *MockDB) Remove(ctx context.Context, table string, key Key) error {
 	if m.Err != nil {
 		return m.Err
@@ -135,3 +159,33 @@ func (m *MockDB) RemoveAll(ctx context.Context, table string, key Key) error {
 func (m *MockDB) RemoveTag(ctx context.Context, table string, key Key, tag string) error {
 	return m.Err
 }
+
+// mockWatchSnapshot returns the mock items matching the key prefix
+func mockWatchSnapshot(items []map[string]map[string][]byte, prefix map[string]string) (map[string]watchDocument, error) {
+	docs := make(map[string]watchDocument)
+	for _, item := range items {
+		for k, v := range item {
+			key := map[string]string{}
+			json.Unmarshal([]byte(k), &key)
+			if !matchFields(key, prefix) {
+				continue
+			}
+			content, _ := json.Marshal(v)
+			docs[k] = watchDocument{key: key, content: string(content)}
+		}
+	}
+	return docs, nil
+}
+
+func (m *MockDB) Watch(ctx context.Context, table string, key Key) (<-chan WatchEvent, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	prefix, err := watchPrefix(key)
+	if err != nil {
+		return nil, err
+	}
+	return pollWatch(ctx, func(ctx context.Context) (map[string]watchDocument, error) {
+		return mockWatchSnapshot(m.Items, prefix)
+	})
+}
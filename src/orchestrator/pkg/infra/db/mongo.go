@@ -10,6 +10,7 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/context"
@@ -46,6 +47,8 @@ type MongoCollection interface {
 		opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	CountDocuments(ctx context.Context, filter interface{},
 		opts ...*options.CountOptions) (int64, error)
+	Watch(ctx context.Context, pipeline interface{},
+		opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error)
 }
 
 // MongoStore is an implementation of the db.Store interface
@@ -282,7 +285,9 @@ func findKeyValues(inKey, filterKey map[string]struct{}, data interface{}) (map[
 // 2. The keys for other references, as identified for the schema, are found
 //    by searching the "spec" object of the resource "data".
 //    These references are then verified to exist.
-func (m *MongoStore) verifyReferences(ctx context.Context, coll string, key Key, keyId string, data interface{}) ([]ReferenceEntry, error) {
+// The lookups are done through the given Store so that every backend
+// enforces the same referential schema.
+func verifyReferences(ctx context.Context, m Store, coll string, key Key, keyId string, data interface{}) ([]ReferenceEntry, error) {
 
 	// make a references slice to store keys of any references found
 	refs := make([]ReferenceEntry, 0)
@@ -472,7 +477,7 @@ func (m *MongoStore) verifyReferences(ctx context.Context, coll string, key Key,
 }
 
 // validateParams checks to see if any parameters are empty
-func (m *MongoStore) validateParams(args ...interface{}) bool {
+func validateParams(args ...interface{}) bool {
 	for _, v := range args {
 		val, ok := v.(string)
 		if ok {
@@ -609,6 +614,12 @@ func (m *MongoStore) updateFilter(key interface{}) (primitive.M, error) {
 }
 
 func (m *MongoStore) createKeyIdField(key interface{}) (string, error) {
+	return createKeyIdField(key)
+}
+
+// createKeyIdField returns the keyId of a key, i.e. the sorted list
+// of the key element names, which identifies the type of the resource.
+func createKeyIdField(key interface{}) (string, error) {
 
 	var n map[string]string
 	st, err := json.Marshal(key)
@@ -635,7 +646,7 @@ func (m *MongoStore) Insert(ctx context.Context, coll string, key Key, query int
 		return pkgerrors.Errorf("db Insert error: No data to store")
 	}
 
-	if !m.validateParams(coll, key, tag) {
+	if !validateParams(coll, key, tag) {
 		return pkgerrors.Errorf("db Insert error: Mandatory fields are missing. Collection: %s, Key: %T %v, Tag: %s", coll, key, key, tag)
 	}
 
@@ -674,7 +685,7 @@ func (m *MongoStore) Insert(ctx context.Context, coll string, key Key, query int
 	refs := make([]ReferenceEntry, 0)
 
 	if tag == "data" {
-		refs, err = m.verifyReferences(ctx, coll, key, keyId, data)
+		refs, err = verifyReferences(ctx, m, coll, key, keyId, data)
 		if err != nil {
 			if strings.Contains(err.Error(), "Parent resource not found") {
 				// these errors should be handled separately, not as an internal server error
@@ -746,7 +757,7 @@ func (m *MongoStore) Find(ctx context.Context, coll string, key Key, tag string)
 
 	//result, err := m.findInternal(coll, key, tag, "")
 	//return result, err
-	if !m.validateParams(coll, key, tag) {
+	if !validateParams(coll, key, tag) {
 		return nil, pkgerrors.Errorf("db Find error: Mandatory fields are missing. Collection: %s, Key: %T %v, Tag: %s", coll, key, key, tag)
 	}
 
@@ -767,6 +778,11 @@ func (m *MongoStore) Find(ctx context.Context, coll string, key Key, tag string)
 		return nil, pkgerrors.Wrap(err, "db Find error")
 	}
 	defer cursorClose(ctx, cursor)
+	return readTagValues(ctx, cursor, tag), nil
+}
+
+// readTagValues returns the values of the tag of the documents of the cursor
+func readTagValues(ctx context.Context, cursor *mongo.Cursor, tag string) [][]byte {
 	var data []byte
 	var result [][]byte
 	for cursorNext(ctx, cursor) {
@@ -784,12 +800,248 @@ func (m *MongoStore) Find(ctx context.Context, coll string, key Key, tag string)
 		}
 		result = append(result, data)
 	}
-	return result, nil
+	return result
+}
+
+// fieldFilter returns the filter matching the documents where the field at
+// the path is a string with the value. The filter is an aggregation
+// expression, so that the field is compared as a whole, as in the stores
+// that filter the documents in memory, rather than matching the elements of
+// arrays, and so that array elements can be indexed from the end.
+func fieldFilter(path string, value string, tagTypes map[string]interface{}) bson.M {
+	return bson.M{"$expr": bson.M{"$eq": bson.A{fieldExpr(bsonFieldPath(path, tagTypes)), value}}}
+}
+
+// fieldExpr returns the aggregation expression of the field at the path,
+// where the integer elements index arrays
+func fieldExpr(elems []string) interface{} {
+	isIndex := func(e string) bool {
+		_, err := strconv.Atoi(e)
+		return err == nil
+	}
+
+	var expr interface{}
+	for i := 0; i < len(elems); {
+		if expr != nil && isIndex(elems[i]) {
+			n, _ := strconv.Atoi(elems[i])
+			expr = bson.M{"$arrayElemAt": bson.A{expr, n}}
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(elems) && !isIndex(elems[j]) {
+			j++
+		}
+		if expr == nil {
+			expr = "$" + strings.Join(elems[i:j], ".")
+		} else {
+			expr = bson.M{"$let": bson.M{
+				"vars": bson.M{"v": expr},
+				"in":   "$$v." + strings.Join(elems[i:j], "."),
+			}}
+		}
+		i = j
+	}
+	return expr
+}
+
+// timeRangeFilter returns the filter selecting the documents where the time
+// at the path is in the range. The default bson codec encodes the times as
+// BSON dates.
+func timeRangeFilter(path string, r TimeRange, tagTypes map[string]interface{}) bson.M {
+	cond := bson.M{}
+	if !r.From.IsZero() {
+		cond["$gte"] = r.From
+	}
+	if !r.To.IsZero() {
+		cond["$lt"] = r.To
+	}
+	if len(cond) == 0 {
+		cond["$exists"] = true
+	}
+	return bson.M{strings.Join(bsonFieldPath(path, tagTypes), "."): cond}
+}
+
+// extJSONValue returns the canonical extended json of a bson value, which
+// keeps its bson type
+func extJSONValue(v interface{}) (json.RawMessage, error) {
+	b, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: v}}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var d map[string]json.RawMessage
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, err
+	}
+	return d["v"], nil
+}
+
+// bsonValue returns the bson value of its canonical extended json
+func bsonValue(raw json.RawMessage) (interface{}, error) {
+	var d bson.M
+	if err := bson.UnmarshalExtJSON(append(append([]byte(`{"v":`), raw...), '}'), true, &d); err != nil {
+		return nil, err
+	}
+	return d["v"], nil
+}
+
+// continueFilter returns the filter matching the documents after the
+// position of the continue token, in the order of the sort field, if any,
+// then of the _id. The values of the sort field of a list have the same
+// type, with null or missing values sorted first.
+func continueFilter(token *continueToken, field string, desc bool) (bson.M, error) {
+	id, err := bsonValue(token.ID)
+	if err != nil {
+		return nil, pkgerrors.New("Invalid continue token")
+	}
+	after := bson.M{"_id": bson.M{"$gt": id}}
+	if field == "" {
+		return after, nil
+	}
+
+	var value interface{}
+	if len(token.Value) > 0 {
+		if value, err = bsonValue(token.Value); err != nil {
+			return nil, pkgerrors.New("Invalid continue token")
+		}
+	}
+	after[field] = value
+
+	switch {
+	case value == nil && desc:
+		return after, nil
+	case value == nil:
+		return bson.M{"$or": bson.A{bson.M{field: bson.M{"$ne": nil}}, after}}, nil
+	case desc:
+		return bson.M{"$or": bson.A{bson.M{field: bson.M{"$lt": value}}, after, bson.M{field: nil}}}, nil
+	}
+	return bson.M{"$or": bson.A{bson.M{field: bson.M{"$gt": value}}, after}}, nil
+}
+
+// FindWithOptions method returns a page of the data stored for this key and
+// for this particular tag, filtered and sorted using the options
+func (m *MongoStore) FindWithOptions(ctx context.Context, coll string, key Key, tag string, opts FindOptions) ([][]byte, string, error) {
+	if !validateParams(coll, key, tag) {
+		return nil, "", pkgerrors.Errorf("db Find error: Mandatory fields are missing. Collection: %s, Key: %T %v, Tag: %s", coll, key, key, tag)
+	}
+
+	token, err := decodeContinueToken(opts.Continue)
+	if err != nil {
+		return nil, "", pkgerrors.Wrap(err, "db Find error")
+	}
+
+	c := getCollection(coll, m)
+
+	filter, err := m.findFilterWithKey(key)
+	if err != nil {
+		return nil, "", pkgerrors.Wrapf(err, "db Find error: Error finding filter with key %T %v", key, key)
+	}
+	for path, value := range opts.Filter {
+		filter["$and"] = append(filter["$and"].([]bson.M), fieldFilter(path, value, opts.TagTypes))
+	}
+	for path, r := range opts.TimeRanges {
+		filter["$and"] = append(filter["$and"].([]bson.M), timeRangeFilter(path, r, opts.TagTypes))
+	}
+
+	// The documents are always sorted, by _id last, so that the pages
+	// continue after the last document of the previous page
+	var sortField []string
+	desc := false
+	order := bson.D{}
+	if opts.Sort != "" {
+		var path string
+		path, desc = sortPath(opts.Sort)
+		sortField = bsonFieldPath(path, opts.TagTypes)
+		dir := 1
+		if desc {
+			dir = -1
+		}
+		order = append(order, bson.E{Key: strings.Join(sortField, "."), Value: dir})
+	}
+	order = append(order, bson.E{Key: "_id", Value: 1})
+
+	if token != nil {
+		after, err := continueFilter(token, strings.Join(sortField, "."), desc)
+		if err != nil {
+			return nil, "", pkgerrors.Wrap(err, "db Find error")
+		}
+		filter["$and"] = append(filter["$and"].([]bson.M), after)
+	}
+
+	// Find only the field requested, with the _id and the sort field of
+	// the continue token
+	projection := bson.D{
+		{tag, 1},
+		{"_id", 1},
+	}
+	if len(sortField) > 0 && sortField[0] != tag {
+		projection = append(projection, bson.E{Key: strings.Join(sortField, "."), Value: 1})
+	}
+	findOpts := options.Find().SetProjection(projection).SetSort(order)
+	if opts.Limit > 0 {
+		// read one more document to know if there is a next page
+		findOpts.SetLimit(opts.Limit + 1)
+	}
+
+	cursor, err := c.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, "", pkgerrors.Wrap(err, "db Find error")
+	}
+	defer cursorClose(ctx, cursor)
+
+	var result [][]byte
+	var last bson.Raw
+	for cursorNext(ctx, cursor) {
+		if opts.Limit > 0 && int64(len(result)) == opts.Limit {
+			// a document after the page
+			next, err := mongoContinueToken(last, sortField)
+			if err != nil {
+				return nil, "", pkgerrors.Wrap(err, "db Find error")
+			}
+			return result, next, nil
+		}
+		d := cursor.Current
+		switch d.Lookup(tag).Type {
+		case bson.TypeString:
+			result = append(result, []byte(d.Lookup(tag).StringValue()))
+		default:
+			result = append(result, d.Lookup(tag).Value)
+		}
+		last = append(bson.Raw{}, d...)
+	}
+	return result, "", nil
+}
+
+// mongoContinueToken returns the continue token of the page ending with the
+// document
+func mongoContinueToken(d bson.Raw, sortField []string) (string, error) {
+	var id interface{}
+	if err := d.Lookup("_id").Unmarshal(&id); err != nil {
+		return "", err
+	}
+	rawID, err := extJSONValue(id)
+	if err != nil {
+		return "", err
+	}
+
+	var value json.RawMessage
+	if len(sortField) > 0 {
+		var v interface{}
+		if rv, err := d.LookupErr(sortField...); err == nil {
+			if err := rv.Unmarshal(&v); err != nil {
+				return "", err
+			}
+		}
+		if value, err = extJSONValue(v); err != nil {
+			return "", err
+		}
+	}
+	return encodeContinueToken(value, rawID), nil
 }
 
 // RemoveAll method to removes all the documet matching key
 func (m *MongoStore) RemoveAll(ctx context.Context, coll string, key Key) error {
-	if !m.validateParams(coll, key) {
+	if !validateParams(coll, key) {
 		return pkgerrors.Errorf("db Remove error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
 	}
 	c := getCollection(coll, m)
@@ -806,7 +1058,7 @@ func (m *MongoStore) RemoveAll(ctx context.Context, coll string, key Key) error
 
 // Remove method to remove the documet by key if no child references
 func (m *MongoStore) Remove(ctx context.Context, coll string, key Key) error {
-	if !m.validateParams(coll, key) {
+	if !validateParams(coll, key) {
 		return pkgerrors.Errorf("db Remove error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
 	}
 
@@ -875,3 +1127,446 @@ func (m *MongoStore) RemoveTag(ctx context.Context, coll string, key Key, tag st
 
 	return nil
 }
+
+// mongoChangeEvent holds the fields of a change stream event used by Watch
+type mongoChangeEvent struct {
+	OperationType string   `bson:"operationType"`
+	DocumentKey   bson.Raw `bson:"documentKey"`
+	FullDocument  bson.Raw `bson:"fullDocument"`
+}
+
+// watchKey returns the key of the document if it matches the key prefix
+func watchKey(doc bson.Raw, prefix map[string]string) (map[string]string, bool) {
+	for k, v := range prefix {
+		if fv, ok := doc.Lookup(k).StringValueOK(); !ok || fv != v {
+			return nil, false
+		}
+	}
+	keyId, _ := doc.Lookup("keyId").StringValueOK()
+	key := make(map[string]string)
+	for _, f := range keyIdFields(keyId) {
+		key[f], _ = doc.Lookup(f).StringValueOK()
+	}
+	return key, true
+}
+
+// watchSnapshot returns the documents of the collection matching the key prefix
+func (m *MongoStore) watchSnapshot(ctx context.Context, c MongoCollection, prefix map[string]string) (map[string]watchDocument, error) {
+	filter := bson.M{}
+	for k, v := range prefix {
+		filter[k] = v
+	}
+
+	cursor, err := c.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursorClose(ctx, cursor)
+	docs := make(map[string]watchDocument)
+	for cursorNext(ctx, cursor) {
+		d := cursor.Current
+		key, _ := watchKey(d, prefix)
+		docs[d.Lookup("_id").String()] = watchDocument{key: key, content: d.String()}
+	}
+	return docs, nil
+}
+
+// Watch reports the changes to the document(s) matching the key prefix using
+// a change stream. Change streams are only available when MongoDB is deployed
+// as a replica set, otherwise the collection is polled for changes.
+func (m *MongoStore) Watch(ctx context.Context, coll string, key Key) (<-chan WatchEvent, error) {
+	if !validateParams(coll, key) {
+		return nil, pkgerrors.Errorf("db Watch error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
+	}
+
+	prefix, err := watchPrefix(key)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "db Watch error: Error finding filter with key %T %v", key, key)
+	}
+
+	c := getCollection(coll, m)
+
+	stream, err := c.Watch(ctx, mongo.Pipeline{}, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		log.Warn("Change streams are not available, polling for changes", log.Fields{"collection": coll, "error": err.Error()})
+		ch, err := pollWatch(ctx, func(ctx context.Context) (map[string]watchDocument, error) {
+			return m.watchSnapshot(ctx, c, prefix)
+		})
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db Watch error")
+		}
+		return ch, nil
+	}
+
+	// The documents already matching the prefix are needed to report their
+	// deletion, since the change event only has the identifier of the document
+	known, err := m.watchSnapshot(ctx, c, prefix)
+	if err != nil {
+		stream.Close(ctx)
+		return nil, pkgerrors.Wrap(err, "db Watch error")
+	}
+
+	ch := make(chan WatchEvent)
+	go func() {
+		defer close(ch)
+		defer stream.Close(context.Background())
+		for stream.Next(ctx) {
+			var e mongoChangeEvent
+			if err := stream.Decode(&e); err != nil {
+				log.Error("Error decoding change event", log.Fields{"collection": coll, "error": err.Error()})
+				continue
+			}
+			id := e.DocumentKey.Lookup("_id").String()
+
+			var ev WatchEvent
+			switch e.OperationType {
+			case "insert", "update", "replace":
+				if e.FullDocument == nil {
+					// the document was removed before the update was looked up
+					continue
+				}
+				key, ok := watchKey(e.FullDocument, prefix)
+				if !ok {
+					continue
+				}
+				ev = WatchEvent{Type: WatchEventUpdate, Key: key}
+				if _, ok := known[id]; !ok {
+					ev.Type = WatchEventInsert
+				}
+				known[id] = watchDocument{key: key}
+			case "delete":
+				doc, ok := known[id]
+				if !ok {
+					continue
+				}
+				delete(known, id)
+				ev = WatchEvent{Type: WatchEventDelete, Key: doc.key}
+			default:
+				continue
+			}
+
+			if !sendWatchEvent(ctx, ch, ev) {
+				return
+			}
+		}
+		if err := stream.Err(); err != nil && ctx.Err() == nil {
+			log.Error("Error reading change stream", log.Fields{"collection": coll, "error": err.Error()})
+		}
+	}()
+
+	return ch, nil
+}
+
+// Reencrypt encrypts the encrypted values of all the collections again with
+// the current key encryption key. Each value is updated only if it didn't
+// change since it was read.
+func (m *MongoStore) Reencrypt(ctx context.Context, oe utils.IObjectEncryptor) (ReencryptResult, error) {
+	var result ReencryptResult
+	colls, err := m.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return result, pkgerrors.Wrap(err, "db Reencrypt error: Error listing the collections")
+	}
+	for _, coll := range colls {
+		if strings.HasPrefix(coll, "system.") {
+			continue
+		}
+		c := getCollection(coll, m)
+		cursor, err := c.Find(ctx, bson.M{})
+		if err != nil {
+			return result, pkgerrors.Wrapf(err, "db Reencrypt error: Error reading the collection %s", coll)
+		}
+		for cursorNext(ctx, cursor) {
+			var doc bson.M
+			if err := bson.Unmarshal(cursor.Current, &doc); err != nil {
+				result.Failed++
+				continue
+			}
+			id := doc["_id"]
+			delete(doc, "_id")
+			values, failed := reencryptValues(oe, doc, "")
+			result.Failed += failed
+			changed := false
+			for _, v := range values {
+				filter, update := mongoReencryptFilter(id, v)
+				res, err := c.UpdateOne(ctx, filter, update)
+				if err != nil {
+					log.Warn("Error storing a re-encrypted value", log.Fields{"collection": coll, "path": v.path, "error": err})
+					result.Failed++
+					continue
+				}
+				if res.ModifiedCount > 0 {
+					result.Values++
+					changed = true
+				}
+			}
+			if changed {
+				result.Documents++
+			}
+		}
+		cursorClose(ctx, cursor)
+	}
+	return result, nil
+}
+
+// DocumentFormat returns the encoding of the tags of the documents
+func (m *MongoStore) DocumentFormat() string {
+	return "mongo"
+}
+
+// mongoReference is the representation of a ReferenceEntry in a MongoStore
+type mongoReference struct {
+	Key   map[string]string `bson:"key"`
+	KeyId string            `bson:"keyid"`
+}
+
+// FindDocuments returns the documents matching the key prefix, with their
+// tags as canonical extended JSON
+func (m *MongoStore) FindDocuments(ctx context.Context, coll string, key Key) ([]Document, error) {
+	if !validateParams(coll, key) {
+		return nil, pkgerrors.Errorf("db FindDocuments error: Mandatory fields are missing. Collection: %s, Key: %T %v", coll, key, key)
+	}
+
+	prefix, err := watchPrefix(key)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "db FindDocuments error: Error finding filter with key %T %v", key, key)
+	}
+	filter := bson.M{}
+	for k, v := range prefix {
+		filter[k] = v
+	}
+
+	c := getCollection(coll, m)
+	cursor, err := c.Find(ctx, filter)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "db FindDocuments error")
+	}
+	defer cursorClose(ctx, cursor)
+
+	var docs []Document
+	for cursorNext(ctx, cursor) {
+		doc, err := mongoDocument(cursor.Current)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "db FindDocuments error")
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// mongoDocument splits a document in its key fields, keyId, references and tags
+func mongoDocument(raw bson.Raw) (Document, error) {
+	doc := Document{Fields: make(map[string]string)}
+	doc.KeyId, _ = raw.Lookup("keyId").StringValueOK()
+	keyFields := make(map[string]struct{})
+	for _, f := range keyIdFields(doc.KeyId) {
+		keyFields[f] = struct{}{}
+	}
+
+	elems, err := raw.Elements()
+	if err != nil {
+		return doc, err
+	}
+	tags := bson.D{}
+	for _, e := range elems {
+		name := e.Key()
+		switch name {
+		case "_id", "keyId":
+			continue
+		case "references":
+			var refs []mongoReference
+			if err := e.Value().Unmarshal(&refs); err != nil {
+				return doc, pkgerrors.Wrap(err, "Error Unmarshalling the references")
+			}
+			doc.References = make([]ReferenceEntry, 0, len(refs))
+			for _, r := range refs {
+				doc.References = append(doc.References, ReferenceEntry{Key: r.Key, KeyId: r.KeyId})
+			}
+			continue
+		}
+		if _, ok := keyFields[name]; ok {
+			doc.Fields[name], _ = e.Value().StringValueOK()
+			continue
+		}
+		tags = append(tags, bson.E{Key: name, Value: e.Value()})
+	}
+
+	data, err := bson.MarshalExtJSON(tags, true, false)
+	if err != nil {
+		return doc, pkgerrors.Wrap(err, "Error Marshalling the tags")
+	}
+	if err := json.Unmarshal(data, &doc.Tags); err != nil {
+		return doc, pkgerrors.Wrap(err, "Error Unmarshalling the tags")
+	}
+	return doc, nil
+}
+
+// InsertDocument inserts the document, or updates the document with the same key
+func (m *MongoStore) InsertDocument(ctx context.Context, coll string, doc Document) error {
+	key := doc.Key()
+	if !validateParams(coll, key) || len(doc.Tags) == 0 {
+		return pkgerrors.Errorf("db InsertDocument error: Mandatory fields are missing. Collection: %s, Key: %v", coll, key)
+	}
+
+	filter, err := m.findFilter(key)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "db InsertDocument error: Error finding filter with key %v", key)
+	}
+
+	data, err := json.Marshal(doc.Tags)
+	if err != nil {
+		return pkgerrors.Wrap(err, "db InsertDocument error: Error Marshalling the tags")
+	}
+	var set bson.D
+	if err := bson.UnmarshalExtJSON(data, true, &set); err != nil {
+		return pkgerrors.Wrap(err, "db InsertDocument error: Error Unmarshalling the tags")
+	}
+	for k, v := range doc.Fields {
+		set = append(set, bson.E{Key: k, Value: v})
+	}
+	set = append(set, bson.E{Key: "keyId", Value: doc.KeyId})
+	if _, ok := doc.Tags["data"]; ok {
+		refs := make([]mongoReference, 0, len(doc.References))
+		for _, r := range doc.References {
+			k, err := keyToMap(r.Key)
+			if err != nil {
+				return pkgerrors.Wrap(err, "db InsertDocument error")
+			}
+			refs = append(refs, mongoReference{Key: k, KeyId: r.KeyId})
+		}
+		set = append(set, bson.E{Key: "references", Value: refs})
+	}
+
+	_, err = decodeBytes(
+		getCollection(coll, m).FindOneAndUpdate(
+			ctx,
+			filter,
+			bson.D{{Key: "$set", Value: set}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)))
+	if err != nil {
+		return pkgerrors.Wrap(err, "db InsertDocument error")
+	}
+
+	return nil
+}
+
+// Snapshot calls fn with all the documents of all the collections, as
+// canonical extended JSON. The documents are read from a snapshot of the
+// database if it supports the snapshot reads, i.e. if it is a replica set,
+// and one collection after the other otherwise.
+func (m *MongoStore) Snapshot(ctx context.Context, fn func(coll string, doc json.RawMessage) error) (bool, error) {
+	sess, err := m.db.Client().StartSession(options.Session().SetSnapshot(true))
+	if err == nil {
+		defer sess.EndSession(ctx)
+		read := false
+		err = mongo.WithSession(ctx, sess, func(sc mongo.SessionContext) error {
+			return m.snapshot(sc, func(coll string, doc json.RawMessage) error {
+				read = true
+				return fn(coll, doc)
+			})
+		})
+		if err == nil {
+			return true, nil
+		}
+		// the snapshot reads are refused before any document is read
+		if read {
+			return false, pkgerrors.Wrap(err, "db Snapshot error")
+		}
+	}
+	log.Warn("The database doesn't support the snapshot reads, reading the collections one after the other", log.Fields{"error": err})
+	if err := m.snapshot(ctx, fn); err != nil {
+		return false, pkgerrors.Wrap(err, "db Snapshot error")
+	}
+	return false, nil
+}
+
+// snapshot calls fn with all the documents of all the collections
+func (m *MongoStore) snapshot(ctx context.Context, fn func(coll string, doc json.RawMessage) error) error {
+	colls, err := m.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error listing the collections")
+	}
+	for _, coll := range colls {
+		if strings.HasPrefix(coll, "system.") {
+			continue
+		}
+		cursor, err := getCollection(coll, m).Find(ctx, dataFilter())
+		if err != nil {
+			return pkgerrors.Wrapf(err, "Error reading the collection %s", coll)
+		}
+		for cursorNext(ctx, cursor) {
+			doc, err := bson.MarshalExtJSON(cursor.Current, true, false)
+			if err != nil {
+				cursorClose(ctx, cursor)
+				return pkgerrors.Wrapf(err, "Error Marshalling a document of the collection %s", coll)
+			}
+			if err := fn(coll, doc); err != nil {
+				cursorClose(ctx, cursor)
+				return err
+			}
+		}
+		err = cursor.Err()
+		cursorClose(ctx, cursor)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "Error reading the collection %s", coll)
+		}
+	}
+	return nil
+}
+
+// Empty returns true if no collection holds a document
+func (m *MongoStore) Empty(ctx context.Context) (bool, error) {
+	colls, err := m.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "db Empty error: Error listing the collections")
+	}
+	for _, coll := range colls {
+		if strings.HasPrefix(coll, "system.") {
+			continue
+		}
+		n, err := getCollection(coll, m).CountDocuments(ctx, dataFilter(), options.Count().SetLimit(1))
+		if err != nil {
+			return false, pkgerrors.Wrapf(err, "db Empty error: Error counting the documents of %s", coll)
+		}
+		if n > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// RestoreCollection inserts the documents in the collection, with their _id
+func (m *MongoStore) RestoreCollection(ctx context.Context, coll string, docs []json.RawMessage) error {
+	c := getCollection(coll, m)
+	for _, doc := range docs {
+		var d bson.D
+		if err := bson.UnmarshalExtJSON(doc, true, &d); err != nil {
+			return pkgerrors.Wrapf(err, "db RestoreCollection error: Error Unmarshalling a document of %s", coll)
+		}
+		if _, err := c.InsertOne(ctx, d); err != nil {
+			return pkgerrors.Wrapf(err, "db RestoreCollection error: Error inserting a document in %s", coll)
+		}
+	}
+	return nil
+}
+
+// Clear deletes the documents of all the collections
+func (m *MongoStore) Clear(ctx context.Context) error {
+	colls, err := m.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return pkgerrors.Wrap(err, "db Clear error: Error listing the collections")
+	}
+	for _, coll := range colls {
+		if strings.HasPrefix(coll, "system.") {
+			continue
+		}
+		if _, err := getCollection(coll, m).DeleteMany(ctx, dataFilter()); err != nil {
+			return pkgerrors.Wrapf(err, "db Clear error: Error deleting the documents of %s", coll)
+		}
+	}
+	return nil
+}
+
+// dataFilter matches the documents but the segments of the referential schema
+func dataFilter() bson.M {
+	return bson.M{"keyId": bson.M{"$ne": schemaKeyId()}}
+}
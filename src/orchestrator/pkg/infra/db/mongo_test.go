@@ -65,3 +65,8 @@ func (c *mockCollection) CountDocuments(ctx context.Context, filter interface{},
 	opts ...*options.CountOptions) (int64, error) {
 	return 1, c.Err
 }
+
+func (c *mockCollection) Watch(ctx context.Context, pipeline interface{},
+	opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	return nil, c.Err
+}
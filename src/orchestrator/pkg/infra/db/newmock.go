@@ -6,6 +6,7 @@ package db
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	pkgerrors "github.com/pkg/errors"
@@ -90,12 +91,12 @@ func (m *NewMockDB) Unmarshal(inp []byte, out interface{}) error {
 	return m.MarshalErr
 }
 
-func (m *NewMockDB) Find(ctx context.Context, table string, key Key, tag string) ([][]byte, error) {
+// findItems returns the items matching the key, like Find, with their
+// position as id, so that the items are listed in the order of insertion
+func (m *NewMockDB) findItems(key Key) []findDocument {
 
 	tkey, _ := createKeyField(key)
 
-	newr := make([][]byte, 0)
-
 	// Make match key
 	matchkey := make(map[string]string)
 	var n map[string]string
@@ -113,8 +114,8 @@ func (m *NewMockDB) Find(ctx context.Context, table string, key Key, tag string)
 		matchkey["key"] = tkey
 	}
 
-	cnt := 0
-	for _, item := range m.Items {
+	var items []findDocument
+	for i, item := range m.Items {
 		for _, v := range item {
 			// check if matchkey matches this item
 			notfound := false
@@ -136,11 +137,22 @@ func (m *NewMockDB) Find(ctx context.Context, table string, key Key, tag string)
 				break
 			}
 
-			// this items key matches - add to the return list if tag is present
-			if _, ok := v[tag]; ok {
-				newr = append(newr, v[tag])
-				cnt++
-			}
+			items = append(items, findDocument{id: fmt.Sprintf("%010d", i), tags: v})
+		}
+	}
+	return items
+}
+
+func (m *NewMockDB) Find(ctx context.Context, table string, key Key, tag string) ([][]byte, error) {
+
+	newr := make([][]byte, 0)
+
+	cnt := 0
+	for _, item := range m.findItems(key) {
+		// this items key matches - add to the return list if tag is present
+		if v, ok := item.tags[tag]; ok {
+			newr = append(newr, v)
+			cnt++
 		}
 	}
 	if cnt > 0 {
@@ -154,6 +166,30 @@ func (m *NewMockDB) Find(ctx context.Context, table string, key Key, tag string)
 	}
 }
 
+func (m *NewMockDB) FindWithOptions(ctx context.Context, table string, key Key, tag string, opts FindOptions) ([][]byte, string, error) {
+	if m.Err != nil {
+		return nil, "", m.Err
+	}
+
+	var items []findDocument
+	for _, item := range m.findItems(key) {
+		if _, ok := item.tags[tag]; ok {
+			items = append(items, item)
+		}
+	}
+
+	items, next, err := applyFindOptions(items, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	newr := make([][]byte, 0, len(items))
+	for _, item := range items {
+		newr = append(newr, item.tags[tag])
+	}
+	return newr, next, nil
+}
+
 func (m *NewMockDB) Remove(ctx context.Context, table string, key Key) error {
 	jkey, _ := json.Marshal(key)
 	str := (string(jkey))
@@ -169,10 +205,33 @@ func (m *NewMockDB) Remove(ctx context.Context, table string, key Key) error {
 	return m.Err
 }
 
+// RemoveAll removes the items of the key, which must be complete
 func (m *NewMockDB) RemoveAll(ctx context.Context, table string, key Key) error {
+	jkey, _ := json.Marshal(key)
+	str := (string(jkey))
+	items := m.Items[:0]
+	for _, item := range m.Items {
+		if _, ok := item[str]; !ok {
+			items = append(items, item)
+		}
+	}
+	m.Items = items
 	return m.Err
 }
 
 func (m *NewMockDB) RemoveTag(ctx context.Context, table string, key Key, tag string) error {
 	return m.Err
 }
+
+func (m *NewMockDB) Watch(ctx context.Context, table string, key Key) (<-chan WatchEvent, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	prefix, err := watchPrefix(key)
+	if err != nil {
+		return nil, err
+	}
+	return pollWatch(ctx, func(ctx context.Context) (map[string]watchDocument, error) {
+		return mockWatchSnapshot(m.Items, prefix)
+	})
+}
@@ -0,0 +1,446 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// FindOptions controls the filtering, sorting and paging of FindWithOptions.
+//
+// Field paths are dotted paths into the document, starting with the tag
+// holding the field, using the json names of the fields. For example,
+// "data.metadata.name" is the name in the metadata of the "data" tag.
+// A negative integer element indexes an array from its end, e.g.
+// "stateInfo.actions.-1.state" is the state of the last action.
+type FindOptions struct {
+	// Filter selects the documents where the field at each path is a string
+	// with the value
+	Filter map[string]string
+	// TimeRanges selects the documents where the time at each path is in
+	// the range. Array elements can't be indexed from the end.
+	TimeRanges map[string]TimeRange
+	// Sort is the path of the field to sort by, in descending order if
+	// it starts with '-'. Array elements can't be indexed from the end.
+	Sort string
+	// Limit is the maximum number of values returned, or 0 for all of them
+	Limit int64
+	// Continue is the token returned with the previous page of values
+	Continue string
+	// TagTypes has a value of the type stored in each tag used by Filter
+	// and Sort, so that stores that don't encode the documents as json
+	// can find the name of the fields
+	TagTypes map[string]interface{}
+}
+
+// TimeRange is the range of times from From, included, to To, excluded.
+// A zero time leaves that end of the range open.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains returns whether the time is in the range
+func (r TimeRange) Contains(t time.Time) bool {
+	if !r.From.IsZero() && t.Before(r.From) {
+		return false
+	}
+	if !r.To.IsZero() && !t.Before(r.To) {
+		return false
+	}
+	return true
+}
+
+// FieldSelectors maps the names of the fields that can be used to filter and
+// sort a list of resources to their path in the documents (see FindOptions)
+type FieldSelectors struct {
+	Paths map[string]string
+	// TagTypes has a value of the type stored in each tag of the Paths
+	TagTypes map[string]interface{}
+}
+
+// Resolve converts the options where Filter and Sort use the names of the
+// selectors to options that use the paths of the fields
+func (s FieldSelectors) Resolve(opts FindOptions) (FindOptions, error) {
+	resolved := FindOptions{
+		Limit:    opts.Limit,
+		Continue: opts.Continue,
+		TagTypes: s.TagTypes,
+	}
+
+	if len(opts.TimeRanges) > 0 {
+		resolved.TimeRanges = make(map[string]TimeRange, len(opts.TimeRanges))
+	}
+	for name, r := range opts.TimeRanges {
+		path, ok := s.Paths[name]
+		if !ok || strings.Contains(path, ".-") {
+			return FindOptions{}, pkgerrors.Errorf("Invalid time range field: %s", name)
+		}
+		resolved.TimeRanges[path] = r
+	}
+
+	if len(opts.Filter) > 0 {
+		resolved.Filter = make(map[string]string, len(opts.Filter))
+	}
+	for name, value := range opts.Filter {
+		path, ok := s.Paths[name]
+		if !ok {
+			return FindOptions{}, pkgerrors.Errorf("Invalid filter field: %s", name)
+		}
+		resolved.Filter[path] = value
+	}
+
+	if opts.Sort != "" {
+		name := strings.TrimPrefix(opts.Sort, "-")
+		path, ok := s.Paths[name]
+		if !ok || strings.Contains(path, ".-") {
+			return FindOptions{}, pkgerrors.Errorf("Invalid sort field: %s", name)
+		}
+		if strings.HasPrefix(opts.Sort, "-") {
+			path = "-" + path
+		}
+		resolved.Sort = path
+	}
+
+	return resolved, resolved.Validate()
+}
+
+// Validate checks the limit and the continue token of the options
+func (o FindOptions) Validate() error {
+	if o.Limit < 0 {
+		return pkgerrors.Errorf("Invalid limit: %d", o.Limit)
+	}
+	_, err := decodeContinueToken(o.Continue)
+	return err
+}
+
+// continueToken is the position of the last value of a page: the value of
+// its sort field and its id. The next page starts after it in the order of
+// the sort field then of the id, so that the values inserted or deleted
+// between the pages don't shift the next page.
+type continueToken struct {
+	// Value is the json value of the sort field, if any
+	Value json.RawMessage `json:"v,omitempty"`
+	// ID is the json id of the value in the store
+	ID json.RawMessage `json:"id"`
+}
+
+// encodeContinueToken returns the token of the page after the value with the
+// sort field value and the id, encoded in json
+func encodeContinueToken(value, id []byte) string {
+	b, _ := json.Marshal(continueToken{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeContinueToken returns the position of the token, or nil for the
+// first page
+func decodeContinueToken(token string) (*continueToken, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, pkgerrors.New("Invalid continue token")
+	}
+	var t continueToken
+	if err := json.Unmarshal(b, &t); err != nil || len(t.ID) == 0 {
+		return nil, pkgerrors.New("Invalid continue token")
+	}
+	return &t, nil
+}
+
+// sortPath returns the path of the sort field and whether the order is descending
+func sortPath(s string) (string, bool) {
+	if strings.HasPrefix(s, "-") {
+		return s[1:], true
+	}
+	return s, false
+}
+
+// jsonFieldValue returns the value of the field at the path of a document
+// whose tags are json encoded, and whether the field exists
+func jsonFieldValue(tags map[string][]byte, path string) (interface{}, bool) {
+	elems := strings.Split(path, ".")
+	raw, ok := tags[elems[0]]
+	if !ok {
+		return nil, false
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, false
+	}
+
+	for _, e := range elems[1:] {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if v, ok = t[e]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(e)
+			if err != nil {
+				return nil, false
+			}
+			if i < 0 {
+				i += len(t)
+			}
+			if i < 0 || i >= len(t) {
+				return nil, false
+			}
+			v = t[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// jsonFieldString returns the string form of a json value, used to sort
+// values of different types
+func jsonFieldString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case nil:
+		return ""
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// jsonFieldTime returns the time of a json value encoded in RFC 3339 format
+func jsonFieldTime(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
+
+// jsonFieldLess orders numbers and times by value and any other values by
+// their string form
+func jsonFieldLess(a, b interface{}) bool {
+	fa, aok := a.(float64)
+	fb, bok := b.(float64)
+	if aok && bok {
+		return fa < fb
+	}
+	ta, aok := jsonFieldTime(a)
+	tb, bok := jsonFieldTime(b)
+	if aok && bok {
+		return ta.Before(tb)
+	}
+	return jsonFieldString(a) < jsonFieldString(b)
+}
+
+// findDocument is a document whose tags are json encoded, with its id
+type findDocument struct {
+	id   string
+	tags map[string][]byte
+}
+
+// compareDocuments orders the documents by the value of the sort field, if
+// any, then by their id, so that the pages of a list don't depend on the
+// position of the documents
+func compareDocuments(va interface{}, ida string, vb interface{}, idb string, desc bool) int {
+	switch {
+	case jsonFieldLess(va, vb):
+		if desc {
+			return 1
+		}
+		return -1
+	case jsonFieldLess(vb, va):
+		if desc {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(ida, idb)
+}
+
+// applyFindOptions filters, sorts and pages documents whose tags are json
+// encoded, for the stores that implement FindWithOptions in memory. The
+// documents are sorted by the sort field, if any, then by their id. It
+// returns the page of documents and the continue token of the next page,
+// if any.
+func applyFindOptions(docs []findDocument, opts FindOptions) ([]findDocument, string, error) {
+	token, err := decodeContinueToken(opts.Continue)
+	if err != nil {
+		return nil, "", err
+	}
+	path, desc := sortPath(opts.Sort)
+	sortValue := func(d findDocument) interface{} {
+		if path == "" {
+			return nil
+		}
+		v, _ := jsonFieldValue(d.tags, path)
+		return v
+	}
+
+	var after interface{}
+	var afterID string
+	if token != nil {
+		if len(token.Value) > 0 && json.Unmarshal(token.Value, &after) != nil {
+			return nil, "", pkgerrors.New("Invalid continue token")
+		}
+		if json.Unmarshal(token.ID, &afterID) != nil {
+			return nil, "", pkgerrors.New("Invalid continue token")
+		}
+	}
+
+	var result []findDocument
+	for _, doc := range docs {
+		match := true
+		for path, value := range opts.Filter {
+			v, _ := jsonFieldValue(doc.tags, path)
+			if s, ok := v.(string); !ok || s != value {
+				match = false
+				break
+			}
+		}
+		for path, r := range opts.TimeRanges {
+			if !match {
+				break
+			}
+			v, _ := jsonFieldValue(doc.tags, path)
+			t, ok := jsonFieldTime(v)
+			match = ok && r.Contains(t)
+		}
+		if match && token != nil {
+			match = compareDocuments(sortValue(doc), doc.id, after, afterID, desc) > 0
+		}
+		if match {
+			result = append(result, doc)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return compareDocuments(sortValue(result[i]), result[i].id, sortValue(result[j]), result[j].id, desc) < 0
+	})
+
+	next := ""
+	if opts.Limit > 0 && int64(len(result)) > opts.Limit {
+		result = result[:opts.Limit]
+		last := result[len(result)-1]
+		var value []byte
+		if path != "" {
+			value, _ = json.Marshal(sortValue(last))
+		}
+		id, _ := json.Marshal(last.id)
+		next = encodeContinueToken(value, id)
+	}
+	return result, next, nil
+}
+
+// ApplyFindOptions filters, sorts and pages json encoded values in memory,
+// with the same semantics as FindWithOptions. The paths of the options start
+// with a field of the values, e.g. "metadata.name", rather than with a tag.
+func ApplyFindOptions(values [][]byte, opts FindOptions) ([][]byte, string, error) {
+	const tag = "value"
+	prefixed := FindOptions{
+		Limit:    opts.Limit,
+		Continue: opts.Continue,
+	}
+	if len(opts.Filter) > 0 {
+		prefixed.Filter = make(map[string]string, len(opts.Filter))
+	}
+	for path, value := range opts.Filter {
+		prefixed.Filter[tag+"."+path] = value
+	}
+	if len(opts.TimeRanges) > 0 {
+		prefixed.TimeRanges = make(map[string]TimeRange, len(opts.TimeRanges))
+	}
+	for path, r := range opts.TimeRanges {
+		prefixed.TimeRanges[tag+"."+path] = r
+	}
+	if opts.Sort != "" {
+		path, desc := sortPath(opts.Sort)
+		prefixed.Sort = tag + "." + path
+		if desc {
+			prefixed.Sort = "-" + prefixed.Sort
+		}
+	}
+
+	// the values have no id, they are their own id
+	docs := make([]findDocument, len(values))
+	for i, v := range values {
+		docs[i] = findDocument{id: string(v), tags: map[string][]byte{tag: v}}
+	}
+	docs, next, err := applyFindOptions(docs, prefixed)
+	if err != nil {
+		return nil, "", err
+	}
+	result := make([][]byte, len(docs))
+	for i, d := range docs {
+		result[i] = d.tags[tag]
+	}
+	return result, next, nil
+}
+
+// bsonFieldPath converts a path using the json names of the fields to the
+// path of the fields in a document encoded by the default bson codec, which
+// uses the bson tag or the lowercased name of the struct fields
+func bsonFieldPath(path string, tagTypes map[string]interface{}) []string {
+	elems := strings.Split(path, ".")
+
+	var t reflect.Type
+	if v, ok := tagTypes[elems[0]]; ok && v != nil {
+		t = reflect.TypeOf(v)
+	}
+
+	for i := 1; i < len(elems); i++ {
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil {
+			elems[i] = strings.ToLower(elems[i])
+			continue
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			f, ok := jsonStructField(t, elems[i])
+			if !ok {
+				elems[i] = strings.ToLower(elems[i])
+				t = nil
+				continue
+			}
+			name := strings.Split(f.Tag.Get("bson"), ",")[0]
+			if name == "" {
+				name = strings.ToLower(f.Name)
+			}
+			elems[i] = name
+			t = f.Type
+		case reflect.Map, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			t = nil
+		}
+	}
+	return elems
+}
+
+// jsonStructField returns the field of the struct type with the json name
+func jsonStructField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if jsonName == "" {
+			jsonName = f.Name
+		}
+		if jsonName == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
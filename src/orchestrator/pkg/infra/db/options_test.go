@@ -0,0 +1,323 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type testOptionsSpec struct {
+	Version string `json:"compositeAppVersion"`
+	Cloud   string `json:"logicalCloud" bson:"cloud"`
+}
+
+type testOptionsData struct {
+	Metadata map[string]string `json:"metadata"`
+	Spec     testOptionsSpec   `json:"spec"`
+	Created  time.Time         `json:"created" bson:"createdAt"`
+}
+
+type testOptionsState struct {
+	Actions []struct {
+		State string `json:"state"`
+	} `json:"actions"`
+}
+
+func testOptionsDocs() []findDocument {
+	return []findDocument{
+		{id: "1", tags: map[string][]byte{
+			"data":      []byte(`{"metadata":{"name":"b"},"spec":{"logicalCloud":"lc1","size":10},"created":"2022-05-02T10:00:00Z"}`),
+			"stateInfo": []byte(`{"actions":[{"state":"Created"},{"state":"Instantiated"}]}`),
+		}},
+		{id: "2", tags: map[string][]byte{
+			"data":      []byte(`{"metadata":{"name":"c"},"spec":{"logicalCloud":"lc2","size":2},"created":"2022-05-03T10:00:00.5+02:00"}`),
+			"stateInfo": []byte(`{"actions":[{"state":"Created"}]}`),
+		}},
+		{id: "3", tags: map[string][]byte{
+			"data":      []byte(`{"metadata":{"name":"a"},"spec":{"logicalCloud":"lc1","size":1},"created":"2022-05-01T10:00:00.25Z"}`),
+			"stateInfo": []byte(`{"actions":[{"state":"Instantiated"},{"state":"Terminated"}]}`),
+		}},
+	}
+}
+
+func testOptionsNames(t *testing.T, docs []findDocument) []string {
+	var names []string
+	for _, d := range docs {
+		v, ok := jsonFieldValue(d.tags, "data.metadata.name")
+		if !ok {
+			t.Fatalf("Document without name %s", string(d.tags["data"]))
+		}
+		names = append(names, v.(string))
+	}
+	return names
+}
+
+func TestApplyFindOptions(t *testing.T) {
+	testCases := []struct {
+		label    string
+		opts     FindOptions
+		expected []string
+		paged    bool
+	}{
+		{
+			label:    "No options",
+			expected: []string{"b", "c", "a"},
+		},
+		{
+			label:    "Filter on data",
+			opts:     FindOptions{Filter: map[string]string{"data.spec.logicalCloud": "lc1"}},
+			expected: []string{"b", "a"},
+		},
+		{
+			label:    "Filter on the last action",
+			opts:     FindOptions{Filter: map[string]string{"stateInfo.actions.-1.state": "Created"}},
+			expected: []string{"c"},
+		},
+		{
+			label:    "Filter on a number",
+			opts:     FindOptions{Filter: map[string]string{"data.spec.size": "10"}},
+			expected: nil,
+		},
+		{
+			label: "Time range",
+			opts: FindOptions{TimeRanges: map[string]TimeRange{"data.created": {
+				From: time.Date(2022, 5, 1, 10, 0, 0, 250000000, time.UTC),
+				To:   time.Date(2022, 5, 3, 8, 0, 0, 0, time.UTC),
+			}}},
+			expected: []string{"b", "a"},
+		},
+		{
+			label:    "Open time range",
+			opts:     FindOptions{TimeRanges: map[string]TimeRange{"data.created": {From: time.Date(2022, 5, 2, 0, 0, 0, 0, time.UTC)}}},
+			expected: []string{"b", "c"},
+		},
+		{
+			label:    "Sort by time",
+			opts:     FindOptions{Sort: "data.created"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			label:    "Sort by name",
+			opts:     FindOptions{Sort: "data.metadata.name"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			label:    "Sort by size descending",
+			opts:     FindOptions{Sort: "-data.spec.size"},
+			expected: []string{"b", "c", "a"},
+		},
+		{
+			label:    "First page",
+			opts:     FindOptions{Sort: "data.metadata.name", Limit: 2},
+			expected: []string{"a", "b"},
+			paged:    true,
+		},
+		{
+			label:    "Last page",
+			opts:     FindOptions{Sort: "data.metadata.name", Limit: 2, Continue: encodeContinueToken([]byte(`"b"`), []byte(`"1"`))},
+			expected: []string{"c"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			docs, next, err := applyFindOptions(testOptionsDocs(), testCase.opts)
+			if err != nil {
+				t.Fatalf("applyFindOptions returned an error (%s)", err)
+			}
+			got := testOptionsNames(t, docs)
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Fatalf("applyFindOptions returned %v, expected %v", got, testCase.expected)
+			}
+			if (next != "") != testCase.paged {
+				t.Fatalf("applyFindOptions returned continue token %q", next)
+			}
+		})
+	}
+}
+
+func TestApplyFindOptionsContinue(t *testing.T) {
+	docs := testOptionsDocs()
+	for _, opts := range []FindOptions{{Limit: 1}, {Sort: "-data.spec.size", Limit: 1}} {
+		page, next, err := applyFindOptions(docs, opts)
+		if err != nil || next == "" {
+			t.Fatalf("applyFindOptions returned (%q, %v)", next, err)
+		}
+		first := testOptionsNames(t, page)[0]
+
+		// the first document is deleted and a document is inserted before it
+		inserted := findDocument{id: "0", tags: map[string][]byte{"data": []byte(`{"metadata":{"name":"d"},"spec":{"size":20}}`)}}
+		changed := []findDocument{inserted}
+		for _, d := range docs {
+			if testOptionsNames(t, []findDocument{d})[0] != first {
+				changed = append(changed, d)
+			}
+		}
+
+		opts.Continue = next
+		opts.Limit = 0
+		page, _, err = applyFindOptions(changed, opts)
+		if err != nil {
+			t.Fatalf("applyFindOptions returned an error (%s)", err)
+		}
+		got := testOptionsNames(t, page)
+		if len(got) != 2 || got[0] == first || got[1] == first {
+			t.Fatalf("applyFindOptions of %v returned the next page %v after %s", opts, got, first)
+		}
+	}
+}
+
+func TestContinueFilter(t *testing.T) {
+	id := primitive.NewObjectID()
+	rawID, _ := extJSONValue(id)
+	value, _ := extJSONValue("c1")
+	null, _ := extJSONValue(nil)
+	after := bson.M{"_id": bson.M{"$gt": id}, "data.metadata.name": "c1"}
+
+	testCases := []struct {
+		label    string
+		token    *continueToken
+		field    string
+		desc     bool
+		expected bson.M
+	}{
+		{
+			label:    "No sort",
+			token:    &continueToken{ID: rawID},
+			expected: bson.M{"_id": bson.M{"$gt": id}},
+		},
+		{
+			label:    "Ascending",
+			token:    &continueToken{Value: value, ID: rawID},
+			field:    "data.metadata.name",
+			expected: bson.M{"$or": bson.A{bson.M{"data.metadata.name": bson.M{"$gt": "c1"}}, after}},
+		},
+		{
+			label: "Descending",
+			token: &continueToken{Value: value, ID: rawID},
+			field: "data.metadata.name",
+			desc:  true,
+			expected: bson.M{"$or": bson.A{bson.M{"data.metadata.name": bson.M{"$lt": "c1"}}, after,
+				bson.M{"data.metadata.name": nil}}},
+		},
+		{
+			label: "Missing value",
+			token: &continueToken{Value: null, ID: rawID},
+			field: "data.metadata.name",
+			expected: bson.M{"$or": bson.A{bson.M{"data.metadata.name": bson.M{"$ne": nil}},
+				bson.M{"_id": bson.M{"$gt": id}, "data.metadata.name": nil}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			got, err := continueFilter(testCase.token, testCase.field, testCase.desc)
+			if err != nil {
+				t.Fatalf("continueFilter returned an error (%s)", err)
+			}
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Fatalf("continueFilter returned %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFieldSelectorsResolve(t *testing.T) {
+	selectors := FieldSelectors{
+		Paths: map[string]string{
+			"name":  "data.metadata.name",
+			"state": "stateInfo.actions.-1.state",
+		},
+	}
+
+	opts, err := selectors.Resolve(FindOptions{Filter: map[string]string{"state": "Created"}, Sort: "-name", Limit: 5})
+	if err != nil {
+		t.Fatalf("Resolve returned an error (%s)", err)
+	}
+	expected := FindOptions{Filter: map[string]string{"stateInfo.actions.-1.state": "Created"}, Sort: "-data.metadata.name", Limit: 5}
+	if !reflect.DeepEqual(opts, expected) {
+		t.Fatalf("Resolve returned %v, expected %v", opts, expected)
+	}
+
+	errorCases := []struct {
+		opts FindOptions
+		err  string
+	}{
+		{FindOptions{Filter: map[string]string{"label": "edge"}}, "Invalid filter field"},
+		{FindOptions{Sort: "state"}, "Invalid sort field"},
+		{FindOptions{Limit: -1}, "Invalid limit"},
+		{FindOptions{Continue: "not a token"}, "Invalid continue token"},
+	}
+	for _, c := range errorCases {
+		_, err := selectors.Resolve(c.opts)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Fatalf("Resolve of %v returned (%v), expected %s", c.opts, err, c.err)
+		}
+	}
+}
+
+func TestFieldFilter(t *testing.T) {
+	tagTypes := map[string]interface{}{
+		"data":      testOptionsData{},
+		"stateInfo": testOptionsState{},
+	}
+
+	eq := func(expr interface{}, value string) bson.M {
+		return bson.M{"$expr": bson.M{"$eq": bson.A{expr, value}}}
+	}
+
+	got := fieldFilter("data.spec.compositeAppVersion", "v1", tagTypes)
+	if !reflect.DeepEqual(got, eq("$data.spec.version", "v1")) {
+		t.Fatalf("fieldFilter returned %v", got)
+	}
+
+	got = fieldFilter("data.spec.logicalCloud", "lc1", tagTypes)
+	if !reflect.DeepEqual(got, eq("$data.spec.cloud", "lc1")) {
+		t.Fatalf("fieldFilter returned %v", got)
+	}
+
+	got = fieldFilter("data.metadata.userData1", "u", tagTypes)
+	if !reflect.DeepEqual(got, eq("$data.metadata.userData1", "u")) {
+		t.Fatalf("fieldFilter returned %v", got)
+	}
+
+	got = fieldFilter("stateInfo.actions.0", "Created", tagTypes)
+	if !reflect.DeepEqual(got, eq(bson.M{"$arrayElemAt": bson.A{"$stateInfo.actions", 0}}, "Created")) {
+		t.Fatalf("fieldFilter returned %v", got)
+	}
+
+	got = fieldFilter("stateInfo.actions.-1.state", "Created", tagTypes)
+	expected := bson.M{"$expr": bson.M{"$eq": bson.A{
+		bson.M{"$let": bson.M{
+			"vars": bson.M{"v": bson.M{"$arrayElemAt": bson.A{"$stateInfo.actions", -1}}},
+			"in":   "$$v.state",
+		}},
+		"Created",
+	}}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("fieldFilter returned %v, expected %v", got, expected)
+	}
+}
+
+func TestTimeRangeFilter(t *testing.T) {
+	tagTypes := map[string]interface{}{"data": testOptionsData{}}
+	from := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	got := timeRangeFilter("data.created", TimeRange{From: from, To: to}, tagTypes)
+	if !reflect.DeepEqual(got, bson.M{"data.createdAt": bson.M{"$gte": from, "$lt": to}}) {
+		t.Fatalf("timeRangeFilter returned %v", got)
+	}
+
+	got = timeRangeFilter("data.created", TimeRange{}, tagTypes)
+	if !reflect.DeepEqual(got, bson.M{"data.createdAt": bson.M{"$exists": true}}) {
+		t.Fatalf("timeRangeFilter returned %v", got)
+	}
+}
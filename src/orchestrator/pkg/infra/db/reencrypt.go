@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	utils "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReencryptResult counts the values encrypted again by Reencrypt
+type ReencryptResult struct {
+	// KeyID is the ID of the key encryption key of the values
+	KeyID string `json:"keyId"`
+	// Documents is the number of documents changed
+	Documents int `json:"documents"`
+	// Values is the number of values encrypted again
+	Values int `json:"values"`
+	// Failed is the number of values that couldn't be decrypted or stored
+	Failed int `json:"failed"`
+}
+
+// Reencrypter is implemented by the stores that can encrypt their encrypted
+// values again with the current key encryption key
+type Reencrypter interface {
+	Reencrypt(ctx context.Context, oe utils.IObjectEncryptor) (ReencryptResult, error)
+}
+
+// Reencrypt encrypts the encrypted values of all the documents of the
+// database again with the current key encryption key, e.g. after it is
+// rotated, and the values of the previous releases with the new format.
+// The documents are updated one value at a time, while they are in use: a
+// value changed meanwhile is already encrypted with the current key.
+func Reencrypt(ctx context.Context) (ReencryptResult, error) {
+	oe := utils.GetObjectEncryptor("emco")
+	if oe == nil {
+		return ReencryptResult{}, pkgerrors.New("The encryption isn't configured")
+	}
+	r, ok := DBconn.(Reencrypter)
+	if !ok {
+		return ReencryptResult{}, pkgerrors.Errorf("The database %T doesn't support the re-encryption", DBconn)
+	}
+	keyID, err := oe.RefreshKey()
+	if err != nil {
+		return ReencryptResult{}, pkgerrors.Wrap(err, "Error reading the current key encryption key")
+	}
+	result, err := r.Reencrypt(ctx, oe)
+	result.KeyID = keyID
+	if err != nil {
+		return result, err
+	}
+	log.Info("Re-encrypted the database", log.Fields{"keyId": keyID, "documents": result.Documents, "values": result.Values, "failed": result.Failed})
+	return result, nil
+}
+
+// reencryptedValue is a value of a document encrypted again
+type reencryptedValue struct {
+	path     string
+	old, new string
+}
+
+// reencryptValues returns the values of the document encrypted again, with
+// their dotted path, and the number of values that couldn't be. The values
+// are json or bson documents, arrays or values.
+func reencryptValues(oe utils.IObjectEncryptor, v interface{}, path string) ([]reencryptedValue, int) {
+	var values []reencryptedValue
+	failed := 0
+	walk := func(p string, e interface{}) {
+		vs, f := reencryptValues(oe, e, p)
+		values = append(values, vs...)
+		failed += f
+	}
+	child := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	switch t := v.(type) {
+	case string:
+		s, changed, err := oe.Reencrypt(t)
+		if err != nil {
+			log.Warn("Error re-encrypting a value", log.Fields{"path": path, "error": err})
+			return nil, 1
+		}
+		if changed {
+			values = append(values, reencryptedValue{path: path, old: t, new: s})
+		}
+	case map[string]interface{}:
+		for k, e := range t {
+			if validPathElement(k) {
+				walk(child(k), e)
+			}
+		}
+	case primitive.M:
+		for k, e := range t {
+			if validPathElement(k) {
+				walk(child(k), e)
+			}
+		}
+	case primitive.D:
+		for _, e := range t {
+			if validPathElement(e.Key) {
+				walk(child(e.Key), e.Value)
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			walk(child(strconv.Itoa(i)), e)
+		}
+	case primitive.A:
+		for i, e := range t {
+			walk(child(strconv.Itoa(i)), e)
+		}
+	}
+	return values, failed
+}
+
+// validPathElement returns whether the field name can be used in a dotted path
+func validPathElement(name string) bool {
+	return name != "" && !strings.Contains(name, ".") && !strings.HasPrefix(name, "$")
+}
+
+// setPath sets the value at the dotted path of a json document
+func setPath(doc interface{}, path string, value string) bool {
+	elems := strings.Split(path, ".")
+	for i, e := range elems {
+		last := i == len(elems)-1
+		switch t := doc.(type) {
+		case map[string]interface{}:
+			if last {
+				t[e] = value
+				return true
+			}
+			doc = t[e]
+		case []interface{}:
+			n, err := strconv.Atoi(e)
+			if err != nil || n < 0 || n >= len(t) {
+				return false
+			}
+			if last {
+				t[n] = value
+				return true
+			}
+			doc = t[n]
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// mongoReencryptFilter returns the filter and the update of a value encrypted
+// again, the update only applies if the value didn't change meanwhile
+func mongoReencryptFilter(id interface{}, v reencryptedValue) (bson.M, bson.M) {
+	return bson.M{"_id": id, v.path: v.old}, bson.M{"$set": bson.M{v.path: v.new}}
+}
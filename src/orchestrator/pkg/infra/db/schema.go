@@ -92,6 +92,12 @@ func (key DbSchemaKey) String() string {
 
 // ReadRefSchema reads the Referential Schema Segment file and creates the refSchemaMap.
 func (m *MongoStore) ReadRefSchema(ctx context.Context) {
+	readRefSchema(ctx, m)
+}
+
+// readRefSchema reads the Referential Schema Segment file and registers it
+// using the given Store. It is shared by all the Store implementations.
+func readRefSchema(ctx context.Context, s Store) {
 	// This function is executed asynchronously, so we must create
 	// a new (not derived) context to prevent the context from
 	// being cancelled when the caller completes: a cancelled
@@ -108,14 +114,14 @@ func (m *MongoStore) ReadRefSchema(ctx context.Context) {
 		return
 	}
 
-	m.verifyReferentialIntegrity(ctx, schema)
+	verifyReferentialIntegrity(ctx, s, schema)
 }
 
 // verifyReferentialIntegrity verifies the referential integrity of the resources
 // defined by the controller(s) schema.
 // Wait for controllers to register schema in scenarios where
 // multiple controllers start simultaneously.
-func (m *MongoStore) verifyReferentialIntegrity(ctx context.Context, serviceSchema DbSchema) {
+func verifyReferentialIntegrity(ctx context.Context, s Store, serviceSchema DbSchema) {
 	var (
 		backOff       int   = config.GetConfiguration().BackOff
 		maxBackOff    int   = config.GetConfiguration().MaxBackOff
@@ -124,7 +130,7 @@ func (m *MongoStore) verifyReferentialIntegrity(ctx context.Context, serviceSche
 	)
 
 	for waitForSchema {
-		waitForSchema, err = m.processSchema(ctx, serviceSchema)
+		waitForSchema, err = processSchema(ctx, s, serviceSchema)
 		if err != nil {
 			return
 		}
@@ -149,7 +155,7 @@ func (m *MongoStore) verifyReferentialIntegrity(ctx context.Context, serviceSche
 }
 
 // processSchema process each schema segment in the db.
-func (m *MongoStore) processSchema(ctx context.Context, serviceSchema DbSchema) (bool, error) {
+func processSchema(ctx context.Context, s Store, serviceSchema DbSchema) (bool, error) {
 	var (
 		emcoRefSchema    DbSchema
 		schemaExists     bool
@@ -162,7 +168,7 @@ func (m *MongoStore) processSchema(ctx context.Context, serviceSchema DbSchema)
 	defer schemaLock.Unlock()
 
 	// Retrieve all the schema segments.
-	segments, err := m.Find(ctx, "resources", DbSchemaKey{}, "segment")
+	segments, err := s.Find(ctx, "resources", DbSchemaKey{}, "segment")
 	if err != nil {
 		log.Error("DatabaseReferentialSchema: failed to retrieve schema segments from db.",
 			log.Fields{
@@ -178,9 +184,9 @@ func (m *MongoStore) processSchema(ctx context.Context, serviceSchema DbSchema)
 	}
 
 	// Put together a complete schema using the schema segments.
-	for _, s := range segments {
+	for _, seg := range segments {
 		schema := DbSchema{}
-		err := m.Unmarshal(s, &schema)
+		err := s.Unmarshal(seg, &schema)
 		if err != nil {
 			log.Error("DatabaseReferentialSchema: failed to unmarshal schema segment.",
 				log.Fields{
@@ -242,7 +248,7 @@ func (m *MongoStore) processSchema(ctx context.Context, serviceSchema DbSchema)
 	if !schemaExists &&
 		serviceSchema.SegmentId != "" {
 		// Register the controller schema in the db.
-		err := m.Insert(ctx, "resources", DbSchemaKey{SegmentId: serviceSchema.SegmentId}, nil, "segment", serviceSchema)
+		err := s.Insert(ctx, "resources", DbSchemaKey{SegmentId: serviceSchema.SegmentId}, nil, "segment", serviceSchema)
 		if err != nil {
 			log.Error("DatabaseReferentialSchema: failed to insert service schema into the db.",
 				log.Fields{
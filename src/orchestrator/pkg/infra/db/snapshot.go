@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Snapshotter is implemented by the stores that can copy all the documents
+// of the database as they are stored, e.g. to back the database up and
+// restore it in another instance
+type Snapshotter interface {
+	// DocumentFormat returns the encoding of the documents, the documents
+	// can only be restored in a store of the same format
+	DocumentFormat() string
+	// Snapshot calls fn with each document of each collection of the
+	// database, encoded by the store, but the segments of the referential
+	// schema, which the services register when they start. It returns true
+	// if the documents were read from a single point in time of the
+	// database.
+	Snapshot(ctx context.Context, fn func(coll string, doc json.RawMessage) error) (bool, error)
+	// Empty returns true if no collection holds a document but the
+	// segments of the referential schema
+	Empty(ctx context.Context) (bool, error)
+	// RestoreCollection inserts the documents returned by Snapshot in the
+	// collection of an empty database
+	RestoreCollection(ctx context.Context, coll string, docs []json.RawMessage) error
+	// Clear deletes the documents of all the collections but the segments
+	// of the referential schema, e.g. to roll a restore back
+	Clear(ctx context.Context) error
+}
+
+// schemaKeyId returns the keyId of the segments of the referential schema
+func schemaKeyId() string {
+	keyId, _ := createKeyIdField(DbSchemaKey{})
+	return keyId
+}
@@ -36,6 +36,10 @@ type Store interface {
 	// Find the document(s) with key and get the tag values from the document(s)
 	Find(ctx context.Context, coll string, key Key, tag string) ([][]byte, error)
 
+	// Find the document(s) with key like Find, then filter, sort and page them with the
+	// options. Returns the tag values and the continue token of the next page, if any.
+	FindWithOptions(ctx context.Context, coll string, key Key, tag string, opts FindOptions) ([][]byte, string, error)
+
 	// Removes the document(s) matching the key if no child reference in collection
 	Remove(ctx context.Context, coll string, key Key) error
 
@@ -44,6 +48,10 @@ type Store interface {
 
 	// Remove the specifiec tag from the document matching the key
 	RemoveTag(ctx context.Context, coll string, key Key, tag string) error
+
+	// Watch the document(s) whose key starts with the non-empty elements of the
+	// key and report their changes until the context is done
+	Watch(ctx context.Context, coll string, key Key) (<-chan WatchEvent, error)
 }
 
 // CreateDBClient creates the DB client
@@ -53,6 +61,9 @@ func createDBClient(ctx context.Context, dbType string, dbName string) error {
 	case "mongo":
 		// create a mongodb database with orchestrator as the name
 		DBconn, err = NewMongoStore(ctx, dbName, nil)
+	case "bolt":
+		// create an embedded bolt database file with dbName as the name
+		DBconn, err = NewBoltStore(ctx, dbName, nil)
 	default:
 		return pkgerrors.New(dbType + "DB not supported")
 	}
@@ -8,6 +8,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 )
 
 func TestCreateDBClient(t *testing.T) {
@@ -23,6 +25,23 @@ func TestCreateDBClient(t *testing.T) {
 			t.Fatalf("CreateDBClient set DBconn as:\n result=%T\n expected=%T", DBconn, expected)
 		}
 	})
+	t.Run("Successfully create embedded DB client", func(t *testing.T) {
+		expected := &BoltStore{}
+
+		config.SetConfigValue("DatabasePath", t.TempDir())
+		ctx := context.Background()
+		err := createDBClient(ctx, "bolt", "testdb")
+		if err != nil {
+			t.Fatalf("CreateDBClient returned an error (%s)", err)
+		}
+		if reflect.TypeOf(DBconn) != reflect.TypeOf(expected) {
+			t.Fatalf("CreateDBClient set DBconn as:\n result=%T\n expected=%T", DBconn, expected)
+		}
+		err = DBconn.HealthCheck(ctx)
+		if err != nil {
+			t.Fatalf("HealthCheck returned an error (%s)", err)
+		}
+	})
 	t.Run("Fail to create client for unsupported DB", func(t *testing.T) {
 		ctx := context.Background()
 		err := createDBClient(ctx, "fakeDB", "testdb2")
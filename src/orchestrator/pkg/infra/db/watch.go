@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package db
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// WatchEventType is the type of change reported by Watch
+type WatchEventType string
+
+const (
+	// WatchEventInsert is reported when a document is created
+	WatchEventInsert WatchEventType = "insert"
+	// WatchEventUpdate is reported when any tag of a document is modified
+	WatchEventUpdate WatchEventType = "update"
+	// WatchEventDelete is reported when a document is removed
+	WatchEventDelete WatchEventType = "delete"
+)
+
+// WatchEvent is a change to the document identified by Key
+type WatchEvent struct {
+	Type WatchEventType    `json:"type"`
+	Key  map[string]string `json:"key"`
+}
+
+// watchPollInterval is the interval at which the polling fallback of Watch
+// looks for changes in the database
+var watchPollInterval = 2 * time.Second
+
+// watchDocument is the state of a document as seen by the polling fallback.
+// The content is only used to detect updates.
+type watchDocument struct {
+	key     map[string]string
+	content string
+}
+
+// watchSnapshot returns the documents currently matching a Watch by identifier
+type watchSnapshot func(ctx context.Context) (map[string]watchDocument, error)
+
+// watchPrefix returns the non-empty elements of the key of a Watch. A document
+// matches the Watch if all these elements are present in its key.
+func watchPrefix(key Key) (map[string]string, error) {
+	k, err := keyToMap(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make(map[string]string, len(k))
+	for f, v := range k {
+		if v != "" {
+			prefix[f] = v
+		}
+	}
+	return prefix, nil
+}
+
+// keyIdFields returns the names of the key elements from a keyId
+// of the form {element1,element2,...}
+func keyIdFields(keyId string) []string {
+	s := strings.TrimSuffix(strings.TrimPrefix(keyId, "{"), "}")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// sendWatchEvent sends the event unless the watch is cancelled first
+func sendWatchEvent(ctx context.Context, ch chan<- WatchEvent, ev WatchEvent) bool {
+	select {
+	case ch <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// diffWatchSnapshots returns the events that turn the prev snapshot into cur
+func diffWatchSnapshots(prev, cur map[string]watchDocument) []WatchEvent {
+	var events []WatchEvent
+
+	ids := make([]string, 0, len(cur))
+	for id := range cur {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		doc := cur[id]
+		old, ok := prev[id]
+		switch {
+		case !ok:
+			events = append(events, WatchEvent{Type: WatchEventInsert, Key: doc.key})
+		case old.content != doc.content:
+			events = append(events, WatchEvent{Type: WatchEventUpdate, Key: doc.key})
+		}
+	}
+
+	ids = ids[:0]
+	for id := range prev {
+		if _, ok := cur[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		events = append(events, WatchEvent{Type: WatchEventDelete, Key: prev[id].key})
+	}
+
+	return events
+}
+
+// pollWatch implements Watch for the stores that can't be notified of the
+// changes by periodically comparing snapshots of the matching documents
+func pollWatch(ctx context.Context, snapshot watchSnapshot) (<-chan WatchEvent, error) {
+	prev, err := snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan WatchEvent)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			cur, err := snapshot(ctx)
+			if err != nil {
+				log.Error("Error polling the database for changes", log.Fields{"error": err.Error()})
+				continue
+			}
+			for _, ev := range diffWatchSnapshots(prev, cur) {
+				if !sendWatchEvent(ctx, ch, ev) {
+					return
+				}
+			}
+			prev = cur
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+// Package lease elects the replica of a service that runs a job, e.g. a
+// periodic job or a long running operation, when the service has several
+// replicas.
+//
+// A lease is a record of the ContextDb owned by one replica, which keeps
+// renewing it. The other replicas take the lease over once its record hasn't
+// changed for the lease duration of their local clock, so the clocks of the
+// replicas don't need to be in sync.
+package lease
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+const leasePrefix string = "/lease/"
+
+// record is the record of the replica owning a lease
+type record struct {
+	Owner     string    `json:"owner"`
+	RenewedAt time.Time `json:"renewedAt"`
+}
+
+// Lease is a lease of a job held by one replica at a time
+type Lease struct {
+	key      string
+	owner    string
+	duration time.Duration
+	now      func() time.Time
+
+	mutex sync.Mutex
+	// the last revision of the record owned by another replica and the
+	// time it was seen
+	revision  int64
+	changedAt time.Time
+}
+
+// ReplicaID returns the identity of the replica, its host name, i.e. the
+// name of the pod
+func ReplicaID() string {
+	id, err := os.Hostname()
+	if err != nil || id == "" {
+		return "pid-" + strconv.Itoa(os.Getpid())
+	}
+	return id
+}
+
+// New returns the lease of the job with the name for the replica
+func New(name string, duration time.Duration) *Lease {
+	return &Lease{
+		key:      leasePrefix + name + "/",
+		owner:    ReplicaID(),
+		duration: duration,
+		now:      time.Now,
+	}
+}
+
+// Acquire claims the lease if it's free or its owner stopped renewing it,
+// renews it if the replica owns it, and returns true if the replica owns it
+func (l *Lease) Acquire(ctx context.Context) (bool, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var r record
+	rev, err := contextdb.Db.GetWithRevision(ctx, l.key, &r)
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "Error reading the lease %s", l.key)
+	}
+	now := l.now()
+	if rev != 0 && r.Owner != l.owner {
+		if rev != l.revision {
+			l.revision, l.changedAt = rev, now
+			return false, nil
+		}
+		if now.Sub(l.changedAt) < l.duration {
+			return false, nil
+		}
+		log.Info("Taking over the lease of a replica", log.Fields{"lease": l.key, "replica": r.Owner})
+	}
+
+	err = contextdb.Db.PutIfRevision(ctx, l.key, record{Owner: l.owner, RenewedAt: now}, rev)
+	if err == contextdb.ErrRevisionMismatch {
+		// Claimed or renewed by another replica meanwhile
+		return false, nil
+	}
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "Error claiming the lease %s", l.key)
+	}
+	l.revision = 0
+	return true, nil
+}
+
+// Release frees the lease if the replica owns it
+func (l *Lease) Release(ctx context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var r record
+	rev, err := contextdb.Db.GetWithRevision(ctx, l.key, &r)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "Error reading the lease %s", l.key)
+	}
+	if rev == 0 || r.Owner != l.owner {
+		return nil
+	}
+	if err := contextdb.Db.Delete(ctx, l.key); err != nil {
+		return pkgerrors.Wrapf(err, "Error releasing the lease %s", l.key)
+	}
+	return nil
+}
+
+// Hold runs fn, renewing the lease until it returns, then releases the
+// lease. The context of fn is canceled if the lease can't be renewed, as
+// another replica may then take it over. The lease must be owned by the
+// replica.
+func (l *Lease) Hold(ctx context.Context, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(l.duration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				owned, err := l.Acquire(ctx)
+				if err != nil || !owned {
+					log.Error("Lost the lease", log.Fields{"lease": l.key, "error": err})
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	fn(ctx)
+	close(done)
+	if err := l.Release(context.Background()); err != nil {
+		log.Error("Error releasing the lease", log.Fields{"lease": l.key, "error": err.Error()})
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package lease
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+)
+
+// newLease returns the lease of the replica with a clock set by the test
+func newLease(owner string, now *time.Time) *Lease {
+	l := New("job", time.Minute)
+	l.owner = owner
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestAcquire(t *testing.T) {
+	ctx := context.Background()
+	var err error
+	contextdb.Db, err = contextdb.NewBoltClient(contextdb.BoltConfig{
+		Path:    filepath.Join(t.TempDir(), "contextdb.db"),
+		Timeout: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	now := time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC)
+	l1, l2 := newLease("replica1", &now), newLease("replica2", &now)
+
+	if owned, err := l1.Acquire(ctx); err != nil || !owned {
+		t.Fatalf("Expected the free lease claimed, got %v %v", owned, err)
+	}
+	if owned, err := l2.Acquire(ctx); err != nil || owned {
+		t.Fatalf("Expected the lease owned by replica1, got %v %v", owned, err)
+	}
+
+	// the renewed lease isn't taken over
+	now = now.Add(50 * time.Second)
+	if owned, err := l1.Acquire(ctx); err != nil || !owned {
+		t.Fatalf("Expected the lease renewed, got %v %v", owned, err)
+	}
+	now = now.Add(50 * time.Second)
+	if owned, err := l2.Acquire(ctx); err != nil || owned {
+		t.Fatalf("Expected the renewed lease owned by replica1, got %v %v", owned, err)
+	}
+
+	// the lease not renewed for its duration is taken over
+	now = now.Add(time.Minute)
+	if owned, err := l2.Acquire(ctx); err != nil || !owned {
+		t.Fatalf("Expected the expired lease taken over, got %v %v", owned, err)
+	}
+	if owned, err := l1.Acquire(ctx); err != nil || owned {
+		t.Fatalf("Expected the lease owned by replica2, got %v %v", owned, err)
+	}
+
+	// the lease released by its owner is free
+	if err := l1.Release(ctx); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if owned, _ := l1.Acquire(ctx); owned {
+		t.Fatalf("Expected the lease released by another replica kept")
+	}
+	if err := l2.Release(ctx); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if owned, err := l1.Acquire(ctx); err != nil || !owned {
+		t.Fatalf("Expected the released lease claimed, got %v %v", owned, err)
+	}
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("Invalid time %s (%s)", s, err)
+	}
+	return v
+}
+
+func TestParseCron(t *testing.T) {
+	errorCases := []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	}
+	for _, spec := range errorCases {
+		_, err := ParseCron(spec)
+		if err == nil || !strings.Contains(err.Error(), "Invalid cron schedule") {
+			t.Fatalf("ParseCron of %q returned (%v)", spec, err)
+		}
+	}
+}
+
+func TestCronMatches(t *testing.T) {
+	testCases := []struct {
+		spec     string
+		time     string
+		expected bool
+	}{
+		{"* * * * *", "2022-06-01T10:17:00Z", true},
+		{"*/15 * * * *", "2022-06-01T10:30:00Z", true},
+		{"*/15 * * * *", "2022-06-01T10:31:00Z", false},
+		{"5/20 * * * *", "2022-06-01T10:45:00Z", true},
+		{"0 22-23,0-5 * * *", "2022-06-01T03:00:00Z", true},
+		{"0 22-23,0-5 * * *", "2022-06-01T12:00:00Z", false},
+		// 2022-06-05 is a Sunday
+		{"0 0 * * 7", "2022-06-05T00:00:00Z", true},
+		{"0 0 * * 0", "2022-06-05T00:00:00Z", true},
+		{"0 0 * * 1-5", "2022-06-05T00:00:00Z", false},
+		// the day matches either field when both are restricted
+		{"0 0 1 * 1", "2022-06-01T00:00:00Z", true},
+		{"0 0 1 * 1", "2022-06-06T00:00:00Z", true},
+		{"0 0 1 * 1", "2022-06-07T00:00:00Z", false},
+	}
+	for _, testCase := range testCases {
+		c, err := ParseCron(testCase.spec)
+		if err != nil {
+			t.Fatalf("ParseCron of %q returned an error (%s)", testCase.spec, err)
+		}
+		if got := c.Matches(mustTime(t, testCase.time)); got != testCase.expected {
+			t.Fatalf("Matches of %q at %s returned %v", testCase.spec, testCase.time, got)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	// weekday nights from 22:00 to 04:00
+	w := Window{Schedule: "0 22 * * 1-5", DurationMinutes: 360}
+
+	open, err := w.IsOpen(mustTime(t, "2022-06-01T23:30:00Z"))
+	if err != nil || !open {
+		t.Fatalf("IsOpen returned (%v, %v), expected open", open, err)
+	}
+	open, err = w.IsOpen(mustTime(t, "2022-06-02T04:00:00Z"))
+	if err != nil || open {
+		t.Fatalf("IsOpen returned (%v, %v), expected closed", open, err)
+	}
+
+	next, err := w.NextOpen(mustTime(t, "2022-06-02T12:00:00Z"))
+	if err != nil || !next.Equal(mustTime(t, "2022-06-02T22:00:00Z")) {
+		t.Fatalf("NextOpen returned (%v, %v)", next, err)
+	}
+
+	// the same window in another time zone
+	w.TimeZone = "Europe/Paris"
+	open, err = w.IsOpen(mustTime(t, "2022-06-01T20:30:00Z"))
+	if err != nil || !open {
+		t.Fatalf("IsOpen in time zone returned (%v, %v), expected open", open, err)
+	}
+
+	for _, bad := range []Window{
+		{Schedule: "0 22 * * 1-5"},
+		{Schedule: "0 22 * *", DurationMinutes: 60},
+		{Schedule: "0 22 * * *", DurationMinutes: 60, TimeZone: "Nowhere/City"},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Fatalf("Validate of %v returned no error", bad)
+		}
+	}
+}
+
+func TestNextAllOpen(t *testing.T) {
+	nights := []Window{{Schedule: "0 22 * * *", DurationMinutes: 360}}
+	weekend := []Window{{Schedule: "0 0 * * 6", DurationMinutes: 2 * 24 * 60}}
+
+	// Wednesday noon, the first night of the weekend is Saturday 00:00
+	next, err := NextAllOpen(mustTime(t, "2022-06-01T12:00:00Z"), nights, weekend)
+	if err != nil || !next.Equal(mustTime(t, "2022-06-04T00:00:00Z")) {
+		t.Fatalf("NextAllOpen returned (%v, %v)", next, err)
+	}
+
+	open, err := AllOpen(mustTime(t, "2022-06-04T23:00:00Z"), nights, weekend, nil)
+	if err != nil || !open {
+		t.Fatalf("AllOpen returned (%v, %v), expected open", open, err)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package schedule
+
+import (
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// maxWindowDuration is the longest a maintenance window can stay open
+const maxWindowDuration = 7 * 24 * 60
+
+// Window is a maintenance window. It opens each time the cron schedule
+// fires and stays open for the duration.
+type Window struct {
+	// Schedule is the cron schedule of the opening of the window, e.g. "0 22 * * 1-5"
+	Schedule string `json:"schedule"`
+	// DurationMinutes is the time the window stays open
+	DurationMinutes int `json:"durationMinutes"`
+	// TimeZone is the IANA name of the time zone of the schedule, UTC by default
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// parse returns the schedule and the location of the window
+func (w Window) parse() (Cron, *time.Location, error) {
+	c, err := ParseCron(w.Schedule)
+	if err != nil {
+		return Cron{}, nil, err
+	}
+	if w.DurationMinutes < 1 || w.DurationMinutes > maxWindowDuration {
+		return Cron{}, nil, pkgerrors.Errorf("Invalid maintenance window duration: %d minutes", w.DurationMinutes)
+	}
+	loc := time.UTC
+	if w.TimeZone != "" {
+		loc, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return Cron{}, nil, pkgerrors.Wrapf(err, "Invalid maintenance window time zone: %s", w.TimeZone)
+		}
+	}
+	return c, loc, nil
+}
+
+// Validate checks the schedule, the duration and the time zone of the window
+func (w Window) Validate() error {
+	_, _, err := w.parse()
+	return err
+}
+
+// ValidateWindows checks each window of a list of maintenance windows
+func ValidateWindows(windows []Window) error {
+	for i, w := range windows {
+		if err := w.Validate(); err != nil {
+			return pkgerrors.Wrapf(err, "Invalid maintenance window %d", i)
+		}
+	}
+	return nil
+}
+
+// IsOpen returns whether the window is open at t
+func (w Window) IsOpen(t time.Time) (bool, error) {
+	c, loc, err := w.parse()
+	if err != nil {
+		return false, err
+	}
+	t = t.In(loc)
+	// the window is open if the schedule fired during the last duration
+	start := t.Truncate(time.Minute)
+	for i := 0; i < w.DurationMinutes; i++ {
+		if c.Matches(start.Add(-time.Duration(i) * time.Minute)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NextOpen returns t if the window is open at t, or the next time the window
+// opens. It returns the zero time if the window doesn't open in the next year.
+func (w Window) NextOpen(t time.Time) (time.Time, error) {
+	open, err := w.IsOpen(t)
+	if err != nil || open {
+		return t, err
+	}
+	c, loc, _ := w.parse()
+	return c.Next(t.In(loc)), nil
+}
+
+// AllOpen returns whether each set of windows has an open window at t. An
+// empty set of windows is always open.
+func AllOpen(t time.Time, sets ...[]Window) (bool, error) {
+	for _, windows := range sets {
+		if len(windows) == 0 {
+			continue
+		}
+		open := false
+		for _, w := range windows {
+			o, err := w.IsOpen(t)
+			if err != nil {
+				return false, err
+			}
+			if o {
+				open = true
+				break
+			}
+		}
+		if !open {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// NextAllOpen returns the first time from t at which each set of windows has
+// an open window, or the zero time if there is none in the next year
+func NextAllOpen(t time.Time, sets ...[]Window) (time.Time, error) {
+	end := t.Add(maxCronSearch)
+	for t.Before(end) {
+		open, err := AllOpen(t, sets...)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if open {
+			return t, nil
+		}
+
+		// move to the earliest opening of a window in the sets that are closed
+		var next time.Time
+		for _, windows := range sets {
+			for _, w := range windows {
+				n, err := w.NextOpen(t)
+				if err != nil {
+					return time.Time{}, err
+				}
+				if n.After(t) && (next.IsZero() || n.Before(next)) {
+					next = n
+				}
+			}
+		}
+		if next.IsZero() {
+			return time.Time{}, nil
+		}
+		t = next
+	}
+	return time.Time{}, nil
+}
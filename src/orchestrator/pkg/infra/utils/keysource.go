@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package utils
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// KeySource holds the key encryption keys, which encrypt the data encryption
+// keys of the object encryptors. Each key has an ID, stored alongside the
+// data encrypted with it, and one of them, the current key, encrypts the new
+// data encryption keys.
+type KeySource interface {
+	// KeyID returns the ID of the current key
+	KeyID(ctx context.Context) (string, error)
+	// Encrypt encrypts the plaintext with the current key, and returns the
+	// ID of the key with the ciphertext
+	Encrypt(ctx context.Context, plaintext []byte) (string, []byte, error)
+	// Decrypt decrypts the ciphertext with the key of the ID
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// localKeys is a set of AES-256 keys held by the service
+type localKeys struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// newLocalKeys returns the key set of the base64 encoded 32 bytes keys
+func newLocalKeys(current string, keys map[string]string) (*localKeys, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, pkgerrors.Errorf("The current key %s is missing", current)
+	}
+	l := &localKeys{current: current, keys: make(map[string]cipher.AEAD)}
+	for id, k := range keys {
+		if !validKeyID(id) {
+			return nil, pkgerrors.Errorf("Invalid key ID %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(k)
+		if err != nil || len(key) != 32 {
+			return nil, pkgerrors.Errorf("The key %s isn't a base64 encoded 32 bytes key", id)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		l.keys[id] = aead
+	}
+	return l, nil
+}
+
+func (l *localKeys) encrypt(plaintext []byte) (string, []byte, error) {
+	ciphertext, err := seal(l.keys[l.current], plaintext, []byte(l.current))
+	return l.current, ciphertext, err
+}
+
+func (l *localKeys) decrypt(keyID string, ciphertext []byte) ([]byte, error) {
+	aead, ok := l.keys[keyID]
+	if !ok {
+		return nil, pkgerrors.Errorf("Unknown key %s", keyID)
+	}
+	return open(aead, ciphertext, []byte(keyID))
+}
+
+// envKeySource holds the keys of the environment variables of a provider:
+//
+//	<PROVIDER>_DATA_KEYS: comma separated id=key, the first key is the
+//	  current one, e.g. EMCO_DATA_KEYS=k2=<base64 key>,k1=<base64 key>
+//	<PROVIDER>_DATA_KEY: the key of the previous releases, used as the key
+//	  "legacy" if there are no other keys
+type envKeySource struct {
+	keys *localKeys
+}
+
+// newEnvKeySource returns the keys of the environment variables of the
+// provider, or nil if there are none
+func newEnvKeySource(provider string) (KeySource, error) {
+	prefix := strings.ToUpper(provider)
+	if v := os.Getenv(prefix + "_DATA_KEYS"); v != "" {
+		var current string
+		keys := make(map[string]string)
+		for _, kv := range strings.Split(v, ",") {
+			p := strings.SplitN(strings.TrimSpace(kv), "=", 2)
+			if len(p) != 2 {
+				return nil, pkgerrors.Errorf("Invalid key in %s_DATA_KEYS", prefix)
+			}
+			if current == "" {
+				current = p[0]
+			}
+			keys[p[0]] = p[1]
+		}
+		l, err := newLocalKeys(current, keys)
+		if err != nil {
+			return nil, err
+		}
+		return &envKeySource{keys: l}, nil
+	}
+	if v := os.Getenv(prefix + "_DATA_KEY"); v != "" {
+		log.Warn("Deriving the key encryption key from the legacy data key, configure the data keys instead", log.Fields{"provider": provider})
+		key := sha256.Sum256([]byte(v))
+		l, err := newLocalKeys(legacyKeyID, map[string]string{legacyKeyID: base64.StdEncoding.EncodeToString(key[:])})
+		if err != nil {
+			return nil, err
+		}
+		return &envKeySource{keys: l}, nil
+	}
+	return nil, nil
+}
+
+func (s *envKeySource) KeyID(ctx context.Context) (string, error) {
+	return s.keys.current, nil
+}
+
+func (s *envKeySource) Encrypt(ctx context.Context, plaintext []byte) (string, []byte, error) {
+	return s.keys.encrypt(plaintext)
+}
+
+func (s *envKeySource) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	return s.keys.decrypt(keyID, ciphertext)
+}
+
+// fileKeySource holds the keys of a json file, e.g. mounted from a secret:
+//
+//	{"current": "k2", "keys": {"k1": "<base64 key>", "k2": "<base64 key>"}}
+//
+// The file is read again when it changes, so that a new key can be added
+// and made current without restarting the services.
+type fileKeySource struct {
+	file string
+
+	mutex   sync.Mutex
+	modTime time.Time
+	keys    *localKeys
+}
+
+// newFileKeySource returns the keys of the file
+func newFileKeySource(file string) (KeySource, error) {
+	s := &fileKeySource{file: file}
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load returns the keys, reading the file again if it changed
+func (s *fileKeySource) load() (*localKeys, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	info, err := os.Stat(s.file)
+	if err != nil {
+		if s.keys != nil {
+			log.Warn("Error reading the key file, using the previous keys", log.Fields{"file": s.file, "error": err})
+			return s.keys, nil
+		}
+		return nil, pkgerrors.Wrap(err, "Error reading the key file")
+	}
+	if s.keys != nil && info.ModTime().Equal(s.modTime) {
+		return s.keys, nil
+	}
+
+	data, err := ioutil.ReadFile(s.file)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error reading the key file")
+	}
+	var f struct {
+		Current string            `json:"current"`
+		Keys    map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, pkgerrors.Wrap(err, "Invalid key file")
+	}
+	keys, err := newLocalKeys(f.Current, f.Keys)
+	if err != nil {
+		if s.keys != nil {
+			log.Error("Invalid key file, using the previous keys", log.Fields{"file": s.file, "error": err})
+			return s.keys, nil
+		}
+		return nil, err
+	}
+	if s.keys != nil && s.keys.current != keys.current {
+		log.Info("The current key encryption key changed", log.Fields{"file": s.file, "key": keys.current})
+	}
+	s.keys, s.modTime = keys, info.ModTime()
+	return keys, nil
+}
+
+func (s *fileKeySource) KeyID(ctx context.Context) (string, error) {
+	keys, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	return keys.current, nil
+}
+
+func (s *fileKeySource) Encrypt(ctx context.Context, plaintext []byte) (string, []byte, error) {
+	keys, err := s.load()
+	if err != nil {
+		return "", nil, err
+	}
+	return keys.encrypt(plaintext)
+}
+
+func (s *fileKeySource) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	keys, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return keys.decrypt(keyID, ciphertext)
+}
+
+// validKeyID returns whether the ID can be stored in the ciphertexts
+func validKeyID(id string) bool {
+	return id != "" && !strings.ContainsAny(id, ": ")
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext with a random nonce, prepended to the ciphertext
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, pkgerrors.Wrap(err, "Error generating a nonce")
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a ciphertext returned by seal
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, pkgerrors.New("Invalid ciphertext")
+	}
+	n := aead.NonceSize()
+	return aead.Open(nil, ciphertext[:n], ciphertext[n:], additionalData)
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/grpc/kms"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// kmsTimeout bounds each call to the plugin
+const kmsTimeout = 10 * time.Second
+
+// pluginKeySource holds the keys in a plugin implementing the
+// KeyManagementService gRPC API, e.g. in front of a KMS or an HSM. The keys
+// never leave the plugin.
+type pluginKeySource struct {
+	client kms.KeyManagementServiceClient
+}
+
+// pluginTLS has the files of the TLS credentials of a TCP endpoint: the CA
+// certificate verifying the plugin and, optionally, the client certificate
+// and key presented to it
+type pluginTLS struct {
+	caFile, certFile, keyFile string
+}
+
+// newPluginKeySource returns the keys of the plugin at the endpoint, a unix
+// socket, e.g. unix:///var/run/kms-plugin.sock, or a TCP endpoint with TLS,
+// e.g. kms-plugin:9090
+func newPluginKeySource(endpoint string, t pluginTLS) (KeySource, error) {
+	creds, err := pluginCredentials(endpoint, t)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(endpoint, creds)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error connecting to the KMS plugin %s", endpoint)
+	}
+	return &pluginKeySource{client: kms.NewKeyManagementServiceClient(conn)}, nil
+}
+
+// pluginCredentials returns the credentials of the connection to the
+// endpoint. A unix socket is only reachable from the pod, the keys sent over
+// TCP are protected with TLS.
+func pluginCredentials(endpoint string, t pluginTLS) (grpc.DialOption, error) {
+	if strings.HasPrefix(endpoint, "unix:") {
+		return grpc.WithInsecure(), nil
+	}
+	if t.caFile == "" {
+		return nil, pkgerrors.Errorf("The KMS plugin %s needs a CA certificate, or a unix socket endpoint", endpoint)
+	}
+	ca, err := ioutil.ReadFile(t.caFile)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error reading the CA certificate of the KMS plugin")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, pkgerrors.Errorf("Invalid CA certificate of the KMS plugin %s", t.caFile)
+	}
+	cfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	if t.certFile != "" || t.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.certFile, t.keyFile)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "Error reading the client certificate of the KMS plugin")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(cfg)), nil
+}
+
+func (s *pluginKeySource) KeyID(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, kmsTimeout)
+	defer cancel()
+	resp, err := s.client.Status(ctx, &kms.StatusRequest{})
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "Error reading the status of the KMS plugin")
+	}
+	if !validKeyID(resp.KeyId) {
+		return "", pkgerrors.Errorf("Invalid key ID %q from the KMS plugin", resp.KeyId)
+	}
+	return resp.KeyId, nil
+}
+
+func (s *pluginKeySource) Encrypt(ctx context.Context, plaintext []byte) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, kmsTimeout)
+	defer cancel()
+	resp, err := s.client.Encrypt(ctx, &kms.EncryptRequest{Plaintext: plaintext})
+	if err != nil {
+		return "", nil, pkgerrors.Wrap(err, "Error encrypting with the KMS plugin")
+	}
+	if !validKeyID(resp.KeyId) {
+		return "", nil, pkgerrors.Errorf("Invalid key ID %q from the KMS plugin", resp.KeyId)
+	}
+	return resp.KeyId, resp.Ciphertext, nil
+}
+
+func (s *pluginKeySource) Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, kmsTimeout)
+	defer cancel()
+	resp, err := s.client.Decrypt(ctx, &kms.DecryptRequest{KeyId: keyID, Ciphertext: ciphertext})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error decrypting with the KMS plugin")
+	}
+	return resp.Plaintext, nil
+}
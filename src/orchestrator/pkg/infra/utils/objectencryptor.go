@@ -4,49 +4,153 @@
 package utils
 
 import (
-	"crypto/aes"
+	"context"
 	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"os"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 )
 
+const (
+	// ciphertextPrefix starts the values encrypted by the object encryptors.
+	// It is followed by the ID of the key encryption key, the encrypted data
+	// key and the encrypted value with its nonce, base64 url encoded:
+	// emco:v1:<key ID>:<data key>:<value>
+	ciphertextPrefix = "emco:v1:"
+	// legacyKeyID is the ID of the key derived from the data key of the
+	// previous releases
+	legacyKeyID = "legacy"
+	// keyCheckInterval is the interval the current key encryption key is
+	// checked at, a new data key is used when it changes
+	keyCheckInterval = time.Minute
+	// maxDataKeyUses is the number of values encrypted with a data key
+	// before a new data key is used
+	maxDataKeyUses = 1 << 20
+)
+
 type IObjectEncryptor interface {
 	EncryptObject(o interface{}) (interface{}, error)
 	EncryptString(message string) (string, error)
 	DecryptObject(o interface{}) (interface{}, error)
 	DecryptString(ciphermessage string) (string, error)
+	// RefreshKey checks the current key encryption key and returns its ID
+	RefreshKey() (string, error)
+	// Reencrypt returns the encrypted value encrypted again with the
+	// current key encryption key, and whether it changed
+	Reencrypt(value string) (string, bool, error)
+}
+
+// dataKey is a data encryption key, encrypted with a key encryption key
+type dataKey struct {
+	keyID string
+	// header starts the values encrypted with the data key
+	header string
+	aead   cipher.AEAD
+	uses   int
 }
 
+// MyObjectEncryptor encrypts the values with data encryption keys, each
+// value with a random nonce, and stores the data keys encrypted with the
+// key encryption keys of a KeySource alongside the values
 type MyObjectEncryptor struct {
-	gcm   cipher.AEAD
-	nonce []byte
+	keys KeySource
+	// legacy decrypts the values of the previous releases, if any
+	legacy      cipher.AEAD
+	legacyNonce []byte
+
+	mutex     sync.Mutex
+	current   *dataKey
+	checkedAt time.Time
+	// dataKeys are the decrypted data keys by header
+	dataKeys map[string]cipher.AEAD
+	now      func() time.Time
 }
 
-var gobjencs = make(map[string]IObjectEncryptor)
+var (
+	gobjencsMutex sync.Mutex
+	gobjencs      = make(map[string]IObjectEncryptor)
+)
 
+// GetObjectEncryptor returns the encryptor of the provider, or nil if no
+// key is configured. The key source is selected by the encryption-key-source
+// of the configuration: env, file or plugin.
 func GetObjectEncryptor(provider string) IObjectEncryptor {
+	gobjencsMutex.Lock()
+	defer gobjencsMutex.Unlock()
 	if gobjencs[provider] == nil {
-		envkey := strings.ToUpper(provider) + "_DATA_KEY"
-		if len(os.Getenv(envkey)) > 0 {
-			oe, err := createObjectEncryptor([]byte(os.Getenv(envkey)), []byte("emco nonce"))
-			if err != nil {
-				log.Error("Create Object Encryptor error :: ", log.Fields{"Error": err})
-				return nil
-			}
-			gobjencs[provider] = oe
-		} else {
+		keys, err := newKeySource(provider)
+		if err != nil {
+			log.Error("Create Object Encryptor error :: ", log.Fields{"Error": err})
+			return nil
+		}
+		if keys == nil {
+			return nil
+		}
+		oe, err := NewObjectEncryptor(keys, []byte(os.Getenv(strings.ToUpper(provider)+"_DATA_KEY")))
+		if err != nil {
+			log.Error("Create Object Encryptor error :: ", log.Fields{"Error": err})
 			return nil
 		}
+		gobjencs[provider] = oe
 	}
 
 	return gobjencs[provider]
 }
 
-func createObjectEncryptor(key []byte, nonce []byte) (IObjectEncryptor, error) {
+// newKeySource returns the key source of the configuration, or nil if the
+// environment of the provider has no key
+func newKeySource(provider string) (KeySource, error) {
+	cfg := config.GetConfiguration()
+	switch cfg.EncryptionKeySource {
+	case "", "env":
+		return newEnvKeySource(provider)
+	case "file":
+		return newFileKeySource(cfg.EncryptionKeyFile)
+	case "plugin":
+		return newPluginKeySource(cfg.EncryptionPluginEndpoint, pluginTLS{
+			caFile:   cfg.EncryptionPluginCAFile,
+			certFile: cfg.EncryptionPluginCertFile,
+			keyFile:  cfg.EncryptionPluginKeyFile,
+		})
+	}
+	return nil, pkgerrors.Errorf("Unknown encryption key source %s", cfg.EncryptionKeySource)
+}
+
+// NewObjectEncryptor returns the encryptor of the keys. The values encrypted
+// by the previous releases are decrypted with the legacy key, if any.
+func NewObjectEncryptor(keys KeySource, legacyKey []byte) (IObjectEncryptor, error) {
+	c := &MyObjectEncryptor{
+		keys:     keys,
+		dataKeys: make(map[string]cipher.AEAD),
+		now:      time.Now,
+	}
+	if len(legacyKey) > 0 {
+		var err error
+		if c.legacy, c.legacyNonce, err = createLegacyCipher(legacyKey, []byte("emco nonce")); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// IsEncrypted returns whether the value is encrypted by an object encryptor.
+// The values of the previous releases can't be told from plain values.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+// createLegacyCipher returns the cipher and the nonce of the values
+// encrypted by the previous releases
+func createLegacyCipher(key []byte, nonce []byte) (cipher.AEAD, []byte, error) {
 	// Format key and nonce
 	nkey := make([]byte, 32)
 	nnonce := make([]byte, 12)
@@ -66,17 +170,11 @@ func createObjectEncryptor(key []byte, nonce []byte) (IObjectEncryptor, error) {
 		}
 	}
 
-	block, err := aes.NewCipher(nkey)
+	aesgcm, err := newAEAD(nkey)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	aesgcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return nil, err
-	}
-
-	return &MyObjectEncryptor{aesgcm, nnonce}, nil
+	return aesgcm, nnonce, nil
 }
 
 func (c *MyObjectEncryptor) EncryptObject(o interface{}) (interface{}, error) {
@@ -88,17 +186,50 @@ func (c *MyObjectEncryptor) DecryptObject(o interface{}) (interface{}, error) {
 }
 
 func (c *MyObjectEncryptor) EncryptString(message string) (string, error) {
-	ciphermessage := c.gcm.Seal(nil, c.nonce, []byte(message), nil)
-	return hex.EncodeToString(ciphermessage), nil
+	dk, err := c.dataKey(false)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(dk.aead, []byte(message), []byte(dk.header))
+	if err != nil {
+		return "", err
+	}
+	return dk.header + base64.RawURLEncoding.EncodeToString(ciphertext), nil
 }
 
 func (c *MyObjectEncryptor) DecryptString(ciphermessage string) (string, error) {
+	if !strings.HasPrefix(ciphermessage, ciphertextPrefix) {
+		return c.decryptLegacy(ciphermessage)
+	}
+	i := strings.LastIndex(ciphermessage, ":")
+	header := ciphermessage[:i+1]
+	aead, err := c.headerKey(header)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(ciphermessage[i+1:])
+	if err != nil {
+		return "", pkgerrors.New("Invalid ciphertext")
+	}
+	message, err := open(aead, ciphertext, []byte(header))
+	if err != nil {
+		return "", err
+	}
+
+	return string(message), nil
+}
+
+// decryptLegacy decrypts a value encrypted by the previous releases
+func (c *MyObjectEncryptor) decryptLegacy(ciphermessage string) (string, error) {
+	if c.legacy == nil {
+		return "", pkgerrors.New("The value isn't encrypted")
+	}
 	cm, err := hex.DecodeString(ciphermessage)
 	if err != nil {
 		return "", err
 	}
 
-	message, err := c.gcm.Open(nil, c.nonce, cm, nil)
+	message, err := c.legacy.Open(nil, c.legacyNonce, cm, nil)
 
 	if err != nil {
 		return "", err
@@ -107,7 +238,125 @@ func (c *MyObjectEncryptor) DecryptString(ciphermessage string) (string, error)
 	return string(message), nil
 }
 
+func (c *MyObjectEncryptor) RefreshKey() (string, error) {
+	dk, err := c.dataKey(true)
+	if err != nil {
+		return "", err
+	}
+	return dk.keyID, nil
+}
+
+func (c *MyObjectEncryptor) Reencrypt(value string) (string, bool, error) {
+	if strings.HasPrefix(value, ciphertextPrefix) {
+		keyID := strings.SplitN(strings.TrimPrefix(value, ciphertextPrefix), ":", 2)[0]
+		dk, err := c.dataKey(false)
+		if err != nil {
+			return "", false, err
+		}
+		if keyID == dk.keyID {
+			return value, false, nil
+		}
+	}
+	message, err := c.DecryptString(value)
+	if err != nil {
+		// not encrypted by the encryptor
+		if !strings.HasPrefix(value, ciphertextPrefix) {
+			return value, false, nil
+		}
+		return "", false, err
+	}
+	encrypted, err := c.EncryptString(message)
+	if err != nil {
+		return "", false, err
+	}
+	return encrypted, true, nil
+}
+
+// dataKey returns the data key of the new values. A new data key is
+// encrypted with the current key encryption key when it changes, checked
+// at most every keyCheckInterval unless refresh is set, or once the data
+// key encrypted maxDataKeyUses values.
+func (c *MyObjectEncryptor) dataKey(refresh bool) (*dataKey, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ctx := context.Background()
+
+	if c.current != nil && c.current.uses < maxDataKeyUses {
+		if !refresh && c.now().Sub(c.checkedAt) < keyCheckInterval {
+			c.current.uses++
+			return c.current, nil
+		}
+		keyID, err := c.keys.KeyID(ctx)
+		if err != nil {
+			if refresh {
+				return nil, err
+			}
+			// keep the data key until the key source is available
+			log.Warn("Error checking the current key encryption key", log.Fields{"error": err})
+			keyID = c.current.keyID
+		}
+		if keyID == c.current.keyID {
+			c.checkedAt = c.now()
+			c.current.uses++
+			return c.current, nil
+		}
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, pkgerrors.Wrap(err, "Error generating a data key")
+	}
+	keyID, encrypted, err := c.keys.Encrypt(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	dk := &dataKey{
+		keyID:  keyID,
+		header: ciphertextPrefix + keyID + ":" + base64.RawURLEncoding.EncodeToString(encrypted) + ":",
+		aead:   aead,
+		uses:   1,
+	}
+	c.dataKeys[dk.header] = aead
+	c.current, c.checkedAt = dk, c.now()
+	return dk, nil
+}
+
+// headerKey returns the data key of the values starting with the header
+func (c *MyObjectEncryptor) headerKey(header string) (cipher.AEAD, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if aead, ok := c.dataKeys[header]; ok {
+		return aead, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(header, ciphertextPrefix), ":")
+	if len(parts) != 3 {
+		return nil, pkgerrors.New("Invalid ciphertext")
+	}
+	encrypted, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, pkgerrors.New("Invalid ciphertext")
+	}
+	key, err := c.keys.Decrypt(context.Background(), parts[0], encrypted)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error decrypting the data key encrypted with the key %s", parts[0])
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	c.dataKeys[header] = aead
+	return aead, nil
+}
+
 func (c *MyObjectEncryptor) processObject(o interface{}, encrypt bool, oper func(string) (string, error)) (interface{}, error) {
+	if o == nil {
+		return nil, nil
+	}
 	t := reflect.TypeOf(o)
 	switch t.Kind() {
 	case reflect.String:
@@ -122,6 +371,9 @@ func (c *MyObjectEncryptor) processObject(o interface{}, encrypt bool, oper func
 		}
 	case reflect.Ptr:
 		v := reflect.ValueOf(o)
+		if v.IsNil() {
+			return o, nil
+		}
 		newv, err := c.processObject(v.Elem().Interface(), encrypt, oper)
 		if err != nil {
 			return nil, err
@@ -140,7 +392,7 @@ func (c *MyObjectEncryptor) processObject(o interface{}, encrypt bool, oper func
 				if err != nil {
 					return nil, err
 				}
-				newv.Field(k).Set(reflect.ValueOf(newf))
+				newv.Field(k).Set(valueOf(newf, t.Field(k).Type))
 			}
 		}
 		return newv.Interface(), nil
@@ -152,7 +404,7 @@ func (c *MyObjectEncryptor) processObject(o interface{}, encrypt bool, oper func
 			if err != nil {
 				return nil, err
 			}
-			newv.Index(k).Set(reflect.ValueOf(newf))
+			newv.Index(k).Set(valueOf(newf, t.Elem()))
 		}
 		return newv.Interface(), nil
 	case reflect.Slice:
@@ -163,7 +415,7 @@ func (c *MyObjectEncryptor) processObject(o interface{}, encrypt bool, oper func
 			if err != nil {
 				return nil, err
 			}
-			newv.Index(k).Set(reflect.ValueOf(newf))
+			newv.Index(k).Set(valueOf(newf, t.Elem()))
 		}
 		return newv.Interface(), nil
 	case reflect.Map:
@@ -174,7 +426,7 @@ func (c *MyObjectEncryptor) processObject(o interface{}, encrypt bool, oper func
 			if err != nil {
 				return nil, err
 			}
-			newv.SetMapIndex(k, reflect.ValueOf(newf))
+			newv.SetMapIndex(k, valueOf(newf, t.Elem()))
 		}
 		return newv.Interface(), nil
 	default:
@@ -182,3 +434,12 @@ func (c *MyObjectEncryptor) processObject(o interface{}, encrypt bool, oper func
 
 	return o, nil
 }
+
+// valueOf returns the value of a processed field or element of the type,
+// the zero value of the type for a nil interface
+func valueOf(o interface{}, t reflect.Type) reflect.Value {
+	if o == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(o)
+}
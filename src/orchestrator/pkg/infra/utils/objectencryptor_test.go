@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/grpc/kms"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func newKey() string {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+type testSecret struct {
+	Name   string `json:"name"`
+	Config string `json:"config" encrypted:""`
+}
+
+func TestEncryptString(t *testing.T) {
+	os.Setenv("TEST_DATA_KEYS", "k2="+newKey()+",k1="+newKey())
+	defer os.Unsetenv("TEST_DATA_KEYS")
+	keys, err := newEnvKeySource("test")
+	if err != nil || keys == nil {
+		t.Fatalf("Unexpected key source %v %v", keys, err)
+	}
+	oe, err := NewObjectEncryptor(keys, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	c1, _ := oe.EncryptString("secret")
+	c2, _ := oe.EncryptString("secret")
+	if c1 == c2 {
+		t.Errorf("Expected a random nonce for each value")
+	}
+	if !strings.HasPrefix(c1, "emco:v1:k2:") {
+		t.Errorf("Expected the ID of the current key in %s", c1)
+	}
+	for _, c := range []string{c1, c2} {
+		if m, err := oe.DecryptString(c); err != nil || m != "secret" {
+			t.Errorf("Unexpected decryption %q %v", m, err)
+		}
+	}
+
+	// a new encryptor decrypts the data key with the key source
+	other, _ := NewObjectEncryptor(keys, nil)
+	if m, err := other.DecryptString(c1); err != nil || m != "secret" {
+		t.Errorf("Unexpected decryption %q %v", m, err)
+	}
+
+	tampered := c1[:len(c1)-2] + "AA"
+	if _, err := oe.DecryptString(tampered); err == nil {
+		t.Errorf("Expected an error for a tampered value")
+	}
+
+	s, err := oe.EncryptObject(testSecret{Name: "c1", Config: "kubeconfig"})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	encrypted := s.(testSecret)
+	if encrypted.Name != "c1" || !strings.HasPrefix(encrypted.Config, ciphertextPrefix) {
+		t.Errorf("Unexpected encrypted object %+v", encrypted)
+	}
+	if _, err := oe.DecryptObject(&encrypted); err != nil || encrypted.Config != "kubeconfig" {
+		t.Errorf("Unexpected decrypted object %+v %v", encrypted, err)
+	}
+}
+
+type testRollout struct {
+	Waves []string `json:"waves"`
+}
+
+type testIntent struct {
+	Secret  string       `json:"secret" encrypted:""`
+	Rollout *testRollout `json:"rollout,omitempty"`
+}
+
+func TestEncryptNilPointer(t *testing.T) {
+	os.Setenv("TEST_DATA_KEYS", "k1="+newKey())
+	defer os.Unsetenv("TEST_DATA_KEYS")
+	keys, _ := newEnvKeySource("test")
+	oe, err := NewObjectEncryptor(keys, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	e, err := oe.EncryptObject(testIntent{Secret: "secret"})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	encrypted := e.(testIntent)
+	if encrypted.Rollout != nil || encrypted.Secret == "secret" {
+		t.Errorf("Unexpected encrypted object %+v", encrypted)
+	}
+
+	d, err := oe.DecryptObject(encrypted)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if decrypted := d.(testIntent); decrypted.Rollout != nil || decrypted.Secret != "secret" {
+		t.Errorf("Unexpected decrypted object %+v", decrypted)
+	}
+}
+
+func TestLegacyValues(t *testing.T) {
+	legacyKey := []byte("0123456789abcdefghijklmnopqrstuv")
+	aead, nonce, _ := createLegacyCipher(legacyKey, []byte("emco nonce"))
+	legacy := hex.EncodeToString(aead.Seal(nil, nonce, []byte("kubeconfig"), nil))
+
+	os.Setenv("TEST_DATA_KEY", string(legacyKey))
+	defer os.Unsetenv("TEST_DATA_KEY")
+	keys, err := newEnvKeySource("test")
+	if err != nil || keys == nil {
+		t.Fatalf("Unexpected key source %v %v", keys, err)
+	}
+	oe, _ := NewObjectEncryptor(keys, legacyKey)
+
+	if m, err := oe.DecryptString(legacy); err != nil || m != "kubeconfig" {
+		t.Errorf("Unexpected decryption of the legacy value %q %v", m, err)
+	}
+	v, changed, err := oe.Reencrypt(legacy)
+	if err != nil || !changed || !strings.HasPrefix(v, "emco:v1:legacy:") {
+		t.Fatalf("Unexpected re-encryption of the legacy value %q %v %v", v, changed, err)
+	}
+	if m, _ := oe.DecryptString(v); m != "kubeconfig" {
+		t.Errorf("Unexpected decryption %q", m)
+	}
+	if _, changed, _ := oe.Reencrypt(v); changed {
+		t.Errorf("Expected the value to be encrypted with the current key")
+	}
+	if v, changed, err := oe.Reencrypt("plain"); err != nil || changed || v != "plain" {
+		t.Errorf("Expected a plain value to be kept, got %q %v %v", v, changed, err)
+	}
+}
+
+func writeKeyFile(t *testing.T, file, current string, keys map[string]string) {
+	data, _ := json.Marshal(map[string]interface{}{"current": current, "keys": keys})
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+}
+
+func TestFileKeyRotation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "keys.json")
+	k1 := newKey()
+	writeKeyFile(t, file, "k1", map[string]string{"k1": k1})
+	keys, err := newFileKeySource(file)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	oe, _ := NewObjectEncryptor(keys, nil)
+	old, _ := oe.EncryptString("secret")
+
+	writeKeyFile(t, file, "k2", map[string]string{"k1": k1, "k2": newKey()})
+	os.Chtimes(file, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if id, err := oe.RefreshKey(); err != nil || id != "k2" {
+		t.Fatalf("Expected the new key, got %q %v", id, err)
+	}
+	v, changed, err := oe.Reencrypt(old)
+	if err != nil || !changed || !strings.HasPrefix(v, "emco:v1:k2:") {
+		t.Fatalf("Unexpected re-encryption %q %v %v", v, changed, err)
+	}
+	if m, err := oe.DecryptString(old); err != nil || m != "secret" {
+		t.Errorf("Expected the old key to decrypt the old value, got %q %v", m, err)
+	}
+
+	if _, err := newFileKeySource(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+	writeKeyFile(t, file, "k3", map[string]string{"k1": k1})
+	if _, err := newFileKeySource(file); err == nil {
+		t.Errorf("Expected an error for a missing current key")
+	}
+}
+
+// testPlugin is a KMS plugin holding a single key
+type testPlugin struct {
+	kms.UnimplementedKeyManagementServiceServer
+	keys *localKeys
+}
+
+func (p *testPlugin) Status(ctx context.Context, req *kms.StatusRequest) (*kms.StatusResponse, error) {
+	return &kms.StatusResponse{KeyId: p.keys.current}, nil
+}
+
+func (p *testPlugin) Encrypt(ctx context.Context, req *kms.EncryptRequest) (*kms.EncryptResponse, error) {
+	id, ciphertext, err := p.keys.encrypt(req.Plaintext)
+	return &kms.EncryptResponse{KeyId: id, Ciphertext: ciphertext}, err
+}
+
+func (p *testPlugin) Decrypt(ctx context.Context, req *kms.DecryptRequest) (*kms.DecryptResponse, error) {
+	plaintext, err := p.keys.decrypt(req.KeyId, req.Ciphertext)
+	return &kms.DecryptResponse{Plaintext: plaintext}, err
+}
+
+// servePlugin serves a KMS plugin holding the key hsm-1 on the listener
+func servePlugin(t *testing.T, lis net.Listener, opts ...grpc.ServerOption) {
+	keys, _ := newLocalKeys("hsm-1", map[string]string{"hsm-1": newKey()})
+	server := grpc.NewServer(opts...)
+	kms.RegisterKeyManagementServiceServer(server, &testPlugin{keys: keys})
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+}
+
+// checkPluginKeySource encrypts and decrypts a value with the plugin
+func checkPluginKeySource(t *testing.T, source KeySource) {
+	oe, _ := NewObjectEncryptor(source, nil)
+	v, err := oe.EncryptString("private key")
+	if err != nil || !strings.HasPrefix(v, "emco:v1:hsm-1:") {
+		t.Fatalf("Unexpected encryption %q %v", v, err)
+	}
+	other, _ := NewObjectEncryptor(source, nil)
+	if m, err := other.DecryptString(v); err != nil || m != "private key" {
+		t.Errorf("Unexpected decryption %q %v", m, err)
+	}
+}
+
+func TestPluginKeySource(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "kms-plugin.sock")
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	servePlugin(t, lis)
+
+	source, err := newPluginKeySource("unix://"+socket, pluginTLS{})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	checkPluginKeySource(t, source)
+}
+
+func TestPluginKeySourceTLS(t *testing.T) {
+	dir := t.TempDir()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kms-plugin"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	caFile := filepath.Join(dir, "ca.pem")
+	ioutil.WriteFile(caFile, certPEM, 0600)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	servePlugin(t, lis, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+	endpoint := "localhost:" + strings.Split(lis.Addr().String(), ":")[1]
+
+	// a TCP endpoint requires TLS
+	if _, err := newPluginKeySource(endpoint, pluginTLS{}); err == nil {
+		t.Fatalf("Expected an error for a TCP endpoint without a CA certificate")
+	}
+	source, err := newPluginKeySource(endpoint, pluginTLS{caFile: caFile})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	checkPluginKeySource(t, source)
+}
+
+type testScheduled struct {
+	Operation string `json:"operation"`
+}
+
+type testState struct {
+	Secret    string         `json:"secret" encrypted:""`
+	Scheduled *testScheduled `json:"scheduled,omitempty"`
+	Extra     interface{}    `json:"extra"`
+	Values    []interface{}  `json:"values"`
+}
+
+func TestEncryptNilFields(t *testing.T) {
+	os.Setenv("TEST_DATA_KEYS", "k1="+newKey())
+	defer os.Unsetenv("TEST_DATA_KEYS")
+	keys, _ := newEnvKeySource("test")
+	oe, _ := NewObjectEncryptor(keys, nil)
+
+	s, err := oe.EncryptObject(testState{Secret: "s", Values: []interface{}{nil, "v"}})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	encrypted := s.(testState)
+	if encrypted.Scheduled != nil || encrypted.Extra != nil || encrypted.Values[0] != nil || encrypted.Values[1] != "v" ||
+		!strings.HasPrefix(encrypted.Secret, ciphertextPrefix) {
+		t.Errorf("Unexpected encrypted object %+v", encrypted)
+	}
+}
@@ -47,6 +47,7 @@ type IntentManager interface {
 	AddIntent(ctx context.Context, a Intent, p string, ca string, v string, di string, failIfExists bool) (Intent, bool, error)
 	GetIntent(ctx context.Context, i string, p string, ca string, v string, di string) (Intent, error)
 	GetAllIntents(ctx context.Context, p, ca, v, di string) (ListOfIntents, error)
+	ListIntents(ctx context.Context, p, ca, v, di string, opts db.FindOptions) (ListOfIntents, string, error)
 	GetIntentByName(ctx context.Context, i, p, ca, v, di string) (IntentSpecData, error)
 	DeleteIntent(ctx context.Context, i string, p string, ca string, v string, di string) error
 }
@@ -71,6 +72,16 @@ func (ik IntentKey) String() string {
 	return string(out)
 }
 
+// intentSelectors are the fields a list of Intents can be filtered and sorted on
+var intentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": Intent{},
+	},
+}
+
 // IntentClient implements the AddIntentManager interface
 type IntentClient struct {
 	storeName   string
@@ -187,6 +198,18 @@ GetAllIntents takes in projectName, CompositeAppName, CompositeAppVersion,
 DeploymentIntentName . It returns ListOfIntents.
 */
 func (c IntentClient) GetAllIntents(ctx context.Context, p string, ca string, v string, di string) (ListOfIntents, error) {
+	res, _, err := c.ListIntents(ctx, p, ca, v, di, db.FindOptions{})
+	return res, err
+}
+
+// ListIntents returns a page of the Intents of the deployment intent group,
+// filtered and sorted on the intentSelectors, and the token of the next page
+func (c IntentClient) ListIntents(ctx context.Context, p string, ca string, v string, di string, opts db.FindOptions) (ListOfIntents, string, error) {
+	opts, err := intentSelectors.Resolve(opts)
+	if err != nil {
+		return ListOfIntents{}, "", err
+	}
+
 	k := IntentKey{
 		Name:                  "",
 		Project:               p,
@@ -195,9 +218,9 @@ func (c IntentClient) GetAllIntents(ctx context.Context, p string, ca string, v
 		DeploymentIntentGroup: di,
 	}
 
-	result, err := db.DBconn.Find(ctx, c.storeName, k, c.tagMetaData)
+	result, next, err := db.DBconn.FindWithOptions(ctx, c.storeName, k, c.tagMetaData, opts)
 	if err != nil {
-		return ListOfIntents{}, err
+		return ListOfIntents{}, "", err
 	}
 	var a Intent
 	var listOfMapOfIntents []map[string]string
@@ -207,13 +230,13 @@ func (c IntentClient) GetAllIntents(ctx context.Context, p string, ca string, v
 			a = Intent{}
 			err = db.DBconn.Unmarshal(result[i], &a)
 			if err != nil {
-				return ListOfIntents{}, err
+				return ListOfIntents{}, "", err
 			}
 			listOfMapOfIntents = append(listOfMapOfIntents, a.Spec.Intent)
 		}
-		return ListOfIntents{listOfMapOfIntents}, nil
+		return ListOfIntents{listOfMapOfIntents}, next, nil
 	}
-	return ListOfIntents{}, err
+	return ListOfIntents{}, "", err
 }
 
 func (c IntentClient) getAllIntents(ctx context.Context, p string, ca string, v string, di string) ([]*Intent, error) {
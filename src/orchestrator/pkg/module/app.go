@@ -54,9 +54,20 @@ type AppManager interface {
 	GetApp(ctx context.Context, name string, p string, cN string, cV string) (App, error)
 	GetAppContent(ctx context.Context, name string, p string, cN string, cV string) (AppContent, error)
 	GetApps(ctx context.Context, p string, cN string, cV string) ([]App, error)
+	ListApps(ctx context.Context, p string, cN string, cV string, opts db.FindOptions) ([]App, string, error)
 	DeleteApp(ctx context.Context, name string, p string, cN string, cV string) error
 }
 
+// appSelectors are the fields a list of Apps can be filtered and sorted on
+var appSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": App{},
+	},
+}
+
 // AppClient implements the AppManager
 // It will also be used to maintain some localized state
 type AppClient struct {
@@ -166,6 +177,17 @@ func (v *AppClient) GetAppContent(ctx context.Context, name string, p string, cN
 
 // GetApps returns all Apps for given composite App
 func (v *AppClient) GetApps(ctx context.Context, project, compositeApp, compositeAppVersion string) ([]App, error) {
+	res, _, err := v.ListApps(ctx, project, compositeApp, compositeAppVersion, db.FindOptions{})
+	return res, err
+}
+
+// ListApps returns a page of the Apps of the composite App, filtered and
+// sorted on the appSelectors, and the token of the next page
+func (v *AppClient) ListApps(ctx context.Context, project, compositeApp, compositeAppVersion string, opts db.FindOptions) ([]App, string, error) {
+	opts, err := appSelectors.Resolve(opts)
+	if err != nil {
+		return []App{}, "", err
+	}
 
 	key := AppKey{
 		App:                 "",
@@ -175,21 +197,21 @@ func (v *AppClient) GetApps(ctx context.Context, project, compositeApp, composit
 	}
 
 	var resp []App
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []App{}, err
+		return []App{}, "", err
 	}
 
 	for _, value := range values {
 		a := App{}
 		err = db.DBconn.Unmarshal(value, &a)
 		if err != nil {
-			return []App{}, err
+			return []App{}, "", err
 		}
 		resp = append(resp, a)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteApp deletes the  App from database
@@ -22,6 +22,9 @@ type Instantiator struct {
 	compAppVersion      string
 	deploymentIntent    string
 	deploymentIntentGrp DeploymentIntentGroup
+	// revision of the DeploymentIntentGroup the app context deploys, 0 if
+	// it isn't instantiated
+	revision int64
 }
 
 // MakeAppContext shall make an app context and store the app context into etcd. This shall return contextForCompositeApp
@@ -67,6 +70,11 @@ func (i *Instantiator) makeAppContextForCompositeApp(ctx context.Context, namesp
 		Level:                 level,
 		LogicalCloud:          logicalCloud,
 		Services:              utils.MapKeys(i.deploymentIntentGrp.Spec.InstantiatedServices),
+		DriftDetection:        i.deploymentIntentGrp.Spec.DriftDetection,
+		RetryPolicy:           i.deploymentIntentGrp.Spec.RetryPolicy,
+		ForceConflicts:        i.deploymentIntentGrp.Spec.ForceConflicts,
+		PruneOrphans:          i.deploymentIntentGrp.Spec.PruneOrphans,
+		Revision:              i.revision,
 	})
 	if err != nil {
 		return contextForCompositeApp{}, pkgerrors.Wrap(err, "Error Adding CompositeAppMeta")
@@ -109,6 +117,25 @@ func (i *Instantiator) storeAppContextIntoRunTimeDB(ctx context.Context, cxtForC
 		log.Error(str, log.Fields{"composite app": i.compositeApp})
 		return pkgerrors.New(str)
 	}
+
+	// resolve the placement intents of all the apps together, for the exclusions between apps
+	intents := make(map[string]gpic.IntentStruc, len(allApps))
+	for _, eachApp := range allApps {
+		specData, err := NewAppIntentClient().GetAllIntentsByApp(ctx, eachApp.Metadata.Name, i.project, i.compositeApp, i.compAppVersion, gIntent, i.deploymentIntent)
+		if err != nil {
+			return pkgerrors.Wrap(err, "Unable to get the intents for app")
+		}
+		intents[eachApp.Metadata.Name] = specData.Intent
+	}
+	allowedClusters := make([]gpic.ClusterWithName, 0, len(dcmClusters))
+	for _, c := range dcmClusters {
+		allowedClusters = append(allowedClusters, gpic.ClusterWithName{ProviderName: c.Specification.ClusterProvider, ClusterName: c.Specification.ClusterName})
+	}
+	resolvedIntents, err := gpic.ResolveIntents(intents, allowedClusters)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Unable to get the intents resolved for app")
+	}
+
 	for _, eachApp := range allApps {
 		appOrdInsStr.Apporder = append(appOrdInsStr.Apporder, eachApp.Metadata.Name)
 		appDepStr.AppDepMap[eachApp.Metadata.Name] = "go"
@@ -126,16 +153,8 @@ func (i *Instantiator) storeAppContextIntoRunTimeDB(ctx context.Context, cxtForC
 		// Read app dependency, if err continue
 		appDep, _ := NewAppDependencyClient().GetAllSpecAppDependency(ctx, i.project, i.compositeApp, i.compAppVersion, eachApp.Metadata.Name)
 
-		specData, err := NewAppIntentClient().GetAllIntentsByApp(ctx, eachApp.Metadata.Name, i.project, i.compositeApp, i.compAppVersion, gIntent, i.deploymentIntent)
-		if err != nil {
-			return pkgerrors.Wrap(err, "Unable to get the intents for app")
-		}
-
 		// listOfClusters shall have both mandatoryClusters and optionalClusters where the app needs to be installed.
-		listOfClusters, err := gpic.IntentResolver(specData.Intent)
-		if err != nil {
-			return pkgerrors.Wrap(err, "Unable to get the intents resolved for app")
-		}
+		listOfClusters := resolvedIntents[eachApp.Metadata.Name]
 
 		log.Info(":: listOfClusters ::", log.Fields{"listOfClusters": listOfClusters})
 		if listOfClusters.MandatoryClusters == nil && listOfClusters.OptionalClusters == nil {
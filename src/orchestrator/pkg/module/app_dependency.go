@@ -60,6 +60,19 @@ type AppDependencyManager interface {
 	GetAppDependency(ctx context.Context, dep string, p string, ca string, v string, app string) (AppDependency, error)
 	DeleteAppDependency(ctx context.Context, dep string, p string, ca string, v string, app string) error
 	GetAllAppDependency(ctx context.Context, p string, ca string, v string, app string) ([]AppDependency, error)
+	ListAppDependencies(ctx context.Context, p string, ca string, v string, app string, opts db.FindOptions) ([]AppDependency, string, error)
+}
+
+// appDependencySelectors are the fields a list of AppDependencies can be filtered and sorted on
+var appDependencySelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":     "data.metadata.name",
+		"app":      "data.spec.app",
+		"opStatus": "data.spec.opStatus",
+	},
+	TagTypes: map[string]interface{}{
+		"data": AppDependency{},
+	},
 }
 
 // AppDependencyClient implements the AppDependencyManager
@@ -137,6 +150,18 @@ func (d *AppDependencyClient) GetAppDependency(ctx context.Context, dep string,
 
 // GetAllAppDependency returns all the AppDependencys
 func (d *AppDependencyClient) GetAllAppDependency(ctx context.Context, p string, ca string, v string, app string) ([]AppDependency, error) {
+	res, _, err := d.ListAppDependencies(ctx, p, ca, v, app, db.FindOptions{})
+	return res, err
+}
+
+// ListAppDependencies returns a page of the dependencies of the App, filtered
+// and sorted on the appDependencySelectors, and the token of the next page
+func (d *AppDependencyClient) ListAppDependencies(ctx context.Context, p string, ca string, v string, app string, opts db.FindOptions) ([]AppDependency, string, error) {
+	opts, err := appDependencySelectors.Resolve(opts)
+	if err != nil {
+		return []AppDependency{}, "", err
+	}
+
 	key := AppDependencyKey{
 		Project:      p,
 		CompositeApp: ca,
@@ -146,20 +171,20 @@ func (d *AppDependencyClient) GetAllAppDependency(ctx context.Context, p string,
 	}
 
 	res := make([]AppDependency, 0)
-	values, err := db.DBconn.Find(ctx, d.storeName, key, d.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, d.storeName, key, d.tagMeta, opts)
 	if err != nil {
-		return []AppDependency{}, err
+		return []AppDependency{}, "", err
 	}
 
 	for _, value := range values {
 		p := AppDependency{}
 		err = db.DBconn.Unmarshal(value, &p)
 		if err != nil {
-			return []AppDependency{}, err
+			return []AppDependency{}, "", err
 		}
 		res = append(res, p)
 	}
-	return res, nil
+	return res, next, nil
 }
 
 // DeleteAppDependency the  AppDependency from database
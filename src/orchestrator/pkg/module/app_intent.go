@@ -50,6 +50,7 @@ type AppIntentManager interface {
 	GetAppIntent(ctx context.Context, ai string, p string, ca string, v string, i string, digName string) (AppIntent, error)
 	GetAllIntentsByApp(ctx context.Context, aN, p, ca, v, i, digName string) (SpecData, error)
 	GetAllAppIntents(ctx context.Context, p, ca, v, i, digName string) ([]AppIntent, error)
+	ListAppIntents(ctx context.Context, p, ca, v, i, digName string, opts db.FindOptions) ([]AppIntent, string, error)
 	DeleteAppIntent(ctx context.Context, ai string, p string, ca string, v string, i string, digName string) error
 }
 
@@ -122,6 +123,17 @@ func NewIntentSelectorHandler() IntentSelectorHandler {
 	return &intentSelectorHandler{}
 }
 
+// appIntentSelectors are the fields a list of AppIntents can be filtered and sorted on
+var appIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+		"app":  "data.spec.app",
+	},
+	TagTypes: map[string]interface{}{
+		"data": AppIntent{},
+	},
+}
+
 // AppIntentClient implements the AppIntentManager interface
 type AppIntentClient struct {
 	storeName        string
@@ -249,6 +261,19 @@ GetAllAppIntents takes in paramaters ProjectName, CompositeAppName, CompositeNam
 and GenericPlacementIntentName,DeploymentIntentGroupName. Returns an array of AppIntents
 */
 func (c *AppIntentClient) GetAllAppIntents(ctx context.Context, p, ca, v, i, digName string) ([]AppIntent, error) {
+	res, _, err := c.ListAppIntents(ctx, p, ca, v, i, digName, db.FindOptions{})
+	return res, err
+}
+
+// ListAppIntents returns a page of the AppIntents of the generic placement
+// intent, filtered and sorted on the appIntentSelectors, and the token of the
+// next page
+func (c *AppIntentClient) ListAppIntents(ctx context.Context, p, ca, v, i, digName string, opts db.FindOptions) ([]AppIntent, string, error) {
+	opts, err := appIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []AppIntent{}, "", err
+	}
+
 	k := AppIntentKey{
 		Name:                      "",
 		Project:                   p,
@@ -257,9 +282,9 @@ func (c *AppIntentClient) GetAllAppIntents(ctx context.Context, p, ca, v, i, dig
 		Intent:                    i,
 		DeploymentIntentGroupName: digName,
 	}
-	result, err := db.DBconn.Find(ctx, c.storeName, k, c.tagMetaData)
+	result, next, err := db.DBconn.FindWithOptions(ctx, c.storeName, k, c.tagMetaData, opts)
 	if err != nil {
-		return []AppIntent{}, err
+		return []AppIntent{}, "", err
 	}
 
 	var appIntents []AppIntent
@@ -269,13 +294,13 @@ func (c *AppIntentClient) GetAllAppIntents(ctx context.Context, p, ca, v, i, dig
 			aI := AppIntent{}
 			err = db.DBconn.Unmarshal(result[i], &aI)
 			if err != nil {
-				return []AppIntent{}, err
+				return []AppIntent{}, "", err
 			}
 			appIntents = append(appIntents, aI)
 		}
 	}
 
-	return appIntents, err
+	return appIntents, next, nil
 }
 
 // DeleteAppIntent delete an AppIntent
@@ -311,6 +336,10 @@ func (c *AppIntentClient) CloneAppIntents(ctx context.Context, p string, ca stri
 
 func (i *intentSelectorHandler) Handle(ctx context.Context, appIntent *AppIntent, digName, project, contextApp, version string) error {
 
+	if err := gpic.ValidateIntent(appIntent.Spec.Intent); err != nil {
+		return pkgerrors.Wrap(err, "Invalid placement intent")
+	}
+
 	dig, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, digName, project, contextApp, version)
 	if err != nil {
 		return err
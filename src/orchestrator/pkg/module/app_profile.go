@@ -61,12 +61,24 @@ type AppProfileManager interface {
 	CreateAppProfile(ctx context.Context, provider, compositeApp, compositeAppVersion, compositeProfile string, ap AppProfile, ac AppProfileContent, exists bool) (AppProfile, error)
 	GetAppProfile(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile, profile string) (AppProfile, error)
 	GetAppProfiles(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile string) ([]AppProfile, error)
+	ListAppProfiles(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile string, opts db.FindOptions) ([]AppProfile, string, error)
 	GetAppProfileByApp(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile, appName string) (AppProfile, error)
 	GetAppProfileContent(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile, profile string) (AppProfileContent, error)
 	GetAppProfileContentByApp(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile, appName string) (AppProfileContent, error)
 	DeleteAppProfile(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile, profile string) error
 }
 
+// appProfileSelectors are the fields a list of AppProfiles can be filtered and sorted on
+var appProfileSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+		"app":  "data.spec.app",
+	},
+	TagTypes: map[string]interface{}{
+		"data": AppProfile{},
+	},
+}
+
 // AppProfileClient implements the Manager
 // It will also be used to maintain some localized state
 type AppProfileClient struct {
@@ -160,6 +172,17 @@ func (c *AppProfileClient) GetAppProfile(ctx context.Context, project, composite
 
 // GetAppProfile - return all App Profiles for given composite profile
 func (c *AppProfileClient) GetAppProfiles(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile string) ([]AppProfile, error) {
+	res, _, err := c.ListAppProfiles(ctx, project, compositeApp, compositeAppVersion, compositeProfile, db.FindOptions{})
+	return res, err
+}
+
+// ListAppProfiles returns a page of the AppProfiles of the composite profile,
+// filtered and sorted on the appProfileSelectors, and the token of the next page
+func (c *AppProfileClient) ListAppProfiles(ctx context.Context, project, compositeApp, compositeAppVersion, compositeProfile string, opts db.FindOptions) ([]AppProfile, string, error) {
+	opts, err := appProfileSelectors.Resolve(opts)
+	if err != nil {
+		return []AppProfile{}, "", err
+	}
 
 	key := AppProfileKey{
 		Project:             project,
@@ -170,21 +193,21 @@ func (c *AppProfileClient) GetAppProfiles(ctx context.Context, project, composit
 	}
 
 	var resp []AppProfile
-	values, err := db.DBconn.Find(ctx, c.storeName, key, c.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, c.storeName, key, c.tagMeta, opts)
 	if err != nil {
-		return []AppProfile{}, err
+		return []AppProfile{}, "", err
 	}
 
 	for _, value := range values {
 		ap := AppProfile{}
 		err = db.DBconn.Unmarshal(value, &ap)
 		if err != nil {
-			return []AppProfile{}, err
+			return []AppProfile{}, "", err
 		}
 		resp = append(resp, ap)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // GetAppProfileByApp - return all App Profiles for given composite profile
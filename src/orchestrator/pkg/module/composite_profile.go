@@ -53,10 +53,22 @@ type CompositeProfileManager interface {
 		compositeAppName string, version string) (CompositeProfile, error)
 	GetCompositeProfiles(ctx context.Context, projectName string, compositeAppName string,
 		version string) ([]CompositeProfile, error)
+	ListCompositeProfiles(ctx context.Context, projectName string, compositeAppName string,
+		version string, opts db.FindOptions) ([]CompositeProfile, string, error)
 	DeleteCompositeProfile(ctx context.Context, compositeProfileName string, projectName string,
 		compositeAppName string, version string) error
 }
 
+// compositeProfileSelectors are the fields a list of CompositeProfiles can be filtered and sorted on
+var compositeProfileSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": CompositeProfile{},
+	},
+}
+
 // CompositeProfileClient implements the Manager
 // It will also be used to maintain some localized state
 type CompositeProfileClient struct {
@@ -127,6 +139,19 @@ func (c *CompositeProfileClient) GetCompositeProfile(ctx context.Context, cpf st
 
 // GetCompositeProfiles shall take arguments - name of the project, name of the composite profile and version of the composite app. It shall return an array of CompositeProfile.
 func (c *CompositeProfileClient) GetCompositeProfiles(ctx context.Context, p string, ca string, v string) ([]CompositeProfile, error) {
+	res, _, err := c.ListCompositeProfiles(ctx, p, ca, v, db.FindOptions{})
+	return res, err
+}
+
+// ListCompositeProfiles returns a page of the CompositeProfiles of the composite
+// App, filtered and sorted on the compositeProfileSelectors, and the token of
+// the next page
+func (c *CompositeProfileClient) ListCompositeProfiles(ctx context.Context, p string, ca string, v string, opts db.FindOptions) ([]CompositeProfile, string, error) {
+	opts, err := compositeProfileSelectors.Resolve(opts)
+	if err != nil {
+		return []CompositeProfile{}, "", err
+	}
+
 	key := CompositeProfileKey{
 		Name:         "",
 		Project:      p,
@@ -134,9 +159,9 @@ func (c *CompositeProfileClient) GetCompositeProfiles(ctx context.Context, p str
 		Version:      v,
 	}
 
-	values, err := db.DBconn.Find(ctx, c.storeName, key, c.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, c.storeName, key, c.tagMeta, opts)
 	if err != nil {
-		return []CompositeProfile{}, err
+		return []CompositeProfile{}, "", err
 	}
 
 	var resp []CompositeProfile
@@ -145,12 +170,12 @@ func (c *CompositeProfileClient) GetCompositeProfiles(ctx context.Context, p str
 		cp := CompositeProfile{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []CompositeProfile{}, err
+			return []CompositeProfile{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteCompositeProfile deletes the compsiteApp profile from the database
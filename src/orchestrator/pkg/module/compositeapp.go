@@ -54,9 +54,21 @@ type CompositeAppManager interface {
 	CreateCompositeApp(ctx context.Context, c CompositeApp, p string, exists bool) (CompositeApp, error)
 	GetCompositeApp(ctx context.Context, name string, version string, p string) (CompositeApp, error)
 	GetAllCompositeApps(ctx context.Context, p string) ([]CompositeApp, error)
+	ListCompositeApps(ctx context.Context, p string, opts db.FindOptions) ([]CompositeApp, string, error)
 	DeleteCompositeApp(ctx context.Context, name string, version string, p string) error
 }
 
+// compositeAppSelectors are the fields a list of CompositeApps can be filtered and sorted on
+var compositeAppSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":    "data.metadata.name",
+		"version": "data.spec.compositeAppVersion",
+	},
+	TagTypes: map[string]interface{}{
+		"data": CompositeApp{},
+	},
+}
+
 // CompositeAppClient implements the CompositeAppManager
 // It will also be used to maintain some localized state
 type CompositeAppClient struct {
@@ -128,10 +140,22 @@ func (v *CompositeAppClient) GetCompositeApp(ctx context.Context, name string, v
 
 // GetAllCompositeApps returns all the compositeApp for a given project
 func (v *CompositeAppClient) GetAllCompositeApps(ctx context.Context, p string) ([]CompositeApp, error) {
+	caList, _, err := v.ListCompositeApps(ctx, p, db.FindOptions{})
+	return caList, err
+}
+
+// ListCompositeApps returns a page of the CompositeApps of the project, filtered and
+// sorted on the compositeAppSelectors, and the token of the next page
+func (v *CompositeAppClient) ListCompositeApps(ctx context.Context, p string, opts db.FindOptions) ([]CompositeApp, string, error) {
+
+	opts, err := compositeAppSelectors.Resolve(opts)
+	if err != nil {
+		return []CompositeApp{}, "", err
+	}
 
-	_, err := NewProjectClient().GetProject(ctx, p)
+	_, err = NewProjectClient().GetProject(ctx, p)
 	if err != nil {
-		return []CompositeApp{}, pkgerrors.Wrap(err, "Project not found")
+		return []CompositeApp{}, "", pkgerrors.Wrap(err, "Project not found")
 	}
 
 	key := CompositeAppKey{
@@ -141,21 +165,21 @@ func (v *CompositeAppClient) GetAllCompositeApps(ctx context.Context, p string)
 	}
 
 	var caList []CompositeApp
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []CompositeApp{}, err
+		return []CompositeApp{}, "", err
 	}
 
 	for _, value := range values {
 		ca := CompositeApp{}
 		err = db.DBconn.Unmarshal(value, &ca)
 		if err != nil {
-			return []CompositeApp{}, err
+			return []CompositeApp{}, "", err
 		}
 		caList = append(caList, ca)
 	}
 
-	return caList, nil
+	return caList, next, nil
 }
 
 // DeleteCompositeApp deletes the  CompositeApp from database
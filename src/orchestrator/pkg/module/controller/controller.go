@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
@@ -19,6 +20,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	register "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/grpc"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apiauth"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/audit"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
@@ -73,6 +77,7 @@ type ControllerManager interface {
 	CreateController(ctx context.Context, ms Controller, mayExist bool) (Controller, error)
 	GetController(ctx context.Context, name string) (Controller, error)
 	GetControllers(ctx context.Context) ([]Controller, error)
+	ListControllers(ctx context.Context, opts db.FindOptions) ([]Controller, string, error)
 	InitControllers(ctx context.Context)
 	DeleteController(ctx context.Context, name string) error
 }
@@ -154,8 +159,33 @@ func (mc *ControllerClient) GetController(ctx context.Context, name string) (Con
 	return Controller{}, pkgerrors.New("Unknown Error")
 }
 
+// selectors returns the fields a list of Controllers can be filtered and sorted on
+func (mc *ControllerClient) selectors() db.FieldSelectors {
+	return db.FieldSelectors{
+		Paths: map[string]string{
+			"name": mc.tagMeta + ".metadata.name",
+			"type": mc.tagMeta + ".spec.type",
+		},
+		TagTypes: map[string]interface{}{
+			mc.tagMeta: Controller{},
+		},
+	}
+}
+
 // GetControllers returns all the  Controllers that are registered
 func (mc *ControllerClient) GetControllers(ctx context.Context) ([]Controller, error) {
+	resp, _, err := mc.ListControllers(ctx, db.FindOptions{})
+	return resp, err
+}
+
+// ListControllers returns a page of the Controllers that are registered,
+// filtered and sorted on their selectors, and the token of the next page
+func (mc *ControllerClient) ListControllers(ctx context.Context, opts db.FindOptions) ([]Controller, string, error) {
+	opts, err := mc.selectors().Resolve(opts)
+	if err != nil {
+		return []Controller{}, "", err
+	}
+
 	// Construct the composite key to select the entry
 	key := ControllerKey{
 		ControllerName:  "",
@@ -163,22 +193,22 @@ func (mc *ControllerClient) GetControllers(ctx context.Context) ([]Controller, e
 	}
 
 	var resp []Controller
-	values, err := db.DBconn.Find(ctx, mc.collectionName, key, mc.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, mc.collectionName, key, mc.tagMeta, opts)
 	if err != nil {
-		return []Controller{}, err
+		return []Controller{}, "", err
 	}
 
 	for _, value := range values {
 		microserv := Controller{}
 		err = db.DBconn.Unmarshal(value, &microserv)
 		if err != nil {
-			return []Controller{}, err
+			return []Controller{}, "", err
 		}
 
 		resp = append(resp, microserv)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteController the  Controller from database
@@ -230,6 +260,27 @@ func NewControllerServer(name string, httpRouter *mux.Router, grpcServer *regist
 		httpRouter = mux.NewRouter()
 	}
 	httpRouter.Use(tracing.Middleware)
+	// The audit precedes the authentication to record the rejected requests
+	if config.GetConfiguration().AuditEnabled {
+		recorder, err := audit.NewRecorder(name, config.GetConfiguration().AuditFile)
+		if err != nil {
+			log.Error("Unable to configure the audit", log.Fields{"Error": err})
+			return nil, err
+		}
+		httpRouter.Use(recorder.Middleware)
+		if days := config.GetConfiguration().AuditRetention; days > 0 {
+			go recorder.RunRetention(context.Background(), time.Duration(days)*24*time.Hour)
+		}
+	}
+	if config.GetConfiguration().AuthEnabled {
+		authMiddleware, err := apiauth.New(config.GetConfiguration())
+		if err != nil {
+			log.Error("Unable to configure the authentication", log.Fields{"Error": err})
+			return nil, err
+		}
+		httpRouter.Use(authMiddleware.Handler)
+	}
+	httpRouter.Use(apilist.Middleware)
 	httpServer, err := newHttpServer(name, httpServerPort, httpRouter)
 	if err != nil {
 		log.Error("Unable to create HTTP server", log.Fields{"Error": err})
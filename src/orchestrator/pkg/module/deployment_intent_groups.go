@@ -16,6 +16,7 @@ import (
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/schedule"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
 )
 
@@ -35,14 +36,20 @@ type DepMetaData struct {
 
 // DepSpecData has profile, version, OverrideValuesObj
 type DepSpecData struct {
-	Id                   string                 `json:"id"`
-	Profile              string                 `json:"compositeProfile"`
-	Version              string                 `json:"version"`
-	OverrideValuesObj    []OverrideValues       `json:"overrideValues"`
-	LogicalCloud         string                 `json:"logicalCloud"`
-	Services             map[string]interface{} `json:"services"`
-	InstantiatedServices map[string]interface{} `json:"instantiatedServices"`
-	Action               string                 `json:"action"`
+	Id                   string                     `json:"id"`
+	Profile              string                     `json:"compositeProfile"`
+	Version              string                     `json:"version"`
+	OverrideValuesObj    []OverrideValues           `json:"overrideValues"`
+	LogicalCloud         string                     `json:"logicalCloud"`
+	Services             map[string]interface{}     `json:"services"`
+	InstantiatedServices map[string]interface{}     `json:"instantiatedServices"`
+	Action               string                     `json:"action"`
+	RolloutStrategy      *RolloutStrategy           `json:"rolloutStrategy,omitempty"`
+	MaintenanceWindows   []schedule.Window          `json:"maintenanceWindows,omitempty"`
+	DriftDetection       *appcontext.DriftDetection `json:"driftDetection,omitempty"`
+	RetryPolicy          *appcontext.RetryPolicy    `json:"retryPolicy,omitempty"`
+	ForceConflicts       bool                       `json:"forceConflicts,omitempty"`
+	PruneOrphans         *appcontext.PruneOrphans   `json:"pruneOrphans,omitempty"`
 }
 
 // OverrideValues has appName and ValuesObj
@@ -83,6 +90,7 @@ type DeploymentIntentGroupManager interface {
 	GetDeploymentIntentGroupState(ctx context.Context, di string, p string, ca string, v string) (state.StateInfo, error)
 	DeleteDeploymentIntentGroup(ctx context.Context, di string, p string, ca string, v string) error
 	GetAllDeploymentIntentGroups(ctx context.Context, p string, ca string, v string) ([]DeploymentIntentGroup, error)
+	ListDeploymentIntentGroups(ctx context.Context, p string, ca string, v string, opts db.FindOptions) ([]DeploymentIntentGroup, string, error)
 }
 
 // DeploymentIntentGroupKey consists of Name of the deployment group, project name, CompositeApp name, CompositeApp version
@@ -133,6 +141,21 @@ func (dk DeploymentIntentGroupKey) String() string {
 	return string(out)
 }
 
+// deploymentIntentGroupSelectors are the fields a list of DeploymentIntentGroups can be filtered and sorted on
+var deploymentIntentGroupSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":             "data.metadata.name",
+		"version":          "data.spec.version",
+		"compositeProfile": "data.spec.compositeProfile",
+		"logicalCloud":     "data.spec.logicalCloud",
+		"state":            "stateInfo.actions.-1.state",
+	},
+	TagTypes: map[string]interface{}{
+		"data":      DeploymentIntentGroup{},
+		"stateInfo": state.StateInfo{},
+	},
+}
+
 // DeploymentIntentGroupClient implements the DeploymentIntentGroupManager interface
 type DeploymentIntentGroupClient struct {
 	storeName   string
@@ -269,6 +292,13 @@ func (c *DeploymentIntentGroupClient) GetDeploymentIntentGroup(ctx context.Conte
 
 // GetAllDeploymentIntentGroups returns all the deploymentIntentGroups under a specific project, compositeApp and version
 func (c *DeploymentIntentGroupClient) GetAllDeploymentIntentGroups(ctx context.Context, p string, ca string, v string) ([]DeploymentIntentGroup, error) {
+	diList, _, err := c.ListDeploymentIntentGroups(ctx, p, ca, v, db.FindOptions{})
+	return diList, err
+}
+
+// ListDeploymentIntentGroups returns a page of the deploymentIntentGroups under a specific project, compositeApp and version,
+// filtered and sorted on the deploymentIntentGroupSelectors, and the token of the next page
+func (c *DeploymentIntentGroupClient) ListDeploymentIntentGroups(ctx context.Context, p string, ca string, v string, opts db.FindOptions) ([]DeploymentIntentGroup, string, error) {
 
 	key := DeploymentIntentGroupKey{
 		Name:         "",
@@ -277,43 +307,48 @@ func (c *DeploymentIntentGroupClient) GetAllDeploymentIntentGroups(ctx context.C
 		Version:      v,
 	}
 
+	opts, err := deploymentIntentGroupSelectors.Resolve(opts)
+	if err != nil {
+		return []DeploymentIntentGroup{}, "", err
+	}
+
 	//Check if project exists
-	_, err := NewProjectClient().GetProject(ctx, p)
+	_, err = NewProjectClient().GetProject(ctx, p)
 	if err != nil {
-		return []DeploymentIntentGroup{}, pkgerrors.Wrap(err, "Project not found")
+		return []DeploymentIntentGroup{}, "", pkgerrors.Wrap(err, "Project not found")
 	}
 
 	//check if compositeApp exists
 	_, err = NewCompositeAppClient().GetCompositeApp(ctx, ca, v, p)
 	if err != nil {
-		return []DeploymentIntentGroup{}, err
+		return []DeploymentIntentGroup{}, "", err
 	}
 	var diList []DeploymentIntentGroup
-	result, err := db.DBconn.Find(ctx, c.storeName, key, c.tagMetaData)
+	result, next, err := db.DBconn.FindWithOptions(ctx, c.storeName, key, c.tagMetaData, opts)
 	if err != nil {
-		return []DeploymentIntentGroup{}, err
+		return []DeploymentIntentGroup{}, "", err
 	}
 
 	for _, value := range result {
 		di := DeploymentIntentGroup{}
 		err = db.DBconn.Unmarshal(value, &di)
 		if err != nil {
-			return []DeploymentIntentGroup{}, err
+			return []DeploymentIntentGroup{}, "", err
 		}
 		stateInfo, err := c.GetDeploymentIntentGroupState(ctx, di.MetaData.Name, p, ca, v)
 		if err != nil {
-			return []DeploymentIntentGroup{}, pkgerrors.New("DeploymentIntentGroup stateInfo not found")
+			return []DeploymentIntentGroup{}, "", pkgerrors.New("DeploymentIntentGroup stateInfo not found")
 		}
 
 		currentState, err := state.GetCurrentStateFromStateInfo(stateInfo)
 		if err != nil {
-			return []DeploymentIntentGroup{}, pkgerrors.New("DeploymentIntentGroup currentState not found")
+			return []DeploymentIntentGroup{}, "", pkgerrors.New("DeploymentIntentGroup currentState not found")
 		}
 		di.Spec.Action = currentState
 		diList = append(diList, di)
 	}
 
-	return diList, nil
+	return diList, next, nil
 
 }
 
@@ -34,6 +34,7 @@ type GenericPlacementIntentManager interface {
 		compositeAppName string, version string, digName string) error
 
 	GetAllGenericPlacementIntents(ctx context.Context, p string, ca string, v string, digName string) ([]GenericPlacementIntent, error)
+	ListGenericPlacementIntents(ctx context.Context, p string, ca string, v string, digName string, opts db.FindOptions) ([]GenericPlacementIntent, string, error)
 }
 
 // GenericPlacementIntentKey is used as the primary key
@@ -55,6 +56,16 @@ func (gk GenericPlacementIntentKey) String() string {
 	return string(out)
 }
 
+// genericPlacementIntentSelectors are the fields a list of GenericPlacementIntents can be filtered and sorted on
+var genericPlacementIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": GenericPlacementIntent{},
+	},
+}
+
 // GenericPlacementIntentClient implements the GenericPlacementIntentManager interface
 type GenericPlacementIntentClient struct {
 	storeName   string
@@ -135,17 +146,29 @@ func (c *GenericPlacementIntentClient) GetGenericPlacementIntent(ctx context.Con
 
 // GetAllGenericPlacementIntents returns all the generic placement intents for a given compsoite app name, composite app version, project and deploymentIntentGroupName
 func (c *GenericPlacementIntentClient) GetAllGenericPlacementIntents(ctx context.Context, p string, ca string, v string, digName string) ([]GenericPlacementIntent, error) {
+	res, _, err := c.ListGenericPlacementIntents(ctx, p, ca, v, digName, db.FindOptions{})
+	return res, err
+}
+
+// ListGenericPlacementIntents returns a page of the GenericPlacementIntents of
+// the deployment intent group, filtered and sorted on the
+// genericPlacementIntentSelectors, and the token of the next page
+func (c *GenericPlacementIntentClient) ListGenericPlacementIntents(ctx context.Context, p string, ca string, v string, digName string, opts db.FindOptions) ([]GenericPlacementIntent, string, error) {
+	opts, err := genericPlacementIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []GenericPlacementIntent{}, "", err
+	}
 
 	//Check if project exists
-	_, err := NewProjectClient().GetProject(ctx, p)
+	_, err = NewProjectClient().GetProject(ctx, p)
 	if err != nil {
-		return []GenericPlacementIntent{}, pkgerrors.Wrap(err, "Project not found")
+		return []GenericPlacementIntent{}, "", pkgerrors.Wrap(err, "Project not found")
 	}
 
 	// check if compositeApp exists
 	_, err = NewCompositeAppClient().GetCompositeApp(ctx, ca, v, p)
 	if err != nil {
-		return []GenericPlacementIntent{}, err
+		return []GenericPlacementIntent{}, "", err
 	}
 
 	key := GenericPlacementIntentKey{
@@ -157,21 +180,21 @@ func (c *GenericPlacementIntentClient) GetAllGenericPlacementIntents(ctx context
 	}
 
 	var gpList []GenericPlacementIntent
-	values, err := db.DBconn.Find(ctx, c.storeName, key, c.tagMetaData)
+	values, next, err := db.DBconn.FindWithOptions(ctx, c.storeName, key, c.tagMetaData, opts)
 	if err != nil {
-		return []GenericPlacementIntent{}, err
+		return []GenericPlacementIntent{}, "", err
 	}
 
 	for _, value := range values {
 		gp := GenericPlacementIntent{}
 		err = db.DBconn.Unmarshal(value, &gp)
 		if err != nil {
-			return []GenericPlacementIntent{}, err
+			return []GenericPlacementIntent{}, "", err
 		}
 		gpList = append(gpList, gp)
 	}
 
-	return gpList, nil
+	return gpList, next, nil
 
 }
 
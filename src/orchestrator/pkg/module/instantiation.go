@@ -105,6 +105,8 @@ type CloneJson struct {
 type InstantiationManager interface {
 	Approve(ctx context.Context, p string, ca string, v string, di string) error
 	Instantiate(ctx context.Context, p string, ca string, v string, di string) error
+	Plan(ctx context.Context, p string, ca string, v string, di string) (DeploymentPlan, error)
+	CancelScheduled(ctx context.Context, p string, ca string, v string, di string) error
 	Status(ctx context.Context, p, ca, v, di, qInstance, qType, qOutput string, fApps, fClusters, fResources []string) (DeploymentStatus, error)
 	GenericStatus(ctx context.Context, p, ca, v, di, qInstance, qType, qOutput string, fApps, fClusters, fResources []string) (status.StatusResult, error)
 	StatusAppsList(ctx context.Context, p, ca, v, di, qInstance string) (DeploymentAppsListStatus, error)
@@ -113,23 +115,26 @@ type InstantiationManager interface {
 	Terminate(ctx context.Context, p string, ca string, v string, di string) error
 	Stop(ctx context.Context, p string, ca string, v string, di string) error
 	Migrate(ctx context.Context, p string, ca string, v string, tCav string, di string, tDi string) error
-	Update(ctx context.Context, p string, ca string, v string, di string) (int64, error)
+	GetRolloutStatus(ctx context.Context, p string, ca string, v string, di string) (RolloutStatus, error)
+	Update(ctx context.Context, p string, ca string, v string, di string) (int64, *state.ScheduledEntry, error)
 	Rollback(ctx context.Context, p string, ca string, v string, di string, rbRev string) error
 	CloneDig(ctx context.Context, p, ca, v, di string, cloneSpec *CloneJson) ([]DeploymentIntentGroup, error)
 }
 
 // InstantiationClientDbInfo consists of storeName and tagState
 type InstantiationClientDbInfo struct {
-	storeName string // name of the mongodb collection to use for Instantiationclient documents
-	tagState  string // attribute key name for context object in App Context
+	storeName  string // name of the mongodb collection to use for Instantiationclient documents
+	tagState   string // attribute key name for context object in App Context
+	tagRollout string // attribute key name for the status of a rollout
 }
 
 // NewInstantiationClient returns an instance of InstantiationClient
 func NewInstantiationClient() *InstantiationClient {
 	return &InstantiationClient{
 		db: InstantiationClientDbInfo{
-			storeName: "resources",
-			tagState:  "stateInfo",
+			storeName:  "resources",
+			tagState:   "stateInfo",
+			tagRollout: "rolloutStatus",
 		},
 	}
 }
@@ -415,9 +420,16 @@ func (c InstantiationClient) Instantiate(ctx context.Context, p string, ca strin
 		return pkgerrors.Wrap(err, "Error in handleStateInfo for DeploymentIntent:: "+di)
 	}
 
+	// wait for the maintenance windows if they are closed
+	key := DeploymentIntentGroupKey{Name: di, Project: p, CompositeApp: ca, Version: v}
+	scheduled, err := c.scheduleOperation(ctx, ScheduledOperationInstantiate, key, s, nil, dIGrp)
+	if err != nil || scheduled != nil {
+		return err
+	}
+
 	// BEGIN : Make app context
 	span.AddEvent("create-app-context")
-	instantiator := Instantiator{p, ca, v, di, dIGrp}
+	instantiator := Instantiator{p, ca, v, di, dIGrp, 1}
 	cca, err := instantiator.MakeAppContext(ctx)
 	if err != nil {
 		return pkgerrors.Wrap(err, "Error in making AppContext")
@@ -439,7 +451,7 @@ func (c InstantiationClient) Instantiate(ctx context.Context, p string, ca strin
 	}
 	// END : Rsync code
 
-	err = storeAppContextIntoMetaDB(ctx, cca.ctxval, c.db.storeName, c.db.tagState, s, p, ca, v, di)
+	err = storeAppContextIntoMetaDB(ctx, cca.ctxval, c.db.storeName, c.db.tagState, s, p, ca, v, di, 1)
 
 	// Call Post INSTANTIATE Event for all controllers
 	_ = callPostEventScheduler(ctx, cca.ctxval, p, ca, v, di, "INSTANTIATE")
@@ -624,6 +636,18 @@ func (c InstantiationClient) Terminate(ctx context.Context, p string, ca string,
 		return pkgerrors.Errorf("DeploymentIntentGroup is not instantiated :" + di)
 	}
 
+	dIGrp, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, di, p, ca, v)
+	if err != nil {
+		return pkgerrors.Wrap(err, "DeploymentIntentGroup not found")
+	}
+
+	// wait for the maintenance windows if they are closed
+	scheduled, err := c.scheduleOperation(ctx, ScheduledOperationTerminate,
+		DeploymentIntentGroupKey{Name: di, Project: p, CompositeApp: ca, Version: v}, s, nil, dIGrp)
+	if err != nil || scheduled != nil {
+		return err
+	}
+
 	currentCtxId := state.GetLastContextIdFromStateInfo(s)
 
 	// BEGIN : callScheduler
@@ -842,7 +866,8 @@ func (c InstantiationClient) UpdateInstantiated(ctx context.Context, p string, c
 
 	// BEGIN : Make app context
 	span.AddEvent("create-app-context")
-	instantiator := Instantiator{p, ca, v, di, dIGrp}
+	revision := nextRevision(s)
+	instantiator := Instantiator{p, ca, v, di, dIGrp, revision}
 	cca, err := instantiator.MakeAppContext(ctx)
 	if err != nil {
 		return pkgerrors.Wrap(err, "Error in making AppContext")
@@ -864,7 +889,7 @@ func (c InstantiationClient) UpdateInstantiated(ctx context.Context, p string, c
 	}
 	// END : Rsync code
 
-	err = storeAppContextIntoMetaDB(ctx, cca.ctxval, c.db.storeName, c.db.tagState, s, p, ca, v, di)
+	err = storeAppContextIntoMetaDB(ctx, cca.ctxval, c.db.storeName, c.db.tagState, s, p, ca, v, di, revision)
 
 	// Call Post INSTANTIATE Event for all controllers
 	_ = callPostEventScheduler(ctx, cca.ctxval, p, ca, v, di, "UPDATE")
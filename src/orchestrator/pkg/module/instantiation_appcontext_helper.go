@@ -324,7 +324,7 @@ func (ah *AppHandler) verifyResources(ctx context.Context, cxtForCApp contextFor
 	return nil
 }
 
-func storeAppContextIntoMetaDB(ctx context.Context, ctxval interface{}, storeName string, colName string, s state.StateInfo, p, ca, v, di string) error {
+func storeAppContextIntoMetaDB(ctx context.Context, ctxval interface{}, storeName string, colName string, s state.StateInfo, p, ca, v, di string, revision int64) error {
 
 	// BEGIN:: save the context in the orchestrator db record
 	key := DeploymentIntentGroupKey{
@@ -337,7 +337,7 @@ func storeAppContextIntoMetaDB(ctx context.Context, ctxval interface{}, storeNam
 		State:     state.StateEnum.Instantiated,
 		ContextId: ctxval.(string),
 		TimeStamp: time.Now(),
-		Revision:  1,
+		Revision:  revision,
 	}
 	s.StatusContextId = ctxval.(string)
 	s.Actions = append(s.Actions, a)
@@ -350,6 +350,16 @@ func storeAppContextIntoMetaDB(ctx context.Context, ctxval interface{}, storeNam
 	return nil
 }
 
+// nextRevision returns the revision of the next AppContext of the
+// DeploymentIntentGroup, the first one if it has no revision yet
+func nextRevision(s state.StateInfo) int64 {
+	revision, err := state.GetLatestRevisionFromStateInfo(s)
+	if err != nil || revision < 1 {
+		return 1
+	}
+	return revision + 1
+}
+
 func handleStateInfo(ctx context.Context, p, ca, v, di string) (state.StateInfo, error) {
 
 	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, di, p, ca, v)
@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package module
+
+/*
+This file implements the maintenance windows of a DeploymentIntentGroup. An
+instantiate, update, migrate or terminate requested while the windows of the
+DeploymentIntentGroup, or of the cluster providers of its clusters, are closed
+is recorded in the stateInfo and executed when the windows open.
+*/
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/clm/pkg/cluster"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/lease"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/schedule"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
+)
+
+const (
+	ScheduledOperationInstantiate = "instantiate"
+	ScheduledOperationUpdate      = "update"
+	ScheduledOperationMigrate     = "migrate"
+	ScheduledOperationTerminate   = "terminate"
+)
+
+// scheduleInterval is the interval at which the scheduled operations are checked
+var scheduleInterval = time.Minute
+
+// scheduledRunKey marks the context of an operation executed by the scheduler,
+// so that it isn't scheduled again
+type scheduledRunKey struct{}
+
+func isScheduledRun(ctx context.Context) bool {
+	v, _ := ctx.Value(scheduledRunKey{}).(bool)
+	return v
+}
+
+// contextProviders returns the cluster providers of the clusters of an AppContext
+func contextProviders(ctx context.Context, ctxId string) ([]string, error) {
+	var ac appcontext.AppContext
+	if _, err := ac.LoadAppContext(ctx, ctxId); err != nil {
+		return nil, err
+	}
+	_, appClusters, err := contextAppClusters(ctx, ac)
+	if err != nil {
+		return nil, err
+	}
+	var providers []string
+	for _, clusters := range appClusters {
+		for _, c := range clusters {
+			providers = append(providers, strings.SplitN(c, "+", 2)[0])
+		}
+	}
+	return providers, nil
+}
+
+/*
+maintenanceWindows returns the sets of maintenance windows that must all be open
+to change the DeploymentIntentGroups: the windows of each DeploymentIntentGroup
+and of each cluster provider of the clusters of their logical clouds and of
+the AppContext ctxId, if any.
+*/
+func maintenanceWindows(ctx context.Context, p string, ctxId string, digs ...DeploymentIntentGroup) ([][]schedule.Window, error) {
+	var sets [][]schedule.Window
+	providers := make(map[string]bool)
+	for _, dig := range digs {
+		sets = append(sets, dig.Spec.MaintenanceWindows)
+		clusters, err := NewClusterClient().GetAllClusters(ctx, p, dig.Spec.LogicalCloud)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "Error getting the clusters of the logical cloud: "+dig.Spec.LogicalCloud)
+		}
+		for _, c := range clusters {
+			providers[c.Specification.ClusterProvider] = true
+		}
+	}
+	if ctxId != "" {
+		names, err := contextProviders(ctx, ctxId)
+		if err != nil {
+			// the AppContext may have been deleted after a terminate
+			log.Warn("Unable to get the cluster providers of the AppContext", log.Fields{"contextId": ctxId, "error": err})
+		}
+		for _, name := range names {
+			providers[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cp, err := cluster.NewClusterClient().GetClusterProvider(ctx, name)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "Error getting the maintenance windows of cluster provider "+name)
+		}
+		sets = append(sets, cp.Spec.MaintenanceWindows)
+	}
+	return sets, nil
+}
+
+/*
+scheduleOperation records the operation in the stateInfo of the
+DeploymentIntentGroup and returns it if the maintenance windows are closed.
+It returns nil if the operation can be executed now.
+*/
+func (c InstantiationClient) scheduleOperation(ctx context.Context, op string, key DeploymentIntentGroupKey, s state.StateInfo,
+	params map[string]string, digs ...DeploymentIntentGroup) (*state.ScheduledEntry, error) {
+	if isScheduledRun(ctx) {
+		return nil, nil
+	}
+	if s.Scheduled != nil && s.Scheduled.State == state.StateEnum.Scheduled {
+		return nil, pkgerrors.Errorf("DeploymentIntentGroup has a scheduled operation: %s", s.Scheduled.Operation)
+	}
+
+	sets, err := maintenanceWindows(ctx, key.Project, state.GetLastContextIdFromStateInfo(s), digs...)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	next, err := schedule.NextAllOpen(now, sets...)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Invalid maintenance window")
+	}
+	if next.IsZero() {
+		return nil, pkgerrors.New("The maintenance windows of the DeploymentIntentGroup don't open in the next year")
+	}
+	if !next.After(now) {
+		return nil, nil
+	}
+
+	p := map[string]string{
+		"project":               key.Project,
+		"compositeApp":          key.CompositeApp,
+		"compositeAppVersion":   key.Version,
+		"deploymentIntentGroup": key.Name,
+	}
+	for k, v := range params {
+		p[k] = v
+	}
+	s.Scheduled = &state.ScheduledEntry{
+		State:     state.StateEnum.Scheduled,
+		Operation: op,
+		Params:    p,
+		TimeStamp: now,
+		NotBefore: next,
+	}
+	err = db.DBconn.Insert(ctx, c.db.storeName, key, nil, c.db.tagState, s)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "Error updating the stateInfo of the DeploymentIntentGroup: "+key.Name)
+	}
+	log.Info("Operation scheduled in the next maintenance window", log.Fields{"operation": op, "deploymentintentgroup": key.Name, "notBefore": next})
+	return s.Scheduled, nil
+}
+
+/*
+CancelScheduled takes in projectName, compositeAppName, compositeAppVersion,
+DeploymentIntentName and removes the scheduled operation, or the failure of the
+last scheduled operation, from the stateInfo of the DeploymentIntentGroup.
+*/
+func (c InstantiationClient) CancelScheduled(ctx context.Context, p string, ca string, v string, di string) error {
+	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, di, p, ca, v)
+	if err != nil {
+		return pkgerrors.Wrap(err, "DeploymentIntentGroup has no state info: "+di)
+	}
+	if s.Scheduled == nil {
+		return pkgerrors.New("DeploymentIntentGroup has no scheduled operation")
+	}
+
+	key := DeploymentIntentGroupKey{Name: di, Project: p, CompositeApp: ca, Version: v}
+	s.Scheduled = nil
+	err = db.DBconn.Insert(ctx, c.db.storeName, key, nil, c.db.tagState, s)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error updating the stateInfo of the DeploymentIntentGroup: "+di)
+	}
+	return nil
+}
+
+// RunScheduledOperations executes the scheduled operations when their
+// maintenance windows open, until the context is done. Only the replica
+// holding the lease of the scheduler executes them.
+func (c InstantiationClient) RunScheduledOperations(ctx context.Context) {
+	l := lease.New("scheduler", 3*scheduleInterval)
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			l.Release(context.Background())
+			return
+		case <-ticker.C:
+			owned, err := l.Acquire(ctx)
+			if err != nil {
+				log.Error("Error acquiring the lease of the scheduler", log.Fields{"error": err})
+			}
+			if owned {
+				c.runScheduledOperations(ctx)
+			}
+		}
+	}
+}
+
+func (c InstantiationClient) runScheduledOperations(ctx context.Context) {
+	values, _, err := db.DBconn.FindWithOptions(ctx, c.db.storeName, DeploymentIntentGroupKey{}, c.db.tagState, db.FindOptions{
+		Filter:   map[string]string{c.db.tagState + ".scheduled.state": string(state.StateEnum.Scheduled)},
+		TagTypes: map[string]interface{}{c.db.tagState: state.StateInfo{}},
+	})
+	if err != nil {
+		log.Error("Error finding the scheduled operations", log.Fields{"error": err})
+		return
+	}
+
+	now := time.Now()
+	for _, value := range values {
+		s := state.StateInfo{}
+		if err := db.DBconn.Unmarshal(value, &s); err != nil || s.Scheduled == nil {
+			continue
+		}
+		if now.Before(s.Scheduled.NotBefore) {
+			continue
+		}
+		c.runScheduledOperation(ctx, *s.Scheduled)
+	}
+}
+
+// runScheduledOperation executes the operation if the maintenance windows are
+// open, and records its failure in the stateInfo
+func (c InstantiationClient) runScheduledOperation(ctx context.Context, e state.ScheduledEntry) {
+	p, ca, v, di := e.Params["project"], e.Params["compositeApp"], e.Params["compositeAppVersion"], e.Params["deploymentIntentGroup"]
+	key := DeploymentIntentGroupKey{Name: di, Project: p, CompositeApp: ca, Version: v}
+	fields := log.Fields{"operation": e.Operation, "project": p, "compositeapp": ca, "version": v, "deploymentintentgroup": di}
+
+	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, di, p, ca, v)
+	if err != nil {
+		log.Error("Error getting the stateInfo of the scheduled operation", log.Fields{"error": err, "deploymentintentgroup": di})
+		return
+	}
+	digs := make([]DeploymentIntentGroup, 0, 2)
+	for _, k := range []DeploymentIntentGroupKey{key, {Name: e.Params["targetDeploymentIntentGroup"], Project: p, CompositeApp: ca, Version: e.Params["targetCompositeAppVersion"]}} {
+		if k.Name == "" {
+			continue
+		}
+		dig, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, k.Name, k.Project, k.CompositeApp, k.Version)
+		if err != nil {
+			c.failScheduled(ctx, key, e, pkgerrors.Wrap(err, "DeploymentIntentGroup not found"))
+			return
+		}
+		digs = append(digs, dig)
+	}
+
+	// the windows may have changed since the operation was scheduled
+	sets, err := maintenanceWindows(ctx, p, state.GetLastContextIdFromStateInfo(s), digs...)
+	if err != nil {
+		c.failScheduled(ctx, key, e, err)
+		return
+	}
+	open, err := schedule.AllOpen(time.Now(), sets...)
+	if err != nil {
+		c.failScheduled(ctx, key, e, err)
+		return
+	}
+	if !open {
+		return
+	}
+
+	s.Scheduled = nil
+	err = db.DBconn.Insert(ctx, c.db.storeName, key, nil, c.db.tagState, s)
+	if err != nil {
+		log.Error("Error updating the stateInfo of the scheduled operation", log.Fields{"error": err, "deploymentintentgroup": di})
+		return
+	}
+
+	log.Info("Executing the scheduled operation", fields)
+	runCtx := context.WithValue(ctx, scheduledRunKey{}, true)
+	switch e.Operation {
+	case ScheduledOperationInstantiate:
+		err = c.Instantiate(runCtx, p, ca, v, di)
+	case ScheduledOperationUpdate:
+		_, _, err = c.Update(runCtx, p, ca, v, di)
+	case ScheduledOperationMigrate:
+		err = c.Migrate(runCtx, p, ca, v, e.Params["targetCompositeAppVersion"], di, e.Params["targetDeploymentIntentGroup"])
+	case ScheduledOperationTerminate:
+		err = c.Terminate(runCtx, p, ca, v, di)
+	default:
+		err = pkgerrors.Errorf("Unknown scheduled operation: %s", e.Operation)
+	}
+	if err != nil {
+		log.Error("Scheduled operation failed", log.Fields{"error": err, "operation": e.Operation, "deploymentintentgroup": di})
+		c.failScheduled(ctx, key, e, err)
+	}
+}
+
+// failScheduled records the failure of the scheduled operation in the stateInfo
+func (c InstantiationClient) failScheduled(ctx context.Context, key DeploymentIntentGroupKey, e state.ScheduledEntry, cause error) {
+	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, key.Name, key.Project, key.CompositeApp, key.Version)
+	if err != nil {
+		log.Error("Error getting the stateInfo of the scheduled operation", log.Fields{"error": err, "deploymentintentgroup": key.Name})
+		return
+	}
+	e.State = state.StateEnum.ScheduleFailed
+	e.Error = cause.Error()
+	s.Scheduled = &e
+	err = db.DBconn.Insert(ctx, c.db.storeName, key, nil, c.db.tagState, s)
+	if err != nil {
+		log.Error("Error updating the stateInfo of the scheduled operation", log.Fields{"error": err, "deploymentintentgroup": key.Name})
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package module
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gitlab.com/project-emco/core/emco-base/src/clm/pkg/cluster"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/common"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/schedule"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
+)
+
+func TestScheduleOperation(t *testing.T) {
+	ctx := context.Background()
+	db.DBconn = &db.NewMockDB{}
+	c := NewInstantiationClient()
+	key := DeploymentIntentGroupKey{Name: "dig1", Project: "p", CompositeApp: "ca", Version: "v1"}
+
+	// the logical cloud has a cluster of a provider without windows
+	_, err := cluster.NewClusterClient().CreateClusterProvider(ctx, cluster.ClusterProvider{Metadata: types.Metadata{Name: "cp"}}, false)
+	if err != nil {
+		t.Fatalf("CreateClusterProvider returned an error (%s)", err)
+	}
+	err = db.DBconn.Insert(ctx, "resources", common.ClusterKey{Project: "p", LogicalCloudName: "lc", ClusterReference: "c1"}, nil, "data",
+		common.Cluster{MetaData: types.Metadata{Name: "c1"}, Specification: common.ClusterSpec{ClusterProvider: "cp", ClusterName: "c1"}})
+	if err != nil {
+		t.Fatalf("Insert returned an error (%s)", err)
+	}
+	_, err = c.scheduleOperation(ctx, ScheduledOperationInstantiate, key, state.StateInfo{}, nil, DeploymentIntentGroup{Spec: DepSpecData{LogicalCloud: "lc2"}})
+	if err == nil || !strings.Contains(err.Error(), "Error getting the clusters of the logical cloud") {
+		t.Fatalf("scheduleOperation without clusters returned (%v)", err)
+	}
+
+	// no maintenance window, the operation is executed now
+	scheduled, err := c.scheduleOperation(ctx, ScheduledOperationInstantiate, key, state.StateInfo{}, nil, DeploymentIntentGroup{Spec: DepSpecData{LogicalCloud: "lc"}})
+	if err != nil || scheduled != nil {
+		t.Fatalf("scheduleOperation without windows returned (%v, %v)", scheduled, err)
+	}
+
+	// a window open one minute a year
+	dig := DeploymentIntentGroup{Spec: DepSpecData{LogicalCloud: "lc", MaintenanceWindows: []schedule.Window{{Schedule: "0 0 1 1 *", DurationMinutes: 1}}}}
+	scheduled, err = c.scheduleOperation(context.WithValue(ctx, scheduledRunKey{}, true), ScheduledOperationUpdate, key, state.StateInfo{}, nil, dig)
+	if err != nil || scheduled != nil {
+		t.Fatalf("scheduleOperation of a scheduled run returned (%v, %v)", scheduled, err)
+	}
+	scheduled, err = c.scheduleOperation(ctx, ScheduledOperationMigrate, key, state.StateInfo{},
+		map[string]string{"targetDeploymentIntentGroup": "dig2"}, dig)
+	if err != nil || scheduled == nil || scheduled.Operation != ScheduledOperationMigrate {
+		t.Fatalf("scheduleOperation with closed windows returned (%v, %v)", scheduled, err)
+	}
+
+	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, key.Name, key.Project, key.CompositeApp, key.Version)
+	if err != nil || s.Scheduled == nil {
+		t.Fatalf("DeploymentIntentGroup has no scheduled operation (%v)", err)
+	}
+	if s.Scheduled.State != state.StateEnum.Scheduled || s.Scheduled.Operation != ScheduledOperationMigrate ||
+		s.Scheduled.Params["targetDeploymentIntentGroup"] != "dig2" || s.Scheduled.Params["project"] != "p" ||
+		s.Scheduled.NotBefore.Month() != 1 || s.Scheduled.NotBefore.Day() != 1 {
+		t.Fatalf("DeploymentIntentGroup has the scheduled operation %v", s.Scheduled)
+	}
+
+	// the runner finds the scheduled operation
+	values, _, err := db.DBconn.FindWithOptions(ctx, c.db.storeName, DeploymentIntentGroupKey{}, c.db.tagState, db.FindOptions{
+		Filter:   map[string]string{c.db.tagState + ".scheduled.state": string(state.StateEnum.Scheduled)},
+		TagTypes: map[string]interface{}{c.db.tagState: state.StateInfo{}},
+	})
+	if err != nil || len(values) != 1 {
+		t.Fatalf("FindWithOptions of the scheduled operations returned (%d, %v)", len(values), err)
+	}
+
+	_, err = c.scheduleOperation(ctx, ScheduledOperationTerminate, key, s, nil, dig)
+	if err == nil || !strings.Contains(err.Error(), "DeploymentIntentGroup has a scheduled operation") {
+		t.Fatalf("scheduleOperation with a scheduled operation returned (%v)", err)
+	}
+
+	if err := c.CancelScheduled(ctx, key.Project, key.CompositeApp, key.Version, key.Name); err != nil {
+		t.Fatalf("CancelScheduled returned an error (%s)", err)
+	}
+	other := DeploymentIntentGroupKey{Name: "dig3", Project: "p", CompositeApp: "ca", Version: "v1"}
+	if err := db.DBconn.Insert(ctx, c.db.storeName, other, nil, c.db.tagState, state.StateInfo{}); err != nil {
+		t.Fatalf("Insert returned an error (%s)", err)
+	}
+	err = c.CancelScheduled(ctx, other.Project, other.CompositeApp, other.Version, other.Name)
+	if err == nil || !strings.Contains(err.Error(), "DeploymentIntentGroup has no scheduled operation") {
+		t.Fatalf("CancelScheduled without a scheduled operation returned (%v)", err)
+	}
+}
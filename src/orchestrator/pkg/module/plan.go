@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package module
+
+/*
+This file implements the plan of a DeploymentIntentGroup: the resources an
+instantiate or update would deploy, and how they differ from the resources
+currently deployed, without calling rsync.
+*/
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
+)
+
+// DeploymentPlan is the result of the plan of a DeploymentIntentGroup
+type DeploymentPlan struct {
+	Project               string         `json:"project"`
+	CompositeApp          string         `json:"compositeApp"`
+	CompositeAppVersion   string         `json:"compositeAppVersion"`
+	DeploymentIntentGroup string         `json:"deploymentIntentGroup"`
+	Operation             string         `json:"operation"`
+	CurrentContextId      string         `json:"currentInstance,omitempty"`
+	Summary               map[string]int `json:"summary"`
+	Apps                  []PlanApp      `json:"apps"`
+}
+
+// PlanApp has the planned changes of the clusters of an app
+type PlanApp struct {
+	Name     string        `json:"name"`
+	Clusters []PlanCluster `json:"clusters"`
+}
+
+// PlanCluster has the planned changes of the resources of a cluster
+type PlanCluster struct {
+	Cluster   string         `json:"cluster"`
+	Resources []PlanResource `json:"resources"`
+}
+
+// PlanResource is the planned change of a resource. Manifest is the rendered
+// resource, unless it is deleted, and Diff the unified diff of a modified resource.
+type PlanResource struct {
+	Name     string     `json:"name"`
+	Change   PlanChange `json:"change"`
+	Manifest string     `json:"manifest,omitempty"`
+	Diff     string     `json:"diff,omitempty"`
+}
+
+type PlanChange string
+type planChanges struct {
+	Added     PlanChange
+	Modified  PlanChange
+	Deleted   PlanChange
+	Unchanged PlanChange
+}
+
+// PlanChangeEnum has the changes of a resource in a plan
+var PlanChangeEnum = &planChanges{
+	Added:     "Added",
+	Modified:  "Modified",
+	Deleted:   "Deleted",
+	Unchanged: "Unchanged",
+}
+
+const (
+	PlanOperationInstantiate = "instantiate"
+	PlanOperationUpdate      = "update"
+)
+
+// contextResources returns the resource values of an AppContext by app, cluster and resource name
+func contextResources(ctx context.Context, ac appcontext.AppContext) (map[string]map[string]map[string]string, error) {
+	_, appClusters, err := contextAppClusters(ctx, ac)
+	if err != nil {
+		return nil, err
+	}
+	resources := make(map[string]map[string]map[string]string, len(appClusters))
+	for app, clusters := range appClusters {
+		resources[app] = make(map[string]map[string]string, len(clusters))
+		for _, c := range clusters {
+			names, err := clusterResourceNames(ctx, ac, app, c)
+			if err != nil {
+				return nil, err
+			}
+			resources[app][c] = make(map[string]string, len(names))
+			for _, name := range names {
+				v, err := clusterResourceValue(ctx, ac, app, c, name)
+				if err != nil {
+					return nil, err
+				}
+				resources[app][c][name] = fmt.Sprintf("%v", v)
+			}
+		}
+	}
+	return resources, nil
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch t := m.(type) {
+	case map[string]map[string]map[string]string:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]map[string]string:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	case map[string]string:
+		for k := range t {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeKeys returns the sorted union of the keys
+func mergeKeys(a, b []string) []string {
+	set := make(map[string]bool, len(a)+len(b))
+	for _, k := range append(append([]string{}, a...), b...) {
+		set[k] = true
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffResources compares the planned resources with the current ones. The apps,
+// clusters and resources are sorted by name.
+func diffResources(current, planned map[string]map[string]map[string]string) ([]PlanApp, map[string]int) {
+	summary := map[string]int{
+		string(PlanChangeEnum.Added):     0,
+		string(PlanChangeEnum.Modified):  0,
+		string(PlanChangeEnum.Deleted):   0,
+		string(PlanChangeEnum.Unchanged): 0,
+	}
+
+	apps := make([]PlanApp, 0)
+	for _, app := range mergeKeys(sortedKeys(current), sortedKeys(planned)) {
+		pa := PlanApp{Name: app, Clusters: make([]PlanCluster, 0)}
+		for _, c := range mergeKeys(sortedKeys(current[app]), sortedKeys(planned[app])) {
+			pc := PlanCluster{Cluster: c, Resources: make([]PlanResource, 0)}
+			cur, pl := current[app][c], planned[app][c]
+			for _, name := range mergeKeys(sortedKeys(cur), sortedKeys(pl)) {
+				oldValue, inCurrent := cur[name]
+				newValue, inPlan := pl[name]
+				pr := PlanResource{Name: name, Manifest: newValue}
+				switch {
+				case !inCurrent:
+					pr.Change = PlanChangeEnum.Added
+				case !inPlan:
+					pr.Change = PlanChangeEnum.Deleted
+				case oldValue != newValue:
+					pr.Change = PlanChangeEnum.Modified
+					pr.Diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+						A:        difflib.SplitLines(oldValue),
+						B:        difflib.SplitLines(newValue),
+						FromFile: "current",
+						ToFile:   "planned",
+						Context:  3,
+					})
+				default:
+					pr.Change = PlanChangeEnum.Unchanged
+				}
+				summary[string(pr.Change)]++
+				pc.Resources = append(pc.Resources, pr)
+			}
+			pa.Clusters = append(pa.Clusters, pc)
+		}
+		apps = append(apps, pa)
+	}
+	return apps, summary
+}
+
+/*
+Plan takes in projectName, compositeAppName, compositeAppVersion,
+DeploymentIntentName. It makes the AppContext the DeploymentIntentGroup would be
+instantiated or updated to, and returns its resources and their difference with
+the instantiated AppContext. The AppContext is deleted and rsync isn't called.
+*/
+func (c InstantiationClient) Plan(ctx context.Context, p string, ca string, v string, di string) (DeploymentPlan, error) {
+	log.Info("Plan API", log.Fields{"project": p, "compositeapp": ca, "version": v, "deploymentintentgroup": di})
+
+	dIGrp, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, di, p, ca, v)
+	if err != nil {
+		return DeploymentPlan{}, pkgerrors.Wrap(err, "DeploymentIntentGroup not found")
+	}
+
+	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, di, p, ca, v)
+	if err != nil {
+		return DeploymentPlan{}, pkgerrors.Wrap(err, "DeploymentIntentGroup has no state info: "+di)
+	}
+	stateVal, err := state.GetCurrentStateFromStateInfo(s)
+	if err != nil {
+		return DeploymentPlan{}, pkgerrors.Wrap(err, "Error getting current state from DeploymentIntentGroup stateInfo: "+di)
+	}
+
+	plan := DeploymentPlan{
+		Project:               p,
+		CompositeApp:          ca,
+		CompositeAppVersion:   v,
+		DeploymentIntentGroup: di,
+		Operation:             PlanOperationInstantiate,
+	}
+	// the update from AppContext passed to the action controllers, like Update does
+	var ctxUpdateFrom interface{}
+	var revision int64 = 1
+	current := make(map[string]map[string]map[string]string)
+	if stateVal == state.StateEnum.Instantiated || stateVal == state.StateEnum.InstantiateStopped {
+		plan.Operation = PlanOperationUpdate
+		plan.CurrentContextId = state.GetLastContextIdFromStateInfo(s)
+		ctxUpdateFrom = plan.CurrentContextId
+		revision = nextRevision(s)
+
+		ac, err := state.GetAppContextFromId(ctx, plan.CurrentContextId)
+		if err != nil {
+			return DeploymentPlan{}, pkgerrors.Wrap(err, "Error getting the instantiated AppContext")
+		}
+		current, err = contextResources(ctx, ac)
+		if err != nil {
+			return DeploymentPlan{}, err
+		}
+	}
+
+	instantiator := Instantiator{p, ca, v, di, dIGrp, revision}
+	cca, err := instantiator.MakeAppContext(ctx)
+	if err != nil {
+		return DeploymentPlan{}, pkgerrors.Wrap(err, "Error in making AppContext")
+	}
+	// callScheduler deletes the AppContext on error
+	err = callScheduler(ctx, cca.context, cca.ctxval, ctxUpdateFrom, p, ca, v, di)
+	if err != nil {
+		return DeploymentPlan{}, pkgerrors.Wrap(err, "Error in callScheduler")
+	}
+	defer deleteAppContext(ctx, cca.context)
+
+	planned, err := contextResources(ctx, cca.context)
+	if err != nil {
+		return DeploymentPlan{}, err
+	}
+	plan.Apps, plan.Summary = diffResources(current, planned)
+	return plan, nil
+}
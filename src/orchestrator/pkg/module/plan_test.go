@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package module
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffResources(t *testing.T) {
+	current := map[string]map[string]map[string]string{
+		"app1": {
+			"p+c1": {"r1": "kind: A\nspec: 1\n", "r2": "kind: B\n"},
+			"p+c2": {"r1": "kind: A\nspec: 1\n"},
+		},
+	}
+	planned := map[string]map[string]map[string]string{
+		"app1": {
+			"p+c1": {"r1": "kind: A\nspec: 2\n", "r3": "kind: C\n"},
+			"p+c2": {"r1": "kind: A\nspec: 1\n"},
+		},
+		"app2": {
+			"p+c1": {"r4": "kind: D\n"},
+		},
+	}
+
+	apps, summary := diffResources(current, planned)
+
+	expectedSummary := map[string]int{"Added": 2, "Modified": 1, "Deleted": 1, "Unchanged": 1}
+	if !reflect.DeepEqual(summary, expectedSummary) {
+		t.Fatalf("diffResources returned summary %v, expected %v", summary, expectedSummary)
+	}
+	if len(apps) != 2 || apps[0].Name != "app1" || apps[1].Name != "app2" {
+		t.Fatalf("diffResources returned apps %v", apps)
+	}
+
+	changes := make(map[string]PlanResource)
+	for _, a := range apps {
+		for _, c := range a.Clusters {
+			for _, r := range c.Resources {
+				changes[a.Name+"/"+c.Cluster+"/"+r.Name] = r
+			}
+		}
+	}
+	expected := map[string]PlanChange{
+		"app1/p+c1/r1": PlanChangeEnum.Modified,
+		"app1/p+c1/r2": PlanChangeEnum.Deleted,
+		"app1/p+c1/r3": PlanChangeEnum.Added,
+		"app1/p+c2/r1": PlanChangeEnum.Unchanged,
+		"app2/p+c1/r4": PlanChangeEnum.Added,
+	}
+	for k, change := range expected {
+		if changes[k].Change != change {
+			t.Fatalf("diffResources returned %s for %s, expected %s", changes[k].Change, k, change)
+		}
+	}
+
+	modified := changes["app1/p+c1/r1"]
+	if !strings.Contains(modified.Diff, "-spec: 1") || !strings.Contains(modified.Diff, "+spec: 2") {
+		t.Fatalf("diffResources returned diff %q", modified.Diff)
+	}
+	if changes["app1/p+c1/r2"].Manifest != "" || changes["app1/p+c1/r3"].Manifest != "kind: C\n" {
+		t.Fatalf("diffResources returned unexpected manifests")
+	}
+}
+
+func TestContextResources(t *testing.T) {
+	ac := makeTestRolloutContext(t, "v1", []string{"app1"}, map[string][]string{
+		"app1": {"p+c1", "p+c2"},
+	})
+
+	got, err := contextResources(context.Background(), ac)
+	if err != nil {
+		t.Fatalf("contextResources returned an error (%s)", err)
+	}
+	expected := map[string]map[string]map[string]string{
+		"app1": {
+			"p+c1": {"app1+Deployment": "v1"},
+			"p+c2": {"app1+Deployment": "v1"},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("contextResources returned %v, expected %v", got, expected)
+	}
+}
@@ -47,6 +47,18 @@ type ProjectManager interface {
 	GetProject(ctx context.Context, name string) (Project, error)
 	DeleteProject(ctx context.Context, name string) error
 	GetAllProjects(ctx context.Context) ([]Project, error)
+	ListProjects(ctx context.Context, opts db.FindOptions) ([]Project, string, error)
+	WatchProject(ctx context.Context, name string) (<-chan db.WatchEvent, error)
+}
+
+// projectSelectors are the fields a list of Projects can be filtered and sorted on
+var projectSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": Project{},
+	},
 }
 
 // ProjectClient implements the ProjectManager
@@ -116,25 +128,37 @@ func (v *ProjectClient) GetProject(ctx context.Context, name string) (Project, e
 
 // GetAllProjects returns all the projects
 func (v *ProjectClient) GetAllProjects(ctx context.Context) ([]Project, error) {
+	res, _, err := v.ListProjects(ctx, db.FindOptions{})
+	return res, err
+}
+
+// ListProjects returns a page of the projects, filtered and sorted on the
+// projectSelectors, and the token of the next page
+func (v *ProjectClient) ListProjects(ctx context.Context, opts db.FindOptions) ([]Project, string, error) {
+	opts, err := projectSelectors.Resolve(opts)
+	if err != nil {
+		return []Project{}, "", err
+	}
+
 	key := ProjectKey{
 		ProjectName: "",
 	}
 
 	var res []Project
-	values, err := db.DBconn.Find(ctx, v.storeName, key, v.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.storeName, key, v.tagMeta, opts)
 	if err != nil {
-		return []Project{}, err
+		return []Project{}, "", err
 	}
 
 	for _, value := range values {
 		p := Project{}
 		err = db.DBconn.Unmarshal(value, &p)
 		if err != nil {
-			return []Project{}, err
+			return []Project{}, "", err
 		}
 		res = append(res, p)
 	}
-	return res, nil
+	return res, next, nil
 }
 
 // DeleteProject the  Project from database
@@ -149,3 +173,19 @@ func (v *ProjectClient) DeleteProject(ctx context.Context, name string) error {
 
 	//TODO: Delete the collection when the project is deleted
 }
+
+// WatchProject reports the changes to the Project and to all the resources
+// in the Project until the context is done
+func (v *ProjectClient) WatchProject(ctx context.Context, name string) (<-chan db.WatchEvent, error) {
+
+	//Check if this Project exists
+	_, err := v.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := ProjectKey{
+		ProjectName: name,
+	}
+	return db.DBconn.Watch(ctx, v.storeName, key)
+}
@@ -0,0 +1,674 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package module
+
+/*
+This file implements the progressive rollout of the migration of a
+DeploymentIntentGroup to another version of the composite app. The clusters
+of the DeploymentIntentGroups are migrated in waves, and each wave must reach
+the Ready state before the next one starts.
+
+The progress of a rollout is stored in the target DeploymentIntentGroup, and
+the rollout is run by the replica of the orchestrator holding its lease. The
+other replicas resume the rollouts left running by a replica that stopped.
+*/
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/lease"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/status"
+)
+
+// RolloutStrategy defines how the clusters of a DeploymentIntentGroup are
+// migrated to it from a DeploymentIntentGroup of another composite app version
+type RolloutStrategy struct {
+	// WavePercentage is the percentage of the clusters migrated in each wave, 100 migrates all of them at once
+	WavePercentage int `json:"wavePercentage"`
+	// PauseSeconds is the time to wait after a wave is Ready before starting the next one
+	PauseSeconds int `json:"pauseSeconds,omitempty"`
+	// ReadyTimeoutSeconds is the time a wave has to reach the Ready state
+	ReadyTimeoutSeconds int `json:"readyTimeoutSeconds,omitempty"`
+	// OnFailure is the action taken when a wave doesn't reach the Ready state
+	OnFailure string `json:"onFailure,omitempty"`
+}
+
+const (
+	RolloutOnFailureHalt     = "halt"
+	RolloutOnFailureRollback = "rollback"
+
+	defaultRolloutReadyTimeoutSeconds = 600
+)
+
+// RolloutStatus is the progress of the rollout to a DeploymentIntentGroup
+type RolloutStatus struct {
+	Source    RolloutSource `json:"source"`
+	State     RolloutState  `json:"state"`
+	Message   string        `json:"message,omitempty"`
+	Waves     []RolloutWave `json:"waves"`
+	TimeStamp time.Time     `json:"time"`
+	// The strategy, the target and the AppContexts of the rollout, to resume it
+	Strategy        RolloutStrategy          `json:"strategy"`
+	Target          DeploymentIntentGroupKey `json:"target"`
+	SourceContextId string                   `json:"sourceInstance"`
+	TargetContextId string                   `json:"targetInstance"`
+	StatusContextId string                   `json:"statusInstance,omitempty"`
+}
+
+// RolloutSource is the DeploymentIntentGroup the clusters are migrated from
+type RolloutSource struct {
+	DeploymentIntentGroup string `json:"deploymentIntentGroup"`
+	CompositeAppVersion   string `json:"compositeAppVersion"`
+}
+
+// RolloutWave is a set of clusters migrated together
+type RolloutWave struct {
+	Clusters  []string         `json:"clusters"`
+	State     RolloutWaveState `json:"state"`
+	ContextId string           `json:"instance,omitempty"`
+}
+
+type RolloutState string
+type rolloutStates struct {
+	Running    RolloutState
+	Completed  RolloutState
+	Halted     RolloutState
+	RolledBack RolloutState
+}
+
+// RolloutStateEnum has the states of a rollout
+var RolloutStateEnum = &rolloutStates{
+	Running:    "Running",
+	Completed:  "Completed",
+	Halted:     "Halted",
+	RolledBack: "RolledBack",
+}
+
+type RolloutWaveState string
+type rolloutWaveStates struct {
+	Pending    RolloutWaveState
+	Migrating  RolloutWaveState
+	Ready      RolloutWaveState
+	NotReady   RolloutWaveState
+	RolledBack RolloutWaveState
+}
+
+// RolloutWaveStateEnum has the states of a wave of a rollout
+var RolloutWaveStateEnum = &rolloutWaveStates{
+	Pending:    "Pending",
+	Migrating:  "Migrating",
+	Ready:      "Ready",
+	NotReady:   "NotReady",
+	RolledBack: "RolledBack",
+}
+
+// rolloutPollInterval is the interval at which the readiness of a wave is checked
+var rolloutPollInterval = 10 * time.Second
+
+// rolloutResumeInterval is the interval at which the rollouts left running by
+// another replica are looked for
+var rolloutResumeInterval = 30 * time.Second
+
+// rolloutLeaseDuration is the time after which a rollout that isn't renewed
+// by its replica is resumed by another one
+var rolloutLeaseDuration = time.Minute
+
+// activeRollouts has the source DeploymentIntentGroups with a rollout run by the replica
+var activeRollouts sync.Map
+
+// rollout is the migration of a DeploymentIntentGroup in waves
+type rollout struct {
+	c         InstantiationClient
+	strategy  RolloutStrategy
+	source    DeploymentIntentGroupKey
+	target    DeploymentIntentGroupKey
+	sourceCtx appcontext.AppContext
+	targetCtx appcontext.AppContext
+	status    RolloutStatus
+}
+
+// validateRolloutStrategy checks the strategy and fills the default values
+func validateRolloutStrategy(s RolloutStrategy) (RolloutStrategy, error) {
+	if s.WavePercentage < 1 || s.WavePercentage > 100 {
+		return s, pkgerrors.Errorf("Invalid rollout wave percentage: %d", s.WavePercentage)
+	}
+	if s.PauseSeconds < 0 || s.ReadyTimeoutSeconds < 0 {
+		return s, pkgerrors.New("Invalid rollout pause or ready timeout")
+	}
+	if s.ReadyTimeoutSeconds == 0 {
+		s.ReadyTimeoutSeconds = defaultRolloutReadyTimeoutSeconds
+	}
+	switch s.OnFailure {
+	case "":
+		s.OnFailure = RolloutOnFailureRollback
+	case RolloutOnFailureHalt, RolloutOnFailureRollback:
+	default:
+		return s, pkgerrors.Errorf("Invalid rollout onFailure action: %s", s.OnFailure)
+	}
+	return s, nil
+}
+
+// rolloutWaves splits the clusters in waves of the percentage of the clusters
+func rolloutWaves(clusters []string, pct int) [][]string {
+	size := (len(clusters)*pct + 99) / 100
+	if size < 1 {
+		size = 1
+	}
+	var waves [][]string
+	for i := 0; i < len(clusters); i += size {
+		end := i + size
+		if end > len(clusters) {
+			end = len(clusters)
+		}
+		waves = append(waves, clusters[i:end])
+	}
+	return waves
+}
+
+func rolloutKey(k DeploymentIntentGroupKey) string {
+	return strings.Join([]string{k.Project, k.CompositeApp, k.Version, k.Name}, "/")
+}
+
+// startRollout starts the migration in waves of the clusters of the source
+// DeploymentIntentGroup to the target AppContext
+func (c InstantiationClient) startRollout(ctx context.Context, strategy RolloutStrategy, source, target DeploymentIntentGroupKey,
+	sourceCtxId, targetCtxId, statusID string) error {
+	strategy, err := validateRolloutStrategy(strategy)
+	if err != nil {
+		return err
+	}
+
+	r := &rollout{
+		c:        c,
+		strategy: strategy,
+		source:   source,
+		target:   target,
+		status: RolloutStatus{
+			Source: RolloutSource{
+				DeploymentIntentGroup: source.Name,
+				CompositeAppVersion:   source.Version,
+			},
+			State:           RolloutStateEnum.Running,
+			Strategy:        strategy,
+			Target:          target,
+			SourceContextId: sourceCtxId,
+			TargetContextId: targetCtxId,
+			StatusContextId: statusID,
+		},
+	}
+	if err := r.loadContexts(ctx); err != nil {
+		return err
+	}
+	_, sourceClusters, err := contextAppClusters(ctx, r.sourceCtx)
+	if err != nil {
+		return err
+	}
+	_, targetClusters, err := contextAppClusters(ctx, r.targetCtx)
+	if err != nil {
+		return err
+	}
+	for _, w := range rolloutWaves(rolloutClusters(sourceClusters, targetClusters), strategy.WavePercentage) {
+		r.status.Waves = append(r.status.Waves, RolloutWave{Clusters: w, State: RolloutWaveStateEnum.Pending})
+	}
+
+	l, err := r.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		return pkgerrors.Errorf("DeploymentIntentGroup has a rollout in progress: " + source.Name)
+	}
+	if err := r.saveStatus(ctx); err != nil {
+		activeRollouts.Delete(rolloutKey(source))
+		l.Release(ctx)
+		return err
+	}
+
+	r.start(l)
+	return nil
+}
+
+// loadContexts gets the source and the target AppContexts of the rollout
+func (r *rollout) loadContexts(ctx context.Context) error {
+	var err error
+	r.sourceCtx, err = state.GetAppContextFromId(ctx, r.status.SourceContextId)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error getting the source AppContext")
+	}
+	r.targetCtx, err = state.GetAppContextFromId(ctx, r.status.TargetContextId)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error getting the target AppContext")
+	}
+	return nil
+}
+
+// acquire returns the lease of the rollout, or nil if the rollout is run
+// by this or another replica
+func (r *rollout) acquire(ctx context.Context) (*lease.Lease, error) {
+	key := rolloutKey(r.source)
+	if _, running := activeRollouts.LoadOrStore(key, true); running {
+		return nil, nil
+	}
+	l := lease.New("rollout/"+key, rolloutLeaseDuration)
+	owned, err := l.Acquire(ctx)
+	if err != nil || !owned {
+		activeRollouts.Delete(key)
+		return nil, err
+	}
+	return l, nil
+}
+
+// start runs the rollout in the background while the replica holds its lease
+func (r *rollout) start(l *lease.Lease) {
+	go func() {
+		defer activeRollouts.Delete(rolloutKey(r.source))
+		l.Hold(context.Background(), r.run)
+	}()
+}
+
+// RunRollouts resumes the rollouts left running by the replicas that stopped,
+// including this one before it restarted, until the context is done
+func (c InstantiationClient) RunRollouts(ctx context.Context) {
+	c.resumeRollouts(ctx)
+	ticker := time.NewTicker(rolloutResumeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.resumeRollouts(ctx)
+		}
+	}
+}
+
+func (c InstantiationClient) resumeRollouts(ctx context.Context) {
+	values, _, err := db.DBconn.FindWithOptions(ctx, c.db.storeName, DeploymentIntentGroupKey{}, c.db.tagRollout, db.FindOptions{
+		Filter:   map[string]string{c.db.tagRollout + ".state": string(RolloutStateEnum.Running)},
+		TagTypes: map[string]interface{}{c.db.tagRollout: RolloutStatus{}},
+	})
+	if err != nil {
+		log.Error("Error finding the running rollouts", log.Fields{"error": err})
+		return
+	}
+
+	for _, value := range values {
+		rs := RolloutStatus{}
+		if err := db.DBconn.Unmarshal(value, &rs); err != nil || rs.Target.Name == "" {
+			continue
+		}
+		source := rs.Target
+		source.Name, source.Version = rs.Source.DeploymentIntentGroup, rs.Source.CompositeAppVersion
+		r := &rollout{
+			c:        c,
+			strategy: rs.Strategy,
+			source:   source,
+			target:   rs.Target,
+			status:   rs,
+		}
+		l, err := r.acquire(ctx)
+		if err != nil {
+			log.Error("Error acquiring the lease of the rollout", log.Fields{"error": err, "deploymentintentgroup": r.target.Name})
+			continue
+		}
+		if l == nil {
+			continue
+		}
+		log.Info("Resuming the rollout", log.Fields{"deploymentintentgroup": r.target.Name})
+		if err := r.loadContexts(ctx); err != nil {
+			r.end(ctx, RolloutStateEnum.Halted, err.Error())
+			activeRollouts.Delete(rolloutKey(r.source))
+			l.Release(ctx)
+			continue
+		}
+		r.start(l)
+	}
+}
+
+// saveStatus stores the status of the rollout in the target DeploymentIntentGroup
+func (r *rollout) saveStatus(ctx context.Context) error {
+	r.status.TimeStamp = time.Now()
+	err := db.DBconn.Insert(ctx, r.c.db.storeName, r.target, nil, r.c.db.tagRollout, r.status)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error updating the rollout status of the DeploymentIntentGroup: "+r.target.Name)
+	}
+	return nil
+}
+
+// appendState adds actions to the stateInfo of a DeploymentIntentGroup
+func (r *rollout) appendState(ctx context.Context, key DeploymentIntentGroupKey, setStatusID bool, actions ...state.ActionEntry) error {
+	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, key.Name, key.Project, key.CompositeApp, key.Version)
+	if err != nil {
+		return pkgerrors.Wrap(err, "DeploymentIntentGroup has no state info: "+key.Name)
+	}
+	for _, a := range actions {
+		a.TimeStamp = time.Now()
+		s.Actions = append(s.Actions, a)
+	}
+	if setStatusID {
+		s.StatusContextId = r.status.StatusContextId
+	}
+	err = db.DBconn.Insert(ctx, r.c.db.storeName, key, nil, r.c.db.tagState, s)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error updating the stateInfo of the DeploymentIntentGroup: "+key.Name)
+	}
+	return nil
+}
+
+// end records the final state of the rollout, once the AppContexts of the
+// waves no longer deployed are deleted
+func (r *rollout) end(ctx context.Context, st RolloutState, msg string) {
+	r.deleteWaveContexts(ctx)
+	r.status.State = st
+	r.status.Message = msg
+	log.Info("Rollout ended", log.Fields{"deploymentintentgroup": r.target.Name, "state": st, "message": msg})
+	if err := r.saveStatus(ctx); err != nil {
+		log.Error("Error saving the rollout status", log.Fields{"error": err.Error()})
+	}
+}
+
+// deleteWaveContexts deletes the AppContexts created for the waves, but the
+// one deployed if the rollout is halted, and removes them from the stateInfo
+// of the source DeploymentIntentGroup. It waits for rsync to complete the
+// update to the deployed AppContext, which reads the previous one.
+func (r *rollout) deleteWaveContexts(ctx context.Context) {
+	s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, r.source.Name, r.source.Project, r.source.CompositeApp, r.source.Version)
+	if err != nil {
+		log.Error("Error getting the stateInfo of the DeploymentIntentGroup", log.Fields{"error": err.Error(), "deploymentintentgroup": r.source.Name})
+		return
+	}
+	deployed := state.GetLastContextIdFromStateInfo(s)
+
+	waves := make(map[string]bool)
+	for _, w := range r.status.Waves {
+		if w.ContextId != "" && w.ContextId != r.status.TargetContextId && w.ContextId != deployed {
+			waves[w.ContextId] = true
+		}
+	}
+	if len(waves) == 0 {
+		return
+	}
+
+	deadline := time.Now().Add(time.Duration(r.strategy.ReadyTimeoutSeconds) * time.Second)
+	for {
+		acStatus, err := state.GetAppContextStatus(ctx, deployed)
+		if err != nil || acStatus.Status != appcontext.AppContextStatusEnum.Updating {
+			break
+		}
+		if time.Now().After(deadline) || !sleepContext(ctx, rolloutPollInterval) {
+			log.Error("Keeping the AppContexts of the rollout waves, the update isn't complete", log.Fields{"instance": deployed})
+			return
+		}
+	}
+
+	for id := range waves {
+		ac, err := state.GetAppContextFromId(ctx, id)
+		if err == nil {
+			err = deleteAppContext(ctx, ac)
+		}
+		if err != nil {
+			log.Error("Error deleting the AppContext of a rollout wave", log.Fields{"error": err.Error(), "instance": id})
+			delete(waves, id)
+		}
+	}
+	actions := s.Actions[:0]
+	for _, a := range s.Actions {
+		if !waves[a.ContextId] {
+			actions = append(actions, a)
+		}
+	}
+	s.Actions = actions
+	err = db.DBconn.Insert(ctx, r.c.db.storeName, r.source, nil, r.c.db.tagState, s)
+	if err != nil {
+		log.Error("Error updating the stateInfo of the DeploymentIntentGroup", log.Fields{"error": err.Error(), "deploymentintentgroup": r.source.Name})
+	}
+}
+
+// sleepContext waits for the duration, and returns false if the context is
+// done before
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// run migrates the waves one after the other. The source DeploymentIntentGroup
+// is moved to an AppContext with the target resources on the clusters of the
+// waves done so far, until the last wave moves it to the target AppContext.
+// A resumed rollout skips the waves that are Ready, and waits for the wave
+// being migrated if its update was sent to rsync. The rollout stops without
+// ending if the context is done, i.e. the replica lost its lease.
+func (r *rollout) run(ctx context.Context) {
+	prevCtxId := r.status.SourceContextId
+	promoted := make(map[string]bool)
+
+	for i := range r.status.Waves {
+		wave := &r.status.Waves[i]
+		for _, c := range wave.Clusters {
+			promoted[c] = true
+		}
+		if wave.State == RolloutWaveStateEnum.Ready {
+			prevCtxId = wave.ContextId
+			continue
+		}
+
+		s, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, r.source.Name, r.source.Project, r.source.CompositeApp, r.source.Version)
+		if ctx.Err() != nil {
+			return
+		}
+		deployed := ""
+		if err == nil {
+			deployed = state.GetLastContextIdFromStateInfo(s)
+		}
+
+		if wave.State == RolloutWaveStateEnum.Migrating && wave.ContextId != "" && deployed == prevCtxId && r.updateSent(ctx, wave.ContextId) {
+			// the replica stopped after sending the update of the wave to rsync
+			err = r.appendState(ctx, r.source, false,
+				state.ActionEntry{State: state.StateEnum.Updated, ContextId: prevCtxId},
+				state.ActionEntry{State: state.StateEnum.Instantiated, ContextId: wave.ContextId})
+			if err != nil {
+				r.end(ctx, RolloutStateEnum.Halted, err.Error())
+				return
+			}
+			deployed = wave.ContextId
+		}
+		if wave.State != RolloutWaveStateEnum.Migrating || wave.ContextId == "" || deployed != wave.ContextId {
+			// stop if the source DeploymentIntentGroup was changed by another operation
+			if deployed != prevCtxId {
+				r.end(ctx, RolloutStateEnum.Halted, "DeploymentIntentGroup was modified during the rollout: "+r.source.Name)
+				return
+			}
+			if err := r.migrate(ctx, wave, i, prevCtxId, promoted); err != nil {
+				if ctx.Err() == nil {
+					r.end(ctx, RolloutStateEnum.Halted, err.Error())
+				}
+				return
+			}
+		}
+
+		err = r.waitReady(ctx, wave.ContextId, wave.Clusters)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			wave.State = RolloutWaveStateEnum.NotReady
+			r.fail(ctx, wave.ContextId, err)
+			return
+		}
+		wave.State = RolloutWaveStateEnum.Ready
+		if err := r.saveStatus(ctx); err != nil {
+			// a resumed rollout would deploy the wave again
+			r.fail(ctx, wave.ContextId, err)
+			return
+		}
+		prevCtxId = wave.ContextId
+
+		if i < len(r.status.Waves)-1 && r.strategy.PauseSeconds > 0 {
+			if !sleepContext(ctx, time.Duration(r.strategy.PauseSeconds)*time.Second) {
+				return
+			}
+		}
+	}
+
+	// the target DeploymentIntentGroup takes over the migrated clusters
+	err := r.appendState(ctx, r.source, false, state.ActionEntry{State: state.StateEnum.Updated, ContextId: r.status.TargetContextId})
+	if err == nil {
+		err = r.appendState(ctx, r.target, true, state.ActionEntry{State: state.StateEnum.Instantiated, ContextId: r.status.TargetContextId, Revision: 1})
+	}
+	if err != nil {
+		r.end(ctx, RolloutStateEnum.Halted, err.Error())
+		return
+	}
+	_ = callPostEventScheduler(ctx, r.status.TargetContextId, r.source.Project, r.source.CompositeApp, r.source.Version, r.source.Name, "UPDATE")
+	r.end(ctx, RolloutStateEnum.Completed, "")
+}
+
+// updateSent returns whether rsync received the update to the AppContext
+func (r *rollout) updateSent(ctx context.Context, ctxId string) bool {
+	acStatus, err := state.GetAppContextStatus(ctx, ctxId)
+	return err == nil && acStatus.Status != "" && acStatus.Status != appcontext.AppContextStatusEnum.Created
+}
+
+// migrate updates the source DeploymentIntentGroup from the AppContext of the
+// previous wave to the AppContext of the wave
+func (r *rollout) migrate(ctx context.Context, wave *RolloutWave, i int, prevCtxId string, promoted map[string]bool) error {
+	// the AppContext of the wave created before a restart was never deployed
+	if wave.ContextId != "" && wave.ContextId != r.status.TargetContextId {
+		if ac, err := state.GetAppContextFromId(ctx, wave.ContextId); err == nil {
+			deleteAppContext(ctx, ac)
+		}
+	}
+
+	nextCtxId := r.status.TargetContextId
+	if i < len(r.status.Waves)-1 {
+		cca, err := makeWaveAppContext(ctx, r.sourceCtx, r.targetCtx, promoted)
+		if err != nil {
+			return err
+		}
+		nextCtxId = fmt.Sprintf("%v", cca.ctxval)
+	}
+
+	wave.State = RolloutWaveStateEnum.Migrating
+	wave.ContextId = nextCtxId
+	if err := r.saveStatus(ctx); err != nil {
+		return err
+	}
+
+	err := state.UpdateAppContextStatusContextID(ctx, nextCtxId, r.status.StatusContextId)
+	if err == nil {
+		err = callRsyncUpdate(ctx, prevCtxId, nextCtxId)
+	}
+	if err != nil {
+		return err
+	}
+	return r.appendState(ctx, r.source, false,
+		state.ActionEntry{State: state.StateEnum.Updated, ContextId: prevCtxId},
+		state.ActionEntry{State: state.StateEnum.Instantiated, ContextId: nextCtxId})
+}
+
+// fail halts the rollout, or rolls the clusters back to the source AppContext
+func (r *rollout) fail(ctx context.Context, ctxId string, cause error) {
+	if r.strategy.OnFailure == RolloutOnFailureHalt {
+		r.end(ctx, RolloutStateEnum.Halted, cause.Error())
+		return
+	}
+
+	sourceCtxId := r.status.SourceContextId
+	err := callRsyncUpdate(ctx, ctxId, sourceCtxId)
+	if err == nil {
+		err = r.appendState(ctx, r.source, false,
+			state.ActionEntry{State: state.StateEnum.Updated, ContextId: ctxId},
+			state.ActionEntry{State: state.StateEnum.Instantiated, ContextId: sourceCtxId})
+	}
+	if err != nil {
+		r.end(ctx, RolloutStateEnum.Halted, pkgerrors.Wrap(err, "Error rolling back after "+cause.Error()).Error())
+		return
+	}
+	for i := range r.status.Waves {
+		if r.status.Waves[i].State != RolloutWaveStateEnum.Pending {
+			r.status.Waves[i].State = RolloutWaveStateEnum.RolledBack
+		}
+	}
+	r.end(ctx, RolloutStateEnum.RolledBack, cause.Error())
+}
+
+// waitReady waits for the resources of the clusters in the AppContext to be Ready
+func (r *rollout) waitReady(ctx context.Context, ctxId string, clusters []string) error {
+	// clusters without apps in the AppContext have nothing to be Ready
+	hasApps := false
+	if ac, err := state.GetAppContextFromId(ctx, ctxId); err == nil {
+		if _, appClusters, err := contextAppClusters(ctx, ac); err == nil {
+			in := make(map[string]bool, len(clusters))
+			for _, c := range clusters {
+				in[c] = true
+			}
+			for _, cl := range appClusters {
+				for _, c := range cl {
+					hasApps = hasApps || in[c]
+				}
+			}
+		}
+	}
+
+	si := state.StateInfo{
+		StatusContextId: r.status.StatusContextId,
+		Actions:         []state.ActionEntry{{State: state.StateEnum.Instantiated, ContextId: ctxId}},
+	}
+	deadline := time.Now().Add(time.Duration(r.strategy.ReadyTimeoutSeconds) * time.Second)
+	for {
+		sr, err := status.GenericPrepareStatusResult(ctx, status.DeploymentIntentGroupStatusQuery, si, "", "ready", "summary", nil, clusters, nil)
+		if err != nil {
+			log.Info("Rollout wave status not available", log.Fields{"instance": ctxId, "error": err.Error()})
+		} else if sr.DeployedStatus == appcontext.AppContextStatusEnum.InstantiateFailed {
+			return pkgerrors.Errorf("Rollout wave failed to deploy: %v", clusters)
+		} else if sr.DeployedStatus == appcontext.AppContextStatusEnum.Instantiated && sr.ReadyStatus == "Ready" &&
+			(!hasApps || sr.ReadyCounts["Ready"] > 0) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return pkgerrors.Errorf("Rollout wave not Ready after %d seconds: %v", r.strategy.ReadyTimeoutSeconds, clusters)
+		}
+		if !sleepContext(ctx, rolloutPollInterval) {
+			return ctx.Err()
+		}
+	}
+}
+
+// GetRolloutStatus returns the status of the rollout to a DeploymentIntentGroup
+func (c InstantiationClient) GetRolloutStatus(ctx context.Context, p, ca, v, di string) (RolloutStatus, error) {
+	key := DeploymentIntentGroupKey{
+		Name:         di,
+		Project:      p,
+		CompositeApp: ca,
+		Version:      v,
+	}
+	result, err := db.DBconn.Find(ctx, c.db.storeName, key, c.db.tagRollout)
+	if err != nil {
+		return RolloutStatus{}, pkgerrors.Wrap(err, "db Find error")
+	}
+	if len(result) == 0 {
+		return RolloutStatus{}, pkgerrors.New("DeploymentIntentGroup rollout not found")
+	}
+
+	rs := RolloutStatus{}
+	err = db.DBconn.Unmarshal(result[0], &rs)
+	if err != nil {
+		return RolloutStatus{}, pkgerrors.Wrap(err, "Unmarshalling RolloutStatus")
+	}
+	return rs, nil
+}
@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package module
+
+/*
+This file deals with the AppContexts of the waves of a rollout.
+A wave AppContext holds the resources of the target AppContext on the clusters
+promoted so far and the resources of the source AppContext on the others, so
+that an rsync update to it only modifies the promoted clusters.
+*/
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+type resOrderInstr struct {
+	Resorder []string `json:"resorder"`
+}
+
+type resDepInstr struct {
+	Resdep map[string][]string `json:"resdependency"`
+}
+
+// clusterCopy is a cluster of an app copied into a wave AppContext
+type clusterCopy struct {
+	from    appcontext.AppContext
+	cluster string
+}
+
+// contextAppClusters returns the apps of the AppContext in order and the clusters of each app
+func contextAppClusters(ctx context.Context, ac appcontext.AppContext) ([]string, map[string][]string, error) {
+	v, err := ac.GetAppInstruction(ctx, appcontext.OrderInstruction)
+	if err != nil {
+		return nil, nil, pkgerrors.Wrap(err, "Error getting the app order instruction")
+	}
+	var order appOrderInstr
+	err = json.Unmarshal([]byte(fmt.Sprintf("%v", v)), &order)
+	if err != nil {
+		return nil, nil, pkgerrors.Wrap(err, "Error unmarshalling the app order instruction")
+	}
+
+	clusters := make(map[string][]string, len(order.Apporder))
+	for _, app := range order.Apporder {
+		// an app may have no clusters, e.g. when a placement intent has no match
+		cl, err := ac.GetClusterNames(ctx, app)
+		if err != nil {
+			log.Info(":: No clusters for app ::", log.Fields{"app": app, "error": err})
+			continue
+		}
+		clusters[app] = cl
+	}
+	return order.Apporder, clusters, nil
+}
+
+// rolloutClusters returns the sorted clusters of either AppContext
+func rolloutClusters(appClusters ...map[string][]string) []string {
+	set := make(map[string]bool)
+	for _, ac := range appClusters {
+		for _, cl := range ac {
+			for _, c := range cl {
+				set[c] = true
+			}
+		}
+	}
+	clusters := make([]string, 0, len(set))
+	for c := range set {
+		clusters = append(clusters, c)
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// copyCluster copies a cluster of an app with its group number, resources and
+// resource instructions from one AppContext to the app handle of another
+func copyCluster(ctx context.Context, from, to appcontext.AppContext, appHandle interface{}, app, cluster string) error {
+	ch, err := to.AddCluster(ctx, appHandle, cluster)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "Error adding cluster %s of app %s to AppContext", cluster, app)
+	}
+
+	if mh, err := from.GetClusterMetaHandle(ctx, app, cluster); err == nil {
+		if gn, err := from.GetValue(ctx, mh); err == nil {
+			err = to.AddClusterMetaGrp(ctx, ch, fmt.Sprintf("%v", gn))
+			if err != nil {
+				return pkgerrors.Wrapf(err, "Error adding group number of cluster %s of app %s to AppContext", cluster, app)
+			}
+		}
+	}
+
+	v, err := from.GetResourceInstruction(ctx, app, cluster, appcontext.OrderInstruction)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "Error getting resource order of cluster %s of app %s", cluster, app)
+	}
+	_, err = to.AddInstruction(ctx, ch, appcontext.ResourceLevel, appcontext.OrderInstruction, v)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "Error adding resource order of cluster %s of app %s", cluster, app)
+	}
+	if v, err := from.GetResourceInstruction(ctx, app, cluster, appcontext.DependencyInstruction); err == nil {
+		_, err = to.AddInstruction(ctx, ch, appcontext.ResourceLevel, appcontext.DependencyInstruction, v)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "Error adding resource dependency of cluster %s of app %s", cluster, app)
+		}
+	}
+
+	names, err := clusterResourceNames(ctx, from, app, cluster)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		value, err := clusterResourceValue(ctx, from, app, cluster, name)
+		if err != nil {
+			return err
+		}
+		_, err = to.AddResource(ctx, ch, name, value)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "Error adding resource %s of cluster %s of app %s", name, cluster, app)
+		}
+	}
+	return nil
+}
+
+// clusterResourceNames returns the resources of a cluster of an app in the order
+// they are deployed. These are the resources of the order and dependency
+// instructions, rsync may have added others to the AppContext.
+func clusterResourceNames(ctx context.Context, ac appcontext.AppContext, app, cluster string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	addNames := func(rl []string) {
+		for _, r := range rl {
+			if !seen[r] {
+				seen[r] = true
+				names = append(names, r)
+			}
+		}
+	}
+
+	v, err := ac.GetResourceInstruction(ctx, app, cluster, appcontext.OrderInstruction)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error getting resource order of cluster %s of app %s", cluster, app)
+	}
+	var order resOrderInstr
+	if err := json.Unmarshal([]byte(fmt.Sprintf("%v", v)), &order); err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error unmarshalling resource order of cluster %s of app %s", cluster, app)
+	}
+	addNames(order.Resorder)
+
+	if v, err := ac.GetResourceInstruction(ctx, app, cluster, appcontext.DependencyInstruction); err == nil {
+		var dep resDepInstr
+		if err := json.Unmarshal([]byte(fmt.Sprintf("%v", v)), &dep); err != nil {
+			return nil, pkgerrors.Wrapf(err, "Error unmarshalling resource dependency of cluster %s of app %s", cluster, app)
+		}
+		for _, rl := range dep.Resdep {
+			addNames(rl)
+		}
+	}
+	return names, nil
+}
+
+// clusterResourceValue returns the value of a resource of a cluster of an app
+func clusterResourceValue(ctx context.Context, ac appcontext.AppContext, app, cluster, name string) (interface{}, error) {
+	rh, err := ac.GetResourceHandle(ctx, app, cluster, name)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error getting resource %s of cluster %s of app %s", name, cluster, app)
+	}
+	value, err := ac.GetValue(ctx, rh)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "Error getting resource %s of cluster %s of app %s", name, cluster, app)
+	}
+	return value, nil
+}
+
+// appDependency returns the app level dependency instruction of the app, dropping
+// the dependencies on apps that are not in the AppContext
+func appDependency(ctx context.Context, ac appcontext.AppContext, app string, apps map[string]bool) (string, bool) {
+	v, err := ac.GetAppLevelInstruction(ctx, app, appcontext.DependencyInstruction)
+	if err != nil {
+		return "", false
+	}
+	var deps []AdSpecData
+	if err := json.Unmarshal([]byte(fmt.Sprintf("%v", v)), &deps); err != nil {
+		return "", false
+	}
+	var kept []AdSpecData
+	for _, d := range deps {
+		if apps[d.AppName] {
+			kept = append(kept, d)
+		}
+	}
+	if len(kept) == 0 {
+		return "", false
+	}
+	b, err := json.Marshal(kept)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// makeWaveAppContext creates an AppContext with the resources of the target AppContext on
+// the promoted clusters and the resources of the source AppContext on the other clusters
+func makeWaveAppContext(ctx context.Context, source, target appcontext.AppContext, promoted map[string]bool) (contextForCompositeApp, error) {
+	wave := appcontext.AppContext{}
+	ctxval, err := wave.InitAppContext()
+	if err != nil {
+		return contextForCompositeApp{}, pkgerrors.Wrap(err, "Error creating AppContext CompositeApp")
+	}
+	handle, err := wave.CreateCompositeApp(ctx)
+	if err != nil {
+		return contextForCompositeApp{}, pkgerrors.Wrap(err, "Error creating CompositeApp handle")
+	}
+	cca := contextForCompositeApp{context: wave, ctxval: ctxval, compositeAppHandle: handle}
+
+	meta, err := target.GetCompositeAppMeta(ctx)
+	if err != nil {
+		deleteAppContext(ctx, wave)
+		return contextForCompositeApp{}, pkgerrors.Wrap(err, "Error getting CompositeAppMeta")
+	}
+	err = wave.AddCompositeAppMeta(ctx, meta)
+	if err != nil {
+		deleteAppContext(ctx, wave)
+		return contextForCompositeApp{}, pkgerrors.Wrap(err, "Error Adding CompositeAppMeta")
+	}
+
+	err = addWaveApps(ctx, cca, source, target, promoted)
+	if err != nil {
+		deleteAppContext(ctx, wave)
+		return contextForCompositeApp{}, err
+	}
+	return cca, nil
+}
+
+// addWaveApps adds the apps of the target AppContext, followed by the apps
+// only in the source AppContext, to the wave AppContext
+func addWaveApps(ctx context.Context, cca contextForCompositeApp, source, target appcontext.AppContext, promoted map[string]bool) error {
+	sourceApps, sourceClusters, err := contextAppClusters(ctx, source)
+	if err != nil {
+		return err
+	}
+	targetApps, targetClusters, err := contextAppClusters(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	inTarget := make(map[string]bool, len(targetApps))
+	for _, app := range targetApps {
+		inTarget[app] = true
+	}
+	apps := append([]string{}, targetApps...)
+	for _, app := range sourceApps {
+		if !inTarget[app] {
+			apps = append(apps, app)
+		}
+	}
+
+	copies := make(map[string][]clusterCopy)
+	inWave := make(map[string]bool)
+	var order appOrderInstr
+	for _, app := range apps {
+		for _, c := range targetClusters[app] {
+			if promoted[c] {
+				copies[app] = append(copies[app], clusterCopy{from: target, cluster: c})
+			}
+		}
+		for _, c := range sourceClusters[app] {
+			if !promoted[c] {
+				copies[app] = append(copies[app], clusterCopy{from: source, cluster: c})
+			}
+		}
+		if len(copies[app]) > 0 {
+			inWave[app] = true
+			order.Apporder = append(order.Apporder, app)
+		}
+	}
+
+	depMap := make(map[string]string)
+	for _, app := range order.Apporder {
+		appHandle, err := cca.context.AddApp(ctx, cca.compositeAppHandle, app)
+		if err != nil {
+			return pkgerrors.Wrap(err, "Error adding App to AppContext")
+		}
+		depMap[app] = "go"
+
+		from := source
+		if inTarget[app] {
+			from = target
+		}
+		if dep, ok := appDependency(ctx, from, app, inWave); ok {
+			_, err = cca.context.AddLevelValue(ctx, appHandle, "instruction/dependency", dep)
+			if err != nil {
+				return pkgerrors.Wrap(err, "Error adding App dependency to AppContext")
+			}
+		}
+
+		for _, cp := range copies[app] {
+			err = copyCluster(ctx, cp.from, cca.context, appHandle, app, cp.cluster)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	jappOrderInstr, err := json.Marshal(order)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error marshalling app order instruction")
+	}
+	jappDepInstr, err := json.Marshal(depMap)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error marshalling app dependency instruction")
+	}
+	_, err = cca.context.AddInstruction(ctx, cca.compositeAppHandle, appcontext.AppLevel, appcontext.OrderInstruction, string(jappOrderInstr))
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error adding app order instruction")
+	}
+	_, err = cca.context.AddInstruction(ctx, cca.compositeAppHandle, appcontext.AppLevel, appcontext.DependencyInstruction, string(jappDepInstr))
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error adding app dependency instruction")
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+)
+
+func TestRolloutWaves(t *testing.T) {
+	clusters := []string{"p+c1", "p+c2", "p+c3", "p+c4", "p+c5"}
+
+	testCases := []struct {
+		label    string
+		pct      int
+		expected [][]string
+	}{
+		{
+			label:    "Blue-green",
+			pct:      100,
+			expected: [][]string{clusters},
+		},
+		{
+			label:    "Waves of 40 percent",
+			pct:      40,
+			expected: [][]string{{"p+c1", "p+c2"}, {"p+c3", "p+c4"}, {"p+c5"}},
+		},
+		{
+			label:    "Waves of at least one cluster",
+			pct:      1,
+			expected: [][]string{{"p+c1"}, {"p+c2"}, {"p+c3"}, {"p+c4"}, {"p+c5"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			got := rolloutWaves(clusters, testCase.pct)
+			if !reflect.DeepEqual(got, testCase.expected) {
+				t.Fatalf("rolloutWaves returned %v, expected %v", got, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestValidateRolloutStrategy(t *testing.T) {
+	s, err := validateRolloutStrategy(RolloutStrategy{WavePercentage: 25})
+	if err != nil {
+		t.Fatalf("validateRolloutStrategy returned an error (%s)", err)
+	}
+	if s.OnFailure != RolloutOnFailureRollback || s.ReadyTimeoutSeconds != defaultRolloutReadyTimeoutSeconds {
+		t.Fatalf("validateRolloutStrategy returned %v", s)
+	}
+
+	for _, s := range []RolloutStrategy{
+		{WavePercentage: 0},
+		{WavePercentage: 101},
+		{WavePercentage: 50, PauseSeconds: -1},
+		{WavePercentage: 50, OnFailure: "retry"},
+	} {
+		_, err := validateRolloutStrategy(s)
+		if err == nil || !strings.Contains(err.Error(), "Invalid rollout") {
+			t.Fatalf("validateRolloutStrategy of %v returned (%v)", s, err)
+		}
+	}
+}
+
+// makeTestRolloutContext creates an AppContext with a resource named after the
+// version in each cluster of each app
+func makeTestRolloutContext(t *testing.T, version string, apps []string, clusters map[string][]string) appcontext.AppContext {
+	ctx := context.Background()
+	ac := appcontext.AppContext{}
+	if _, err := ac.InitAppContext(); err != nil {
+		t.Fatalf("InitAppContext returned an error (%s)", err)
+	}
+	handle, err := ac.CreateCompositeApp(ctx)
+	if err != nil {
+		t.Fatalf("CreateCompositeApp returned an error (%s)", err)
+	}
+	if err := ac.AddCompositeAppMeta(ctx, appcontext.CompositeAppMeta{Project: "p", CompositeApp: "ca", Version: version}); err != nil {
+		t.Fatalf("AddCompositeAppMeta returned an error (%s)", err)
+	}
+
+	depMap := make(map[string]string)
+	for _, app := range apps {
+		depMap[app] = "go"
+		appHandle, err := ac.AddApp(ctx, handle, app)
+		if err != nil {
+			t.Fatalf("AddApp returned an error (%s)", err)
+		}
+		for _, c := range clusters[app] {
+			ch, err := ac.AddCluster(ctx, appHandle, c)
+			if err != nil {
+				t.Fatalf("AddCluster returned an error (%s)", err)
+			}
+			res := app + "+Deployment"
+			ac.AddResource(ctx, ch, res, version)
+			order, _ := json.Marshal(resOrderInstr{Resorder: []string{res}})
+			ac.AddInstruction(ctx, ch, appcontext.ResourceLevel, appcontext.OrderInstruction, string(order))
+		}
+	}
+	order, _ := json.Marshal(appOrderInstr{Apporder: apps})
+	dep, _ := json.Marshal(depMap)
+	ac.AddInstruction(ctx, handle, appcontext.AppLevel, appcontext.OrderInstruction, string(order))
+	ac.AddInstruction(ctx, handle, appcontext.AppLevel, appcontext.DependencyInstruction, string(dep))
+	return ac
+}
+
+func TestMakeWaveAppContext(t *testing.T) {
+	ctx := context.Background()
+
+	source := makeTestRolloutContext(t, "v1", []string{"app1", "app2"}, map[string][]string{
+		"app1": {"p+c1", "p+c2"},
+		"app2": {"p+c1", "p+c2"},
+	})
+	target := makeTestRolloutContext(t, "v2", []string{"app1", "app3"}, map[string][]string{
+		"app1": {"p+c1", "p+c2"},
+		"app3": {"p+c1"},
+	})
+
+	cca, err := makeWaveAppContext(ctx, source, target, map[string]bool{"p+c1": true})
+	if err != nil {
+		t.Fatalf("makeWaveAppContext returned an error (%s)", err)
+	}
+
+	apps, clusters, err := contextAppClusters(ctx, cca.context)
+	if err != nil {
+		t.Fatalf("contextAppClusters returned an error (%s)", err)
+	}
+	if !reflect.DeepEqual(apps, []string{"app1", "app3", "app2"}) {
+		t.Fatalf("Wave AppContext has apps %v", apps)
+	}
+
+	expected := map[string]map[string]string{
+		"app1": {"p+c1": "v2", "p+c2": "v1"},
+		"app2": {"p+c2": "v1"},
+		"app3": {"p+c1": "v2"},
+	}
+	for app, cl := range expected {
+		if len(clusters[app]) != len(cl) {
+			t.Fatalf("Wave AppContext has clusters %v for %s", clusters[app], app)
+		}
+		for c, version := range cl {
+			rh, err := cca.context.GetResourceHandle(ctx, app, c, app+"+Deployment")
+			if err != nil {
+				t.Fatalf("Wave AppContext has no resource for %s on %s (%s)", app, c, err)
+			}
+			v, err := cca.context.GetValue(ctx, rh)
+			if err != nil || fmt.Sprintf("%v", v) != version {
+				t.Fatalf("Wave AppContext has resource %v for %s on %s, expected %s", v, app, c, version)
+			}
+		}
+	}
+
+	meta, err := cca.context.GetCompositeAppMeta(ctx)
+	if err != nil || meta.Version != "v2" {
+		t.Fatalf("Wave AppContext has meta %v (%v)", meta, err)
+	}
+}
@@ -214,6 +214,7 @@ type ServiceManager interface {
 	CreateService(ctx context.Context, service *Service) error
 	UpdateService(ctx context.Context, service *Service) error
 	GetAllServices(ctx context.Context, project string) ([]*Service, error)
+	ListServices(ctx context.Context, project string, opts db.FindOptions) ([]*Service, string, error)
 	GetService(ctx context.Context, key *ServiceKey) (*Service, error)
 	IsServiceExists(ctx context.Context, key *ServiceKey) (bool, error)
 	UpdateServiceDigs(ctx context.Context, key *ServiceKey, sdu *ServiceDigsUpdate) (*Service, error)
@@ -225,6 +226,16 @@ type ServiceManager interface {
 	ServiceStatus(ctx context.Context, key *ServiceKey) (*ServiceStatusInfo, error)
 }
 
+// serviceSelectors are the fields a list of Services can be filtered and sorted on
+var serviceSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": Service{},
+	},
+}
+
 // ServiceClient implements the ServiceManager interface
 type ServiceClient struct {
 	storeName           string
@@ -414,34 +425,46 @@ func (sc *ServiceClient) UpdateService(ctx context.Context, newService *Service)
 
 // GetAllServices returns all the Services
 func (sc *ServiceClient) GetAllServices(ctx context.Context, project string) ([]*Service, error) {
+	res, _, err := sc.ListServices(ctx, project, db.FindOptions{})
+	return res, err
+}
+
+// ListServices returns a page of the Services of the project, filtered and
+// sorted on the serviceSelectors, and the token of the next page
+func (sc *ServiceClient) ListServices(ctx context.Context, project string, opts db.FindOptions) ([]*Service, string, error) {
+	opts, err := serviceSelectors.Resolve(opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	key := ServiceKey{
 		Name:    "",
 		Project: project,
 	}
 
 	//Check if project exists
-	_, err := NewProjectClient().GetProject(ctx, project)
+	_, err = NewProjectClient().GetProject(ctx, project)
 	if err != nil {
-		return nil, pkgerrors.Wrap(err, "Project not found")
+		return nil, "", pkgerrors.Wrap(err, "Project not found")
 	}
 
 	var serviceList []*Service
-	result, err := db.DBconn.Find(ctx, sc.storeName, key, sc.tag)
+	result, next, err := db.DBconn.FindWithOptions(ctx, sc.storeName, key, sc.tag, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	for _, value := range result {
 		service := &Service{}
 		err = db.DBconn.Unmarshal(value, service)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		serviceList = append(serviceList, service)
 	}
 
-	return serviceList, nil
+	return serviceList, next, nil
 }
 
 // GetService returns the Service with a given service key
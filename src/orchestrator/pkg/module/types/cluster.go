@@ -12,7 +12,7 @@ type GitOpsSpec struct {
 
 // GitOps Properties for Reference and Resource Objects
 type GitOpsProps struct {
-	// GitOps type - example fluxv2, azureArc, anthos
+	// GitOps type - example fluxv2, azureArc, anthos, argocd
 	GitOpsType string `json:"gitOpsType"`
 	// Refrence Sync object for the cloud configuration
 	GitOpsReferenceObject string `json:"gitOpsReferenceObject"`
@@ -73,8 +73,21 @@ func (c InstantiationClient) Migrate(ctx context.Context, p string, ca string, v
 		return pkgerrors.Wrap(err, "Not finding the deploymentIntentGroup")
 	}
 
+	// wait for the maintenance windows of the source and the target if they are closed
+	sDIGrp, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, di, p, ca, v)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Not finding the deploymentIntentGroup")
+	}
+	scheduled, err := c.scheduleOperation(ctx, ScheduledOperationMigrate,
+		DeploymentIntentGroupKey{Name: di, Project: p, CompositeApp: ca, Version: v}, ss,
+		map[string]string{"targetCompositeAppVersion": tCav, "targetDeploymentIntentGroup": tDi}, sDIGrp, dIGrp)
+	if err != nil || scheduled != nil {
+		return err
+	}
+
 	// BEGIN : Make app context
-	instantiator := Instantiator{p, ca, tCav, tDi, dIGrp}
+	// The target DeploymentIntentGroup starts at its first revision
+	instantiator := Instantiator{p, ca, tCav, tDi, dIGrp, 1}
 	cca, err := instantiator.MakeAppContext(ctx)
 	if err != nil {
 		return pkgerrors.Wrap(err, "Error in making AppContext")
@@ -116,6 +129,17 @@ func (c InstantiationClient) Migrate(ctx context.Context, p string, ca string, v
 		return err
 	}
 
+	// Migrate the clusters in waves if the target has a rollout strategy
+	if dIGrp.Spec.RolloutStrategy != nil {
+		source := DeploymentIntentGroupKey{Name: di, Project: p, CompositeApp: ca, Version: v}
+		target := DeploymentIntentGroupKey{Name: tDi, Project: p, CompositeApp: ca, Version: tCav}
+		err = c.startRollout(ctx, *dIGrp.Spec.RolloutStrategy, source, target, sourceCtxId, targetCtxId, statusID)
+		if err != nil {
+			deleteAppContext(ctx, cca.context)
+		}
+		return err
+	}
+
 	err = callRsyncUpdate(ctx, sourceCtxId, targetCtxId)
 	if err != nil {
 		return err
@@ -150,6 +174,7 @@ func (c InstantiationClient) Migrate(ctx context.Context, p string, ca string, v
 		State:     state.StateEnum.Instantiated,
 		ContextId: targetCtxId,
 		TimeStamp: time.Now(),
+		Revision:  1,
 	}
 	ts.Actions = append(ts.Actions, a)
 	// Update the status context ID to match source
@@ -168,49 +193,58 @@ func (c InstantiationClient) Migrate(ctx context.Context, p string, ca string, v
 Update methods takes in projectName, compositeAppName, compositeAppVersion,
 DeploymentIntentName.
 This method is responsible for creation and saving of context into etcd and ensuring new intents are applied on DeploymentIntentGroup.
+It returns the new revision, or the scheduled operation if the maintenance windows are closed.
 */
-func (c InstantiationClient) Update(ctx context.Context, p string, ca string, v string, di string) (int64, error) {
+func (c InstantiationClient) Update(ctx context.Context, p string, ca string, v string, di string) (int64, *state.ScheduledEntry, error) {
 
 	log.Info("Update API", log.Fields{"project": p, "compositeapp": ca, "version": v, "deploymentintentgroup": di})
 
 	// Fetch source DIG context ID
 	ss, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroupState(ctx, di, p, ca, v)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "DeploymentIntentGroup has no state info: "+di)
+		return -1, nil, pkgerrors.Wrap(err, "DeploymentIntentGroup has no state info: "+di)
 	}
 
 	stateVal, err := state.GetCurrentStateFromStateInfo(ss)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "Error getting current state from DeploymentIntentGroup stateInfo: "+di)
+		return -1, nil, pkgerrors.Wrap(err, "Error getting current state from DeploymentIntentGroup stateInfo: "+di)
 	}
 
 	if stateVal != state.StateEnum.Instantiated && stateVal != state.StateEnum.InstantiateStopped {
-		return -1, pkgerrors.Errorf("DeploymentIntentGroup is not instantiated :" + di)
+		return -1, nil, pkgerrors.Errorf("DeploymentIntentGroup is not instantiated :" + di)
 	}
 
 	sourceCtxId := state.GetLastContextIdFromStateInfo(ss)
 	lastRevision, err := state.GetLatestRevisionFromStateInfo(ss)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "Latest revision not found "+di)
+		return -1, nil, pkgerrors.Wrap(err, "Latest revision not found "+di)
 	}
 
 	dIGrp, err := NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, di, p, ca, v)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "Not finding the deploymentIntentGroup")
+		return -1, nil, pkgerrors.Wrap(err, "Not finding the deploymentIntentGroup")
+	}
+
+	// wait for the maintenance windows if they are closed, the revision is
+	// unknown until the update is executed
+	scheduled, err := c.scheduleOperation(ctx, ScheduledOperationUpdate,
+		DeploymentIntentGroupKey{Name: di, Project: p, CompositeApp: ca, Version: v}, ss, nil, dIGrp)
+	if err != nil || scheduled != nil {
+		return -1, scheduled, err
 	}
 
 	// BEGIN : Make app context
-	instantiator := Instantiator{p, ca, v, di, dIGrp}
+	instantiator := Instantiator{p, ca, v, di, dIGrp, lastRevision + 1}
 	cca, err := instantiator.MakeAppContext(ctx)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "Error in making AppContext")
+		return -1, nil, pkgerrors.Wrap(err, "Error in making AppContext")
 	}
 	// END : Make app context
 
 	// BEGIN : callScheduler
 	err = callScheduler(ctx, cca.context, cca.ctxval, sourceCtxId, p, ca, v, di)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "Error in callScheduler")
+		return -1, nil, pkgerrors.Wrap(err, "Error in callScheduler")
 	}
 	// END : callScheduler
 
@@ -221,11 +255,11 @@ func (c InstantiationClient) Update(ctx context.Context, p string, ca string, v
 	// Update Status context id to be source status collected in source
 	err = state.UpdateAppContextStatusContextID(ctx, targetCtxId, statusID)
 	if err != nil {
-		return -1, err
+		return -1, nil, err
 	}
 	err = callRsyncUpdate(ctx, sourceCtxId, targetCtxId)
 	if err != nil {
-		return -1, err
+		return -1, nil, err
 	}
 
 	key := DeploymentIntentGroupKey{
@@ -246,7 +280,7 @@ func (c InstantiationClient) Update(ctx context.Context, p string, ca string, v
 
 	err = db.DBconn.Insert(ctx, c.db.storeName, key, nil, c.db.tagState, ss)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "Error updating the stateInfo of the DeploymentIntentGroup: "+di)
+		return -1, nil, pkgerrors.Wrap(err, "Error updating the stateInfo of the DeploymentIntentGroup: "+di)
 	}
 
 	// TODO : Atomicity check
@@ -263,7 +297,7 @@ func (c InstantiationClient) Update(ctx context.Context, p string, ca string, v
 
 	err = db.DBconn.Insert(ctx, c.db.storeName, key, nil, c.db.tagState, ss)
 	if err != nil {
-		return -1, pkgerrors.Wrap(err, "Error updating the stateInfo of the DeploymentIntentGroup: "+di)
+		return -1, nil, pkgerrors.Wrap(err, "Error updating the stateInfo of the DeploymentIntentGroup: "+di)
 	}
 
 	log.Info("Updated revisionID", log.Fields{"Updated to revisionID": latestRevision})
@@ -271,7 +305,7 @@ func (c InstantiationClient) Update(ctx context.Context, p string, ca string, v
 	// Call Post Update Event for all controllers
 	_ = callPostEventScheduler(ctx, targetCtxId, p, ca, v, di, "UPDATE")
 
-	return latestRevision, nil
+	return latestRevision, nil, nil
 
 }
 
@@ -306,8 +340,22 @@ func (c InstantiationClient) Rollback(ctx context.Context, p string, ca string,
 		return pkgerrors.Wrap(err, "GetMatchingContextIDforRevision error "+rbRev)
 	}
 
+	// The AppContext of the revision rolled back to deploys the next revision
+	latestRevision := prevRevisionID + 1
+	targetCtx, err := state.GetAppContextFromId(ctx, targetCtxId)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error getting the AppContext of the revision "+rbRev)
+	}
+	err = targetCtx.SetCompositeAppRevision(ctx, latestRevision)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error setting the revision of the AppContext")
+	}
+
 	err = callRsyncUpdate(ctx, sourceCtxId, targetCtxId)
 	if err != nil {
+		if err := targetCtx.SetCompositeAppRevision(ctx, rID); err != nil {
+			log.Error("Error restoring the revision of the AppContext", log.Fields{"contextId": targetCtxId, "error": err.Error()})
+		}
 		return err
 	}
 
@@ -332,8 +380,6 @@ func (c InstantiationClient) Rollback(ctx context.Context, p string, ca string,
 	}
 
 	// TODO : Atomicity check
-	latestRevision := prevRevisionID + 1
-
 	// Instantiating the current state
 	a = state.ActionEntry{
 		State:     state.StateEnum.Instantiated,
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package resourcestatus
+
+import "time"
+
+// DriftStatus is the result of the last comparison, by rsync, of a resource
+// of the appcontext with the resource on the cluster
+type DriftStatus struct {
+	State DriftState `json:"state"`
+	// Fields are the paths of the fields of the resource that differ on the cluster
+	Fields    []string  `json:"fields,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Healed    bool      `json:"healed,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+type DriftState = string
+
+type driftValues struct {
+	InSync  DriftState
+	Drifted DriftState
+	Missing DriftState
+	Unknown DriftState
+}
+
+var DriftStateEnum = &driftValues{
+	InSync:  "InSync",
+	Drifted: "Drifted",
+	Missing: "Missing",
+	Unknown: "Unknown",
+}
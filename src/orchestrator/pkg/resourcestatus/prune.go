@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package resourcestatus
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PruneReport is the result of the last pruning, by rsync, of the orphaned
+// resources of an app on a cluster
+type PruneReport struct {
+	DryRun    bool             `json:"dryRun,omitempty"`
+	Orphans   []OrphanResource `json:"orphans,omitempty"`
+	Message   string           `json:"message,omitempty"`
+	CheckedAt time.Time        `json:"checkedAt"`
+}
+
+// OrphanResource is a resource on the cluster labeled with the deployment of
+// the app that isn't in the appcontext
+type OrphanResource struct {
+	Gvk       schema.GroupVersionKind `json:"GVK"`
+	Name      string                  `json:"name"`
+	Namespace string                  `json:"namespace,omitempty"`
+	Pruned    bool                    `json:"pruned,omitempty"`
+	Error     string                  `json:"error,omitempty"`
+}
@@ -7,6 +7,10 @@ package resourcestatus
 // that rsync is synchronizing to clusters
 type ResourceStatus struct {
 	Status RsyncStatus
+	// Attempts is the number of attempts of the last operation, if it was retried
+	Attempts int `json:"Attempts,omitempty"`
+	// LastError is the error of the last failed attempt
+	LastError string `json:"LastError,omitempty"`
 }
 
 type RsyncStatus = string
@@ -17,6 +21,7 @@ type statusValues struct {
 	Failed   RsyncStatus
 	Retrying RsyncStatus
 	Deleted  RsyncStatus
+	Conflict RsyncStatus
 }
 
 var RsyncStatusEnum = &statusValues{
@@ -25,4 +30,6 @@ var RsyncStatusEnum = &statusValues{
 	Failed:   "Failed",
 	Retrying: "Retrying",
 	Deleted:  "Deleted",
+	// The fields of the resource are managed by another field manager
+	Conflict: "Conflict",
 }
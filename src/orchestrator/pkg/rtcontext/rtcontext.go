@@ -38,6 +38,8 @@ type Rtcontext interface {
 	RtcGetHandles(ctx context.Context, handle interface{}) ([]interface{}, error)
 	RtcGetValue(ctx context.Context, handle interface{}, value interface{}) error
 	RtcUpdateValue(ctx context.Context, handle interface{}, value interface{}) error
+	RtcGetValueWithRevision(ctx context.Context, handle interface{}, value interface{}) (int64, error)
+	RtcUpdateValueIfRevision(ctx context.Context, handle interface{}, value interface{}, revision int64, puts ...contextdb.TxnPut) error
 	RtcGetMeta(ctx context.Context) (interface{}, error)
 	RtcAddOneLevel(ctx context.Context, pl interface{}, level string, value interface{}) (interface{}, error)
 }
@@ -99,7 +101,10 @@ func (rtc *RunTimeContext) RtcCreate(ctx context.Context) (interface{}, error) {
 	id := strings.SplitN(cid, "/", 4)[2]
 	// Create context only if context doesn't exist
 	// Returns error if id is in database
-	err := contextdb.Db.PutWithCheck(ctx, cid, id)
+	err := contextdb.Db.PutIfRevision(ctx, cid, id, 0)
+	if err == contextdb.ErrRevisionMismatch {
+		return nil, pkgerrors.Errorf("Error creating run time context: Key exists %v", cid)
+	}
 	if err != nil {
 		return nil, pkgerrors.Errorf("Error creating run time context: %s", err.Error())
 	}
@@ -342,3 +347,39 @@ func (rtc *RunTimeContext) RtcUpdateValue(ctx context.Context, handle interface{
 	return nil
 
 }
+
+// Get the value and the revision for a given handle
+// The revision is 0 if the handle doesn't exist
+func (rtc *RunTimeContext) RtcGetValueWithRevision(ctx context.Context, handle interface{}, value interface{}) (int64, error) {
+	str := fmt.Sprintf("%v", handle)
+	sid := fmt.Sprintf("%v", rtc.cid)
+	if !strings.HasPrefix(str, sid) {
+		return 0, pkgerrors.Errorf("Not a valid run time context handle")
+	}
+
+	rev, err := contextdb.Db.GetWithRevision(ctx, str, value)
+	if err != nil {
+		return 0, pkgerrors.Errorf("Error getting run time context value: %s", err.Error())
+	}
+
+	return rev, nil
+}
+
+// Update the value of a given handle if it is still at the given revision
+// Any additional puts are committed in the same transaction
+func (rtc *RunTimeContext) RtcUpdateValueIfRevision(ctx context.Context, handle interface{}, value interface{}, revision int64, puts ...contextdb.TxnPut) error {
+	str := fmt.Sprintf("%v", handle)
+	sid := fmt.Sprintf("%v", rtc.cid)
+	if !strings.HasPrefix(str, sid) {
+		return pkgerrors.Errorf("Not a valid run time context handle")
+	}
+	puts = append([]contextdb.TxnPut{{Key: str, Value: value, Revision: revision}}, puts...)
+	err := contextdb.Db.PutTxn(ctx, puts)
+	if err == contextdb.ErrRevisionMismatch {
+		return err
+	}
+	if err != nil {
+		return pkgerrors.Errorf("Error updating run time context value: %s", err.Error())
+	}
+	return nil
+}
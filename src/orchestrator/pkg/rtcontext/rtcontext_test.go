@@ -14,8 +14,9 @@ import (
 
 // MockContextDb for mocking contextdb
 type MockContextDb struct {
-	Items map[string]interface{}
-	Err   error
+	Items     map[string]interface{}
+	Revisions map[string]int64
+	Err       error
 }
 
 // Put function
@@ -81,6 +82,39 @@ func (c *MockContextDb) HealthCheck() error {
 	return nil
 }
 
+// GetWithRevision function
+func (c *MockContextDb) GetWithRevision(ctx context.Context, key string, val interface{}) (int64, error) {
+	if _, ok := c.Items[key]; !ok {
+		return 0, c.Err
+	}
+	return c.Revisions[key], c.Get(ctx, key, val)
+}
+
+// PutIfRevision function
+func (c *MockContextDb) PutIfRevision(ctx context.Context, key string, val interface{}, revision int64) error {
+	return c.PutTxn(ctx, []contextdb.TxnPut{{Key: key, Value: val, Revision: revision}})
+}
+
+// PutTxn function
+func (c *MockContextDb) PutTxn(ctx context.Context, puts []contextdb.TxnPut) error {
+	if c.Err != nil {
+		return c.Err
+	}
+	if c.Revisions == nil {
+		c.Revisions = make(map[string]int64)
+	}
+	for _, p := range puts {
+		if p.Revision != contextdb.AnyRevision && c.Revisions[p.Key] != p.Revision {
+			return contextdb.ErrRevisionMismatch
+		}
+	}
+	for _, p := range puts {
+		c.Put(ctx, p.Key, p.Value)
+		c.Revisions[p.Key]++
+	}
+	return nil
+}
+
 func TestRtcInit(t *testing.T) {
 	var rtc = RunTimeContext{}
 	testCases := []struct {
@@ -718,3 +752,60 @@ func TestRtcDeletePrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestRtcUpdateValueIfRevision(t *testing.T) {
+	var rtc = RunTimeContext{"/context/5345674458787728/", ""}
+	testCases := []struct {
+		label         string
+		mockContextDb *MockContextDb
+		key           interface{}
+		revision      int64
+		expectedError string
+	}{
+		{
+			label:         "Not valid input handle case",
+			mockContextDb: &MockContextDb{},
+			key:           "/context/3528435435454354/",
+			expectedError: "Not a valid run time context handle",
+		},
+		{
+			label:         "Contextdb call returns error case",
+			mockContextDb: &MockContextDb{Err: pkgerrors.Errorf("Key does not exist")},
+			key:           "/context/5345674458787728/queue/",
+			expectedError: "Error updating run time context value:",
+		},
+		{
+			label:         "Revision mismatch case",
+			mockContextDb: &MockContextDb{},
+			key:           "/context/5345674458787728/queue/",
+			revision:      2,
+			expectedError: "Revision mismatch",
+		},
+		{
+			label:         "Success case",
+			mockContextDb: &MockContextDb{},
+			key:           "/context/5345674458787728/queue/",
+			revision:      1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			ctx := context.Background()
+			contextdb.Db = testCase.mockContextDb
+			contextdb.Db.PutIfRevision(ctx, "/context/5345674458787728/queue/", "q1", 0)
+			err := rtc.RtcUpdateValueIfRevision(ctx, testCase.key, "q2", testCase.revision)
+			if err != nil {
+				if testCase.expectedError == "" || !strings.Contains(string(err.Error()), testCase.expectedError) {
+					t.Fatalf("Method returned an error (%s)", err)
+				}
+				return
+			}
+			var v string
+			rev, err := rtc.RtcGetValueWithRevision(ctx, testCase.key, &v)
+			if err != nil || rev != 2 || v != "q2" {
+				t.Fatalf("RtcGetValueWithRevision returned (%d, %s, %v)", rev, v, err)
+			}
+		})
+	}
+}
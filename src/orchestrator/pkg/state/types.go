@@ -12,6 +12,8 @@ type StateInfo struct {
 	// Same Status AppContext between instantiation and termination for a DIG
 	StatusContextId string `json:"statusctxid"`
 	Actions []ActionEntry `json:"actions"`
+	// Operation requested outside of the maintenance windows, waiting to be executed
+	Scheduled *ScheduledEntry `json:"scheduled,omitempty"`
 }
 
 // ActionEntry is used to keep track of the time an action (e.g. Created, Instantiate, Terminate) was invoked
@@ -35,6 +37,8 @@ type states struct {
 	InstantiateStopped StateValue
 	TerminateStopped   StateValue
 	Updated		StateValue
+	Scheduled          StateValue
+	ScheduleFailed     StateValue
 }
 
 var StateEnum = &states{
@@ -47,4 +51,18 @@ var StateEnum = &states{
 	InstantiateStopped: "InstantiateStopped",
 	TerminateStopped:   "TerminateStopped",
 	Updated:            "Updated",
+	Scheduled:          "Scheduled",
+	ScheduleFailed:     "ScheduleFailed",
+}
+
+// ScheduledEntry is an operation (e.g. instantiate, update) that is executed when the
+// maintenance windows are open. Its state is Scheduled until it is executed, or
+// ScheduleFailed with the Error if the execution failed.
+type ScheduledEntry struct {
+	State     StateValue        `json:"state"`
+	Operation string            `json:"operation"`
+	Params    map[string]string `json:"params,omitempty"`
+	TimeStamp time.Time         `json:"time"`
+	NotBefore time.Time         `json:"notBefore"`
+	Error     string            `json:"error,omitempty"`
 }
@@ -542,8 +542,15 @@ func getAppContextResources(ctx context.Context, ac, sac appcontext.AppContext,
 			statusCnts[rstatus.Status] = cnt + 1
 		} else if qType == "deployed" {
 			r.DeployedStatus = fmt.Sprintf("%v", rstatus.Status)
+			r.Attempts = rstatus.Attempts
+			r.LastError = rstatus.LastError
 			cnt := statusCnts[rstatus.Status]
 			statusCnts[rstatus.Status] = cnt + 1
+		} else if qType == "drift" {
+			dstatus := getResourceDriftStatus(ctx, sac, statusH)
+			r.DriftStatus = dstatus.State
+			r.DriftedFields = dstatus.Fields
+			statusCnts[dstatus.State]++
 		} else if qType == "ready" {
 			r.ReadyStatus = "NotPresent"
 			updateNotPresentCount(true, clusterStatusCnts)
@@ -673,6 +680,48 @@ func PrepareCaCertStatusResult(ctx context.Context, stateInfo state.StateInfo, q
 
 // prepareStatusResult takes in a resource stateInfo object, the list of apps and the query parameters.
 // It then fills out the StatusResult structure appropriately from information in the AppContext
+// getResourceDriftStatus returns the drift status rsync recorded for the
+// resource, Unknown if the resource hasn't been compared yet
+func getResourceDriftStatus(ctx context.Context, sac appcontext.AppContext, statusH interface{}) resourcestatus.DriftStatus {
+	dstatus := resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.Unknown}
+	dh, err := sac.GetLevelHandle(ctx, statusH, "drift")
+	if err != nil {
+		return dstatus
+	}
+	d, err := sac.GetValue(ctx, dh)
+	if err != nil {
+		return dstatus
+	}
+	js, err := json.Marshal(d)
+	if err == nil {
+		json.Unmarshal(js, &dstatus)
+	}
+	return dstatus
+}
+
+// getClusterPruneReport returns the report of the last pruning, by rsync, of
+// the orphaned resources of the app on the cluster
+func getClusterPruneReport(ctx context.Context, sac appcontext.AppContext, app, cluster string) (resourcestatus.PruneReport, bool) {
+	report := resourcestatus.PruneReport{}
+	ch, err := sac.GetClusterHandle(ctx, app, cluster)
+	if err != nil {
+		return report, false
+	}
+	ph, err := sac.GetLevelHandle(ctx, ch, "prune")
+	if err != nil {
+		return report, false
+	}
+	p, err := sac.GetValue(ctx, ph)
+	if err != nil {
+		return report, false
+	}
+	js, err := json.Marshal(p)
+	if err != nil || json.Unmarshal(js, &report) != nil {
+		return report, false
+	}
+	return report, true
+}
+
 func prepareStatusResult(ctx context.Context, statusType string, stateInfo state.StateInfo, qInstance, qType, qOutput string, fApps, fClusters, fResources []string) (StatusResult, error) {
 
 	statusResult := StatusResult{}
@@ -806,6 +855,27 @@ func prepareStatusResult(ctx context.Context, statusType string, stateInfo state
 				clusterStatus.Connectivity = connectivity
 			}
 
+			if qType == "prune" {
+				// The clusters on which rsync hasn't pruned yet are left out
+				report, found := getClusterPruneReport(ctx, sac, app, cluster)
+				if !found {
+					continue
+				}
+				for _, o := range report.Orphans {
+					if o.Pruned {
+						rsyncStatusCnts["Pruned"]++
+					} else if o.Error != "" {
+						rsyncStatusCnts["Failed"]++
+					} else {
+						rsyncStatusCnts["NotPruned"]++
+					}
+				}
+				clusterStatus.PruneReport = &report
+				appStatus.Clusters = append(appStatus.Clusters, clusterStatus)
+				appCount++
+				continue
+			}
+
 			ch, err := ac.GetClusterHandle(ctx, app, cluster)
 			if err != nil {
 				log.Error(":: No handle for cluster, app ::",
@@ -840,7 +910,7 @@ func prepareStatusResult(ctx context.Context, statusType string, stateInfo state
 
 				appCount += cnt
 				clusterCount += cnt
-			} else if qType != "rsync" && qType != "deployed" {
+			} else if qType != "rsync" && qType != "deployed" && qType != "drift" {
 				log.Info(":: Invalid status type ::", log.Fields{"Status Type": qType})
 				continue
 			}
@@ -857,6 +927,10 @@ func prepareStatusResult(ctx context.Context, statusType string, stateInfo state
 	if qType == "rsync" || qType == "cluster" {
 		statusResult.RsyncStatus = rsyncStatusCnts
 		statusResult.ClusterStatus = clusterStatusCnts
+	} else if qType == "drift" {
+		statusResult.DriftCounts = rsyncStatusCnts
+	} else if qType == "prune" {
+		statusResult.OrphanCounts = rsyncStatusCnts
 	} else {
 		statusResult.DeployedCounts = rsyncStatusCnts
 		statusResult.ReadyCounts = clusterStatusCnts
@@ -867,7 +941,7 @@ func prepareStatusResult(ctx context.Context, statusType string, stateInfo state
 	notPresentCnt := clusterStatusCnts["NotPresent"]
 
 	if notReadyCnt == 0 && notPresentCnt == 0 {
-		if qType != "rsync" && qType != "deployed" {
+		if qType != "rsync" && qType != "deployed" && qType != "drift" && qType != "prune" {
 			statusResult.ReadyStatus = "Ready"
 		}
 	} else if readyCnt == 0 && notReadyCnt == 0 {
@@ -1139,7 +1213,7 @@ func prepareResourcesByAppStatusResult(ctx context.Context, ac, sac appcontext.A
 						log.Fields{"Cluster": cluster, "AppName": app, "Error": err})
 					continue
 				}
-			} else if qType != "rsync" && qType != "deployed" {
+			} else if qType != "rsync" && qType != "deployed" && qType != "drift" {
 				log.Info(":: Invalid status type ::", log.Fields{"Status Type": qType})
 				continue
 			}
@@ -5,6 +5,7 @@ package status
 
 import (
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/resourcestatus"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/state"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -74,6 +75,8 @@ type StatusResult struct {
 	ClusterStatus   map[string]int         `json:"clusterStatus,omitempty,inline"` // deprecated
 	DeployedCounts  map[string]int         `json:"deployedCounts,omitempty,inline"`
 	ReadyCounts     map[string]int         `json:"readyCounts,omitempty,inline"`
+	DriftCounts     map[string]int         `json:"driftCounts,omitempty,inline"`
+	OrphanCounts    map[string]int         `json:"orphanCounts,omitempty,inline"`
 	Apps            []AppStatus            `json:"apps,omitempty,inline"`
 	ChildContextIDs []string               `json:"ChildContextIDs,omitempty,inline"`
 }
@@ -84,11 +87,12 @@ type AppStatus struct {
 }
 
 type ClusterStatus struct {
-	ClusterProvider string           `json:"clusterProvider,omitempty"`
-	Cluster         string           `json:"cluster,omitempty"`
-	ReadyStatus     string           `json:"readyStatus,omitempty"` // deprecated - to be replaced with Connectivity
-	Connectivity    string           `json:"connectivity,omitempty"`
-	Resources       []ResourceStatus `json:"resources,omitempty"`
+	ClusterProvider string                      `json:"clusterProvider,omitempty"`
+	Cluster         string                      `json:"cluster,omitempty"`
+	ReadyStatus     string                      `json:"readyStatus,omitempty"` // deprecated - to be replaced with Connectivity
+	Connectivity    string                      `json:"connectivity,omitempty"`
+	Resources       []ResourceStatus            `json:"resources,omitempty"`
+	PruneReport     *resourcestatus.PruneReport `json:"pruneReport,omitempty"`
 }
 
 // DeploymentStatus is the structure used to return general status results
@@ -124,6 +128,10 @@ type ResourceStatus struct {
 	ClusterStatus  string                  `json:"clusterStatus,omitempty"` // deprecated - to be replaced with ReadyStatus
 	DeployedStatus string                  `json:"deployedStatus,omitempty"`
 	ReadyStatus    string                  `json:"readyStatus,omitempty"`
+	DriftStatus    string                  `json:"driftStatus,omitempty"`
+	DriftedFields  []string                `json:"driftedFields,omitempty"`
+	Attempts       int                     `json:"attempts,omitempty"`
+	LastError      string                  `json:"lastError,omitempty"`
 }
 
 // AppsListResult returns a list of Apps for the given AppContext
@@ -6,6 +6,7 @@ import (
 	"context"
 	module "gitlab.com/project-emco/core/emco-base/src/ovnaction/pkg/module"
 	mock "github.com/stretchr/testify/mock"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // WorkloadIfIntentManager is an autogenerated mock type for the WorkloadIfIntentManager type
@@ -91,3 +92,33 @@ func (_m *WorkloadIfIntentManager) GetWorkloadIfIntents(ctx context.Context, pro
 
 	return r0, r1
 }
+
+// ListWorkloadIfIntents provides a mock function with given fields: project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent, opts
+func (_m *WorkloadIfIntentManager) ListWorkloadIfIntents(ctx context.Context, project string, compositeapp string, compositeappversion string, dig string, netcontrolintent string, workloadintent string, opts db.FindOptions) ([]module.WorkloadIfIntent, string, error) {
+	ret := _m.Called(ctx, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent, opts)
+
+	var r0 []module.WorkloadIfIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, string, db.FindOptions) []module.WorkloadIfIntent); ok {
+		r0 = rf(project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.WorkloadIfIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
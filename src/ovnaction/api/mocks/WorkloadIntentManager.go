@@ -6,6 +6,7 @@ import (
 	"context"
 	module "gitlab.com/project-emco/core/emco-base/src/ovnaction/pkg/module"
 	mock "github.com/stretchr/testify/mock"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // WorkloadIntentManager is an autogenerated mock type for the WorkloadIntentManager type
@@ -91,3 +92,33 @@ func (_m *WorkloadIntentManager) GetWorkloadIntents(ctx context.Context, project
 
 	return r0, r1
 }
+
+// ListWorkloadIntents provides a mock function with given fields: project, compositeapp, compositeappversion, dig, netcontrolintent, opts
+func (_m *WorkloadIntentManager) ListWorkloadIntents(ctx context.Context, project string, compositeapp string, compositeappversion string, dig string, netcontrolintent string, opts db.FindOptions) ([]module.WorkloadIntent, string, error) {
+	ret := _m.Called(ctx, project, compositeapp, compositeappversion, dig, netcontrolintent, opts)
+
+	var r0 []module.WorkloadIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, db.FindOptions) []module.WorkloadIntent); ok {
+		r0 = rf(project, compositeapp, compositeappversion, dig, netcontrolintent, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.WorkloadIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, compositeapp, compositeappversion, dig, netcontrolintent, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, compositeapp, compositeappversion, dig, netcontrolintent, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
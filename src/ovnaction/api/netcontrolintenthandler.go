@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/ovnaction/pkg/module"
@@ -140,9 +142,17 @@ func (h netcontrolintentHandler) getHandler(w http.ResponseWriter, r *http.Reque
 	deployIntentGroup := vars["deploymentIntentGroup"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetNetControlIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListNetControlIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, opts)
 	} else {
 		ret, err = h.client.GetNetControlIntent(ctx, name, project, compositeApp, compositeAppVersion, deployIntentGroup)
 	}
@@ -153,6 +163,7 @@ func (h netcontrolintentHandler) getHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
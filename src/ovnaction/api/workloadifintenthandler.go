@@ -10,6 +10,8 @@ import (
 
 	pkgerrors "github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/ovnaction/pkg/module"
@@ -210,9 +212,17 @@ func (h workloadifintentHandler) getHandler(w http.ResponseWriter, r *http.Reque
 	workloadIntent := vars["workloadIntent"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetWorkloadIfIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, netControlIntent, workloadIntent)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListWorkloadIfIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, netControlIntent, workloadIntent, opts)
 	} else {
 		ret, err = h.client.GetWorkloadIfIntent(ctx, name, project, compositeApp, compositeAppVersion, deployIntentGroup, netControlIntent, workloadIntent)
 	}
@@ -223,6 +233,7 @@ func (h workloadifintentHandler) getHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -619,7 +619,7 @@ var _ = Describe("Workloadifintenthandler", func() {
 	DescribeTable("Get List WorkloadIfIntent tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetWorkloadIfIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "net-ctl-intent", "testworkloadintent").Return(t.mockVals, t.mockError)
+			t.client.On("ListWorkloadIfIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "net-ctl-intent", "testworkloadintent", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/network-controller-intent/net-ctl-intent/workload-intents/testworkloadintent/interfaces", nil)
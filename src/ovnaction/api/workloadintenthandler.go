@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/ovnaction/pkg/module"
@@ -143,9 +145,17 @@ func (h workloadintentHandler) getHandler(w http.ResponseWriter, r *http.Request
 	netControlIntent := vars["netControllerIntent"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetWorkloadIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, netControlIntent)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(err.Error(), log.Fields{})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListWorkloadIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, netControlIntent, opts)
 	} else {
 		ret, err = h.client.GetWorkloadIntent(ctx, name, project, compositeApp, compositeAppVersion, deployIntentGroup, netControlIntent)
 	}
@@ -156,6 +166,7 @@ func (h workloadintentHandler) getHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
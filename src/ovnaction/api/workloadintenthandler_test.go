@@ -625,7 +625,7 @@ var _ = Describe("Workloadintenthandler", func() {
 	DescribeTable("Get List WorkloadIntent tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetWorkloadIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "net-ctl-intent").Return(t.mockVals, t.mockError)
+			t.client.On("ListWorkloadIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "net-ctl-intent", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/network-controller-intent/net-ctl-intent/workload-intents", nil)
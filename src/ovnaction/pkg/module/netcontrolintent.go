@@ -29,9 +29,20 @@ type NetControlIntentManager interface {
 	CreateNetControlIntent(ctx context.Context, nci NetControlIntent, project, compositeapp, compositeappversion, dig string, exists bool) (NetControlIntent, error)
 	GetNetControlIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig string) (NetControlIntent, error)
 	GetNetControlIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string) ([]NetControlIntent, error)
+	ListNetControlIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string, opts db.FindOptions) ([]NetControlIntent, string, error)
 	DeleteNetControlIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig string) error
 }
 
+// netControlIntentSelectors are the fields a list of NetControlIntents can be filtered and sorted on
+var netControlIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": NetControlIntent{},
+	},
+}
+
 // NetControlIntentClient implements the Manager
 // It will also be used to maintain some localized state
 type NetControlIntentClient struct {
@@ -109,6 +120,18 @@ func (v *NetControlIntentClient) GetNetControlIntent(ctx context.Context, name,
 
 // GetNetControlIntentList returns all of the NetControlIntent for corresponding name
 func (v *NetControlIntentClient) GetNetControlIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string) ([]NetControlIntent, error) {
+	res, _, err := v.ListNetControlIntents(ctx, project, compositeapp, compositeappversion, dig, db.FindOptions{})
+	return res, err
+}
+
+// ListNetControlIntents returns a page of the NetControlIntents of the deployment
+// intent group, filtered and sorted on the netControlIntentSelectors, and the
+// token of the next page
+func (v *NetControlIntentClient) ListNetControlIntents(ctx context.Context, project, compositeapp, compositeappversion, dig string, opts db.FindOptions) ([]NetControlIntent, string, error) {
+	opts, err := netControlIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []NetControlIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := NetControlIntentKey{
@@ -120,21 +143,21 @@ func (v *NetControlIntentClient) GetNetControlIntents(ctx context.Context, proje
 	}
 
 	var resp []NetControlIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []NetControlIntent{}, err
+		return []NetControlIntent{}, "", err
 	}
 
 	for _, value := range values {
 		nci := NetControlIntent{}
 		err = db.DBconn.Unmarshal(value, &nci)
 		if err != nil {
-			return []NetControlIntent{}, err
+			return []NetControlIntent{}, "", err
 		}
 		resp = append(resp, nci)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  NetControlIntent from database
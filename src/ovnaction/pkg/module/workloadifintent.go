@@ -40,9 +40,22 @@ type WorkloadIfIntentManager interface {
 	CreateWorkloadIfIntent(ctx context.Context, wi WorkloadIfIntent, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent string, exists bool) (WorkloadIfIntent, error)
 	GetWorkloadIfIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent string) (WorkloadIfIntent, error)
 	GetWorkloadIfIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent string) ([]WorkloadIfIntent, error)
+	ListWorkloadIfIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent string, opts db.FindOptions) ([]WorkloadIfIntent, string, error)
 	DeleteWorkloadIfIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent string) error
 }
 
+// workloadIfIntentSelectors are the fields a list of WorkloadIfIntents can be filtered and sorted on
+var workloadIfIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":      "data.metadata.name",
+		"interface": "data.spec.interface",
+		"network":   "data.spec.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": WorkloadIfIntent{},
+	},
+}
+
 // WorkloadIfIntentClient implements the Manager
 // It will also be used to maintain some localized state
 type WorkloadIfIntentClient struct {
@@ -126,6 +139,17 @@ func (v *WorkloadIfIntentClient) GetWorkloadIfIntent(ctx context.Context, name,
 
 // GetWorkloadIfIntentList returns all of the WorkloadIfIntent for corresponding name
 func (v *WorkloadIfIntentClient) GetWorkloadIfIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent string) ([]WorkloadIfIntent, error) {
+	res, _, err := v.ListWorkloadIfIntents(ctx, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent, db.FindOptions{})
+	return res, err
+}
+
+// ListWorkloadIfIntents returns a page of the WorkloadIfIntents of the WorkloadIntent,
+// filtered and sorted on the workloadIfIntentSelectors, and the token of the next page
+func (v *WorkloadIfIntentClient) ListWorkloadIfIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent, workloadintent string, opts db.FindOptions) ([]WorkloadIfIntent, string, error) {
+	opts, err := workloadIfIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []WorkloadIfIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := WorkloadIfIntentKey{
@@ -139,21 +163,21 @@ func (v *WorkloadIfIntentClient) GetWorkloadIfIntents(ctx context.Context, proje
 	}
 
 	var resp []WorkloadIfIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []WorkloadIfIntent{}, err
+		return []WorkloadIfIntent{}, "", err
 	}
 
 	for _, value := range values {
 		wif := WorkloadIfIntent{}
 		err = db.DBconn.Unmarshal(value, &wif)
 		if err != nil {
-			return []WorkloadIfIntent{}, err
+			return []WorkloadIfIntent{}, "", err
 		}
 		resp = append(resp, wif)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  WorkloadIfIntent from database
@@ -36,9 +36,23 @@ type WorkloadIntentManager interface {
 	CreateWorkloadIntent(ctx context.Context, wi WorkloadIntent, project, compositeapp, compositeappversion, dig, netcontrolintent string, exists bool) (WorkloadIntent, error)
 	GetWorkloadIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig, netcontrolintent string) (WorkloadIntent, error)
 	GetWorkloadIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent string) ([]WorkloadIntent, error)
+	ListWorkloadIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent string, opts db.FindOptions) ([]WorkloadIntent, string, error)
 	DeleteWorkloadIntent(ctx context.Context, name, project, compositeapp, compositeappversion, dig, netcontrolintent string) error
 }
 
+// workloadIntentSelectors are the fields a list of WorkloadIntents can be filtered and sorted on
+var workloadIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":             "data.metadata.name",
+		"app":              "data.spec.app",
+		"workloadResource": "data.spec.workloadResource",
+		"type":             "data.spec.type",
+	},
+	TagTypes: map[string]interface{}{
+		"data": WorkloadIntent{},
+	},
+}
+
 // WorkloadIntentClient implements the Manager
 // It will also be used to maintain some localized state
 type WorkloadIntentClient struct {
@@ -120,6 +134,17 @@ func (v *WorkloadIntentClient) GetWorkloadIntent(ctx context.Context, name, proj
 
 // GetWorkloadIntentList returns all of the WorkloadIntent for corresponding name
 func (v *WorkloadIntentClient) GetWorkloadIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent string) ([]WorkloadIntent, error) {
+	res, _, err := v.ListWorkloadIntents(ctx, project, compositeapp, compositeappversion, dig, netcontrolintent, db.FindOptions{})
+	return res, err
+}
+
+// ListWorkloadIntents returns a page of the WorkloadIntents of the NetControlIntent,
+// filtered and sorted on the workloadIntentSelectors, and the token of the next page
+func (v *WorkloadIntentClient) ListWorkloadIntents(ctx context.Context, project, compositeapp, compositeappversion, dig, netcontrolintent string, opts db.FindOptions) ([]WorkloadIntent, string, error) {
+	opts, err := workloadIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []WorkloadIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := WorkloadIntentKey{
@@ -132,21 +157,21 @@ func (v *WorkloadIntentClient) GetWorkloadIntents(ctx context.Context, project,
 	}
 
 	var resp []WorkloadIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []WorkloadIntent{}, err
+		return []WorkloadIntent{}, "", err
 	}
 
 	for _, value := range values {
 		wi := WorkloadIntent{}
 		err = db.DBconn.Unmarshal(value, &wi)
 		if err != nil {
-			return []WorkloadIntent{}, err
+			return []WorkloadIntent{}, "", err
 		}
 		resp = append(resp, wi)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  WorkloadIntent from database
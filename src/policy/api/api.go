@@ -8,6 +8,7 @@ import (
 	event "emcopolicy/internal/events"
 	"emcopolicy/internal/intent"
 	"github.com/gorilla/mux"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	"net/http"
 )
 
@@ -23,6 +24,7 @@ type HandleFunc func(string, func(http.ResponseWriter, *http.Request)) *mux.Rout
 
 func NewRouter(c *controller.Controller) *mux.Router {
 	r := mux.NewRouter().PathPrefix("/" + Version).Subrouter()
+	r.Use(apilist.Middleware)
 	r.HandleFunc("/health", c.Health).Methods(http.MethodGet)
 	registerPolicyIntentHandlers(r.HandleFunc, c.PolicyClient())
 	registerEventHandlers(r.HandleFunc, c.EventClient())
@@ -6,6 +6,7 @@ package event
 import (
 	"context"
 	"github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
@@ -40,28 +41,52 @@ func (c Client) RegisterAgent(ctx context.Context, id string, spec AgentSpec) (*
 }
 
 func (c Client) GetAllAgents(ctx context.Context) ([]AgentSpec, error) {
+	agents, _, err := c.ListAgents(ctx, db.FindOptions{})
+	return agents, err
+}
+
+// agentSelectors returns the fields a list of agents can be filtered and sorted on
+func (c Client) agentSelectors() db.FieldSelectors {
+	return db.FieldSelectors{
+		Paths: map[string]string{
+			"id":   c.tag + ".id",
+			"addr": c.tag + ".addr",
+		},
+		TagTypes: map[string]interface{}{
+			c.tag: AgentSpec{},
+		},
+	}
+}
+
+// ListAgents returns a page of the registered agents, filtered and sorted on
+// the agentSelectors, and the token of the next page
+func (c Client) ListAgents(ctx context.Context, opts db.FindOptions) ([]AgentSpec, string, error) {
 	var (
 		agents []AgentSpec
 	)
+	opts, err := c.agentSelectors().Resolve(opts)
+	if err != nil {
+		return nil, "", err
+	}
 	key := struct {
 		PolicyModule string `json:"policyModule"`
 		AgentID      bson.M `json:"agent"`
 	}{"Agent", bson.M{"$exists": true}}
-	value, err := c.db.Find(ctx, c.storeName, key, c.tag)
+	value, next, err := c.db.FindWithOptions(ctx, c.storeName, key, c.tag, opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "GetAgents failed")
+		return nil, "", errors.Wrap(err, "GetAgents failed")
 	}
 	if value == nil || len(value) == 0 {
-		return nil, nil
+		return nil, next, nil
 	}
 	for _, v := range value {
 		agent := new(AgentSpec)
 		if err := c.db.Unmarshal(v, agent); err != nil {
-			return agents, errors.Wrap(err, "GetAgents failed")
+			return agents, "", errors.Wrap(err, "GetAgents failed")
 		}
 		agents = append(agents, *agent)
 	}
-	return agents, nil
+	return agents, next, nil
 }
 
 func (c Client) GetAgent(ctx context.Context, id string) (*AgentSpec, error) {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/gorilla/mux"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"net/http"
 )
@@ -33,8 +34,13 @@ func (c Client) RegisterAgentHandler(ctx context.Context, w http.ResponseWriter,
 	log.Info("Registering Agent processed successfully", log.Fields{"Agent ID": spec.Id})
 }
 
-func (c Client) GetAllAgentHandler(ctx context.Context, w http.ResponseWriter, _ *http.Request) {
-	agents, err := c.GetAllAgents(ctx)
+func (c Client) GetAllAgentHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	opts, err := apilist.FindOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	agents, next, err := c.ListAgents(ctx, opts)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -42,6 +48,7 @@ func (c Client) GetAllAgentHandler(ctx context.Context, w http.ResponseWriter, _
 	if agents == nil {
 		agents = []AgentSpec{}
 	}
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(agents); err != nil {
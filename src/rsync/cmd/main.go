@@ -6,11 +6,15 @@ package main
 import (
 	"context"
 	"math/rand"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 
+	pkgerrors "github.com/pkg/errors"
 	register "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/grpc"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 	contextDb "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
@@ -23,7 +27,11 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/grpc/updateappserver"
 
 	con "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/context"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/lease"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/metrics"
 	"google.golang.org/grpc"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func RegisterRsyncServices(grpcServer *grpc.Server, srv interface{}) {
@@ -32,9 +40,36 @@ func RegisterRsyncServices(grpcServer *grpc.Server, srv interface{}) {
 	updatepb.RegisterUpdateappServer(grpcServer, updateappserver.NewUpdateAppServer())
 }
 
+// startSharding shares the AppContexts with the other replicas of rsync. The
+// replica is named after its host and reached on the address of its pod.
+func startSharding(ctx context.Context, port int) error {
+	duration := config.GetConfiguration().RsyncLeaseDuration
+	if duration <= 0 {
+		return pkgerrors.Errorf("Invalid rsync-lease-duration %d", duration)
+	}
+	id, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	host := os.Getenv("POD_IP")
+	if host == "" {
+		host = id
+	}
+	return lease.Start(ctx, id, net.JoinHostPort(host, strconv.Itoa(port)), time.Duration(duration)*time.Second,
+		con.TakeOverAppContexts, func() {
+			log.Error("Lease of the replica expired, its AppContexts may be owned by another replica", log.Fields{"replica": id})
+			os.Exit(1)
+		})
+}
+
 func main() {
 	rand.Seed(time.Now().UnixNano())
 
+	prometheus.MustRegister(metrics.ClusterOpsQueuedGauge)
+	prometheus.MustRegister(metrics.ClusterOpsInFlightGauge)
+	prometheus.MustRegister(metrics.GitOpsCommitRequestsCounter)
+	prometheus.MustRegister(metrics.GitOpsCommitsCounter)
+
 	ctx := context.Background()
 
 	err := db.InitializeDatabaseConnection(ctx, "emco")
@@ -63,11 +98,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	if config.GetConfiguration().RsyncSharding {
+		err = startSharding(ctx, grpcServer.Port)
+		if err != nil {
+			log.Error("Unable to share the AppContexts with the rsync replicas", log.Fields{"Error": err})
+			os.Exit(1)
+		}
+	}
+
 	err = con.RestoreActiveContext(ctx)
 	if err != nil {
 		log.Error("RestoreActiveContext failed", log.Fields{"Error": err})
 	}
 
+	err = con.RestoreDriftDetection(ctx)
+	if err != nil {
+		log.Error("RestoreDriftDetection failed", log.Fields{"Error": err})
+	}
+
 	connectionsClose := make(chan struct{})
 	go func() {
 		c := make(chan os.Signal, 1)
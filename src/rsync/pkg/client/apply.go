@@ -4,13 +4,18 @@
 package client
 
 import (
+	"bytes"
+	"encoding/json"
+
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/kubectl/pkg/util"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
 )
 
 // Apply creates a resource with the given content
@@ -33,7 +38,7 @@ func (c *Client) ApplyResource(r *resource.Result) error {
 
 	// Is ServerSideApply requested
 	if c.ServerSideApply {
-		return r.Visit(serverSideApply)
+		return r.Visit(c.serverSideApply)
 	}
 
 	return r.Visit(apply)
@@ -60,7 +65,7 @@ func apply(info *resource.Info, err error) error {
 	return patch(info, current)
 }
 
-func serverSideApply(info *resource.Info, err error) error {
+func (c *Client) serverSideApply(info *resource.Info, err error) error {
 	if err != nil {
 		return failedTo("serverside apply", info, err)
 	}
@@ -70,15 +75,148 @@ func serverSideApply(info *resource.Info, err error) error {
 		return failedTo("encode for the serverside apply", info, err)
 	}
 
-	options := metav1.PatchOptions{
-		// TODO: Find out how to get the force conflict flag
-		// Force:        &forceConflicts,
-		FieldManager: "kubectl",
+	helper := resource.NewHelper(info.Client, info.Mapping)
+	obj, err := c.applyPatch(helper, info, data)
+	if err != nil && errors.IsConflict(err) && c.FieldManager != legacyFieldManager {
+		// The fields applied by the earlier releases may conflict with the
+		// new values: move them to the field manager and apply again
+		if upgraded, uerr := c.upgradeManagedFields(helper, info, nil); uerr != nil {
+			return failedTo("move the managed fields", info, uerr)
+		} else if upgraded {
+			obj, err = c.applyPatch(helper, info, data)
+		}
 	}
-	obj, err := resource.NewHelper(info.Client, info.Mapping).Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &options)
 	if err != nil {
 		return failedTo("serverside patch", info, err)
 	}
+
+	if c.FieldManager != legacyFieldManager {
+		upgraded, err := c.upgradeManagedFields(helper, info, obj)
+		if err != nil {
+			return failedTo("move the managed fields", info, err)
+		}
+		if upgraded {
+			// Apply again to prune the fields applied by the earlier releases
+			// and no longer in the resource
+			if obj, err = c.applyPatch(helper, info, data); err != nil {
+				return failedTo("serverside patch", info, err)
+			}
+		}
+	}
 	info.Refresh(obj, true)
 	return nil
 }
+
+func (c *Client) applyPatch(helper *resource.Helper, info *resource.Info, data []byte) (runtime.Object, error) {
+	options := metav1.PatchOptions{
+		Force:        &c.ForceConflicts,
+		FieldManager: c.FieldManager,
+	}
+	return helper.Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &options)
+}
+
+// upgradeManagedFields moves the fields applied by the legacy field manager to
+// the field manager, with a patch of the managed fields of the object, or of
+// the current one if nil. It tells if the managed fields were changed.
+func (c *Client) upgradeManagedFields(helper *resource.Helper, info *resource.Info, obj runtime.Object) (bool, error) {
+	if obj == nil {
+		current, err := helper.Get(info.Namespace, info.Name)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		obj = current
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false, err
+	}
+
+	fields, upgraded, err := upgradeFields(accessor.GetManagedFields(), legacyFieldManager, c.FieldManager)
+	if err != nil || !upgraded {
+		return false, err
+	}
+
+	// The test of the resource version fails the patch if the object was
+	// changed since it was read
+	data, err := json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": accessor.GetResourceVersion()},
+		{"op": "replace", "path": "/metadata/managedFields", "value": fields},
+	})
+	if err != nil {
+		return false, err
+	}
+	if _, err := helper.Patch(info.Namespace, info.Name, types.JSONPatchType, data, &metav1.PatchOptions{}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// upgradeFields moves the fields applied by the manager from to the manager to,
+// merging them with the fields it already applies. It tells if the managed
+// fields were changed.
+func upgradeFields(fields []metav1.ManagedFieldsEntry, from, to string) ([]metav1.ManagedFieldsEntry, bool, error) {
+	source, target := -1, -1
+	for i, f := range fields {
+		if f.Operation != metav1.ManagedFieldsOperationApply || f.Subresource != "" {
+			continue
+		}
+		switch f.Manager {
+		case from:
+			source = i
+		case to:
+			target = i
+		}
+	}
+	if source < 0 {
+		return fields, false, nil
+	}
+
+	upgraded := make([]metav1.ManagedFieldsEntry, 0, len(fields))
+	if target < 0 {
+		for i, f := range fields {
+			if i == source {
+				f.Manager = to
+			}
+			upgraded = append(upgraded, f)
+		}
+		return upgraded, true, nil
+	}
+
+	merged, err := unionFields(fields[source].FieldsV1, fields[target].FieldsV1)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, f := range fields {
+		switch i {
+		case source:
+			continue
+		case target:
+			f.FieldsV1 = merged
+		}
+		upgraded = append(upgraded, f)
+	}
+	return upgraded, true, nil
+}
+
+// unionFields returns the fields in either of the given sets
+func unionFields(a, b *metav1.FieldsV1) (*metav1.FieldsV1, error) {
+	union := &fieldpath.Set{}
+	for _, f := range []*metav1.FieldsV1{a, b} {
+		if f == nil || len(f.Raw) == 0 {
+			continue
+		}
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(f.Raw)); err != nil {
+			return nil, err
+		}
+		union = union.Union(set)
+	}
+	raw, err := union.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.FieldsV1{Raw: raw}, nil
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package client
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func entry(manager string, operation metav1.ManagedFieldsOperationType, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  operation,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestUpgradeFields(t *testing.T) {
+	const (
+		replicas = `{"f:spec":{"f:replicas":{}}}`
+		image    = `{"f:spec":{"f:image":{}}}`
+	)
+	status := entry("controller", metav1.ManagedFieldsOperationUpdate, `{"f:status":{}}`)
+
+	t.Run("No legacy manager", func(t *testing.T) {
+		fields := []metav1.ManagedFieldsEntry{entry("emco", metav1.ManagedFieldsOperationApply, replicas), status}
+		if _, upgraded, err := upgradeFields(fields, "kubectl", "emco"); err != nil || upgraded {
+			t.Fatalf("Unexpected upgrade %v, %v", upgraded, err)
+		}
+	})
+
+	t.Run("Legacy update", func(t *testing.T) {
+		fields := []metav1.ManagedFieldsEntry{entry("kubectl", metav1.ManagedFieldsOperationUpdate, replicas)}
+		if _, upgraded, err := upgradeFields(fields, "kubectl", "emco"); err != nil || upgraded {
+			t.Fatalf("Unexpected upgrade %v, %v", upgraded, err)
+		}
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		fields := []metav1.ManagedFieldsEntry{entry("kubectl", metav1.ManagedFieldsOperationApply, replicas), status}
+		upgraded, ok, err := upgradeFields(fields, "kubectl", "emco")
+		if err != nil || !ok {
+			t.Fatalf("Unexpected upgrade %v, %v", ok, err)
+		}
+		if len(upgraded) != 2 || upgraded[0].Manager != "emco" || string(upgraded[0].FieldsV1.Raw) != replicas || upgraded[1].Manager != "controller" {
+			t.Fatalf("Unexpected managed fields %+v", upgraded)
+		}
+		if fields[0].Manager != "kubectl" {
+			t.Fatalf("The managed fields were changed in place")
+		}
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		fields := []metav1.ManagedFieldsEntry{
+			entry("kubectl", metav1.ManagedFieldsOperationApply, replicas),
+			status,
+			entry("emco", metav1.ManagedFieldsOperationApply, image),
+		}
+		upgraded, ok, err := upgradeFields(fields, "kubectl", "emco")
+		if err != nil || !ok {
+			t.Fatalf("Unexpected upgrade %v, %v", ok, err)
+		}
+		if len(upgraded) != 2 || upgraded[0].Manager != "controller" || upgraded[1].Manager != "emco" {
+			t.Fatalf("Unexpected managed fields %+v", upgraded)
+		}
+		expected := `{"f:spec":{"f:image":{},"f:replicas":{}}}`
+		if got := string(upgraded[1].FieldsV1.Raw); got != expected {
+			t.Fatalf("Expected the fields %s, got %s", expected, got)
+		}
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/kubectl/pkg/validation"
 
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 	resapi "k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -23,6 +24,14 @@ import (
 // default will be validated.
 const DefaultValidation = false
 
+// FieldManager is the field manager of the fields applied by EMCO with the
+// server-side apply
+const FieldManager = "emco"
+
+// legacyFieldManager is the field manager of the fields applied by the earlier
+// releases, moved to FieldManager on the first apply
+const legacyFieldManager = "kubectl"
+
 // Client is a kubernetes client, like `kubectl`
 type Client struct {
 	Clientset        kubernetes.Interface
@@ -32,8 +41,12 @@ type Client struct {
 	validator        validation.Schema
 	namespace        string
 	enforceNamespace bool
-	forceConflicts   bool
 	ServerSideApply  bool
+	// FieldManager manages the fields applied with the server-side apply
+	FieldManager string
+	// ForceConflicts takes the ownership of the fields managed by other
+	// field managers, instead of failing with a conflict
+	ForceConflicts bool
 }
 
 // Result is an alias for the Kubernetes CLI runtime resource.Result
@@ -61,10 +74,22 @@ func NewBuilderOptions() *BuilderOptions {
 
 // NewE creates a kubernetes client, returns an error if fail
 func NewE(context, kubeconfig string, ns string) (*Client, error) {
+	return newE(newFactory(context, kubeconfig), ns)
+}
+
+// NewForCluster creates a kubernetes client of the cluster. The Kubernetes
+// API calls of all the clients of the cluster share its rate limiter.
+func NewForCluster(cluster, kubeconfig string, ns string) *Client {
+	factory := newFactory("", kubeconfig)
+	factory.rateLimiter = ClusterRateLimiter(cluster)
+	client, _ := newE(factory, ns)
+	return client
+}
+
+func newE(factory *factory, ns string) (*Client, error) {
 	var namespace string
 	var enforceNamespace bool
 	var err error
-	factory := newFactory(context, kubeconfig)
 
 	// If `true` it will always validate the given objects/resources
 	// Unless something different is specified in the NewBuilderOptions
@@ -113,7 +138,8 @@ func NewE(context, kubeconfig string, ns string) (*Client, error) {
 		validator:        validator,
 		namespace:        namespace,
 		enforceNamespace: enforceNamespace,
-		ServerSideApply:  true,
+		ServerSideApply:  config.GetConfiguration().ServerSideApply,
+		FieldManager:     FieldManager,
 	}, nil
 }
 
@@ -194,7 +220,7 @@ func failedTo(action string, info *resource.Info, err error) error {
 		resKind = info.Mapping.GroupVersionKind.Kind + " "
 	}
 
-	return fmt.Errorf("cannot %s object Kind: %q,	Name: %q, Namespace: %q. %s", action, resKind, info.Name, info.Namespace, err)
+	return fmt.Errorf("cannot %s object Kind: %q,	Name: %q, Namespace: %q. %w", action, resKind, info.Name, info.Namespace, err)
 }
 
 // IsReachable tests connectivity to the cluster
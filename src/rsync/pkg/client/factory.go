@@ -24,6 +24,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/kubectl/pkg/util/openapi"
 	openapivalidation "k8s.io/kubectl/pkg/util/openapi/validation"
 	"k8s.io/kubectl/pkg/validation"
@@ -40,6 +41,7 @@ type factory struct {
 	openAPIGetter         *openapi.CachedOpenAPIGetter
 	openAPIParser         *openapi.CachedOpenAPIParser
 	parser                sync.Once
+	rateLimiter           flowcontrol.RateLimiter
 }
 
 // If multiple clients are created, this sync.once make sure the CRDs are added
@@ -97,6 +99,10 @@ func (f *factory) ToRESTConfig() (*rest.Config, error) {
 		config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
 	}
 
+	if f.rateLimiter != nil {
+		config.RateLimiter = f.rateLimiter
+	}
+
 	rest.SetKubernetesDefaults(config)
 	return config, nil
 }
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+)
+
+// List lists the resources of all the kinds that can be listed and deleted,
+// in all the namespaces, that match the label selector. The kinds that fail
+// to be listed are skipped.
+func (c *Client) List(ctx context.Context, labelSelector string) ([]unstructured.Unstructured, error) {
+	lists, err := c.Clientset.Discovery().ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("Failed to discover the resources of the cluster %v", err)
+	}
+	// The groups that failed the discovery, e.g. unavailable aggregated
+	// APIs, are skipped
+	lists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "delete"}}, lists)
+
+	var objs []unstructured.Unstructured
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			gvr := gv.WithResource(r.Name)
+			var ul *unstructured.UnstructuredList
+			if r.Namespaced {
+				ul, err = c.DynamicClient.Resource(gvr).Namespace(metav1.NamespaceAll).List(ctx, opts)
+			} else {
+				ul, err = c.DynamicClient.Resource(gvr).List(ctx, opts)
+			}
+			if err != nil {
+				// The kinds that can't be listed, e.g. forbidden to the
+				// credentials of the cluster, are skipped
+				log.Warn("Failed to list the resources of a kind", log.Fields{"resource": gvr.String(), "error": err.Error()})
+				continue
+			}
+			for _, u := range ul.Items {
+				// The items of a list don't always have their kind
+				u.SetGroupVersionKind(gv.WithKind(r.Kind))
+				objs = append(objs, u)
+			}
+		}
+	}
+	return objs, nil
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package client
+
+import (
+	"sync"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+var rateLimiters = struct {
+	sync.Mutex
+	m map[string]flowcontrol.RateLimiter
+}{m: map[string]flowcontrol.RateLimiter{}}
+
+// ClusterRateLimiter returns the token bucket rate limiter of the Kubernetes
+// API calls to the cluster, created from the cluster-api-qps and
+// cluster-api-burst configuration on first use. It returns nil, leaving the
+// client-go default rate limit of each client, if cluster-api-qps isn't set.
+func ClusterRateLimiter(cluster string) flowcontrol.RateLimiter {
+	cfg := config.GetConfiguration()
+	if cfg.ClusterAPIQPS <= 0 {
+		return nil
+	}
+	rateLimiters.Lock()
+	defer rateLimiters.Unlock()
+	rl, ok := rateLimiters.m[cluster]
+	if !ok {
+		burst := cfg.ClusterAPIBurst
+		if burst < cfg.ClusterAPIQPS {
+			burst = cfg.ClusterAPIQPS
+		}
+		rl = flowcontrol.NewTokenBucketRateLimiter(float32(cfg.ClusterAPIQPS), burst)
+		rateLimiters.m[cluster] = rl
+	}
+	return rl
+}
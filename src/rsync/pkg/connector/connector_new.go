@@ -13,6 +13,7 @@ import (
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/plugins/anthos"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/plugins/argocd"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/plugins/azurearcv2"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/plugins/fluxv2"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/plugins/k8s"
@@ -110,6 +111,12 @@ func (p *Provider) GetClientProviders(ctx context.Context, app, cluster, level,
 			return nil, err
 		}
 		return cl, nil
+	case "argocd":
+		cl, err := argocd.NewArgoCDProvider(ctx, p.cid, app, cluster, level, namespace)
+		if err != nil {
+			return nil, err
+		}
+		return cl, nil
 	}
 	return nil, pkgerrors.New("Provider type not supported")
 }
@@ -21,6 +21,8 @@ type resProvd struct {
 	cluster string
 	cl      ClientProvider
 	context Context
+	// slot of the operation, given back while the cluster is unreachable
+	slot *clusterSlot
 }
 
 // Hook Kinds that require wait
@@ -48,9 +50,11 @@ func (r *resProvd) handleResources(ctx context.Context, op RsyncOperation, resou
 		handledRes = 0
 		// Handle all resources in order
 		for _, res := range resources {
-			ref, breakonError, err = r.handleResource(ctx, op, res, ref)
+			ref, breakonError, err = r.handleResourceWithRetry(ctx, op, res, ref)
 			if err != nil {
-				log.Error("Error in resource", log.Fields{"error": err, "cluster": r.cluster, "resource": res})
+				if ctx.Err() != nil {
+					return handledRes, ctx.Err()
+				}
 				// If failure is due to reachability issues start retrying
 				if err1 := r.cl.IsReachable(); err1 != nil {
 					reachable = false
@@ -71,6 +75,43 @@ func (r *resProvd) handleResources(ctx context.Context, op RsyncOperation, resou
 	}
 }
 
+// handleResourceWithRetry performs the operation on the resource, retrying
+// it as long as the retry policy allows. The attempts and the last error are
+// recorded in the status of the resource.
+func (r *resProvd) handleResourceWithRetry(ctx context.Context, op RsyncOperation, res string, ref interface{}) (interface{}, bool, error) {
+	for attempt := 1; ; attempt++ {
+		q, breakonError, err := r.handleResource(ctx, op, res, ref)
+		if err == nil {
+			if attempt > 1 {
+				s := resourcestatus.RsyncStatusEnum.Applied
+				if op == OpDelete {
+					s = resourcestatus.RsyncStatusEnum.Deleted
+				}
+				r.updateResourceStatus(ctx, res, resourcestatus.ResourceStatus{Status: s, Attempts: attempt})
+			}
+			return q, breakonError, nil
+		}
+		log.Error("Error in resource", log.Fields{"error": err, "cluster": r.cluster, "resource": res, "attempt": attempt})
+		// Reachability is retried for the whole cluster
+		if r.cl.IsReachable() != nil {
+			return q, breakonError, err
+		}
+		if attempt >= r.context.retry.maxAttempts || !r.context.retry.retryable(err) {
+			s := resourcestatus.RsyncStatusEnum.Failed
+			if errorClass(err) == ErrorClassFieldConflict {
+				// Another field manager owns some fields of the resource
+				s = resourcestatus.RsyncStatusEnum.Conflict
+			}
+			r.updateResourceStatus(ctx, res, resourcestatus.ResourceStatus{Status: s, Attempts: attempt, LastError: err.Error()})
+			return q, breakonError, err
+		}
+		r.updateResourceStatus(ctx, res, resourcestatus.ResourceStatus{Status: resourcestatus.RsyncStatusEnum.Retrying, Attempts: attempt, LastError: err.Error()})
+		if err := r.context.retry.wait(ctx, attempt-1); err != nil {
+			return q, breakonError, err
+		}
+	}
+}
+
 func (r *resProvd) handleResourcesWithWait(ctx context.Context, op RsyncOperation, resources []string) (int, error) {
 	var handledRes int
 	for _, res := range resources {
@@ -110,6 +151,8 @@ func (r *resProvd) waitForClusterReady(ctx context.Context) error {
 		return nil
 	}
 	r.context.acRef.SetClusterAvailableStatus(ctx, r.app, r.cluster, appcontext.ClusterReadyStatusEnum.Retrying)
+	// let the operations on the other clusters run meanwhile
+	r.slot.give()
 	timedOut := false
 	retryCnt := 0
 	forceDone := false
@@ -125,7 +168,7 @@ Loop:
 			// If cluster is reachable then done
 			if err := r.cl.IsReachable(); err == nil {
 				r.context.acRef.SetClusterAvailableStatus(ctx, r.app, r.cluster, appcontext.ClusterReadyStatusEnum.Available)
-				return nil
+				return r.slot.acquire(ctx)
 			}
 			log.Info("Cluster is not reachable - keep trying::", log.Fields{"cluster": r.cluster, "retry count": retryCnt})
 			retryCnt++
@@ -40,6 +40,8 @@ type Context struct {
 	maxRetry int
 	// wait time (seconds) between trying again for cluster reachability
 	waitTime int
+	// Policy of the retries of the operations on the resources
+	retry retryPolicy
 	// Structure to hold CompositeApp Information
 	ca CompositeApp
 	// Data of the application
@@ -49,6 +51,10 @@ type Context struct {
 	// Keep track for scheduled monitor CR delete functions
 	// Key for the map is app+cluster
 	timerList map[string]*time.Timer
+	// Function to stop the drift detection
+	driftCancel context.CancelFunc
+	// Closed when the drift detection is stopped
+	driftDone chan struct{}
 }
 
 // AppContextData struct
@@ -110,13 +116,14 @@ func (c *Context) EnqueueToAppContext(ctx context.Context, a interface{}, ucid i
 	}
 	// Acquire Mutex before adding to queue
 	c.Lock.Lock()
-	// Push the appContext to ActiveContext space of etcD
-	ok, err := RecordActiveContext(ctx, acID)
-	if !ok {
-		logutils.Info("Already in active context", logutils.Fields{"AppContextID": acID, "err": err})
+	// Enqueue event and push the appContext to ActiveContext space of etcD
+	// in the same transaction
+	_, err = qUtils.Enqueue(ctx, elem, activeContextPut(acID))
+	if err != nil {
+		logutils.Error("Error adding event to the AppContextQueue", logutils.Fields{"AppContextID": acID, "err": err})
+		c.Lock.Unlock()
+		return err
 	}
-	// Enqueue event
-	qUtils.Enqueue(ctx, elem)
 	c.Lock.Unlock()
 	return nil
 }
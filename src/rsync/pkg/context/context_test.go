@@ -103,6 +103,30 @@ func TestInstantiateTerminate(t *testing.T) {
 	}
 }
 
+// revisionMismatchDb fails every transaction as if the keys were modified
+// concurrently by another rsync
+type revisionMismatchDb struct {
+	*contextdb.MockConDb
+}
+
+func (c *revisionMismatchDb) PutTxn(ctx context.Context, puts []contextdb.TxnPut) error {
+	return contextdb.ErrRevisionMismatch
+}
+
+func TestEnqueueRevisionMismatch(t *testing.T) {
+	cid, _ := contextUtils.CreateCompApp(context.Background(), TestCA)
+	con := NewProvider(cid)
+
+	edb := contextdb.Db
+	contextdb.Db = &revisionMismatchDb{edb.(*contextdb.MockConDb)}
+	defer func() { contextdb.Db = edb }()
+
+	err := HandleAppContext(context.Background(), cid, nil, InstantiateEvent, &con)
+	if err == nil {
+		t.Fatalf("HandleAppContext returned no error for an event that wasn't queued")
+	}
+}
+
 func TestUpdate(t *testing.T) {
 
 	testCases := []struct {
@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+/*
+drift.go periodically compares the resources of an instantiated AppContext
+with the resources on the clusters, records the drift of each resource in
+the status AppContext and, if the DeploymentIntentGroup opts in, re-applies
+the resources that drifted
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/resourcestatus"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/client"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/connector"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const driftPrefix string = "/driftcontext/"
+
+// startDriftDetection starts the drift detection of the AppContext, if its
+// DeploymentIntentGroup enables it and it isn't already running
+func (c *Context) startDriftDetection(ctx context.Context) {
+	dd := c.meta.DriftDetection
+	if dd == nil || dd.IntervalSeconds <= 0 {
+		return
+	}
+	c.Lock.Lock()
+	defer c.Lock.Unlock()
+	if c.driftCancel != nil {
+		return
+	}
+	// Record the AppContext to restart its drift detection with rsync
+	k := driftPrefix + c.acID + "/"
+	if err := contextdb.Db.Put(ctx, k, c.acID); err != nil {
+		log.Error("Error recording the drift detection of the context", log.Fields{"context": c.acID, "error": err})
+	}
+	dctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	c.driftCancel = cancel
+	c.driftDone = done
+	// The routine works on a copy, the AppContext is read again by the next event
+	dc := *c
+	go func() {
+		defer close(done)
+		dc.driftRoutine(dctx, time.Duration(dd.IntervalSeconds)*time.Second)
+	}()
+	log.Info("Started drift detection", log.Fields{"context": c.acID, "interval": dd.IntervalSeconds, "selfHeal": dd.SelfHeal})
+}
+
+// stopDriftDetection stops the drift detection of the AppContext and waits
+// for a running comparison to finish
+func (c *Context) stopDriftDetection(ctx context.Context) {
+	c.Lock.Lock()
+	cancel, done := c.driftCancel, c.driftDone
+	c.driftCancel, c.driftDone = nil, nil
+	c.Lock.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+	k := driftPrefix + c.acID + "/"
+	if err := contextdb.Db.Delete(ctx, k); err != nil {
+		log.Error("Error deleting the drift detection record of the context", log.Fields{"context": c.acID, "error": err})
+	}
+	log.Info("Stopped drift detection", log.Fields{"context": c.acID})
+}
+
+func (c *Context) driftRoutine(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.detectDrift(ctx)
+		}
+	}
+}
+
+// detectDrift compares the resources of all the apps on all their clusters
+func (c *Context) detectDrift(ctx context.Context) {
+	for _, app := range c.ca.AppOrder {
+		for cluster := range c.ca.Apps[app].Clusters {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := c.detectClusterDrift(ctx, app, cluster); err != nil {
+				log.Error("Error detecting drift", log.Fields{"context": c.acID, "app": app, "cluster": cluster, "error": err})
+			}
+		}
+	}
+}
+
+func (c *Context) detectClusterDrift(ctx context.Context, app, cluster string) error {
+	resOrder := c.ca.Apps[app].Clusters[cluster].ResOrder
+	if len(resOrder) == 0 {
+		return nil
+	}
+	release, err := getClusterOpLimiter().acquire(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	defer release()
+	namespace, level := c.acRef.GetNamespace(ctx)
+	cl, err := c.con.GetClientProviders(ctx, app, cluster, level, namespace)
+	if err != nil {
+		return err
+	}
+	defer cl.CleanClientProvider()
+
+	reachable := cl.IsReachable() == nil
+	selfHeal := c.meta.DriftDetection.SelfHeal
+	r := resProvd{app: app, cluster: cluster, cl: cl, context: *c}
+	var ref interface{}
+	healed := false
+	for _, res := range resOrder {
+		var ds resourcestatus.DriftStatus
+		if reachable {
+			ds = r.resourceDrift(ctx, res)
+		} else {
+			ds = resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.Unknown, Message: "Cluster is not reachable"}
+		}
+		if selfHeal && (ds.State == resourcestatus.DriftStateEnum.Drifted || ds.State == resourcestatus.DriftStateEnum.Missing) {
+			q, err := r.instantiateResource(ctx, res, ref)
+			if err != nil {
+				ds.Message = "Error re-applying the resource: " + err.Error()
+			} else {
+				ref = q
+				ds.Healed = true
+				healed = true
+				log.Info("Re-applied drifted resource", log.Fields{"context": c.acID, "app": app, "cluster": cluster, "resource": res, "fields": ds.Fields})
+			}
+		}
+		ds.CheckedAt = time.Now()
+		if err := c.scRef.SetResourceDriftStatus(ctx, app, cluster, res, ds); err != nil {
+			log.Error("Error recording the drift of the resource", log.Fields{"context": c.acID, "app": app, "cluster": cluster, "resource": res, "error": err})
+		}
+	}
+	if healed {
+		return cl.Commit(ctx, ref)
+	}
+	return nil
+}
+
+// resourceDrift compares the resource of the AppContext with the resource on
+// the cluster. The resource is read from the cluster, or from the
+// ResourceBundleState of the cluster monitor if the provider can't read it,
+// e.g. for GitOps clusters.
+func (r *resProvd) resourceDrift(ctx context.Context, name string) resourcestatus.DriftStatus {
+	unknown := func(err error) resourcestatus.DriftStatus {
+		return resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.Unknown, Message: err.Error()}
+	}
+
+	res, _, err := r.context.acRef.GetRes(ctx, name, r.app, r.cluster)
+	if err != nil {
+		return unknown(err)
+	}
+	unstruct := &unstructured.Unstructured{}
+	if _, err := utils.DecodeYAMLData(string(res), unstruct); err != nil {
+		return unknown(pkgerrors.Wrap(err, "Invalid resource"))
+	}
+	gvkRes, err := json.Marshal(client.ReadResource{Gvk: unstruct.GroupVersionKind(), Name: unstruct.GetName(), Namespace: unstruct.GetNamespace()})
+	if err != nil {
+		return unknown(err)
+	}
+
+	live, err := r.cl.Get(ctx, name, gvkRes)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.Missing}
+		}
+		return unknown(err)
+	}
+	if len(live) == 0 {
+		var found bool
+		live, found = status.GetBundleResource(ctx, r.context.statusAcID, r.app, r.cluster, unstruct.GroupVersionKind(), unstruct.GetName())
+		if !found {
+			return unknown(fmt.Errorf("Resource state not available"))
+		}
+		// a GitOps agent, e.g. Argo CD, may report whether the resource is in
+		// sync with the repository instead of the resource
+		if sync, health, ok := status.GitOpsSyncStatus(live); ok {
+			switch {
+			case health == "Missing":
+				return resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.Missing}
+			case sync == "OutOfSync":
+				return resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.Drifted, Message: "Resource is OutOfSync on the cluster"}
+			case sync == "Synced":
+				return resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.InSync}
+			default:
+				return unknown(fmt.Errorf("Resource sync status is %q", sync))
+			}
+		}
+	}
+
+	fields, err := status.DriftedFields(res, live)
+	if err != nil {
+		return unknown(err)
+	}
+	if len(fields) > 0 {
+		return resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.Drifted, Fields: fields}
+	}
+	return resourcestatus.DriftStatus{State: resourcestatus.DriftStateEnum.InSync}
+}
+
+// RestoreDriftDetection shall be called everytime the rsync restarts.
+// It restarts the drift detection of the AppContexts that had it running.
+func RestoreDriftDetection(ctx context.Context) error {
+	return restoreDriftDetection(ctx, func(acID string) bool {
+		return ownsAppContext(ctx, acID)
+	})
+}
+
+// restoreDriftDetection restarts the drift detection of the recorded
+// AppContexts selected by the filter
+func restoreDriftDetection(ctx context.Context, filter func(acID string) bool) error {
+	keys, err := contextdb.Db.GetAllKeys(ctx, driftPrefix)
+	if err != nil {
+		log.Info("No drift detection to restore", log.Fields{})
+		return nil
+	}
+	for _, key := range keys {
+		k := strings.Split(fmt.Sprintf("%v", key), "/")
+		if len(k) != 4 || k[1] != "driftcontext" {
+			continue
+		}
+		acID := k[2]
+		if !filter(acID) {
+			continue
+		}
+		_, c := CreateAppContextData(acID)
+		c.Lock.Lock()
+		// A running AppContext starts the drift detection once its events are handled
+		if c.Running {
+			c.Lock.Unlock()
+			continue
+		}
+		con := connector.NewProvider(acID)
+		err := c.loadAppContext(ctx, acID, &con)
+		c.Lock.Unlock()
+		if err != nil {
+			log.Error("Error restoring the drift detection of the context", log.Fields{"context": acID, "error": err})
+			continue
+		}
+		c.startDriftDetection(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+/*
+limits.go bounds the number of cluster operations rsync runs at once, in
+total and per cluster provider, so that an AppContext with many clusters
+doesn't open a Kubernetes client to all of them at the same time
+*/
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/metrics"
+)
+
+// opLimiter hands out the slots of the cluster operations. A nil semaphore
+// doesn't limit the operations.
+type opLimiter struct {
+	sync.Mutex
+	global      chan struct{}
+	perProvider int
+	providers   map[string]chan struct{}
+}
+
+var clusterOps *opLimiter
+var clusterOpsOnce sync.Once
+
+// getClusterOpLimiter returns the limiter of the cluster operations, sized
+// from the configuration on first use
+func getClusterOpLimiter() *opLimiter {
+	clusterOpsOnce.Do(func() {
+		cfg := config.GetConfiguration()
+		clusterOps = newOpLimiter(cfg.MaxClusterOps, cfg.MaxProviderOps)
+	})
+	return clusterOps
+}
+
+func newOpLimiter(global, perProvider int) *opLimiter {
+	l := &opLimiter{perProvider: perProvider, providers: map[string]chan struct{}{}}
+	if global > 0 {
+		l.global = make(chan struct{}, global)
+	}
+	return l
+}
+
+func (l *opLimiter) providerSemaphore(provider string) chan struct{} {
+	if l.perProvider <= 0 {
+		return nil
+	}
+	l.Lock()
+	defer l.Unlock()
+	s, ok := l.providers[provider]
+	if !ok {
+		s = make(chan struct{}, l.perProvider)
+		l.providers[provider] = s
+	}
+	return s
+}
+
+// acquire waits for a slot for an operation on the cluster, named
+// "provider+cluster", and returns the function releasing it. The wait ends
+// with the error of the context if the context is done first.
+func (l *opLimiter) acquire(ctx context.Context, cluster string) (func(), error) {
+	provider := strings.Split(cluster, "+")[0]
+	queued := metrics.ClusterOpsQueuedGauge.WithLabelValues(provider)
+	inFlight := metrics.ClusterOpsInFlightGauge.WithLabelValues(provider)
+
+	queued.Inc()
+	defer queued.Dec()
+	ps := l.providerSemaphore(provider)
+	if err := take(ctx, ps); err != nil {
+		return nil, err
+	}
+	if err := take(ctx, l.global); err != nil {
+		give(ps)
+		return nil, err
+	}
+	inFlight.Inc()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			inFlight.Dec()
+			give(l.global)
+			give(ps)
+		})
+	}, nil
+}
+
+// clusterSlot is the slot of an operation on a cluster, which the operation
+// gives back while it waits for the cluster to be reachable, so that the
+// unreachable clusters don't hold the slots of the other operations
+type clusterSlot struct {
+	l       *opLimiter
+	cluster string
+	release func()
+}
+
+// acquireSlot waits for a slot for an operation on the cluster, like acquire
+func (l *opLimiter) acquireSlot(ctx context.Context, cluster string) (*clusterSlot, error) {
+	s := &clusterSlot{l: l, cluster: cluster}
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// acquire waits for the slot if it was released
+func (s *clusterSlot) acquire(ctx context.Context) error {
+	if s == nil || s.release != nil {
+		return nil
+	}
+	release, err := s.l.acquire(ctx, s.cluster)
+	if err != nil {
+		return err
+	}
+	s.release = release
+	return nil
+}
+
+// give releases the slot if it is held
+func (s *clusterSlot) give() {
+	if s == nil || s.release == nil {
+		return
+	}
+	s.release()
+	s.release = nil
+}
+
+func take(ctx context.Context, s chan struct{}) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func give(s chan struct{}) {
+	if s != nil {
+		<-s
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	. "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
+	contextUtils "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/utils"
+)
+
+func TestOpLimiter(t *testing.T) {
+	testCases := []struct {
+		label       string
+		global      int
+		perProvider int
+		clusters    []string
+		maxInFlight int
+	}{
+		{
+			label:       "Global limit",
+			global:      2,
+			clusters:    []string{"p1+c1", "p1+c2", "p2+c1", "p2+c2", "p2+c3"},
+			maxInFlight: 2,
+		},
+		{
+			label:       "Provider limit",
+			perProvider: 1,
+			clusters:    []string{"p1+c1", "p1+c2", "p1+c3"},
+			maxInFlight: 1,
+		},
+		{
+			label:       "Provider limit across providers",
+			perProvider: 1,
+			clusters:    []string{"p1+c1", "p1+c2", "p2+c1", "p2+c2"},
+			maxInFlight: 2,
+		},
+		{
+			label:       "No limit",
+			clusters:    []string{"p1+c1", "p1+c2", "p2+c1"},
+			maxInFlight: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			l := newOpLimiter(testCase.global, testCase.perProvider)
+			var lock sync.Mutex
+			inFlight, maxInFlight := 0, 0
+			var wg sync.WaitGroup
+			for _, cluster := range testCase.clusters {
+				wg.Add(1)
+				go func(cluster string) {
+					defer wg.Done()
+					release, err := l.acquire(context.Background(), cluster)
+					if err != nil {
+						t.Errorf("Unexpected error %s", err)
+						return
+					}
+					lock.Lock()
+					inFlight++
+					if inFlight > maxInFlight {
+						maxInFlight = inFlight
+					}
+					lock.Unlock()
+					time.Sleep(50 * time.Millisecond)
+					lock.Lock()
+					inFlight--
+					lock.Unlock()
+					release()
+				}(cluster)
+			}
+			wg.Wait()
+			if maxInFlight != testCase.maxInFlight {
+				t.Errorf("Expected at most %d operations in flight, got %d", testCase.maxInFlight, maxInFlight)
+			}
+		})
+	}
+}
+
+func TestOpLimiterCancel(t *testing.T) {
+	l := newOpLimiter(1, 0)
+	release, err := l.acquire(context.Background(), "p1+c1")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx, "p1+c2"); err == nil {
+		t.Fatalf("Expected the wait for a slot to end with the context")
+	}
+	release()
+	// Releasing twice doesn't free a slot of another operation
+	release()
+	release2, err := l.acquire(context.Background(), "p1+c2")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	defer release2()
+	if len(l.global) != 1 {
+		t.Errorf("Expected 1 slot in use, got %d", len(l.global))
+	}
+}
+
+func TestOpLimiterUnreachableCluster(t *testing.T) {
+	if contextdb.Db == nil {
+		contextdb.Db = new(contextdb.MockConDb)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cid, err := contextUtils.CreateCompApp(ctx, CompositeApp{
+		CompMetadata: appcontext.CompositeAppMeta{Project: "proj1", CompositeApp: "ca1", Version: "v1", Release: "r1",
+			DeploymentIntentGroup: "dig1", Namespace: "default", Level: "0"},
+		AppOrder: []string{"a1"},
+		Apps: map[string]*App{"a1": {
+			Name: "a1",
+			Clusters: map[string]*Cluster{"provider1+cluster1": {
+				Name:      "provider1+cluster1",
+				Resources: map[string]*AppResource{"r1": {Name: "r1", Data: "a1c1r1"}},
+				ResOrder:  []string{"r1"}}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	acRef, err := utils.NewAppContextReference(ctx, cid)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	l := newOpLimiter(1, 0)
+	slot, err := l.acquireSlot(ctx, "provider1+cluster1")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	// The cluster is unreachable for the first 2 checks
+	r := resProvd{app: "a1", cluster: "provider1+cluster1", slot: slot,
+		cl:      &MockClient{cluster: "provider1+cluster1", retryCounter: -2},
+		context: Context{acRef: acRef, waitTime: 1, maxRetry: -1}}
+	done := make(chan error)
+	go func() { done <- r.waitForClusterReady(ctx) }()
+
+	// The operation on another cluster doesn't wait for the unreachable one
+	ctxOther, cancelOther := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancelOther()
+	release, err := l.acquire(ctxOther, "provider1+cluster2")
+	if err != nil {
+		t.Fatalf("Expected a slot while the cluster is unreachable, got %s", err)
+	}
+	release()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the cluster ready")
+	}
+	// The slot is taken again once the cluster is reachable
+	if len(l.global) != 1 {
+		t.Errorf("Expected 1 slot in use, got %d", len(l.global))
+	}
+	slot.give()
+	if len(l.global) != 0 {
+		t.Errorf("Expected no slot in use, got %d", len(l.global))
+	}
+}
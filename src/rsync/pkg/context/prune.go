@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+/*
+prune.go deletes, after an update, the resources on the clusters that are
+labeled with the deployment of an app but aren't in the updated AppContext
+anymore, e.g. the resources renamed in a Helm chart or left on a cluster
+that was unreachable when they were removed. In dry-run mode the orphaned
+resources are only reported.
+*/
+
+import (
+	"context"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/resourcestatus"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	. "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
+	contextUtils "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Kinds that are labeled like the resources of the app without being
+// applied by rsync
+var unprunableKinds = map[string]bool{
+	// The status tracker of the app
+	"ResourceBundleState": true,
+	// Copy the labels of their Service
+	"Endpoints":     true,
+	"EndpointSlice": true,
+}
+
+// Interval between the checks of the delete phase of an update
+const updateDeletePollInterval = 1 * time.Second
+
+// pruneOrphans prunes the orphaned resources of the apps of both the updated
+// AppContext and the previous AppContext, on their clusters, once the delete
+// phase of the update in the previous AppContext is done, if the
+// DeploymentIntentGroup of the update opts in
+func (c *Context) pruneOrphans(ctx context.Context, pcid string) {
+	if c.meta.PruneOrphans == nil {
+		return
+	}
+	if err := c.waitForUpdateDelete(ctx, pcid); err != nil {
+		log.Info("Pruning canceled", log.Fields{"context": c.acID, "error": err})
+		return
+	}
+	pca, err := contextUtils.ReadAppContext(ctx, pcid)
+	if err != nil {
+		log.Error("Error reading the previous context for pruning", log.Fields{"context": pcid, "error": err})
+		return
+	}
+
+	// The clusters of each app in any of the AppContexts
+	clusters := map[string]map[string]bool{}
+	for _, ca := range []CompositeApp{c.ca, pca} {
+		for app, a := range ca.Apps {
+			if clusters[app] == nil {
+				clusters[app] = map[string]bool{}
+			}
+			for cluster := range a.Clusters {
+				clusters[app][cluster] = true
+			}
+		}
+	}
+	for app := range clusters {
+		for cluster := range clusters[app] {
+			var resources map[string]*AppResource
+			if a, ok := c.ca.Apps[app]; ok {
+				if cl, ok := a.Clusters[cluster]; ok {
+					resources = cl.Resources
+				}
+			}
+			report := c.pruneClusterOrphans(ctx, c.acRef, app, cluster, resources, c.meta.PruneOrphans.DryRun)
+			report.CheckedAt = time.Now()
+			if err := c.scRef.SetClusterPruneReport(ctx, app, cluster, report); err != nil {
+				log.Info("Pruning report not recorded", log.Fields{"context": c.acID, "app": app, "cluster": cluster, "report": report})
+			}
+		}
+	}
+}
+
+// waitForUpdateDelete waits for the previous AppContext to handle the
+// delete phase of the update to the AppContext
+func (c *Context) waitForUpdateDelete(ctx context.Context, pcid string) error {
+	pRef, err := utils.NewAppContextReference(ctx, pcid)
+	if err != nil {
+		return err
+	}
+	qUtils := &AppContextQueueUtils{ac: pRef.GetAppContextHandle()}
+	for {
+		// The event is enqueued concurrently with the apply phase
+		if acQ, err := qUtils.GetAppContextQueue(ctx); err == nil {
+			for _, ele := range acQ.AcQueue {
+				if ele.Event == UpdateDeleteEvent && ele.UCID == c.acID && ele.Status != "Pending" {
+					return nil
+				}
+			}
+		}
+		select {
+		case <-time.After(updateDeletePollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// pruneClusterOrphans prunes the resources on the cluster labeled with the
+// deployment of the app that aren't among the resources of the app
+func (c *Context) pruneClusterOrphans(ctx context.Context, uRef utils.AppContextReference, app, cluster string, resources map[string]*AppResource, dryRun bool) resourcestatus.PruneReport {
+	report := resourcestatus.PruneReport{DryRun: dryRun}
+	release, err := getClusterOpLimiter().acquire(ctx, cluster)
+	if err != nil {
+		report.Message = err.Error()
+		return report
+	}
+	defer release()
+	namespace, level := uRef.GetNamespace(ctx)
+	cl, err := c.con.GetClientProviders(ctx, app, cluster, level, namespace)
+	if err != nil {
+		report.Message = err.Error()
+		return report
+	}
+	defer cl.CleanClientProvider()
+
+	lister, ok := cl.(ResourceLister)
+	if !ok {
+		report.Message = "Pruning isn't supported by the cluster provider"
+		return report
+	}
+	if err := cl.IsReachable(); err != nil {
+		report.Message = "Cluster is not reachable"
+		return report
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	ids, err := resourceIDs(ctx, uRef, app, cluster, namespace, resources)
+	if err != nil {
+		report.Message = err.Error()
+		return report
+	}
+	objs, err := lister.List(ctx, "emco/deployment-id="+c.statusAcID+"-"+app)
+	if err != nil {
+		report.Message = err.Error()
+		return report
+	}
+
+	var ref interface{}
+	for _, o := range orphans(objs, ids) {
+		or := resourcestatus.OrphanResource{Gvk: o.GroupVersionKind(), Name: o.GetName(), Namespace: o.GetNamespace()}
+		if !dryRun {
+			name := o.GetName() + "+" + o.GetKind()
+			b, err := o.MarshalJSON()
+			if err == nil {
+				var q interface{}
+				if q, err = cl.Delete(name, ref, b); err == nil {
+					ref = q
+				}
+			}
+			if err != nil {
+				or.Error = err.Error()
+			} else {
+				or.Pruned = true
+			}
+		}
+		log.Info("Orphaned resource", log.Fields{"app": app, "cluster": cluster, "resource": or})
+		report.Orphans = append(report.Orphans, or)
+	}
+	if ref == nil {
+		return report
+	}
+	if err := cl.Commit(ctx, ref); err != nil {
+		report.Message = err.Error()
+		for i := range report.Orphans {
+			if report.Orphans[i].Pruned {
+				report.Orphans[i].Pruned = false
+				report.Orphans[i].Error = err.Error()
+			}
+		}
+	}
+	return report
+}
+
+// resourceID identifies a resource on a cluster by its group, kind,
+// namespace and name
+func resourceID(group, kind, namespace, name string) string {
+	return group + "/" + kind + "/" + namespace + "/" + name
+}
+
+// resourceIDs returns the identities of the resources of the app on the
+// cluster, read from the updated AppContext. A resource without a namespace
+// is identified both as a cluster-scoped resource and as a resource of the
+// namespace of the app, as its scope isn't known.
+func resourceIDs(ctx context.Context, uRef utils.AppContextReference, app, cluster, namespace string, resources map[string]*AppResource) (map[string]bool, error) {
+	ids := map[string]bool{}
+	for name := range resources {
+		res, _, err := uRef.GetRes(ctx, name, app, cluster)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "Error reading the resource "+name)
+		}
+		u := &unstructured.Unstructured{}
+		if _, err := utils.DecodeYAMLData(string(res), u); err != nil {
+			return nil, pkgerrors.Wrap(err, "Invalid resource "+name)
+		}
+		group, kind := u.GroupVersionKind().Group, u.GetKind()
+		if u.GetNamespace() != "" {
+			ids[resourceID(group, kind, u.GetNamespace(), u.GetName())] = true
+			continue
+		}
+		ids[resourceID(group, kind, "", u.GetName())] = true
+		ids[resourceID(group, kind, namespace, u.GetName())] = true
+	}
+	return ids, nil
+}
+
+// orphans returns the resources on the cluster that aren't among the
+// resources of the app, identified by resourceIDs. The resources owned by
+// another resource, e.g. the pods of a deployment, and the resources labeled
+// by Kubernetes or the monitor are never orphans.
+func orphans(objs []unstructured.Unstructured, ids map[string]bool) []unstructured.Unstructured {
+	var o []unstructured.Unstructured
+	for _, obj := range objs {
+		if len(obj.GetOwnerReferences()) > 0 || unprunableKinds[obj.GetKind()] {
+			continue
+		}
+		if ids[resourceID(obj.GroupVersionKind().Group, obj.GetKind(), obj.GetNamespace(), obj.GetName())] {
+			continue
+		}
+		o = append(o, obj)
+	}
+	return o
+}
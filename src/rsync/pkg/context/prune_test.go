@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	. "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
+	contextUtils "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newObject(apiVersion, kind, namespace, name string, owned bool) unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetName(name)
+	u.SetNamespace(namespace)
+	if owned {
+		u.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: name + "-1", UID: "1234"}})
+	}
+	return u
+}
+
+func TestOrphans(t *testing.T) {
+	ids := map[string]bool{
+		resourceID("apps", "Deployment", "default", "web"): true,
+		resourceID("", "Service", "default", "web"):        true,
+		resourceID("", "Namespace", "", "web"):             true,
+	}
+	objs := []unstructured.Unstructured{
+		newObject("apps/v1", "Deployment", "default", "web", false),
+		newObject("v1", "Service", "default", "web", false),
+		newObject("v1", "Namespace", "", "web", false),
+		// Renamed in the chart
+		newObject("v1", "ConfigMap", "default", "web-config-old", false),
+		// Same name, other kind
+		newObject("v1", "Secret", "default", "web", false),
+		// Same name and kind, other namespace
+		newObject("v1", "Service", "other", "web", false),
+		// Same name and kind, other group
+		newObject("extensions/v1beta1", "Deployment", "default", "web", false),
+		// Created by the deployment
+		newObject("v1", "Pod", "default", "web-1-abcde", true),
+		// Not applied by rsync
+		newObject("v1", "Endpoints", "default", "web", false),
+		newObject("k8splugin.io/v1alpha1", "ResourceBundleState", "default", "1234-app1", false),
+	}
+
+	o := orphans(objs, ids)
+	if len(o) != 4 || o[0].GetName() != "web-config-old" || o[1].GetKind() != "Secret" ||
+		o[2].GetNamespace() != "other" || o[3].GetAPIVersion() != "extensions/v1beta1" {
+		t.Fatalf("Expected the ConfigMap, the Secret, the Service of the other namespace and the Deployment of the other group to be orphans, got %+v", o)
+	}
+
+	// An app removed from the cluster leaves all its resources orphaned
+	o = orphans(objs, nil)
+	if len(o) != 7 {
+		t.Errorf("Expected 7 orphans, got %d", len(o))
+	}
+}
+
+func TestWaitForUpdateDelete(t *testing.T) {
+	if contextdb.Db == nil {
+		contextdb.Db = new(contextdb.MockConDb)
+	}
+	ctx := context.Background()
+	pcid, err := contextUtils.CreateCompApp(ctx, CompositeApp{
+		CompMetadata: appcontext.CompositeAppMeta{Project: "proj1", CompositeApp: "ca1", Version: "v1", Release: "r1",
+			DeploymentIntentGroup: "dig1", Namespace: "default", Level: "0"},
+		AppOrder: []string{"a1"},
+		Apps:     map[string]*App{"a1": {Name: "a1", Clusters: map[string]*Cluster{}}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	pRef, err := utils.NewAppContextReference(ctx, pcid)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	qUtils := &AppContextQueueUtils{ac: pRef.GetAppContextHandle()}
+	c := &Context{acID: "1234"}
+
+	// The delete phase of another update doesn't count
+	if _, err := qUtils.Enqueue(ctx, AppContextQueueElement{Event: UpdateDeleteEvent, Status: "Done", UCID: "5678"}); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if _, err := qUtils.Enqueue(ctx, AppContextQueueElement{Event: UpdateDeleteEvent, Status: "Pending", UCID: c.acID}); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	wctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := c.waitForUpdateDelete(wctx, pcid); err == nil {
+		t.Fatalf("Expected the wait for the pending delete phase to end with the context")
+	}
+
+	// A failed delete phase leaves orphans too
+	if err := qUtils.UpdateStatus(ctx, 1, "Error"); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	wctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := c.waitForUpdateDelete(wctx, pcid); err != nil {
+		t.Fatalf("Expected the delete phase done, got %s", err)
+	}
+}
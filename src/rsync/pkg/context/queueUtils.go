@@ -10,6 +10,7 @@ import (
 
 	pkgerrors "github.com/pkg/errors"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	types "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
 )
@@ -87,63 +88,77 @@ func (aq *AppContextQueueUtils) GetAppContextQueueLength(ctx context.Context) (i
 	return len(q), nil
 }
 
-// Enqueue shall append new Q-Elemenet in the string format
-func (aq *AppContextQueueUtils) Enqueue(ctx context.Context, qElement types.AppContextQueueElement) (bool, error) {
-	acQ, err := aq.GetAppContextQueue(ctx)
+// maxQueueUpdateRetries is the number of attempts made to update the
+// AppContextQueue when it is modified concurrently (e.g. by another rsync)
+const maxQueueUpdateRetries = 10
+
+// queueHandle returns the handle of the AppContextQueue
+func (aq *AppContextQueueUtils) queueHandle(ctx context.Context) (interface{}, error) {
+	h, err := aq.ac.GetCompositeAppHandle(ctx)
 	if err != nil {
-		log.Info("No existing AppContextQueue, Creating AppContextQueue", log.Fields{"err": err})
-		return aq.CreateQueue(ctx, qElement)
+		log.Error("Error in getting CompApp handle for the AppContextQueue", log.Fields{"err": err})
+		return nil, err
 	}
-	if len(acQ.AcQueue) >= 1 {
-		q := acQ.AcQueue
-		q = append(q, qElement)
-		return aq.UpdateQueue(ctx, q)
-	}
-	return false, nil
+	return fmt.Sprintf("%v%v/", h, types.AppContextEventQueueKey), nil
 }
 
-func (aq *AppContextQueueUtils) UpdateQueue(ctx context.Context, q []types.AppContextQueueElement) (bool, error) {
-	h, err := aq.ac.GetCompositeAppHandle(ctx)
+// modifyQueue applies fn to the AppContextQueue and saves the result only if the
+// queue was not modified since it was read, retrying otherwise. Any additional
+// puts are committed in the same transaction as the queue.
+func (aq *AppContextQueueUtils) modifyQueue(ctx context.Context, fn func(q []types.AppContextQueueElement) ([]types.AppContextQueueElement, error), puts ...contextdb.TxnPut) error {
+	qHandle, err := aq.queueHandle(ctx)
 	if err != nil {
-		log.Error("Error in getting CompApp handle in UpdateQueue", log.Fields{"err": err})
-		return false, err
+		return err
 	}
 
-	qKey := types.AppContextEventQueueKey
+	for i := 0; i < maxQueueUpdateRetries; i++ {
+		v, rev, err := aq.ac.GetValueWithRevision(ctx, qHandle)
+		if err != nil {
+			log.Error("Error getting value for the AppQ handle", log.Fields{"err": err})
+			return err
+		}
 
-	qHandle, err := aq.ac.GetLevelHandle(ctx, h, qKey)
-	if err != nil {
-		log.Error("Error in getting Qhandle", log.Fields{"err": err})
-		return false, err
-	}
-	acQ := types.AppContextQueue{AcQueue: q}
-	err = aq.ac.UpdateValue(ctx, qHandle, acQ)
-	if err != nil {
-		log.Error("Error in updating Qhandle", log.Fields{"err": err})
-		return false, err
-	}
+		acQ := types.AppContextQueue{}
+		if v != nil {
+			js, err := json.Marshal(v)
+			if err != nil {
+				log.Error("Marshal Error in modifyQueue", log.Fields{"err": err})
+				return err
+			}
+			err = json.Unmarshal(js, &acQ)
+			if err != nil {
+				log.Error("UnMarshal Error in modifyQueue", log.Fields{"err": err})
+				return err
+			}
+		}
 
-	return true, nil
-}
+		q, err := fn(acQ.AcQueue)
+		if err != nil {
+			return err
+		}
 
-func (aq *AppContextQueueUtils) CreateQueue(ctx context.Context, qElement types.AppContextQueueElement) (bool, error) {
-	h, err := aq.ac.GetCompositeAppHandle(ctx)
-	if err != nil {
-		log.Error("Error in getting CompApp handle in CreateQueue", log.Fields{"err": err})
-		return false, err
+		err = aq.ac.UpdateValueIfRevision(ctx, qHandle, types.AppContextQueue{AcQueue: q}, rev, puts...)
+		if err != contextdb.ErrRevisionMismatch {
+			if err != nil {
+				log.Error("Error in updating Qhandle", log.Fields{"err": err})
+			}
+			return err
+		}
+		log.Info("AppContextQueue was modified concurrently, retrying", log.Fields{"qhandle": qHandle, "revision": rev})
 	}
-	var q []types.AppContextQueueElement
-	q = append(q, qElement)
-	acQ := types.AppContextQueue{AcQueue: q}
 
-	qKey := types.AppContextEventQueueKey
-	qHandle, err := aq.ac.AddLevelValue(ctx, h, qKey, acQ)
+	return pkgerrors.Errorf("Error updating AppContextQueue: too many concurrent updates")
+}
+
+// Enqueue shall append new Q-Element to the AppContextQueue, creating the queue if needed.
+// Any additional puts are committed in the same transaction as the queue.
+func (aq *AppContextQueueUtils) Enqueue(ctx context.Context, qElement types.AppContextQueueElement, puts ...contextdb.TxnPut) (bool, error) {
+	err := aq.modifyQueue(ctx, func(q []types.AppContextQueueElement) ([]types.AppContextQueueElement, error) {
+		return append(q, qElement), nil
+	}, puts...)
 	if err != nil {
-		log.Error("Error in Adding AppContextQueue Level", log.Fields{"err": err})
 		return false, err
 	}
-	qhandle := fmt.Sprintf("%v", qHandle)
-	log.Info("AppContextQueue created :: Qhandle :: ", log.Fields{"qhandle": qhandle})
 	return true, nil
 }
 
@@ -161,16 +176,11 @@ func (aq *AppContextQueueUtils) FindFirstPending(ctx context.Context) (int, type
 }
 
 func (aq *AppContextQueueUtils) UpdateStatus(ctx context.Context, index int, status string) error {
-	acQ, err := aq.GetAppContextQueue(ctx)
-	if err != nil {
-		log.Error("Error in getting AppContextQueue", log.Fields{"err": err})
-		return err
-	}
-	q := acQ.AcQueue
-	if index >= len(q) || index < 0 {
-		return pkgerrors.Errorf("Invalid index AppContextQueue")
-	}
-	q[index].Status = status
-	_, err = aq.UpdateQueue(ctx, q)
-	return err
+	return aq.modifyQueue(ctx, func(q []types.AppContextQueueElement) ([]types.AppContextQueueElement, error) {
+		if index >= len(q) || index < 0 {
+			return nil, pkgerrors.Errorf("Invalid index AppContextQueue")
+		}
+		q[index].Status = status
+		return q, nil
+	})
 }
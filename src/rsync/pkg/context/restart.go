@@ -24,14 +24,14 @@ const prefix string = "/activecontext/"
 // It shall take in activeContextID
 func RecordActiveContext(ctx context.Context, acID string) (bool, error) {
 
-	exists, _ := ifContextIDActive(ctx, acID)
-	if exists {
+	// Create the record only if it doesn't exist, so that concurrent
+	// callers agree on which one recorded the context
+	k := prefix + acID + "/"
+	err := contextdb.Db.PutIfRevision(ctx, k, acID, 0)
+	if err == contextdb.ErrRevisionMismatch {
 		logutils.Info("ContextID already active", logutils.Fields{"acID": acID})
 		return false, nil
 	}
-
-	k := prefix + acID + "/"
-	err := contextdb.Db.Put(ctx, k, acID)
 	if err != nil {
 		logutils.Info("Error saving the active contextID", logutils.Fields{"err": err.Error(), "contextID": acID})
 		return false, pkgerrors.Errorf("Error:: %s saving contextID:: %s", err.Error(), acID)
@@ -40,6 +40,12 @@ func RecordActiveContext(ctx context.Context, acID string) (bool, error) {
 	return true, nil
 }
 
+// activeContextPut returns the put recording the contextID as active, to be
+// committed along with other updates of the context
+func activeContextPut(acID string) contextdb.TxnPut {
+	return contextdb.TxnPut{Key: prefix + acID + "/", Value: acID, Revision: contextdb.AnyRevision}
+}
+
 // GetAllActiveContext shall return all the active contextIDs
 func GetAllActiveContext(ctx context.Context) ([]string, error) {
 	aContexts, err := contextdb.Db.GetAllKeys(ctx, prefix)
@@ -102,6 +108,9 @@ func RestoreActiveContext(ctx context.Context) error {
 	logutils.Info("Total active contexts to be restored", logutils.Fields{"Total active contextIDs to be restored": len(acIDs)})
 
 	for _, acID := range acIDs {
+		if acID == "" || !ownsAppContext(ctx, acID) {
+			continue
+		}
 		con := connector.NewProvider(acID)
 		err = RestartAppContext(ctx, acID, &con)
 		if err != nil {
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Classes of the errors of the operations on the clusters
+const (
+	ErrorClassServerError   = "ServerError"   // 5xx of the API server
+	ErrorClassConflict      = "Conflict"      // 409
+	ErrorClassFieldConflict = "FieldConflict" // 409 of the server-side apply, the fields are managed by another field manager
+	ErrorClassThrottled     = "Throttled"     // 429
+	ErrorClassTimeout       = "Timeout"       // 504 and client timeouts
+	ErrorClassClientError   = "ClientError"   // the other 4xx, e.g. validation
+	ErrorClassOther         = "Other"         // errors that don't come from the API server
+)
+
+// retryPolicy is the policy of the retries of the operations on a cluster
+type retryPolicy struct {
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	multiplier   float64
+	jitter       float64
+	maxAttempts  int
+	retryOn      map[string]bool
+}
+
+// getRetryPolicy returns the retry policy of the rsync configuration, with
+// the fields set by the DeploymentIntentGroup overridden
+func getRetryPolicy(rp *appcontext.RetryPolicy) retryPolicy {
+	cfg := config.GetConfiguration()
+	initialDelay, maxDelay := cfg.RetryInitialDelay, cfg.RetryMaxDelay
+	p := retryPolicy{
+		multiplier:  cfg.RetryMultiplier,
+		jitter:      cfg.RetryJitter,
+		maxAttempts: cfg.RetryMaxAttempts,
+		retryOn:     map[string]bool{},
+	}
+	retryOn := cfg.RetryOn
+	if rp != nil {
+		if rp.InitialDelaySeconds > 0 {
+			initialDelay = rp.InitialDelaySeconds
+		}
+		if rp.MaxDelaySeconds > 0 {
+			maxDelay = rp.MaxDelaySeconds
+		}
+		if rp.Multiplier > 0 {
+			p.multiplier = rp.Multiplier
+		}
+		if rp.Jitter > 0 {
+			p.jitter = rp.Jitter
+		}
+		if rp.MaxAttempts > 0 {
+			p.maxAttempts = rp.MaxAttempts
+		}
+		if len(rp.RetryOn) > 0 {
+			retryOn = rp.RetryOn
+		}
+	}
+	for _, c := range retryOn {
+		p.retryOn[c] = true
+	}
+	if initialDelay <= 0 {
+		// The fixed delay rsync used before the retry policies
+		initialDelay = 2
+	}
+	p.initialDelay = time.Duration(initialDelay * float64(time.Second))
+	p.maxDelay = time.Duration(maxDelay * float64(time.Second))
+	if p.multiplier < 1 {
+		p.multiplier = 1
+	}
+	if p.maxAttempts < 1 {
+		p.maxAttempts = 1
+	}
+	return p
+}
+
+// delay returns the time to wait before the retry following the given
+// number of retries, growing exponentially up to the max delay
+func (p retryPolicy) delay(retries int) time.Duration {
+	d := float64(p.initialDelay) * math.Pow(p.multiplier, float64(retries))
+	if p.maxDelay > 0 && d > float64(p.maxDelay) {
+		d = float64(p.maxDelay)
+	}
+	if p.jitter > 0 {
+		d += d * p.jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// retryable tells if an operation failing with the error is retried
+func (p retryPolicy) retryable(err error) bool {
+	return p.retryOn[errorClass(err)]
+}
+
+// wait waits before the retry following the given number of retries, and
+// returns the error of the context if it is done first
+func (p retryPolicy) wait(ctx context.Context, retries int) error {
+	select {
+	case <-time.After(p.delay(retries)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// errorClass classifies the error of an operation on a cluster
+func errorClass(err error) string {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		code := int(status.Status().Code)
+		switch {
+		case apierrors.IsTooManyRequests(err) || code == http.StatusTooManyRequests:
+			return ErrorClassThrottled
+		case isFieldManagerConflict(status):
+			return ErrorClassFieldConflict
+		case apierrors.IsConflict(err) || code == http.StatusConflict:
+			return ErrorClassConflict
+		case apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || code == http.StatusGatewayTimeout:
+			return ErrorClassTimeout
+		case code >= 500:
+			return ErrorClassServerError
+		case code >= 400:
+			return ErrorClassClientError
+		}
+	}
+	// Errors of the client, e.g. of the network
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded") {
+		return ErrorClassTimeout
+	}
+	return ErrorClassOther
+}
+
+// isFieldManagerConflict tells if the error is a conflict of the server-side
+// apply with the fields of other field managers
+func isFieldManagerConflict(status apierrors.APIStatus) bool {
+	details := status.Status().Details
+	if details == nil {
+		return false
+	}
+	for _, c := range details.Causes {
+		if c.Type == metav1.CauseTypeFieldManagerConflict {
+			return true
+		}
+	}
+	return false
+}
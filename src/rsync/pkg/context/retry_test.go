@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestErrorClass(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	testCases := []struct {
+		label string
+		err   error
+		class string
+	}{
+		{"Internal error", apierrors.NewInternalError(fmt.Errorf("etcd")), ErrorClassServerError},
+		{"Service unavailable", apierrors.NewServiceUnavailable("down"), ErrorClassServerError},
+		{"Conflict", apierrors.NewConflict(gr, "d1", fmt.Errorf("modified")), ErrorClassConflict},
+		{"Field conflict", apierrors.NewApplyConflict([]metav1.StatusCause{{Type: metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kube-controller-manager"`, Field: ".spec.replicas"}}, "Apply failed with 1 conflict"), ErrorClassFieldConflict},
+		{"Throttled", apierrors.NewTooManyRequests("slow down", 1), ErrorClassThrottled},
+		{"Server timeout", apierrors.NewServerTimeout(gr, "create", 1), ErrorClassTimeout},
+		{"Invalid", apierrors.NewBadRequest("invalid spec"), ErrorClassClientError},
+		{"Forbidden", apierrors.NewForbidden(gr, "d1", fmt.Errorf("denied")), ErrorClassClientError},
+		{"Wrapped", pkgerrors.Wrap(apierrors.NewConflict(gr, "d1", fmt.Errorf("modified")), "apply"), ErrorClassConflict},
+		{"Client timeout", fmt.Errorf("net/http: request canceled (Client.Timeout exceeded)"), ErrorClassTimeout},
+		{"Other", fmt.Errorf("invalid resource"), ErrorClassOther},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			if c := errorClass(testCase.err); c != testCase.class {
+				t.Errorf("Expected class %s, got %s", testCase.class, c)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	p := getRetryPolicy(&appcontext.RetryPolicy{
+		InitialDelaySeconds: 1,
+		MaxDelaySeconds:     5,
+		Multiplier:          3,
+		MaxAttempts:         4,
+		RetryOn:             []string{ErrorClassConflict},
+	})
+	p.jitter = 0
+	expected := []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, e := range expected {
+		if d := p.delay(i); d != e {
+			t.Errorf("Expected delay %v after %d retries, got %v", e, i, d)
+		}
+	}
+	if p.maxAttempts != 4 {
+		t.Errorf("Expected 4 attempts, got %d", p.maxAttempts)
+	}
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	if !p.retryable(apierrors.NewConflict(gr, "d1", fmt.Errorf("modified"))) {
+		t.Errorf("Expected a conflict to be retried")
+	}
+	if p.retryable(apierrors.NewInternalError(fmt.Errorf("etcd"))) {
+		t.Errorf("Expected a server error not to be retried")
+	}
+
+	// The jitter keeps the delay within its fraction
+	p.jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.delay(0); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("Expected delay within 0.5s and 1.5s, got %v", d)
+		}
+	}
+}
+
+func TestRetryPolicyDefaults(t *testing.T) {
+	p := getRetryPolicy(nil)
+	if p.initialDelay <= 0 || p.maxAttempts < 1 || p.multiplier < 1 {
+		t.Errorf("Invalid default retry policy %+v", p)
+	}
+	if !p.retryable(apierrors.NewServiceUnavailable("down")) {
+		t.Errorf("Expected a server error to be retried by default")
+	}
+	if p.retryable(apierrors.NewBadRequest("invalid spec")) {
+		t.Errorf("Expected a validation error to fail at once by default")
+	}
+	conflict := apierrors.NewApplyConflict([]metav1.StatusCause{{Type: metav1.CauseTypeFieldManagerConflict, Field: ".spec.replicas"}}, "Apply failed with 1 conflict")
+	if p.retryable(conflict) {
+		t.Errorf("Expected a conflict with another field manager to fail at once by default")
+	}
+}
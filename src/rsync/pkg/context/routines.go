@@ -14,6 +14,7 @@ import (
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/depend"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/lease"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
 	. "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
 	contextUtils "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/utils"
@@ -113,6 +114,16 @@ func (c *Context) terminateContextRoutine(ctx context.Context) {
 
 // Start Main Thread for handling
 func (c *Context) startMainThread(ctx context.Context, a interface{}, con Connector) error {
+	if err := c.loadAppContext(ctx, a, con); err != nil {
+		return err
+	}
+	// Start Routine to handle AppContext
+	go c.appContextRoutine(ctx)
+	return nil
+}
+
+// Read the AppContext and initialize its flags and status
+func (c *Context) loadAppContext(ctx context.Context, a interface{}, con Connector) error {
 	acID := fmt.Sprintf("%v", a)
 
 	ref, err := utils.NewAppContextReference(ctx, acID)
@@ -152,6 +163,7 @@ func (c *Context) startMainThread(ctx context.Context, a interface{}, con Connec
 	if err != nil {
 		return err
 	}
+	c.retry = getRetryPolicy(c.meta.RetryPolicy)
 
 	_, err = c.acRef.GetAppContextFlag(ctx, StopFlagKey)
 	// Assume doesn't exist and add
@@ -190,8 +202,6 @@ func (c *Context) startMainThread(ctx context.Context, a interface{}, con Connec
 	}
 	// Intialize dependency management
 	c.dm = depend.NewDependManager(c.acID)
-	// Start Routine to handle AppContext
-	go c.appContextRoutine(ctx)
 	return nil
 }
 
@@ -272,6 +282,8 @@ func (c *Context) appContextRoutine(callerCtx context.Context) {
 				// Continue to process more events
 				continue
 			}
+			// Stop comparing the resources with the clusters while handling the event
+			c.stopDriftDetection(ctx)
 			// Create a derived context
 			l, lDone = context.WithCancel(ctx)
 			lGroup, lctx = errgroup.WithContext(l)
@@ -347,6 +359,27 @@ func (c *Context) appContextRoutine(callerCtx context.Context) {
 			ds, _ := c.acRef.GetAppContextStatus(ctx, DesiredStateKey)
 			err = c.acRef.UpdateAppContextStatus(ctx, StatusKey, ds)
 			err = c.acRef.UpdateAppContextStatus(ctx, CurrentStateKey, ds)
+			// Start comparing the resources with the clusters once they are applied
+			if e == InstantiateEvent || e == UpdateEvent {
+				c.startDriftDetection(ctx)
+			}
+			// Prune the resources left on the clusters once the update is applied
+			// and the previous AppContext is done deleting
+			if e == UpdateEvent {
+				pctx, pDone := context.WithCancel(ctx)
+				// A terminate stops the wait for the previous AppContext
+				c.Lock.Lock()
+				c.cancel = pDone
+				c.Lock.Unlock()
+				c.pruneOrphans(pctx, ele.UCID)
+				pDone()
+			}
+			// Let any replica handle the DeploymentIntentGroup once terminated
+			if e == TerminateEvent {
+				if err := lease.Release(ctx, c.acID); err != nil {
+					log.Error("Error releasing the lease of the context", log.Fields{"context": c.acID, "error": err})
+				}
+			}
 
 		} else {
 			// Done Processing all elements in queue
@@ -539,6 +572,11 @@ func (c *Context) runApp(ctx context.Context, g *errgroup.Group, op RsyncOperati
 
 func (c *Context) runCluster(ctx context.Context, op RsyncOperation, e RsyncEvent, app, cluster string) error {
 	log.Info(" runCluster::", log.Fields{"app": app, "cluster": cluster})
+	slot, err := getClusterOpLimiter().acquireSlot(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	defer slot.give()
 	namespace, level := c.acRef.GetNamespace(ctx)
 	cl, err := c.con.GetClientProviders(ctx, app, cluster, level, namespace)
 	if err != nil {
@@ -562,7 +600,7 @@ func (c *Context) runCluster(ctx context.Context, op RsyncOperation, e RsyncEven
 			"cluster": cluster,
 		})
 	}
-	r := resProvd{app: app, cluster: cluster, cl: cl, context: *c}
+	r := resProvd{app: app, cluster: cluster, cl: cl, context: *c, slot: slot}
 	// Timer key
 	key := app + depend.SEPARATOR + cluster
 	switch e {
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package context
+
+/*
+sharding.go restores the AppContexts owned by the replica of rsync, when the
+AppContexts are shared between the replicas
+*/
+
+import (
+	"context"
+
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/connector"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/lease"
+)
+
+// ownsAppContext returns true if the replica owns the AppContext, claiming
+// it if it isn't owned by a live replica
+func ownsAppContext(ctx context.Context, acID string) bool {
+	owned, err := lease.Acquire(ctx, acID)
+	if err != nil {
+		log.Error("Error acquiring the lease of the context", log.Fields{"context": acID, "error": err})
+		return false
+	}
+	return owned
+}
+
+// TakeOverAppContexts restarts the active AppContexts and the drift
+// detection of the leases taken over from a dead replica
+func TakeOverAppContexts(ctx context.Context, keys []string) {
+	taken := make(map[string]bool)
+	for _, key := range keys {
+		taken[key] = true
+	}
+	acIDs, err := GetAllActiveContext(ctx)
+	if err != nil {
+		log.Error("Error getting the active contexts to take over", log.Fields{"error": err})
+	}
+	for _, acID := range acIDs {
+		if acID == "" || !taken[lease.Key(ctx, acID)] {
+			continue
+		}
+		con := connector.NewProvider(acID)
+		if err := RestartAppContext(ctx, acID, &con); err != nil {
+			log.Error("Error restarting the context taken over", log.Fields{"context": acID, "error": err})
+		}
+	}
+	restoreDriftDetection(ctx, func(acID string) bool {
+		return taken[lease.Key(ctx, acID)]
+	})
+}
@@ -72,6 +72,16 @@ type CloudConfigManager interface {
 	GetGitOpsConfig(provider string, cluster string, level string, namespace string) (CloudGitOpsConfig, error)
 }
 
+// clusterSyncObjectsSelectors are the fields a list of cluster sync objects can be filtered and sorted on
+var clusterSyncObjectsSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "meta.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"meta": mtypes.ClusterSyncObjects{},
+	},
+}
+
 // CloudConfigClient implements CloudConfigManager
 // It will also be used to maintain some localized state
 type CloudConfigClient struct {
@@ -355,14 +365,27 @@ func (c *CloudConfigClient) GetClusterSyncObjectsValue(ctx context.Context, prov
 
 // GetAllClusterSyncObjects returns the Cluster Sync Objects for corresponding provider
 func (c *CloudConfigClient) GetAllClusterSyncObjects(ctx context.Context, provider string) ([]mtypes.ClusterSyncObjects, error) {
+	res, _, err := c.ListClusterSyncObjects(ctx, provider, db.FindOptions{})
+	return res, err
+}
+
+// ListClusterSyncObjects returns a page of the Cluster Sync Objects of the
+// provider, filtered and sorted on the clusterSyncObjectsSelectors, and the token
+// of the next page
+func (c *CloudConfigClient) ListClusterSyncObjects(ctx context.Context, provider string, opts db.FindOptions) ([]mtypes.ClusterSyncObjects, string, error) {
+	opts, err := clusterSyncObjectsSelectors.Resolve(opts)
+	if err != nil {
+		return []mtypes.ClusterSyncObjects{}, "", err
+	}
+
 	//Construct key and tag to select the entry
 	key := ClusterSyncObjectsKey{
 		ClusterProviderName:    provider,
 		ClusterSyncObjectsName: "",
 	}
-	values, err := db.DBconn.Find(ctx, c.db.storeName, key, c.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, c.db.storeName, key, c.db.tagMeta, opts)
 	if err != nil {
-		return []mtypes.ClusterSyncObjects{}, err
+		return []mtypes.ClusterSyncObjects{}, "", err
 	}
 
 	resp := make([]mtypes.ClusterSyncObjects, 0)
@@ -370,12 +393,12 @@ func (c *CloudConfigClient) GetAllClusterSyncObjects(ctx context.Context, provid
 		cp := mtypes.ClusterSyncObjects{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []mtypes.ClusterSyncObjects{}, err
+			return []mtypes.ClusterSyncObjects{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // CreateGitOpsConfig allows to create a new cloud config entry to hold a kubeconfig for access
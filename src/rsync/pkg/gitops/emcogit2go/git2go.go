@@ -1,7 +1,9 @@
 package emcogit2go
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -15,11 +17,14 @@ import (
 
 	git "github.com/libgit2/git2go/v33"
 	v1alpha1 "gitlab.com/project-emco/core/emco-base/src/monitor/pkg/apis/k8splugin/v1alpha1"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/gitcommit"
 	gitUtils "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/utils"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 type Git2go struct {
@@ -29,9 +34,28 @@ type Git2go struct {
 	RepoName   string
 	GitToken   string
 	FolderName string
+	SSH        *SSHAuth
+	// Signer signs the commits if set, with the AuthorName and AuthorEmail
+	// of the commits, that default to the user
+	Signer      *gitcommit.Signer
+	AuthorName  string
+	AuthorEmail string
 }
 
-func NewGit2Go(url, branch, user, repo, token string) (*Git2go, error) {
+// SSHAuth authenticates with an SSH key, for the ssh:// and scp like urls
+type SSHAuth struct {
+	// PrivateKey is the PEM encoded private key
+	PrivateKey string
+	// Passphrase of the private key, if it is encrypted
+	Passphrase string
+	// KnownHosts are the accepted host keys, in the known_hosts format.
+	// The host is rejected if empty, unless InsecureIgnoreHostKey is set.
+	KnownHosts string
+	// InsecureIgnoreHostKey accepts any host key
+	InsecureIgnoreHostKey bool
+}
+
+func NewGit2Go(url, branch, user, repo, token string, sshAuth *SSHAuth) (*Git2go, error) {
 
 	folderName := "/tmp/" + user + "-" + repo
 	g := Git2go{
@@ -41,6 +65,7 @@ func NewGit2Go(url, branch, user, repo, token string) (*Git2go, error) {
 		RepoName:   repo,
 		GitToken:   token,
 		FolderName: folderName,
+		SSH:        sshAuth,
 	}
 
 	//check if git repo exists, if not clone
@@ -55,7 +80,8 @@ func NewGit2Go(url, branch, user, repo, token string) (*Git2go, error) {
 			return nil, err
 		}
 		// // clone the repo
-		_, err = git.Clone(url, folderName, &git.CloneOptions{CheckoutBranch: branch, CheckoutOptions: git.CheckoutOptions{Strategy: git.CheckoutSafe}})
+		_, err = git.Clone(url, folderName, &git.CloneOptions{CheckoutBranch: branch, CheckoutOptions: git.CheckoutOptions{Strategy: git.CheckoutSafe},
+			FetchOptions: git.FetchOptions{RemoteCallbacks: g.remoteCallbacks()}})
 		if err != nil {
 			log.Error("Error cloning the repo", log.Fields{"Error": err})
 			return nil, err
@@ -74,6 +100,69 @@ func getCredCallBack(userName, token string) func(url string, username string, a
 	}
 }
 
+func getSSHCredCallBack(sshAuth *SSHAuth) func(url string, username string, allowedTypes git.CredType) (*git.Credential, error) {
+	return func(url string, username string, allowedTypes git.CredType) (*git.Credential, error) {
+		// The user is the one of the url, e.g. git in git@gitlab.com:group/repo.git
+		if username == "" {
+			username = "git"
+		}
+		return git.NewCredentialSSHKeyFromMemory(username, "", sshAuth.PrivateKey, sshAuth.Passphrase)
+	}
+}
+
+// getHostKeyCallBack accepts the host keys of the known hosts only
+func getHostKeyCallBack(knownHosts string) git.CertificateCheckCallback {
+	return func(cert *git.Certificate, valid bool, hostname string) error {
+		if cert.Kind != git.CertificateHostkey {
+			return fmt.Errorf("unexpected certificate for the SSH host %s", hostname)
+		}
+		rest := []byte(knownHosts)
+		for len(rest) > 0 {
+			_, hosts, key, _, r, err := ssh.ParseKnownHosts(rest)
+			if err != nil {
+				break
+			}
+			rest = r
+			if !matchHost(hosts, hostname) {
+				continue
+			}
+			if cert.Hostkey.SSHPublicKey != nil && bytes.Equal(cert.Hostkey.SSHPublicKey.Marshal(), key.Marshal()) {
+				return nil
+			}
+			if cert.Hostkey.Kind&git.HostkeySHA256 != 0 && cert.Hostkey.HashSHA256 == sha256.Sum256(key.Marshal()) {
+				return nil
+			}
+		}
+		return fmt.Errorf("host key of %s is not a known host", hostname)
+	}
+}
+
+func matchHost(hosts []string, hostname string) bool {
+	for _, h := range hosts {
+		if knownhosts.Normalize(h) == knownhosts.Normalize(hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteCallbacks authenticates with the SSH key if one is configured, and
+// with the token otherwise
+func (p *Git2go) remoteCallbacks() git.RemoteCallbacks {
+	if p.SSH == nil {
+		return git.RemoteCallbacks{CredentialsCallback: getCredCallBack(p.UserName, p.GitToken)}
+	}
+	cbs := git.RemoteCallbacks{CredentialsCallback: getSSHCredCallBack(p.SSH)}
+	if p.SSH.InsecureIgnoreHostKey {
+		cbs.CertificateCheckCallback = func(cert *git.Certificate, valid bool, hostname string) error {
+			return nil
+		}
+	} else {
+		cbs.CertificateCheckCallback = getHostKeyCallBack(p.SSH.KnownHosts)
+	}
+	return cbs
+}
+
 // CommitFile contains high-level information about a file  ed to a commit.
 type CommitFile struct {
 
@@ -127,7 +216,8 @@ func createFile(fileName string, content string) error {
 
 var mutex = sync.Mutex{}
 
-// function to commit files to a branch
+// function to commit files to a branch. The commit is made on the branch of
+// the remote, and made again on its new head if it moved before the push.
 func (p *Git2go) CommitFiles(app, message string, files interface{}) error {
 
 	mutex.Lock()
@@ -136,7 +226,6 @@ func (p *Git2go) CommitFiles(app, message string, files interface{}) error {
 	var repo *git.Repository
 
 	branchName := p.Branch
-	userName := p.UserName
 
 	repo, err := git.OpenRepository(p.FolderName)
 	if err != nil {
@@ -144,23 +233,58 @@ func (p *Git2go) CommitFiles(app, message string, files interface{}) error {
 		return err
 	}
 
-	signature := &git.Signature{
-		Name:  userName,
-		Email: userName + "@gmail.com",
-		When:  time.Now(),
+	for attempt := 1; ; attempt++ {
+		_, err = p.commitFilesOnRemote(repo, branchName, branchName, message, convertToCommitFile(files))
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, errPushRejected) || attempt >= gitcommit.CommitRetries {
+			return err
+		}
+		log.Info("Branch updated, trying again!", log.Fields{"err": err, "branchName": branchName, "attempt": attempt})
+		time.Sleep(gitcommit.RetryDelay(attempt))
 	}
+}
 
-	// set head to point to the branch
-	err = repo.SetHead("refs/heads/" + branchName)
+// CommitFilesToBranch commits the files on the head of the branch of the
+// remote and pushes the commit to a new branch, e.g. for a merge request.
+// It returns false if the files don't change the branch.
+func (p *Git2go) CommitFilesToBranch(newBranch, message string, files interface{}) (bool, error) {
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	repo, err := git.OpenRepository(p.FolderName)
 	if err != nil {
-		log.Error("Error in settting the head", log.Fields{"err": err, "branchName": branchName})
-		return err
+		log.Error("Error in Opening the git repository", log.Fields{"err": err, "branchName": p.Branch})
+		return false, err
+	}
+	committed, err := p.commitFilesOnRemote(repo, p.Branch, newBranch, message, convertToCommitFile(files))
+	if ref, lerr := repo.References.Lookup("refs/heads/" + newBranch); lerr == nil {
+		ref.Delete()
 	}
+	return committed, err
+}
+
+// errPushRejected is the error of a push that isn't a fast-forward
+var errPushRejected = errors.New("push rejected")
+
+// commitFilesOnRemote resets the branch to the one of the remote, commits the
+// files on it and pushes the commit to the target branch, the branch itself
+// or a new branch. It returns false if there is nothing to commit.
+func (p *Git2go) commitFilesOnRemote(repo *git.Repository, branchName, targetBranch, message string, f []CommitFile) (bool, error) {
+
+	err := p.resetToRemote(repo, branchName)
+	if err != nil {
+		return false, err
+	}
+
+	signature := p.signature()
 
 	branch, err := repo.References.Lookup("refs/heads/" + branchName)
 	if err != nil {
 		log.Info("Error in looking up ref", log.Fields{"err": err})
-		return err
+		return false, err
 	}
 
 	//Update the index with files and obtain the latest index
@@ -168,9 +292,8 @@ func (p *Git2go) CommitFiles(app, message string, files interface{}) error {
 	idx, err := repo.Index()
 	if err != nil {
 		log.Error("Error in obtaining the repo index", log.Fields{"err": err, "idx": idx})
-		return err
+		return false, err
 	}
-	f := convertToCommitFile(files)
 	for _, file := range f {
 		if file.Add {
 			idx, err = addToCommit(idx, *file.Path, *file.FileName, *file.Content)
@@ -180,43 +303,117 @@ func (p *Git2go) CommitFiles(app, message string, files interface{}) error {
 
 		if err != nil {
 			log.Error("Error in adding or deleting file to commit", log.Fields{"err": err, "idx": idx})
-			return err
+			return false, err
 		}
 	}
 	//commit the files to the branch
 	treeId, err := idx.WriteTree()
 	if err != nil {
 		log.Error("Error from idx.WriteTree()", log.Fields{"err": err})
-		return err
+		return false, err
 	}
 
 	err = idx.Write()
 	if err != nil {
 		log.Error("Error in Deleting file from idx.Write()", log.Fields{"err": err})
-		return err
+		return false, err
 	}
 
 	tree, err := repo.LookupTree(treeId)
 	if err != nil {
 		log.Error("Error in looking up tree", log.Fields{"err": err, "treeId": treeId})
-		return err
+		return false, err
 	}
 
 	commitTarget, err := repo.LookupCommit(branch.Target())
 	if err != nil {
 		log.Error("Error in Looking up Commit for commit", log.Fields{"err": err})
+		return false, err
+	}
+
+	// Nothing to commit, e.g. the files were already committed
+	if commitTarget.TreeId().Equal(treeId) {
+		return false, nil
+	}
+
+	err = p.createCommit(repo, "refs/heads/"+targetBranch, signature, message, tree, commitTarget)
+	if err != nil {
+		log.Error("Error in creating a commit", log.Fields{"err": err, "branchName": targetBranch})
+		return false, err
+	}
+
+	//push branch to origin remote
+	if err := p.pushFastForward(repo, targetBranch); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// resetToRemote fetches the branch of the remote and resets the local branch,
+// and the files, to it. The local commits that weren't pushed are dropped.
+func (p *Git2go) resetToRemote(repo *git.Repository, branchName string) error {
+
+	remote, err := repo.Remotes.Lookup("origin")
+	if err != nil {
+		log.Error("Error in obtaining remote", log.Fields{"err": err, "branchName": branchName})
 		return err
 	}
+	refspec := "+refs/heads/" + branchName + ":refs/remotes/origin/" + branchName
+	if err := remote.Fetch([]string{refspec}, &git.FetchOptions{RemoteCallbacks: p.remoteCallbacks()}, ""); err != nil {
+		log.Error("Error in Fetching", log.Fields{"err": err, "branchName": branchName})
+		return err
+	}
+	remoteBranch, err := repo.References.Lookup("refs/remotes/origin/" + branchName)
+	if err != nil {
+		log.Error("Failed to find remote branch: ", log.Fields{"err": err, "branchName": branchName})
+		return err
+	}
+	commit, err := repo.LookupCommit(remoteBranch.Target())
+	if err != nil {
+		log.Error("Failed to find remote branch commit: ", log.Fields{"err": err, "branchName": branchName})
+		return err
+	}
+	_, err = repo.References.Create("refs/heads/"+branchName, commit.Id(), true, "reset: moving to origin/"+branchName)
+	if err != nil {
+		log.Error("Error in resetting the branch", log.Fields{"err": err, "branchName": branchName})
+		return err
+	}
+	// set head to point to the branch
+	err = repo.SetHead("refs/heads/" + branchName)
+	if err != nil {
+		log.Error("Error in settting the head", log.Fields{"err": err, "branchName": branchName})
+		return err
+	}
+	return repo.ResetToCommit(commit, git.ResetHard, &git.CheckoutOptions{Strategy: git.CheckoutForce})
+}
 
-	_, err = repo.CreateCommit("refs/heads/"+branchName, signature, signature, message, tree, commitTarget)
+// pushFastForward pushes the branch to the remote if it is a fast-forward of
+// the branch of the remote, and returns errPushRejected if it isn't
+func (p *Git2go) pushFastForward(repo *git.Repository, branchName string) error {
+
+	remote, err := repo.Remotes.Lookup("origin")
 	if err != nil {
-		log.Error("Error in creating a commit", log.Fields{"err": err, "branchName": branchName})
+		log.Error("Error in obtaining remote", log.Fields{"err": err, "branchName": branchName})
 		return err
 	}
 
-	//push branch to origin remote
-	err = p.PushBranch(repo, branchName)
+	cbs := p.remoteCallbacks()
+	cbs.PushUpdateReferenceCallback = func(refname, status string) error {
+		// The status is the reason the remote rejected the update
+		if status != "" {
+			return fmt.Errorf("%w: %s %s", errPushRejected, refname, status)
+		}
+		return nil
+	}
+	ref := "refs/heads/" + branchName
+	err = remote.Push([]string{ref + ":" + ref}, &git.PushOptions{RemoteCallbacks: cbs})
 	if err != nil {
+		// A git server that checks the fast-forward before the
+		// protocol reports it as an error
+		if !errors.Is(err, errPushRejected) && git.IsErrorCode(err, git.ErrorCodeNonFastForward) {
+			err = fmt.Errorf("%w: %v", errPushRejected, err)
+		}
+		log.Error("Error in Pushing the branch", log.Fields{"err": err, "branchName": branchName})
 		return err
 	}
 
@@ -227,19 +424,13 @@ func (p *Git2go) CommitFiles(app, message string, files interface{}) error {
 func (p *Git2go) PushBranch(repo *git.Repository, branchName string) error {
 	// push the branch to origin
 
-	userName := p.UserName
-	token := p.GitToken
-
 	remote, err := repo.Remotes.Lookup("origin")
 	if err != nil {
 		log.Error("Error in obtaining remote", log.Fields{"err": err, "branchName": branchName})
 		return err
 	}
-	cbs := &git.RemoteCallbacks{
-		CredentialsCallback: getCredCallBack(userName, token),
-	}
 
-	err = remote.Push([]string{"+refs/heads/" + branchName}, &git.PushOptions{RemoteCallbacks: *cbs})
+	err = remote.Push([]string{"+refs/heads/" + branchName}, &git.PushOptions{RemoteCallbacks: p.remoteCallbacks()})
 	if err != nil {
 		log.Error("Error in Pushing the branch", log.Fields{"err": err, "branchName": branchName})
 		return err
@@ -383,7 +574,8 @@ func (p *Git2go) GitPull(folderName, branchName string) error {
 			return err
 		}
 		// // clone the repo
-		_, err := git.Clone(url, folderName, &git.CloneOptions{CheckoutBranch: branchName, CheckoutOptions: git.CheckoutOptions{Strategy: git.CheckoutSafe}})
+		_, err := git.Clone(url, folderName, &git.CloneOptions{CheckoutBranch: branchName, CheckoutOptions: git.CheckoutOptions{Strategy: git.CheckoutSafe},
+			FetchOptions: git.FetchOptions{RemoteCallbacks: p.remoteCallbacks()}})
 		if err != nil {
 			log.Error("Error in cloning repo Git Pull", log.Fields{"err": err})
 			// remove the local folder
@@ -406,7 +598,7 @@ func (p *Git2go) GitPull(folderName, branchName string) error {
 	}
 
 	// Fetch changes from remote
-	if err := remote.Fetch([]string{}, nil, ""); err != nil {
+	if err := remote.Fetch([]string{}, &git.FetchOptions{RemoteCallbacks: p.remoteCallbacks()}, ""); err != nil {
 		log.Error("Error in Fetching", log.Fields{"err": err})
 		return err
 	}
@@ -679,15 +871,15 @@ func GetLatestCommit(path, branchName string) (*git.Oid, error) {
 }
 
 // function to push branch to remote origin
-func PushDeleteBranch(repo *git.Repository, branchName, userName, token string) error {
+func (p *Git2go) PushDeleteBranch(repo *git.Repository, branchName string) error {
 	// push the branch to origin
 	remote, err := repo.Remotes.Lookup("origin")
-
-	cbs := &git.RemoteCallbacks{
-		CredentialsCallback: getCredCallBack(userName, token),
+	if err != nil {
+		log.Error("Error in obtaining remote", log.Fields{"err": err, "branchName": branchName})
+		return err
 	}
 
-	err = remote.Push([]string{":refs/heads/" + branchName}, &git.PushOptions{RemoteCallbacks: *cbs})
+	err = remote.Push([]string{":refs/heads/" + branchName}, &git.PushOptions{RemoteCallbacks: p.remoteCallbacks()})
 	if err != nil {
 		log.Error("Error in Pushing the branch", log.Fields{"err": err, "branchName": branchName})
 		return err
@@ -696,6 +888,42 @@ func PushDeleteBranch(repo *git.Repository, branchName, userName, token string)
 	return nil
 }
 
+// signature returns the author and committer of the commits
+func (p *Git2go) signature() *git.Signature {
+	name, email := p.AuthorName, p.AuthorEmail
+	if name == "" {
+		name = p.UserName
+	}
+	if email == "" {
+		email = p.UserName + "@gmail.com"
+	}
+	return &git.Signature{Name: name, Email: email, When: time.Now()}
+}
+
+// createCommit creates a commit on the branch, signed if the signer is set
+func (p *Git2go) createCommit(repo *git.Repository, refName string, signature *git.Signature, message string, tree *git.Tree, parent *git.Commit) error {
+	if p.Signer == nil {
+		_, err := repo.CreateCommit(refName, signature, signature, message, tree, parent)
+		return err
+	}
+	buf, err := repo.CreateCommitBuffer(signature, signature, git.MessageEncodingUTF8, message, tree, parent)
+	if err != nil {
+		return err
+	}
+	sig, err := p.Signer.Sign(buf)
+	if err != nil {
+		log.Error("Error in signing the commit", log.Fields{"err": err})
+		return err
+	}
+	// git stores the GPG and SSH signatures in the same header
+	oid, err := repo.CreateCommitWithSignature(string(buf), sig, "gpgsig")
+	if err != nil {
+		return err
+	}
+	_, err = repo.References.Create(refName, oid, true, "commit: "+strings.SplitN(message, "\n", 2)[0])
+	return err
+}
+
 //function to merge branch to main
 func mergeToMain(repo *git.Repository, branchName string, signature *git.Signature) error {
 	// get reference for the target merge branch
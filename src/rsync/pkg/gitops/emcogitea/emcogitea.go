@@ -0,0 +1,373 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package emcogitea
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	gogitea "code.gitea.io/sdk/gitea"
+	"gitlab.com/project-emco/core/emco-base/src/monitor/pkg/apis/k8splugin/v1alpha1"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	maxrand = 0x7fffffffffffffff
+	// Attempts of a merge when the branch changed in between
+	maxMergeRetry = 3
+)
+
+// Gitea works with Gitea and Forgejo, that share the same API
+type Gitea struct {
+	Url          string
+	Branch       string
+	UserName     string
+	RepoName     string
+	GitToken     string
+	Cluster      string
+	MergeRequest bool
+	Client       *gogitea.Client
+}
+
+// CommitFile is a file added to, or deleted from, a commit
+type CommitFile struct {
+	Path string
+	// Content is nil for a file to delete
+	Content *string
+}
+
+/*
+	Function to create a Gitea provider
+	params : cluster, url, branch, user or organization, repo, token, mergeRequest to
+	         open a pull request instead of merging to the branch
+	return : Gitea, error
+*/
+func NewGitea(cluster, url, branch, user, repo, token string, mergeRequest bool) (*Gitea, error) {
+
+	// The version of the server isn't checked, to not query it for each client
+	client, err := gogitea.NewClient(url, gogitea.SetToken(token), gogitea.SetGiteaVersion(""))
+	if err != nil {
+		log.Error("Error getting gitea client", log.Fields{"err": err})
+		return nil, err
+	}
+	g := Gitea{
+		Url:          url,
+		Branch:       branch,
+		UserName:     user,
+		RepoName:     repo,
+		GitToken:     token,
+		Cluster:      cluster,
+		MergeRequest: mergeRequest,
+		Client:       client,
+	}
+	return &g, nil
+}
+
+func convertToCommitFile(ref interface{}) []CommitFile {
+	rf, ok := ref.([]CommitFile)
+	if !ok {
+		return []CommitFile{}
+	}
+	return rf
+}
+
+/*
+	Function to Add file to the commit
+	params : path , content, files (CommitFile array)
+	return : files (CommitFile array)
+*/
+func (p *Gitea) AddToCommit(path, content string, ref interface{}) interface{} {
+	return append(convertToCommitFile(ref), CommitFile{Path: path, Content: &content})
+}
+
+/*
+	Function to Delete file from the commit
+	params : path, files (CommitFile array)
+	return : files (CommitFile array)
+*/
+func (p *Gitea) DeleteToCommit(path string, ref interface{}) interface{} {
+	return append(convertToCommitFile(ref), CommitFile{Path: path})
+}
+
+// fileSHA returns the SHA of the file on the branch, empty if it doesn't exist
+func (p *Gitea) fileSHA(branch, path string) (string, error) {
+	c, resp, err := p.Client.GetContents(p.UserName, p.RepoName, branch, path)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return c.SHA, nil
+}
+
+// commitFile commits the change of a file to the branch
+func (p *Gitea) commitFile(branch, message string, f CommitFile) error {
+	sha, err := p.fileSHA(branch, f.Path)
+	if err != nil {
+		return err
+	}
+	opts := gogitea.FileOptions{Message: message, BranchName: branch}
+	switch {
+	case f.Content == nil && sha == "":
+		// Nothing to delete
+	case f.Content == nil:
+		_, err = p.Client.DeleteFile(p.UserName, p.RepoName, f.Path, gogitea.DeleteFileOptions{FileOptions: opts, SHA: sha})
+	case sha != "":
+		_, _, err = p.Client.UpdateFile(p.UserName, p.RepoName, f.Path, gogitea.UpdateFileOptions{FileOptions: opts, SHA: sha,
+			Content: base64.StdEncoding.EncodeToString([]byte(*f.Content))})
+	default:
+		_, _, err = p.Client.CreateFile(p.UserName, p.RepoName, f.Path, gogitea.CreateFileOptions{FileOptions: opts,
+			Content: base64.StdEncoding.EncodeToString([]byte(*f.Content))})
+	}
+	return err
+}
+
+/*
+	Function to commit multiple files to the gitea repo. The files are committed
+	to a new branch, that is merged to the branch in one step with a pull request,
+	or left open for review if configured.
+	params : app, Commit Message, files (CommitFile array)
+	return : nil/error
+*/
+func (p *Gitea) CommitFiles(app, commitMessage string, files interface{}) error {
+
+	cf := convertToCommitFile(files)
+	if len(cf) == 0 {
+		return nil
+	}
+	// The last change of a file wins
+	last := map[string]int{}
+	for i, f := range cf {
+		last[f.Path] = i
+	}
+
+	for n := 1; ; n++ {
+		ra := rand.New(rand.NewSource(time.Now().UnixNano()))
+		mergeBranch := fmt.Sprintf("%s-%v", app, ra.Int63n(maxrand))
+		if err := p.CreateBranch(mergeBranch, p.Branch); err != nil {
+			return err
+		}
+		for i, f := range cf {
+			if last[f.Path] != i {
+				continue
+			}
+			if err := p.commitFile(mergeBranch, commitMessage, f); err != nil {
+				log.Error("Error in commiting the files", log.Fields{"err": err, "mergeBranch": mergeBranch, "path": f.Path})
+				p.DeleteBranch(mergeBranch)
+				return err
+			}
+		}
+
+		pr, err := p.openPullRequest(mergeBranch, commitMessage)
+		if err != nil {
+			p.DeleteBranch(mergeBranch)
+			return err
+		}
+		if p.MergeRequest {
+			return nil
+		}
+
+		merged, _, err := p.Client.MergePullRequest(p.UserName, p.RepoName, pr.Index, gogitea.MergePullRequestOption{
+			Style:   gogitea.MergeStyleMerge,
+			Message: "merging " + mergeBranch + " to " + p.Branch,
+		})
+		p.DeleteBranch(mergeBranch)
+		if err == nil && merged {
+			return nil
+		}
+		if n < maxMergeRetry {
+			// The branch changed since the merge branch was created
+			log.Error("Merge Conflict, trying again!", log.Fields{"err": err})
+			continue
+		}
+		if err == nil {
+			err = fmt.Errorf("pull request %d of %s can't be merged", pr.Index, mergeBranch)
+		}
+		return err
+	}
+}
+
+// openPullRequest opens a pull request of the branch to the target branch
+func (p *Gitea) openPullRequest(branch, title string) (*gogitea.PullRequest, error) {
+	pr, _, err := p.Client.CreatePullRequest(p.UserName, p.RepoName, gogitea.CreatePullRequestOption{
+		Head:  branch,
+		Base:  p.Branch,
+		Title: title,
+	})
+	if err != nil {
+		log.Error("Error in opening the pull request", log.Fields{"err": err, "mergeBranch": branch})
+		return nil, err
+	}
+	if p.MergeRequest {
+		log.Info("Pull request opened", log.Fields{"pullRequest": pr.HTMLURL})
+	}
+	return pr, nil
+}
+
+// OpenMergeRequest opens a pull request of the branch to the target branch
+func (p *Gitea) OpenMergeRequest(ctx context.Context, branch, title string) error {
+	_, err := p.openPullRequest(branch, title)
+	return err
+}
+
+/*
+	Function to create a branch
+	params : branch, branch it is created from
+	return : error
+*/
+func (p *Gitea) CreateBranch(branch, from string) error {
+	_, _, err := p.Client.CreateBranch(p.UserName, p.RepoName, gogitea.CreateBranchOption{
+		BranchName:    branch,
+		OldBranchName: from,
+	})
+	if err != nil {
+		log.Error("Error in creating the branch", log.Fields{"err": err, "branch": branch})
+		return err
+	}
+	log.Info("Branch Created", log.Fields{"branch": branch})
+	return nil
+}
+
+/*
+	Function to delete a branch
+	params : branch
+	return : error
+*/
+func (p *Gitea) DeleteBranch(branch string) error {
+	_, _, err := p.Client.DeleteRepoBranch(p.UserName, p.RepoName, branch)
+	if err != nil {
+		log.Error("Error in deleting the branch", log.Fields{"err": err, "branch": branch})
+		return err
+	}
+	log.Info("Branch Deleted", log.Fields{"branch": branch})
+	return nil
+}
+
+/*
+	Function to get the files of a path
+	params : branch, path
+	return : contents of the files, error
+*/
+func (p *Gitea) GetFiles(branch, path string) ([]string, error) {
+	entries, _, err := p.Client.ListContents(p.UserName, p.RepoName, branch, path)
+	if err != nil {
+		return nil, err
+	}
+	contents := []string{}
+	for _, e := range entries {
+		if e.Type != "file" {
+			continue
+		}
+		b, _, err := p.Client.GetFile(p.UserName, p.RepoName, branch, e.Path)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, string(b))
+	}
+	return contents, nil
+}
+
+func (p *Gitea) ClusterWatcher(ctx context.Context, cid, app, cluster string, waitTime int) error {
+
+	// create branch for status
+	branch := cluster + "-" + cid + "-" + app
+	if _, resp, err := p.Client.GetRepoBranch(p.UserName, p.RepoName, branch); err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return err
+		}
+		if err := p.CreateBranch(branch, p.Branch); err != nil {
+			return err
+		}
+	}
+
+	// Start thread to sync monitor CR
+	go func() error {
+		// This function is executed asynchronously, so we must create
+		// a new (not derived) context to prevent the context from
+		// being cancelled when the caller completes: a cancelled
+		// context will cause the below work to exit early.  A link is
+		// used so that the traces can be associated.
+		tracer := otel.Tracer("rsync")
+		ctx, span := tracer.Start(context.Background(), "StartClusterWatcher",
+			trace.WithLinks(trace.LinkFromContext(ctx)),
+		)
+		defer span.End()
+
+		var lastCommitSHA string
+		for {
+			select {
+			case <-time.After(time.Duration(waitTime) * time.Second):
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				// Check if AppContext doesn't exist then exit the thread
+				if _, err := utils.NewAppContextReference(ctx, cid); err != nil {
+					// Delete the Status CR updated by Monitor running on the cluster
+					log.Info("Deleting cluster StatusCR", log.Fields{})
+					p.DeleteClusterStatusCR(cid, app, cluster)
+					// AppContext deleted - Exit thread
+					return nil
+				}
+				path := "clusters/" + cluster + "/" + "status" + "/" + cid + "/app/" + app + "/"
+
+				// The status branch only changes with the status of the monitor
+				b, _, err := p.Client.GetRepoBranch(p.UserName, p.RepoName, branch)
+				if err != nil || b.Commit == nil {
+					log.Error("Error in obtaining latest commit SHA", log.Fields{"err": err})
+					continue
+				}
+				if lastCommitSHA == b.Commit.ID {
+					continue
+				}
+				log.Debug("New Status File, pulling files", log.Fields{"LatestSHA": b.Commit.ID, "LastSHA": lastCommitSHA})
+				c, err := p.GetFiles(branch, path)
+				if err != nil {
+					log.Debug("Status file not available", log.Fields{"error": err, "cluster": p.Cluster, "resource": path})
+					continue
+				}
+				if len(c) > 0 {
+					// Only one file expected in the location
+					content := &v1alpha1.ResourceBundleState{}
+					_, err := utils.DecodeYAMLData(c[0], content)
+					if err != nil {
+						log.Error("", log.Fields{"error": err, "cluster": p.Cluster, "resource": path})
+						return err
+					}
+					status.HandleResourcesStatus(ctx, cid, app, p.Cluster, content)
+				}
+				lastCommitSHA = b.Commit.ID
+
+			// Check if the context is canceled
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *Gitea) DeleteClusterStatusCR(cid, app, cluster string) error {
+
+	//Delete the CR from context folder
+	var ref interface{}
+	path := "clusters/" + cluster + "/context/" + cid + "/app/" + app + "/" + cid + "-" + app + ".yaml"
+	files := p.DeleteToCommit(path, ref)
+	err := p.CommitFiles(app, "Deleting status CR files "+path, files)
+	if err != nil {
+		log.Error("Error in commiting files to Delete", log.Fields{"path": path})
+	}
+
+	// Delete the status branch
+	return p.DeleteBranch(cluster + "-" + cid + "-" + app)
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package emcogitea
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeGitea serves the API calls of a commit, with the existing files of the
+// branch, and records the calls
+func fakeGitea(t *testing.T, existing map[string]bool, calls *[]string) *httptest.Server {
+	const repo = "/api/v1/repos/org/repo"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, repo)
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/contents/"):
+			if !existing[strings.TrimPrefix(path, "/contents/")] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"sha":"1234","type":"file"}`))
+			return
+		case r.Method == http.MethodPost && path == "/branches":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case strings.HasPrefix(path, "/contents/"):
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && path == "/pulls":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number":7,"html_url":"http://gitea/pulls/7"}`))
+		case r.Method == http.MethodPost && path == "/pulls/7/merge":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "/branches/"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		*calls = append(*calls, r.Method+" "+strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0])
+	}))
+}
+
+func TestCommitFiles(t *testing.T) {
+	existing := map[string]bool{"clusters/c1/a.yaml": true, "clusters/c1/old.yaml": true}
+	testCases := []struct {
+		label        string
+		mergeRequest bool
+		expected     []string
+	}{
+		{
+			label: "Merge to the branch",
+			expected: []string{"POST branches", "PUT contents", "POST contents", "DELETE contents",
+				"POST pulls", "POST pulls", "DELETE branches"},
+		},
+		{
+			label:        "Open a pull request",
+			mergeRequest: true,
+			expected:     []string{"POST branches", "PUT contents", "POST contents", "DELETE contents", "POST pulls"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			var calls []string
+			server := fakeGitea(t, existing, &calls)
+			defer server.Close()
+
+			p, err := NewGitea("p1+c1", server.URL, "main", "org", "repo", "token", testCase.mergeRequest)
+			if err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+			var files interface{}
+			files = p.AddToCommit("clusters/c1/a.yaml", "a", files)
+			files = p.AddToCommit("clusters/c1/b.yaml", "b", files)
+			files = p.DeleteToCommit("clusters/c1/old.yaml", files)
+			files = p.DeleteToCommit("clusters/c1/missing.yaml", files)
+			if err := p.CommitFiles("app1", "Commit for app1", files); err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+			if strings.Join(calls, ",") != strings.Join(testCase.expected, ",") {
+				t.Errorf("Expected the calls %v, got %v", testCase.expected, calls)
+			}
+		})
+	}
+}
@@ -6,7 +6,6 @@ package emcogithub
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"strings"
 	"time"
 
@@ -16,6 +15,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/monitor/pkg/apis/k8splugin/v1alpha1"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/gitcommit"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
 	"go.opentelemetry.io/otel"
@@ -24,7 +24,6 @@ import (
 
 const (
 	githubDomain = "github.com"
-	maxrand      = 0x7fffffffffffffff
 )
 
 type GithubClient struct {
@@ -40,6 +39,11 @@ type Github struct {
 	GitToken string
 	Cluster  string
 	Client   interface{}
+	// Signer signs the commits if set, with the AuthorName and AuthorEmail
+	// of the commits, that default to the user
+	Signer      *gitcommit.Signer
+	AuthorName  string
+	AuthorEmail string
 }
 
 func NewGithub(cluster, url, branch, user, repo, token string) (*Github, error) {
@@ -130,74 +134,107 @@ func CreateRepo(ctx context.Context, c interface{}, repoName string, userName st
 }
 
 /*
-	Function to commit multiple files to the github repo
-	params : context, github client, User Name, Repo Name, BranchName, Commit Message, files ([]gitprovider.CommitFile)
+	Function to commit multiple files to the github repo in a single commit.
+	The commit is created with the git data API on the head of the branch,
+	and the branch is fast-forwarded to it. If the branch moved in between,
+	the commit is made again on its new head.
+	params : app, Commit Message, files ([]gitprovider.CommitFile)
 	return : nil/error
 */
 func (p *Github) CommitFiles(app, commitMessage string, files interface{}) error {
 
 	// obtain client
 	client := convertToClient(p.Client)
+	cf := convertToCommitFile(files)
+	if len(cf) == 0 {
+		return nil
+	}
 
-	n := 0
-	var ctx context.Context
-	ctx = context.Background()
-	for {
-		// obtain the sha key for main
-		//obtain sha
-		latestSHA, err := GetLatestCommitSHA(ctx, client, p.UserName, p.RepoName, p.Branch, "")
+	ctx := context.Background()
+	for n := 1; ; n++ {
+		ref, _, err := client.gogithubClient.Git.GetRef(ctx, p.UserName, p.RepoName, "refs/heads/"+p.Branch)
 		if err != nil {
 			return err
 		}
-		//create a new branch from main
-		ra := rand.New(rand.NewSource(time.Now().UnixNano()))
-		rn := ra.Int63n(maxrand)
-		id := fmt.Sprintf("%v", rn)
-
-		mergeBranch := app + "-" + id
-		err = CreateBranch(ctx, client, latestSHA, p.UserName, p.RepoName, mergeBranch)
-
-		// defer deletion of the created branch
-		defer DeleteBranch(ctx, client, p.UserName, p.RepoName, mergeBranch)
-
+		parent, _, err := client.gogithubClient.Git.GetCommit(ctx, p.UserName, p.RepoName, ref.GetObject().GetSHA())
 		if err != nil {
 			return err
 		}
 
-		// commit the files to this new branch
-		// create repo reference
-		userRepoRef := getRepoRef(p.UserName, p.RepoName)
-
-		userRepo, err := client.gitProviderClient.UserRepositories().Get(ctx, userRepoRef)
+		entries := []*gogithub.TreeEntry{}
+		for _, f := range cf {
+			// An entry without content and SHA deletes the file
+			entries = append(entries, &gogithub.TreeEntry{
+				Path:    f.Path,
+				Mode:    gogithub.String("100644"),
+				Type:    gogithub.String("blob"),
+				Content: f.Content,
+			})
+		}
+		tree, _, err := client.gogithubClient.Git.CreateTree(ctx, p.UserName, p.RepoName, parent.GetTree().GetSHA(), entries)
 		if err != nil {
+			log.Error("Error in creating the tree", log.Fields{"err": err})
 			return err
 		}
 
-		//Commit file to this repo
-		resp, err := userRepo.Commits().Create(ctx, mergeBranch, commitMessage, convertToCommitFile(files))
+		author := p.commitAuthor()
+		commit := &gogithub.Commit{
+			Message:   gogithub.String(commitMessage),
+			Tree:      &gogithub.Tree{SHA: tree.SHA},
+			Parents:   []*gogithub.Commit{{SHA: parent.SHA}},
+			Author:    author,
+			Committer: author,
+		}
+		if p.Signer != nil {
+			sig, err := p.Signer.Sign([]byte(commitPayload(tree.GetSHA(), parent.GetSHA(), author, commitMessage)))
+			if err != nil {
+				log.Error("Error in signing the commit", log.Fields{"err": err})
+				return err
+			}
+			commit.Verification = &gogithub.SignatureVerification{Signature: gogithub.String(sig)}
+		}
+		commit, _, err = client.gogithubClient.Git.CreateCommit(ctx, p.UserName, p.RepoName, commit)
 		if err != nil {
-			log.Error("Error in commiting the files", log.Fields{"err": err, "mergeBranch": mergeBranch, "commitMessage": commitMessage, "files": files})
+			log.Error("Error in commiting the files", log.Fields{"err": err, "commitMessage": commitMessage})
 			return err
 		}
-		log.Debug("CommitResponse for userRepo:", log.Fields{"resp": resp})
-
-		// merge the branch to the main
-		err = mergeBranchToMain(ctx, client, p.UserName, p.RepoName, p.Branch, mergeBranch)
 
+		_, resp, err := client.gogithubClient.Git.UpdateRef(ctx, p.UserName, p.RepoName, &gogithub.Reference{
+			Ref:    gogithub.String("refs/heads/" + p.Branch),
+			Object: &gogithub.GitObject{SHA: commit.SHA},
+		}, false)
 		if err != nil {
-			// check error for merge conflict "409 Merge conflict"
-			if strings.Contains(err.Error(), "409 Merge conflict") && n < 3 {
-				// Merge conflict flag
-				n++
-				log.Error("Merge Conflict, trying again!", log.Fields{"err": err})
+			// The branch moved since it was read, it isn't a fast-forward
+			if resp != nil && resp.StatusCode == 422 && n < gitcommit.CommitRetries {
+				log.Info("Branch updated, trying again!", log.Fields{"err": err, "attempt": n})
+				time.Sleep(gitcommit.RetryDelay(n))
 				continue
-			} else {
-				return err
 			}
+			return err
 		}
 		return nil
 	}
-	return nil
+}
+
+// commitAuthor returns the author and committer of the commits
+func (p *Github) commitAuthor() *gogithub.CommitAuthor {
+	name, email := p.AuthorName, p.AuthorEmail
+	if name == "" {
+		name = p.UserName
+	}
+	if email == "" {
+		email = p.UserName + "@users.noreply.github.com"
+	}
+	// The date is signed with the commit, in UTC as GitHub stores it
+	now := time.Now().UTC().Truncate(time.Second)
+	return &gogithub.CommitAuthor{Name: gogithub.String(name), Email: gogithub.String(email), Date: &now}
+}
+
+// commitPayload returns the commit object that is signed, as GitHub creates
+// it from the commit
+func commitPayload(tree, parent string, author *gogithub.CommitAuthor, message string) string {
+	person := fmt.Sprintf("%s <%s> %d %s", author.GetName(), author.GetEmail(), author.GetDate().Unix(), author.GetDate().Format("-0700"))
+	return "tree " + tree + "\nparent " + parent + "\nauthor " + person + "\ncommitter " + person + "\n\n" + message
 }
 
 /*
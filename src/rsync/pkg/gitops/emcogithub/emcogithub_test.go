@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package emcogithub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v41/github"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/gitcommit"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+type createCommitRequest struct {
+	Message   string                 `json:"message"`
+	Tree      string                 `json:"tree"`
+	Parents   []string               `json:"parents"`
+	Author    *gogithub.CommitAuthor `json:"author"`
+	Signature string                 `json:"signature"`
+}
+
+// fakeGithub serves the git data API calls of a commit, rejecting the first
+// updates of the branch
+func fakeGithub(t *testing.T, rejects int, trees *[]map[string]interface{}, commits *[]createCommitRequest, refs *[]map[string]interface{}) *httptest.Server {
+	const repo = "/repos/user1/repo1/git"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == repo+"/ref/heads/main":
+			w.Write([]byte(`{"ref":"refs/heads/main","object":{"sha":"parent1"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == repo+"/commits/parent1":
+			w.Write([]byte(`{"sha":"parent1","tree":{"sha":"tree0"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == repo+"/trees":
+			var tree map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&tree)
+			*trees = append(*trees, tree)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"sha":"tree1"}`))
+		case r.Method == http.MethodPost && r.URL.Path == repo+"/commits":
+			var c createCommitRequest
+			json.NewDecoder(r.Body).Decode(&c)
+			*commits = append(*commits, c)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"sha":"commit1"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == repo+"/refs/heads/main":
+			var ref map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&ref)
+			*refs = append(*refs, ref)
+			if len(*refs) <= rejects {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"message":"Update is not a fast forward"}`))
+				return
+			}
+			w.Write([]byte(`{"ref":"refs/heads/main","object":{"sha":"commit1"}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCommitSignedFiles(t *testing.T) {
+	e, err := openpgp.NewEntity("emco", "", "emco@example.com", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	var key bytes.Buffer
+	w, _ := armor.Encode(&key, openpgp.PrivateKeyType, nil)
+	e.SerializePrivate(w, nil)
+	w.Close()
+	signer, err := gitcommit.NewSigner(gitcommit.SigningFormatGPG, key.String(), "")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	var trees, refs []map[string]interface{}
+	var commits []createCommitRequest
+	server := fakeGithub(t, 0, &trees, &commits, &refs)
+	defer server.Close()
+
+	client := gogithub.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	p := Github{
+		Branch:      "main",
+		UserName:    "user1",
+		RepoName:    "repo1",
+		Client:      GithubClient{gogithubClient: client},
+		Signer:      signer,
+		AuthorName:  "emco",
+		AuthorEmail: "emco@example.com",
+	}
+	var files interface{}
+	files = p.AddToCommit("clusters/c1/a.yaml", "a", files)
+	files = p.DeleteToCommit("clusters/c1/old.yaml", files)
+	message := "Commit for app1\n\nEMCO-App: app1\n"
+	if err := p.CommitFiles("app1", message, files); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	if len(trees) != 1 || trees[0]["base_tree"] != "tree0" {
+		t.Fatalf("Expected a tree from tree0, got %+v", trees)
+	}
+	entries := trees[0]["tree"].([]interface{})
+	if len(entries) != 2 || entries[0].(map[string]interface{})["content"] != "a" || entries[1].(map[string]interface{})["sha"] != nil {
+		t.Errorf("Expected to add a.yaml and delete old.yaml, got %+v", entries)
+	}
+
+	if len(commits) != 1 {
+		t.Fatalf("Expected a single commit, got %d", len(commits))
+	}
+	c := commits[0]
+	if c.Tree != "tree1" || len(c.Parents) != 1 || c.Parents[0] != "parent1" || c.Message != message {
+		t.Fatalf("Unexpected commit %+v", c)
+	}
+	date := c.Author.GetDate()
+	person := fmt.Sprintf("emco <emco@example.com> %d %s", date.Unix(), date.Format("-0700"))
+	payload := "tree tree1\nparent parent1\nauthor " + person + "\ncommitter " + person + "\n\n" + message
+	if _, err := openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{e}, strings.NewReader(payload), strings.NewReader(c.Signature)); err != nil {
+		t.Errorf("Invalid signature of the commit %s", err)
+	}
+	if date.Format("-0700") != "+0000" {
+		t.Errorf("Expected a date in UTC, got %s", date)
+	}
+
+	if len(refs) != 1 || refs[0]["sha"] != "commit1" || refs[0]["force"] != false {
+		t.Errorf("Expected to fast-forward main to commit1, got %+v", refs)
+	}
+}
+
+func TestCommitFilesConflict(t *testing.T) {
+	var trees, refs []map[string]interface{}
+	var commits []createCommitRequest
+	server := fakeGithub(t, 1, &trees, &commits, &refs)
+	defer server.Close()
+
+	client := gogithub.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	p := Github{
+		Branch:   "main",
+		UserName: "user1",
+		RepoName: "repo1",
+		Client:   GithubClient{gogithubClient: client},
+	}
+	files := p.AddToCommit("clusters/c1/a.yaml", "a", nil)
+	if err := p.CommitFiles("app1", "Commit for app1\n", files); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	// The commit is made again on the head of the branch
+	if len(trees) != 2 || len(commits) != 2 || len(refs) != 2 {
+		t.Fatalf("Expected the commit to be made twice, got %d trees, %d commits and %d updates of the branch", len(trees), len(commits), len(refs))
+	}
+	if commits[1].Signature != "" || commits[1].Author.GetName() != "user1" {
+		t.Errorf("Expected an unsigned commit of user1, got %+v", commits[1])
+	}
+	// Nothing to commit
+	if err := p.CommitFiles("app1", "Commit for app1\n", nil); err != nil || len(commits) != 2 {
+		t.Errorf("Expected no commit without files, got %v", err)
+	}
+}
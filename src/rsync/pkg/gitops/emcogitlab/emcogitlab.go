@@ -0,0 +1,390 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package emcogitlab
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+	"gitlab.com/project-emco/core/emco-base/src/monitor/pkg/apis/k8splugin/v1alpha1"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	maxrand = 0x7fffffffffffffff
+	// Attempts of a commit when the branch changed in between
+	maxCommitRetry = 3
+)
+
+type Gitlab struct {
+	Url          string
+	Branch       string
+	UserName     string
+	RepoName     string
+	GitToken     string
+	Cluster      string
+	MergeRequest bool
+	Client       *gogitlab.Client
+}
+
+// CommitFile is a file added to, or deleted from, a commit
+type CommitFile struct {
+	Path string
+	// Content is nil for a file to delete
+	Content *string
+}
+
+/*
+	Function to create a Gitlab provider
+	params : cluster, API url, branch, user or group, repo, token, mergeRequest to open
+	         a merge request instead of committing to the branch
+	return : Gitlab, error
+*/
+func NewGitlab(cluster, url, branch, user, repo, token string, mergeRequest bool) (*Gitlab, error) {
+
+	client, err := gogitlab.NewClient(token, gogitlab.WithBaseURL(url))
+	if err != nil {
+		log.Error("Error getting gitlab client", log.Fields{"err": err})
+		return nil, err
+	}
+	g := Gitlab{
+		Url:          url,
+		Branch:       branch,
+		UserName:     user,
+		RepoName:     repo,
+		GitToken:     token,
+		Cluster:      cluster,
+		MergeRequest: mergeRequest,
+		Client:       client,
+	}
+	return &g, nil
+}
+
+// project returns the path of the project, that the API accepts as its ID
+func (p *Gitlab) project() string {
+	return p.UserName + "/" + p.RepoName
+}
+
+func convertToCommitFile(ref interface{}) []CommitFile {
+	rf, ok := ref.([]CommitFile)
+	if !ok {
+		return []CommitFile{}
+	}
+	return rf
+}
+
+/*
+	Function to Add file to the commit
+	params : path , content, files (CommitFile array)
+	return : files (CommitFile array)
+*/
+func (p *Gitlab) AddToCommit(path, content string, ref interface{}) interface{} {
+	return append(convertToCommitFile(ref), CommitFile{Path: path, Content: &content})
+}
+
+/*
+	Function to Delete file from the commit
+	params : path, files (CommitFile array)
+	return : files (CommitFile array)
+*/
+func (p *Gitlab) DeleteToCommit(path string, ref interface{}) interface{} {
+	return append(convertToCommitFile(ref), CommitFile{Path: path})
+}
+
+// fileExists checks if the file exists on the branch
+func (p *Gitlab) fileExists(ctx context.Context, branch, path string) (bool, error) {
+	_, resp, err := p.Client.RepositoryFiles.GetFileMetaData(p.project(), path, &gogitlab.GetFileMetaDataOptions{Ref: gogitlab.String(branch)}, gogitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+/*
+	Function to build the actions of a commit from the files, as the API
+	needs to know if each file is created or updated
+	params : context, branch the commit is based on, files
+	return : commit actions, error
+*/
+func (p *Gitlab) commitActions(ctx context.Context, branch string, files []CommitFile) ([]*gogitlab.CommitActionOptions, error) {
+	// The last change of a file wins
+	last := map[string]int{}
+	for i, f := range files {
+		last[f.Path] = i
+	}
+	actions := []*gogitlab.CommitActionOptions{}
+	for i, f := range files {
+		if last[f.Path] != i {
+			continue
+		}
+		exists, err := p.fileExists(ctx, branch, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		var action gogitlab.FileActionValue
+		switch {
+		case f.Content == nil && !exists:
+			// Nothing to delete
+			continue
+		case f.Content == nil:
+			action = gogitlab.FileDelete
+		case exists:
+			action = gogitlab.FileUpdate
+		default:
+			action = gogitlab.FileCreate
+		}
+		actions = append(actions, &gogitlab.CommitActionOptions{
+			Action:   gogitlab.FileAction(action),
+			FilePath: gogitlab.String(f.Path),
+			Content:  f.Content,
+		})
+	}
+	return actions, nil
+}
+
+/*
+	Function to commit multiple files to the gitlab repo in a single commit, or
+	to open a merge request with the files if configured
+	params : app, Commit Message, files (CommitFile array)
+	return : nil/error
+*/
+func (p *Gitlab) CommitFiles(app, commitMessage string, files interface{}) error {
+
+	ctx := context.Background()
+	for n := 1; ; n++ {
+		actions, err := p.commitActions(ctx, p.Branch, convertToCommitFile(files))
+		if err != nil {
+			return err
+		}
+		if len(actions) == 0 {
+			return nil
+		}
+		opts := &gogitlab.CreateCommitOptions{
+			Branch:        gogitlab.String(p.Branch),
+			CommitMessage: gogitlab.String(commitMessage),
+			Actions:       actions,
+		}
+		if p.MergeRequest {
+			// Commit to a new branch, created from the target branch
+			ra := rand.New(rand.NewSource(time.Now().UnixNano()))
+			opts.Branch = gogitlab.String(fmt.Sprintf("%s-%v", app, ra.Int63n(maxrand)))
+			opts.StartBranch = gogitlab.String(p.Branch)
+		}
+		commit, resp, err := p.Client.Commits.CreateCommit(p.project(), opts, gogitlab.WithContext(ctx))
+		if err != nil {
+			// The files changed since the actions were built, build them again
+			if resp != nil && resp.StatusCode == http.StatusBadRequest && n < maxCommitRetry {
+				log.Error("Commit conflict, trying again!", log.Fields{"err": err})
+				continue
+			}
+			log.Error("Error in commiting the files", log.Fields{"err": err, "branch": *opts.Branch, "commitMessage": commitMessage})
+			return err
+		}
+		log.Debug("Commit created", log.Fields{"commit": commit.ID, "branch": *opts.Branch})
+		if !p.MergeRequest {
+			return nil
+		}
+		return p.OpenMergeRequest(ctx, *opts.Branch, commitMessage)
+	}
+}
+
+// OpenMergeRequest opens a merge request of the branch to the target
+// branch, that removes the branch once merged
+func (p *Gitlab) OpenMergeRequest(ctx context.Context, branch, title string) error {
+	mr, _, err := p.Client.MergeRequests.CreateMergeRequest(p.project(), &gogitlab.CreateMergeRequestOptions{
+		Title:              gogitlab.String(title),
+		SourceBranch:       gogitlab.String(branch),
+		TargetBranch:       gogitlab.String(p.Branch),
+		RemoveSourceBranch: gogitlab.Bool(true),
+	}, gogitlab.WithContext(ctx))
+	if err != nil {
+		log.Error("Error in opening the merge request", log.Fields{"err": err, "branch": branch})
+		return err
+	}
+	log.Info("Merge request opened", log.Fields{"mergeRequest": mr.WebURL})
+	return nil
+}
+
+/*
+	Function to create a branch
+	params : context, branch, ref the branch is created from
+	return : error
+*/
+func (p *Gitlab) CreateBranch(ctx context.Context, branch, ref string) error {
+	_, _, err := p.Client.Branches.CreateBranch(p.project(), &gogitlab.CreateBranchOptions{
+		Branch: gogitlab.String(branch),
+		Ref:    gogitlab.String(ref),
+	}, gogitlab.WithContext(ctx))
+	if err != nil {
+		log.Error("Error in creating the branch", log.Fields{"err": err, "branch": branch})
+		return err
+	}
+	log.Info("Branch Created", log.Fields{"branch": branch})
+	return nil
+}
+
+/*
+	Function to delete a branch
+	params : context, branch
+	return : error
+*/
+func (p *Gitlab) DeleteBranch(ctx context.Context, branch string) error {
+	_, err := p.Client.Branches.DeleteBranch(p.project(), branch, gogitlab.WithContext(ctx))
+	if err != nil {
+		log.Error("Error in deleting the branch", log.Fields{"err": err, "branch": branch})
+		return err
+	}
+	log.Info("Branch Deleted", log.Fields{"branch": branch})
+	return nil
+}
+
+/*
+	Function to obtain the SHA of the latest commit of a path
+	params : context, branch, path
+	return : LatestCommit string, error
+*/
+func (p *Gitlab) GetLatestCommitSHA(ctx context.Context, branch, path string) (string, error) {
+	commits, _, err := p.Client.Commits.ListCommits(p.project(), &gogitlab.ListCommitsOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: 1, Page: 1},
+		RefName:     gogitlab.String(branch),
+		Path:        gogitlab.String(path),
+	}, gogitlab.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	if len(commits) == 0 {
+		return "", nil
+	}
+	return commits[0].ID, nil
+}
+
+/*
+	Function to get the files of a path
+	params : context, branch, path
+	return : contents of the files, error
+*/
+func (p *Gitlab) GetFiles(ctx context.Context, branch, path string) ([]string, error) {
+	nodes, _, err := p.Client.Repositories.ListTree(p.project(), &gogitlab.ListTreeOptions{
+		Path: gogitlab.String(path),
+		Ref:  gogitlab.String(branch),
+	}, gogitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	contents := []string{}
+	for _, n := range nodes {
+		if n.Type != "blob" {
+			continue
+		}
+		b, _, err := p.Client.RepositoryFiles.GetRawFile(p.project(), n.Path, &gogitlab.GetRawFileOptions{Ref: gogitlab.String(branch)}, gogitlab.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, string(b))
+	}
+	return contents, nil
+}
+
+func (p *Gitlab) ClusterWatcher(ctx context.Context, cid, app, cluster string, waitTime int) error {
+
+	// create branch for status
+	branch := cluster + "-" + cid + "-" + app
+	if err := p.CreateBranch(ctx, branch, p.Branch); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return err
+		}
+	}
+
+	// Start thread to sync monitor CR
+	go func() error {
+		// This function is executed asynchronously, so we must create
+		// a new (not derived) context to prevent the context from
+		// being cancelled when the caller completes: a cancelled
+		// context will cause the below work to exit early.  A link is
+		// used so that the traces can be associated.
+		tracer := otel.Tracer("rsync")
+		ctx, span := tracer.Start(context.Background(), "StartClusterWatcher",
+			trace.WithLinks(trace.LinkFromContext(ctx)),
+		)
+		defer span.End()
+
+		var lastCommitSHA string
+		for {
+			select {
+			case <-time.After(time.Duration(waitTime) * time.Second):
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				// Check if AppContext doesn't exist then exit the thread
+				if _, err := utils.NewAppContextReference(ctx, cid); err != nil {
+					// Delete the Status CR updated by Monitor running on the cluster
+					log.Info("Deleting cluster StatusCR", log.Fields{})
+					p.DeleteClusterStatusCR(ctx, cid, app, cluster)
+					// AppContext deleted - Exit thread
+					return nil
+				}
+				path := "clusters/" + cluster + "/" + "status" + "/" + cid + "/app/" + app + "/"
+
+				latestCommitSHA, err := p.GetLatestCommitSHA(ctx, branch, path)
+				if err != nil {
+					log.Error("Error in obtaining latest commit SHA", log.Fields{"err": err})
+					continue
+				}
+				if lastCommitSHA == latestCommitSHA {
+					continue
+				}
+				log.Debug("New Status File, pulling files", log.Fields{"LatestSHA": latestCommitSHA, "LastSHA": lastCommitSHA})
+				c, err := p.GetFiles(ctx, branch, path)
+				if err != nil {
+					log.Debug("Status file not available", log.Fields{"error": err, "cluster": p.Cluster, "resource": path})
+					continue
+				}
+				if len(c) > 0 {
+					// Only one file expected in the location
+					content := &v1alpha1.ResourceBundleState{}
+					_, err := utils.DecodeYAMLData(c[0], content)
+					if err != nil {
+						log.Error("", log.Fields{"error": err, "cluster": p.Cluster, "resource": path})
+						return err
+					}
+					status.HandleResourcesStatus(ctx, cid, app, p.Cluster, content)
+				}
+				lastCommitSHA = latestCommitSHA
+
+			// Check if the context is canceled
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *Gitlab) DeleteClusterStatusCR(ctx context.Context, cid, app, cluster string) error {
+
+	//Delete the CR from context folder
+	var ref interface{}
+	path := "clusters/" + cluster + "/context/" + cid + "/app/" + app + "/" + cid + "-" + app + ".yaml"
+	files := p.DeleteToCommit(path, ref)
+	err := p.CommitFiles(app, "Deleting status CR files "+path, files)
+	if err != nil {
+		log.Error("Error in commiting files to Delete", log.Fields{"path": path})
+	}
+
+	// Delete the status branch
+	return p.DeleteBranch(ctx, cluster+"-"+cid+"-"+app)
+}
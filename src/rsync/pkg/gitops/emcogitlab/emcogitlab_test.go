@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package emcogitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type commitRequest struct {
+	Branch      string `json:"branch"`
+	StartBranch string `json:"start_branch"`
+	Actions     []struct {
+		Action   string `json:"action"`
+		FilePath string `json:"file_path"`
+	} `json:"actions"`
+}
+
+// fakeGitlab serves the API calls of a commit, with the existing files of
+// the branch
+func fakeGitlab(t *testing.T, existing map[string]bool, commits *[]commitRequest, mrs *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case r.Method == http.MethodGet && path == "/api/v4/":
+			// The client reads the rate limit of the server
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodHead && strings.Contains(path, "/repository/files/"):
+			f := path[strings.Index(path, "/repository/files/")+len("/repository/files/"):]
+			if !existing[f] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/repository/commits"):
+			var c commitRequest
+			if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+				t.Errorf("Invalid commit request %s", err)
+			}
+			*commits = append(*commits, c)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"abc"}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(path, "/merge_requests"):
+			var mr map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&mr); err != nil {
+				t.Errorf("Invalid merge request %s", err)
+			}
+			*mrs = append(*mrs, mr)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"iid":1,"web_url":"http://gitlab/mr/1"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestCommitFiles(t *testing.T) {
+	existing := map[string]bool{"clusters/c1/a.yaml": true, "clusters/c1/old.yaml": true}
+	testCases := []struct {
+		label        string
+		mergeRequest bool
+	}{
+		{label: "Commit to the branch"},
+		{label: "Open a merge request", mergeRequest: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			var commits []commitRequest
+			var mrs []map[string]interface{}
+			server := fakeGitlab(t, existing, &commits, &mrs)
+			defer server.Close()
+
+			p, err := NewGitlab("p1+c1", server.URL, "main", "group", "repo", "token", testCase.mergeRequest)
+			if err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+			var files interface{}
+			files = p.AddToCommit("clusters/c1/a.yaml", "a", files)
+			files = p.AddToCommit("clusters/c1/b.yaml", "b", files)
+			files = p.DeleteToCommit("clusters/c1/old.yaml", files)
+			files = p.DeleteToCommit("clusters/c1/missing.yaml", files)
+			if err := p.CommitFiles("app1", "Commit for app1", files); err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+
+			if len(commits) != 1 {
+				t.Fatalf("Expected a single commit, got %d", len(commits))
+			}
+			c := commits[0]
+			expected := map[string]string{"clusters/c1/a.yaml": "update", "clusters/c1/b.yaml": "create", "clusters/c1/old.yaml": "delete"}
+			if len(c.Actions) != len(expected) {
+				t.Fatalf("Expected %d actions, got %+v", len(expected), c.Actions)
+			}
+			for _, a := range c.Actions {
+				if expected[a.FilePath] != a.Action {
+					t.Errorf("Expected action %s for %s, got %s", expected[a.FilePath], a.FilePath, a.Action)
+				}
+			}
+
+			if !testCase.mergeRequest {
+				if c.Branch != "main" || len(mrs) != 0 {
+					t.Errorf("Expected a commit to main without merge request, got branch %s and %d merge requests", c.Branch, len(mrs))
+				}
+				return
+			}
+			if c.StartBranch != "main" || !strings.HasPrefix(c.Branch, "app1-") {
+				t.Errorf("Expected a commit to a new branch from main, got branch %s from %s", c.Branch, c.StartBranch)
+			}
+			if len(mrs) != 1 || mrs[0]["source_branch"] != c.Branch || mrs[0]["target_branch"] != "main" {
+				t.Errorf("Expected a merge request of %s to main, got %+v", c.Branch, mrs)
+			}
+		})
+	}
+}
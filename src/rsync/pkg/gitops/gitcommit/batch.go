@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package gitcommit
+
+/*
+batch.go groups the commits to the same repository and branch. The clusters
+of an AppContext that share a repository commit at about the same time; the
+first commit of a batch waits for the others during a window, and the commits
+requested while a batch is pushed go into the next batch, so that there is
+one push for all of them instead of one per app and cluster.
+*/
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/metrics"
+)
+
+// Number of times a commit is rebased and pushed again when the branch
+// moved since it was read
+const CommitRetries = 5
+
+// Change is the addition of a file to a commit, or its deletion
+type Change struct {
+	Path    string
+	Content string
+	Delete  bool
+}
+
+// CommitFunc commits the changes of a batch in a single commit, with the
+// message
+type CommitFunc func(changes []Change, message string) error
+
+// Batcher groups the commits by key, e.g. the repository and branch
+type Batcher struct {
+	window  time.Duration
+	mutex   sync.Mutex
+	batches map[string][]*request
+}
+
+type request struct {
+	subject    string
+	changes    []Change
+	provenance Provenance
+	commit     CommitFunc
+	done       chan error
+}
+
+/*
+	Function to create a Batcher
+	params : time the first commit of a batch waits for the others
+	return : Batcher
+*/
+func NewBatcher(window time.Duration) *Batcher {
+	return &Batcher{window: window, batches: map[string][]*request{}}
+}
+
+/*
+	Function to commit changes in the batch of the key. It returns once the
+	batch is committed, with the error of the batch, or once the context is
+	done. The changes are then removed from the batch unless it's already
+	being committed. The key must identify the repository, the branch, and
+	everything the commit function uses, e.g. the credentials, as the batch
+	is committed with the function of its first commit.
+	params : ctx, key, subject of the commit, changes, provenance of the
+	         changes, function committing the batch if this commit is the
+	         first one
+	return : error
+*/
+func (b *Batcher) Commit(ctx context.Context, key, subject string, changes []Change, provenance Provenance, commit CommitFunc) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	r := &request{subject: subject, changes: changes, provenance: provenance, commit: commit, done: make(chan error, 1)}
+	metrics.GitOpsCommitRequestsCounter.Inc()
+
+	b.mutex.Lock()
+	reqs, running := b.batches[key]
+	b.batches[key] = append(reqs, r)
+	if !running {
+		go b.run(key)
+	}
+	b.mutex.Unlock()
+
+	select {
+	case err := <-r.done:
+		return err
+	case <-ctx.Done():
+		b.cancel(key, r)
+		return ctx.Err()
+	}
+}
+
+// cancel removes the request from the batch of the key if the batch isn't
+// committed yet
+func (b *Batcher) cancel(key string, r *request) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	reqs := b.batches[key]
+	for i := range reqs {
+		if reqs[i] == r {
+			b.batches[key] = append(reqs[:i:i], reqs[i+1:]...)
+			return
+		}
+	}
+}
+
+// run commits the batches of the key, until there are no more commits
+func (b *Batcher) run(key string) {
+	for {
+		time.Sleep(b.window)
+		b.mutex.Lock()
+		reqs := b.batches[key]
+		if len(reqs) == 0 {
+			delete(b.batches, key)
+			b.mutex.Unlock()
+			return
+		}
+		b.batches[key] = []*request{}
+		b.mutex.Unlock()
+
+		err := commitBatch(reqs)
+		for _, r := range reqs {
+			r.done <- err
+		}
+	}
+}
+
+// commitBatch commits the changes of all the requests, with the commit
+// function of the first one
+func commitBatch(reqs []*request) error {
+	subject := reqs[0].subject
+	if len(reqs) > 1 {
+		subject = fmt.Sprintf("Commit of %d batched changes", len(reqs))
+	}
+	ps := make([]Provenance, 0, len(reqs))
+	var changes []Change
+	for _, r := range reqs {
+		ps = append(ps, r.provenance)
+		changes = append(changes, r.changes...)
+	}
+	metrics.GitOpsCommitsCounter.Inc()
+	return reqs[0].commit(Merge(changes), Message(subject, ps...))
+}
+
+/*
+	Function to merge the changes of a commit, the last change of a file wins
+	params : changes
+	return : changes, in the order of the first change of each file
+*/
+func Merge(changes []Change) []Change {
+	index := map[string]int{}
+	merged := []Change{}
+	for _, c := range changes {
+		if i, ok := index[c.Path]; ok {
+			merged[i] = c
+			continue
+		}
+		index[c.Path] = len(merged)
+		merged = append(merged, c)
+	}
+	return merged
+}
+
+/*
+	Function to get the delay before the attempt of a commit after a
+	conflict, with a jitter so that the rsync instances don't push again at
+	the same time
+	params : number of the attempt, from 1
+	return : delay
+*/
+func RetryDelay(attempt int) time.Duration {
+	d := time.Duration(100<<uint(attempt)) * time.Millisecond
+	return d + time.Duration(rand.Int63n(int64(d)))
+}
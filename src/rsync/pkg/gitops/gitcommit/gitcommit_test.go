@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package gitcommit
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+const payload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor a <a@b> 0 +0000\ncommitter a <a@b> 0 +0000\n\nmessage\n"
+
+func TestSignGPG(t *testing.T) {
+	e, err := openpgp.NewEntity("emco", "", "emco@example.com", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	var key bytes.Buffer
+	w, _ := armor.Encode(&key, openpgp.PrivateKeyType, nil)
+	if err := e.SerializePrivate(w, nil); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	w.Close()
+
+	s, err := NewSigner("", key.String(), "")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	sig, err := s.Sign([]byte(payload))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if _, err := openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{e}, strings.NewReader(payload), strings.NewReader(sig)); err != nil {
+		t.Errorf("Invalid signature %s", err)
+	}
+}
+
+func TestSignSSH(t *testing.T) {
+	priv, _ := rsa.GenerateKey(rand.Reader, 2048)
+	pub, _ := ssh.NewPublicKey(priv.Public())
+	key := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)})
+
+	s, err := NewSigner("ssh", string(key), "")
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	armored, err := s.Sign([]byte(payload))
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if !strings.HasPrefix(armored, "-----BEGIN SSH SIGNATURE-----\n") || !strings.HasSuffix(armored, "-----END SSH SIGNATURE-----\n") {
+		t.Fatalf("Invalid armored signature %s", armored)
+	}
+
+	b64 := strings.TrimSuffix(strings.TrimPrefix(armored, "-----BEGIN SSH SIGNATURE-----\n"), "-----END SSH SIGNATURE-----\n")
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(b64, "\n", ""))
+	if err != nil || !bytes.HasPrefix(blob, []byte("SSHSIG")) {
+		t.Fatalf("Invalid signature blob %s", err)
+	}
+	var sig struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	if err := ssh.Unmarshal(blob[6:], &sig); err != nil {
+		t.Fatalf("Invalid signature blob %s", err)
+	}
+	if sig.Version != 1 || sig.Namespace != "git" || sig.HashAlgorithm != "sha512" || !bytes.Equal(sig.PublicKey, pub.Marshal()) {
+		t.Fatalf("Unexpected signature %+v", sig)
+	}
+	var sshSig ssh.Signature
+	if err := ssh.Unmarshal(sig.Signature, &sshSig); err != nil || sshSig.Format != ssh.SigAlgoRSASHA2512 {
+		t.Fatalf("Invalid signature %s %+v", err, sshSig)
+	}
+	h := sha512.Sum512([]byte(payload))
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{"git", "", "sha512", h[:]})...)
+	if err := pub.Verify(signed, &sshSig); err != nil {
+		t.Errorf("Invalid signature %s", err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	testCases := []struct {
+		label      string
+		provenance Provenance
+		expected   string
+	}{
+		{
+			label: "All trailers",
+			provenance: Provenance{Project: "proj1", CompositeApp: "ca1", CompositeAppVersion: "v1", DeploymentIntentGroup: "dig1",
+				AppContextID: "1234", Revision: 2, App: "app1", Cluster: "p1+c1"},
+			expected: "Commit for app1\n\nEMCO-Project: proj1\nEMCO-Composite-App: ca1\nEMCO-Composite-App-Version: v1\n" +
+				"EMCO-Deployment-Intent-Group: dig1\nEMCO-AppContext-ID: 1234\nEMCO-App: app1\nEMCO-Cluster: p1+c1\nEMCO-Revision: 2\n",
+		},
+		{
+			label:      "Unknown revision",
+			provenance: Provenance{AppContextID: "1234", App: "app1"},
+			expected:   "Commit for app1\n\nEMCO-AppContext-ID: 1234\nEMCO-App: app1\n",
+		},
+		{
+			label:    "No provenance",
+			expected: "Commit for app1\n",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			if m := Message("Commit for app1", testCase.provenance); m != testCase.expected {
+				t.Errorf("Expected the message %q, got %q", testCase.expected, m)
+			}
+		})
+	}
+}
+
+func TestMessageBatch(t *testing.T) {
+	ps := []Provenance{
+		{Project: "proj1", AppContextID: "1234", App: "app1", Cluster: "p1+c1", Revision: 2},
+		{Project: "proj1", AppContextID: "1234", App: "app1", Cluster: "p1+c2", Revision: 2},
+		{Project: "proj1", AppContextID: "1234", App: "app2", Cluster: "p1+c1", Revision: 2},
+	}
+	expected := "Commit of 3 batched changes\n\nEMCO-Project: proj1\nEMCO-AppContext-ID: 1234\nEMCO-App: app1\nEMCO-App: app2\n" +
+		"EMCO-Cluster: p1+c1\nEMCO-Cluster: p1+c2\nEMCO-Revision: 2\n"
+	if m := Message("Commit of 3 batched changes", ps...); m != expected {
+		t.Errorf("Expected the message %q, got %q", expected, m)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	changes := Merge([]Change{
+		{Path: "a.yaml", Content: "a1"},
+		{Path: "b.yaml", Content: "b1"},
+		{Path: "a.yaml", Delete: true},
+		{Path: "c.yaml", Content: "c1"},
+		{Path: "b.yaml", Content: "b2"},
+	})
+	expected := []Change{{Path: "a.yaml", Delete: true}, {Path: "b.yaml", Content: "b2"}, {Path: "c.yaml", Content: "c1"}}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected, changes)
+		}
+	}
+}
+
+func TestBatcher(t *testing.T) {
+	b := NewBatcher(200 * time.Millisecond)
+	var mutex sync.Mutex
+	commits := map[string][]string{}
+	commit := func(key string) CommitFunc {
+		return func(changes []Change, message string) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			commits[key] = append(commits[key], message)
+			if len(changes) != 10 {
+				t.Errorf("Expected 10 changes in the commit, got %d", len(changes))
+			}
+			return nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"repo1", "repo2"} {
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(key string, i int) {
+				defer wg.Done()
+				changes := []Change{{Path: fmt.Sprintf("clusters/c%d/a.yaml", i), Content: "a"}}
+				if err := b.Commit(context.Background(), key, "Commit", changes, Provenance{Cluster: fmt.Sprintf("c%d", i)}, commit(key)); err != nil {
+					t.Errorf("Unexpected error %s", err)
+				}
+			}(key, i)
+		}
+	}
+	wg.Wait()
+
+	for _, key := range []string{"repo1", "repo2"} {
+		if len(commits[key]) != 1 {
+			t.Fatalf("Expected a single commit to %s, got %d", key, len(commits[key]))
+		}
+		if !strings.HasPrefix(commits[key][0], "Commit of 10 batched changes\n\n") || strings.Count(commits[key][0], "EMCO-Cluster: ") != 10 {
+			t.Errorf("Unexpected message %q", commits[key][0])
+		}
+	}
+}
+
+func TestBatcherError(t *testing.T) {
+	b := NewBatcher(0)
+	err := b.Commit(context.Background(), "repo1", "Commit", []Change{{Path: "a.yaml"}}, Provenance{}, func(changes []Change, message string) error {
+		return fmt.Errorf("push failed")
+	})
+	if err == nil || err.Error() != "push failed" {
+		t.Errorf("Expected the error of the commit, got %v", err)
+	}
+	// Nothing to commit
+	if err := b.Commit(context.Background(), "repo1", "Commit", nil, Provenance{}, nil); err != nil {
+		t.Errorf("Unexpected error %s", err)
+	}
+}
+
+func TestBatcherCancel(t *testing.T) {
+	b := NewBatcher(200 * time.Millisecond)
+	committed := make(chan []Change, 1)
+	commit := func(changes []Change, message string) error {
+		committed <- changes
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Commit(context.Background(), "repo1", "Commit", []Change{{Path: "a.yaml"}}, Provenance{}, commit)
+	}()
+	err := b.Commit(ctx, "repo1", "Commit", []Change{{Path: "b.yaml"}}, Provenance{}, commit)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected the commit canceled, got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	// The canceled changes aren't committed
+	if changes := <-committed; len(changes) != 1 || changes[0].Path != "a.yaml" {
+		t.Errorf("Expected the changes of a.yaml committed, got %+v", changes)
+	}
+}
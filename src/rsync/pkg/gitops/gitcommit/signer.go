@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package gitcommit
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Formats of the commit signatures
+const (
+	SigningFormatGPG = "gpg"
+	SigningFormatSSH = "ssh"
+)
+
+// The namespace of the SSH signatures of git
+const sshSigNamespace = "git"
+
+// Signer signs the commits with a GPG or SSH key, like git with the
+// gpg.format "openpgp" or "ssh"
+type Signer struct {
+	format string
+	entity *openpgp.Entity
+	ssh    ssh.Signer
+}
+
+/*
+	Function to create a signer
+	params : format (gpg or ssh, gpg if empty), armored GPG or PEM encoded
+	         SSH private key, passphrase of the key if it is encrypted
+	return : Signer, error
+*/
+func NewSigner(format, key, passphrase string) (*Signer, error) {
+	switch strings.ToLower(format) {
+	case "", SigningFormatGPG:
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "Invalid GPG signing key")
+		}
+		for _, e := range entities {
+			if e.PrivateKey == nil {
+				continue
+			}
+			if e.PrivateKey.Encrypted {
+				if err := e.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+					return nil, pkgerrors.Wrap(err, "Invalid passphrase of the GPG signing key")
+				}
+			}
+			for _, sk := range e.Subkeys {
+				if sk.PrivateKey != nil && sk.PrivateKey.Encrypted {
+					if err := sk.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+						return nil, pkgerrors.Wrap(err, "Invalid passphrase of the GPG signing key")
+					}
+				}
+			}
+			return &Signer{format: SigningFormatGPG, entity: e}, nil
+		}
+		return nil, pkgerrors.New("No private key in the GPG signing key")
+	case SigningFormatSSH:
+		var s ssh.Signer
+		var err error
+		if passphrase != "" {
+			s, err = ssh.ParsePrivateKeyWithPassphrase([]byte(key), []byte(passphrase))
+		} else {
+			s, err = ssh.ParsePrivateKey([]byte(key))
+		}
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "Invalid SSH signing key")
+		}
+		return &Signer{format: SigningFormatSSH, ssh: s}, nil
+	}
+	return nil, pkgerrors.Errorf("Invalid signing format: %s", format)
+}
+
+// Entity returns the GPG key of the signer, nil for an SSH key
+func (s *Signer) Entity() *openpgp.Entity {
+	return s.entity
+}
+
+/*
+	Function to sign a commit
+	params : the commit object, without signature
+	return : the armored signature, error
+*/
+func (s *Signer) Sign(payload []byte) (string, error) {
+	if s.format == SigningFormatSSH {
+		return s.signSSH(payload)
+	}
+	var b bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&b, s.entity, bytes.NewReader(payload), nil); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// signSSH signs the payload in the SSH signature format of OpenSSH, see
+// PROTOCOL.sshsig
+func (s *Signer) signSSH(payload []byte) (string, error) {
+	h := sha512.Sum512(payload)
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{sshSigNamespace, "", "sha512", h[:]})...)
+
+	var sig *ssh.Signature
+	var err error
+	if as, ok := s.ssh.(ssh.AlgorithmSigner); ok && s.ssh.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// The SHA-1 signatures of RSA keys aren't accepted
+		sig, err = as.SignWithAlgorithm(rand.Reader, signed, ssh.SigAlgoRSASHA2512)
+	} else {
+		sig, err = s.ssh.Sign(rand.Reader, signed)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, s.ssh.PublicKey().Marshal(), sshSigNamespace, "", "sha512", ssh.Marshal(sig)})...)
+
+	enc := base64.StdEncoding.EncodeToString(blob)
+	var b strings.Builder
+	b.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(enc) > 70 {
+		b.WriteString(enc[:70] + "\n")
+		enc = enc[70:]
+	}
+	b.WriteString(enc + "\n-----END SSH SIGNATURE-----\n")
+	return b.String(), nil
+}
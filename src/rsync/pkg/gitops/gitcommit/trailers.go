@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package gitcommit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Provenance identifies the EMCO operation a commit is made for
+type Provenance struct {
+	Project               string
+	CompositeApp          string
+	CompositeAppVersion   string
+	DeploymentIntentGroup string
+	AppContextID          string
+	// Revision of the DeploymentIntentGroup, 0 if unknown
+	Revision int64
+	App      string
+	Cluster  string
+}
+
+/*
+	Function to get the message of a commit with its provenance as git
+	trailers, e.g. "EMCO-Project: proj1". A commit of several operations
+	repeats the trailers whose values differ.
+	params : subject of the commit, provenances
+	return : message
+*/
+func Message(subject string, ps ...Provenance) string {
+	keys := []string{"EMCO-Project", "EMCO-Composite-App", "EMCO-Composite-App-Version",
+		"EMCO-Deployment-Intent-Group", "EMCO-AppContext-ID", "EMCO-App", "EMCO-Cluster", "EMCO-Revision"}
+	values := map[string][]string{}
+	seen := map[string]bool{}
+	add := func(key, value string) {
+		if value == "" || seen[key+": "+value] {
+			return
+		}
+		seen[key+": "+value] = true
+		values[key] = append(values[key], value)
+	}
+	for _, p := range ps {
+		add("EMCO-Project", p.Project)
+		add("EMCO-Composite-App", p.CompositeApp)
+		add("EMCO-Composite-App-Version", p.CompositeAppVersion)
+		add("EMCO-Deployment-Intent-Group", p.DeploymentIntentGroup)
+		add("EMCO-AppContext-ID", p.AppContextID)
+		add("EMCO-App", p.App)
+		add("EMCO-Cluster", p.Cluster)
+		if p.Revision > 0 {
+			add("EMCO-Revision", strconv.FormatInt(p.Revision, 10))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimSpace(subject))
+	sep := "\n\n"
+	for _, k := range keys {
+		for _, v := range values[k] {
+			b.WriteString(sep + k + ": " + v)
+			sep = "\n"
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package gitsupport
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/emcogit2go"
+)
+
+// mergeRequester opens the merge requests with the API of the git server
+type mergeRequester interface {
+	GitInterfaceProvider
+	OpenMergeRequest(ctx context.Context, branch, title string) error
+}
+
+// gitWithAPI commits with git, e.g. over SSH, to a GitLab or Gitea server,
+// and uses the API of the server, if it has a token, for the merge requests
+// and the status of the cluster
+type gitWithAPI struct {
+	git          *emcogit2go.Git2go
+	api          mergeRequester
+	mergeRequest bool
+}
+
+func (p *gitWithAPI) AddToCommit(fileName, content string, ref interface{}) interface{} {
+	return p.git.AddToCommit(fileName, content, ref)
+}
+
+func (p *gitWithAPI) DeleteToCommit(fileName string, ref interface{}) interface{} {
+	return p.git.DeleteToCommit(fileName, ref)
+}
+
+// CommitFiles commits the files to the branch, or to a new branch with a
+// merge request to the branch
+func (p *gitWithAPI) CommitFiles(app, message string, files interface{}) error {
+	if !p.mergeRequest {
+		return p.git.CommitFiles(app, message, files)
+	}
+	ra := rand.New(rand.NewSource(time.Now().UnixNano()))
+	branch := fmt.Sprintf("%s-%v", app, ra.Int63())
+	committed, err := p.git.CommitFilesToBranch(branch, message, files)
+	if err != nil || !committed {
+		return err
+	}
+	return p.api.OpenMergeRequest(context.Background(), branch, message)
+}
+
+func (p *gitWithAPI) ClusterWatcher(ctx context.Context, cid, app, cluster string, waitTime int) error {
+	if p.api != nil {
+		return p.api.ClusterWatcher(ctx, cid, app, cluster, waitTime)
+	}
+	return p.git.ClusterWatcher(ctx, cid, app, cluster, waitTime)
+}
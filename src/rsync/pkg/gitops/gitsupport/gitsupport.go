@@ -5,16 +5,23 @@ package gitsupport
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/config"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 
 	pkgerrors "github.com/pkg/errors"
 
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/db"
 	emcogit2go "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/emcogit2go"
+	emcogitea "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/emcogitea"
 	emcogithub "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/emcogithub"
+	emcogitlab "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/emcogitlab"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/gitcommit"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
 )
 
@@ -30,7 +37,26 @@ type GitProvider struct {
 	Branch    string
 	RepoName  string
 	Url       string
-
+	// ApiUrl is the url of the API of the GitLab or Gitea server
+	ApiUrl string
+	// MergeRequest opens a merge request instead of committing to the branch
+	MergeRequest bool
+	// SSHKey, with its SSHPassphrase and SSHKnownHosts, authenticates the
+	// git operations instead of the GitToken. The host key is only ignored
+	// without SSHKnownHosts if SSHInsecureIgnoreHostKey is set.
+	SSHKey                   string
+	SSHPassphrase            string
+	SSHKnownHosts            string
+	SSHInsecureIgnoreHostKey bool
+	// Signer signs the commits with the SigningKey, if set, and the commits
+	// are authored by CommitAuthorName and CommitAuthorEmail
+	Signer            *gitcommit.Signer
+	CommitAuthorName  string
+	CommitAuthorEmail string
+
+	// identity is the hash of the credentials, the signing key and the
+	// author, the commits are only batched with the ones of the same identity
+	identity     string
 	gitInterface GitInterfaceProvider
 }
 
@@ -65,7 +91,9 @@ func NewGitProvider(ctx context.Context, cid, app, cluster, level, namespace str
 
 	kvRef := refObject.Spec.Kv
 
-	var gitType, gitToken, branch, userName, repoName, url string
+	var gitType, gitToken, branch, userName, repoName, url, apiUrl, mergeRequest string
+	var sshKey, sshPassphrase, sshKnownHosts, sshInsecureIgnoreHostKey string
+	var signingKey, signingFormat, signingPassphrase, commitAuthorName, commitAuthorEmail string
 
 	for _, kvpair := range kvRef {
 		log.Info("kvpair", log.Fields{"kvpair": kvpair})
@@ -99,11 +127,90 @@ func NewGitProvider(ctx context.Context, cid, app, cluster, level, namespace str
 			url = fmt.Sprintf("%v", v)
 			continue
 		}
+		v, ok = kvpair["apiUrl"]
+		if ok {
+			apiUrl = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["mergeRequest"]
+		if ok {
+			mergeRequest = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["sshKey"]
+		if ok {
+			sshKey = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["sshPassphrase"]
+		if ok {
+			sshPassphrase = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["sshKnownHosts"]
+		if ok {
+			sshKnownHosts = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["sshInsecureIgnoreHostKey"]
+		if ok {
+			sshInsecureIgnoreHostKey = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["signingKey"]
+		if ok {
+			signingKey = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["signingFormat"]
+		if ok {
+			signingFormat = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["signingPassphrase"]
+		if ok {
+			signingPassphrase = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["commitAuthorName"]
+		if ok {
+			commitAuthorName = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["commitAuthorEmail"]
+		if ok {
+			commitAuthorEmail = fmt.Sprintf("%v", v)
+			continue
+		}
 	}
-	if len(gitToken) <= 0 || len(branch) <= 0 || len(userName) <= 0 || len(repoName) <= 0 || len(url) <= 0 {
-		log.Error("Missing information for Git", log.Fields{"token": gitToken, "branch": branch, "userName": userName, "repoName": repoName, "url": url})
+	// The API of GitHub needs the token. git, also used for GitLab and Gitea
+	// with an SSH key, accepts an SSH key instead, but the merge requests are
+	// opened with the API.
+	apiType := isAPIType(gitType)
+	isMergeRequest := strings.EqualFold(mergeRequest, "true")
+	if (len(gitToken) <= 0 && (strings.EqualFold(gitType, "github") || len(sshKey) <= 0 || isMergeRequest)) ||
+		len(branch) <= 0 || len(userName) <= 0 || len(repoName) <= 0 || len(url) <= 0 {
+		log.Error("Missing information for Git", log.Fields{"gitType": gitType, "branch": branch, "userName": userName, "repoName": repoName, "url": url})
 		return nil, pkgerrors.Errorf("Missing Information for Git")
 	}
+	if len(sshKey) > 0 && strings.EqualFold(gitType, "github") {
+		return nil, pkgerrors.Errorf("SSH key authentication isn't supported with gitType %s", gitType)
+	}
+	insecureHostKey := strings.EqualFold(sshInsecureIgnoreHostKey, "true")
+	if len(sshKey) > 0 && len(sshKnownHosts) <= 0 && !insecureHostKey {
+		return nil, pkgerrors.Errorf("SSH key authentication needs sshKnownHosts, or sshInsecureIgnoreHostKey to accept any host key")
+	}
+	if len(apiUrl) <= 0 {
+		apiUrl = url
+	}
+	var signer *gitcommit.Signer
+	if len(signingKey) > 0 {
+		signer, err = gitcommit.NewSigner(signingFormat, signingKey, signingPassphrase)
+		if err != nil {
+			log.Error("Invalid signing key for Git", log.Fields{"signingFormat": signingFormat, "error": err})
+			return nil, err
+		}
+	}
 
 	p := GitProvider{
 		Cid:       cid,
@@ -117,15 +224,93 @@ func NewGitProvider(ctx context.Context, cid, app, cluster, level, namespace str
 		UserName:  userName,
 		RepoName:  repoName,
 		Url:       url,
+		ApiUrl:    apiUrl,
+		// Any value other than true, e.g. false, commits to the branch
+		MergeRequest:             isMergeRequest,
+		SSHKey:                   sshKey,
+		SSHPassphrase:            sshPassphrase,
+		SSHKnownHosts:            sshKnownHosts,
+		SSHInsecureIgnoreHostKey: insecureHostKey,
+		Signer:                   signer,
+		CommitAuthorName:         commitAuthorName,
+		CommitAuthorEmail:        commitAuthorEmail,
+	}
+	p.identity = fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join([]string{gitToken, apiUrl, mergeRequest, sshKey, sshPassphrase,
+		sshKnownHosts, sshInsecureIgnoreHostKey, signingFormat, signingKey, signingPassphrase, commitAuthorName, commitAuthorEmail}, "\x00"))))
+
+	switch {
+	case strings.EqualFold(gitType, "github"):
+		var g *emcogithub.Github
+		g, err = emcogithub.NewGithub(p.Cluster, p.Url, p.Branch, p.UserName, p.RepoName, p.GitToken)
+		if err == nil {
+			g.Signer, g.AuthorName, g.AuthorEmail = p.Signer, p.CommitAuthorName, p.CommitAuthorEmail
+		}
+		p.gitInterface = g
+	case apiType && (len(p.SSHKey) > 0 || p.Signer != nil):
+		// GitLab or Gitea, with git over SSH or signing the commits, as
+		// the commits made with their APIs are signed by the server
+		p.gitInterface, err = p.newGitWithAPI()
+	case strings.EqualFold(gitType, "gitlab"):
+		p.gitInterface, err = emcogitlab.NewGitlab(p.Cluster, p.ApiUrl, p.Branch, p.UserName, p.RepoName, p.GitToken, p.MergeRequest)
+	case strings.EqualFold(gitType, "gitea"), strings.EqualFold(gitType, "forgejo"):
+		p.gitInterface, err = emcogitea.NewGitea(p.Cluster, p.ApiUrl, p.Branch, p.UserName, p.RepoName, p.GitToken, p.MergeRequest)
+	default:
+		p.gitInterface, err = p.newGit2go()
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// newGit2go returns the git client of the repository
+func (p *GitProvider) newGit2go() (*emcogit2go.Git2go, error) {
+	var sshAuth *emcogit2go.SSHAuth
+	if len(p.SSHKey) > 0 {
+		sshAuth = &emcogit2go.SSHAuth{PrivateKey: p.SSHKey, Passphrase: p.SSHPassphrase, KnownHosts: p.SSHKnownHosts,
+			InsecureIgnoreHostKey: p.SSHInsecureIgnoreHostKey}
+	}
+	g, err := emcogit2go.NewGit2Go(p.Url, p.Branch, p.UserName, p.RepoName, p.GitToken, sshAuth)
+	if err != nil {
+		return nil, err
+	}
+	g.Signer, g.AuthorName, g.AuthorEmail = p.Signer, p.CommitAuthorName, p.CommitAuthorEmail
+	return g, nil
+}
 
-	if strings.EqualFold(gitType, "github") {
-		p.gitInterface, err = emcogithub.NewGithub(p.Cluster, p.Url, p.Branch, p.UserName, p.RepoName, p.GitToken)
+// newGitWithAPI returns the client of a GitLab or Gitea repository committing
+// with git, over SSH or HTTPS with the token, and using the API of the server
+// if the provider has a token
+func (p *GitProvider) newGitWithAPI() (*gitWithAPI, error) {
+	g, err := p.newGit2go()
+	if err != nil {
+		return nil, err
+	}
+	c := &gitWithAPI{git: g, mergeRequest: p.MergeRequest}
+	if len(p.GitToken) <= 0 {
+		return c, nil
+	}
+	if strings.EqualFold(p.GitType, "gitlab") {
+		c.api, err = emcogitlab.NewGitlab(p.Cluster, p.ApiUrl, p.Branch, p.UserName, p.RepoName, p.GitToken, p.MergeRequest)
 	} else {
-		p.gitInterface, err = emcogit2go.NewGit2Go(p.Url, p.Branch, p.UserName, p.RepoName, p.GitToken)
+		c.api, err = emcogitea.NewGitea(p.Cluster, p.ApiUrl, p.Branch, p.UserName, p.RepoName, p.GitToken, p.MergeRequest)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
 
-	return &p, nil
+// isAPIType tells if the git type uses the API of the git server, rather
+// than git
+func isAPIType(gitType string) bool {
+	for _, t := range []string{"github", "gitlab", "gitea", "forgejo"} {
+		if strings.EqualFold(gitType, t) {
+			return true
+		}
+	}
+	return false
 }
 
 /*
@@ -145,7 +330,7 @@ func (p *GitProvider) GetPath(t string) string {
 func (p *GitProvider) Create(name string, ref interface{}, content []byte) (interface{}, error) {
 
 	path := p.GetPath("context") + name + ".yaml"
-	files := p.gitInterface.AddToCommit(path, string(content), ref)
+	files := append(convertToChanges(ref), gitcommit.Change{Path: path, Content: string(content)})
 	return files, nil
 }
 
@@ -156,7 +341,7 @@ func (p *GitProvider) Create(name string, ref interface{}, content []byte) (inte
 */
 func (p *GitProvider) Apply(path string, ref interface{}, content []byte) (interface{}, error) {
 
-	files := p.gitInterface.AddToCommit(path, string(content), ref)
+	files := append(convertToChanges(ref), gitcommit.Change{Path: path, Content: string(content)})
 	return files, nil
 
 }
@@ -168,11 +353,23 @@ func (p *GitProvider) Apply(path string, ref interface{}, content []byte) (inter
 */
 func (p *GitProvider) Delete(path string, ref interface{}, content []byte) (interface{}, error) {
 
-	files := p.gitInterface.DeleteToCommit(path, ref)
+	files := append(convertToChanges(ref), gitcommit.Change{Path: path, Delete: true})
 	return files, nil
 
 }
 
+/*
+	Helper function to convert interface to []gitcommit.Change
+	params: files interface{}
+	return: []gitcommit.Change
+*/
+func convertToChanges(ref interface{}) []gitcommit.Change {
+	if changes, ok := ref.([]gitcommit.Change); ok {
+		return changes
+	}
+	return []gitcommit.Change{}
+}
+
 /*
 	Function to get resource from the cluster
 	params : name string, gvkRes []byte
@@ -183,15 +380,68 @@ func (p *GitProvider) Get(name string, gvkRes []byte) ([]byte, error) {
 	return []byte{}, nil
 }
 
+// batcher groups the commits to the same repository and branch
+var batcher *gitcommit.Batcher
+var batcherOnce sync.Once
+
+// getBatcher returns the batcher of the commits, with the window of the
+// configuration
+func getBatcher() *gitcommit.Batcher {
+	batcherOnce.Do(func() {
+		window := time.Duration(config.GetConfiguration().GitOpsCommitWindow) * time.Millisecond
+		batcher = gitcommit.NewBatcher(window)
+	})
+	return batcher
+}
+
 /*
-	Function to commit resources to the cluster
+	Function to commit resources to the cluster. The files are committed with
+	the ones of the other apps and clusters using the same repository and
+	branch, with the same credentials, signing key and author, at the same
+	time.
 	params : ctx context.Context, ref interface{}
 	return : error
 */
 func (p *GitProvider) Commit(ctx context.Context, ref interface{}) error {
 
-	err := p.gitInterface.CommitFiles(p.App, "Commit for "+p.GetPath("context"), ref)
-	return err
+	key := strings.Join([]string{strings.ToLower(p.GitType), p.Url, p.UserName, p.RepoName, p.Branch, p.identity}, " ")
+	return getBatcher().Commit(ctx, key, "Commit for "+p.GetPath("context"), convertToChanges(ref), p.provenance(ctx), p.commitChanges)
+}
+
+// commitChanges commits the changes with the git interface of the provider
+func (p *GitProvider) commitChanges(changes []gitcommit.Change, message string) error {
+	var files interface{}
+	for _, c := range changes {
+		if c.Delete {
+			files = p.gitInterface.DeleteToCommit(c.Path, files)
+		} else {
+			files = p.gitInterface.AddToCommit(c.Path, c.Content, files)
+		}
+	}
+	return p.gitInterface.CommitFiles(p.App, message, files)
+}
+
+// provenance identifies the EMCO operation of the commits, from the
+// metadata of the AppContext
+func (p *GitProvider) provenance(ctx context.Context) gitcommit.Provenance {
+	prov := gitcommit.Provenance{AppContextID: p.Cid, App: p.App, Cluster: p.Cluster}
+	acRef, err := utils.NewAppContextReference(ctx, p.Cid)
+	if err != nil {
+		log.Error("Error getting the AppContext for the commit provenance", log.Fields{"cid": p.Cid, "error": err})
+		return prov
+	}
+	ac := acRef.GetAppContextHandle()
+	meta, err := ac.GetCompositeAppMeta(ctx)
+	if err != nil {
+		log.Error("Error getting the metadata for the commit provenance", log.Fields{"cid": p.Cid, "error": err})
+		return prov
+	}
+	prov.Project = meta.Project
+	prov.CompositeApp = meta.CompositeApp
+	prov.CompositeAppVersion = meta.Version
+	prov.DeploymentIntentGroup = meta.DeploymentIntentGroup
+	prov.Revision = meta.Revision
+	return prov
 }
 
 /*
@@ -10,6 +10,7 @@ import (
 
 	con "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/context"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/grpc/installapp"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/lease"
 )
 
 type installappServer struct {
@@ -20,9 +21,18 @@ func (cs *installappServer) InstallApp(ctx context.Context, req *installapp.Inst
 	installAppReq, _ := json.Marshal(req)
 	log.Println("GRPC Server received installAppRequest: ", string(installAppReq))
 
+	// Forward the request to the replica owning the AppContext
+	conn, err := lease.Route(ctx, req.GetAppContext())
+	if err != nil {
+		return &installapp.InstallAppResponse{AppContextInstalled: false}, err
+	}
+	if conn != nil {
+		return installapp.NewInstallappClient(conn).InstallApp(lease.Forward(ctx), req)
+	}
+
 	// Try instantiate the comp app
 	instca := con.CompositeAppContext{}
-	err := instca.InstantiateComApp(ctx, req.GetAppContext())
+	err = instca.InstantiateComApp(ctx, req.GetAppContext())
 	if err != nil {
 		log.Println("Instantiation failed: " + err.Error())
 		err := instca.TerminateComApp(ctx, req.GetAppContext())
@@ -38,9 +48,17 @@ func (cs *installappServer) UninstallApp(ctx context.Context, req *installapp.Un
 	uninstallAppReq, _ := json.Marshal(req)
 	log.Println("GRPC Server received uninstallAppRequest: ", string(uninstallAppReq))
 
+	conn, err := lease.Route(ctx, req.GetAppContext())
+	if err != nil {
+		return &installapp.UninstallAppResponse{AppContextUninstalled: false}, err
+	}
+	if conn != nil {
+		return installapp.NewInstallappClient(conn).UninstallApp(lease.Forward(ctx), req)
+	}
+
 	// Try terminating the comp app here
 	instca := con.CompositeAppContext{}
-	err := instca.TerminateComApp(ctx, req.GetAppContext())
+	err = instca.TerminateComApp(ctx, req.GetAppContext())
 	if err != nil {
 		log.Println("Termination failed: " + err.Error())
 		return &installapp.UninstallAppResponse{AppContextUninstalled: false}, err
@@ -53,9 +71,17 @@ func (cs *installappServer) ReadAppContext(ctx context.Context, req *installapp.
 	readAppContext, _ := json.Marshal(req)
 	log.Println("GRPC Server received ReadAppContext: ", string(readAppContext))
 
+	conn, err := lease.Route(ctx, req.GetAppContext())
+	if err != nil {
+		return &installapp.ReadAppContextResponse{AppContextReadSuccessful: false, AppContextReadMessage: "AppContext read failed"}, err
+	}
+	if conn != nil {
+		return installapp.NewInstallappClient(conn).ReadAppContext(lease.Forward(ctx), req)
+	}
+
 	// Try instantiate the comp app
 	instca := con.CompositeAppContext{}
-	err := instca.ReadComApp(ctx, req.GetAppContext())
+	err = instca.ReadComApp(ctx, req.GetAppContext())
 	if err != nil {
 		log.Println("Termination failed: " + err.Error())
 		return &installapp.ReadAppContextResponse{AppContextReadSuccessful: false, AppContextReadMessage: "AppContext read failed"}, err
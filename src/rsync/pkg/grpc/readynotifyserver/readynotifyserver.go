@@ -5,10 +5,13 @@ package readynotifyserver
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	pb "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/grpc/readynotify"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/lease"
+	"google.golang.org/grpc"
 )
 
 // readyNotifyServer will be initialized by NewReadyNotifyServer() and
@@ -31,6 +34,15 @@ func (s *readyNotifyServer) Alert(topic *pb.Topic, stream pb.ReadyNotify_AlertSe
 	log.Info("[ReadyNotify gRPC] Received an Alert subscription request",
 		log.Fields{"client": client, "appContextID": appContextID})
 
+	// The notifications are sent by the replica owning the AppContext
+	conn, err := lease.Route(stream.Context(), appContextID)
+	if err != nil {
+		return err
+	}
+	if conn != nil {
+		return forwardAlert(topic, stream, conn)
+	}
+
 	// Adding the appContextID entry to the map
 	s.mutex.Lock()
 	if len(s.alertNotify[appContextID]) == 0 {
@@ -64,6 +76,27 @@ func (s *readyNotifyServer) Alert(topic *pb.Topic, stream pb.ReadyNotify_AlertSe
 	}
 }
 
+// forwardAlert relays the notifications of the replica owning the AppContext
+// until either the subscriber or the owner closes the stream
+func forwardAlert(topic *pb.Topic, stream pb.ReadyNotify_AlertServer, conn *grpc.ClientConn) error {
+	c, err := pb.NewReadyNotifyClient(conn).Alert(lease.Forward(stream.Context()), topic)
+	if err != nil {
+		return err
+	}
+	for {
+		n, err := c.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(n); err != nil {
+			return err
+		}
+	}
+}
+
 //SendAppContextNotification sends appcontext back to the subscriber if pending
 func SendAppContextNotification(appContextID, app, cluster string) error {
 	streams := notifServer.alertNotify[appContextID]
@@ -82,6 +115,14 @@ func SendAppContextNotification(appContextID, app, cluster string) error {
 
 // Unsubscribe will be called when the subscriber wants to terminate the stream
 func (s *readyNotifyServer) Unsubscribe(ctx context.Context, topic *pb.Topic) (*pb.UnsubscribeResponse, error) {
+	// The stream is held by the replica owning the AppContext
+	conn, err := lease.Route(ctx, topic.GetAppContext())
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		return pb.NewReadyNotifyClient(conn).Unsubscribe(lease.Forward(ctx), topic)
+	}
 
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -10,6 +10,7 @@ import (
 
 	con "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/context"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/grpc/updateapp"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/lease"
 )
 
 type updateappServer struct {
@@ -20,9 +21,19 @@ func (cs *updateappServer) UpdateApp(ctx context.Context, req *updateapp.UpdateA
 	updateAppReq, _ := json.Marshal(req)
 	log.Println("GRPC Server received UpdateAppRequest: ", string(updateAppReq))
 
+	// Forward the request to the replica owning the AppContext being
+	// updated, the updated AppContext shares its lease
+	conn, err := lease.Route(ctx, req.GetUpdateFromAppContext())
+	if err != nil {
+		return &updateapp.UpdateAppResponse{AppContextUpdated: false}, err
+	}
+	if conn != nil {
+		return updateapp.NewUpdateappClient(conn).UpdateApp(lease.Forward(ctx), req)
+	}
+
 	// Try updating the comp app
 	instca := con.CompositeAppContext{}
-	err := instca.UpdateComApp(ctx, req.GetUpdateFromAppContext(), req.GetUpdateToAppContext())
+	err = instca.UpdateComApp(ctx, req.GetUpdateFromAppContext(), req.GetUpdateToAppContext())
 	if err != nil {
 		log.Println("Updating the compApp failed: " + err.Error())
 		return &updateapp.UpdateAppResponse{AppContextUpdated: false}, err
@@ -34,9 +45,17 @@ func (cs *updateappServer) RollbackApp(ctx context.Context, req *updateapp.Rollb
 	updateAppReq, _ := json.Marshal(req)
 	log.Println("GRPC Server received UpdateAppRequest: ", string(updateAppReq))
 
+	conn, err := lease.Route(ctx, req.GetRollbackFromAppContext())
+	if err != nil {
+		return &updateapp.RollbackAppResponse{AppContextRolledback: false}, err
+	}
+	if conn != nil {
+		return updateapp.NewUpdateappClient(conn).RollbackApp(lease.Forward(ctx), req)
+	}
+
 	// Try rollback for the comp app
 	instca := con.CompositeAppContext{}
-	err := instca.UpdateComApp(ctx, req.GetRollbackFromAppContext(), req.GetRollbackToAppContext())
+	err = instca.UpdateComApp(ctx, req.GetRollbackFromAppContext(), req.GetRollbackToAppContext())
 	if err != nil {
 		log.Println("Rollback for compApp failed: " + err.Error())
 		return &updateapp.RollbackAppResponse{AppContextRolledback: false}, err
@@ -218,6 +218,17 @@ func (a *AppContextReference) GetNamespace(ctx context.Context) (string, string)
 	return namespace, level
 }
 
+// GetForceConflicts tells if the server-side apply of the resources takes the
+// ownership of the fields managed by other field managers
+func (a *AppContextReference) GetForceConflicts(ctx context.Context) bool {
+	appmeta, err := a.ac.GetCompositeAppMeta(ctx)
+	if err != nil {
+		log.Error("Error GetForceConflicts", log.Fields{"err": err})
+		return false
+	}
+	return appmeta.ForceConflicts
+}
+
 //GetLogicalCloudInfo reads logical cloud related info from metadata
 func (a *AppContextReference) GetLogicalCloudInfo(ctx context.Context) (string, string, string, string, string, error) {
 
@@ -405,6 +416,37 @@ func (a *AppContextReference) GetResourceReadyStatus(ctx context.Context, app, c
 	return false
 }
 
+// SetResourceDriftStatus sets the drift status of the resource
+func (a *AppContextReference) SetResourceDriftStatus(ctx context.Context, app, cluster, res string, status interface{}) error {
+	rh, err := a.ac.GetResourceHandle(ctx, app, cluster, res)
+	if err != nil {
+		return err
+	}
+	dh, _ := a.ac.GetLevelHandle(ctx, rh, "drift")
+	// If drift handle was not found, then create it
+	if dh == nil {
+		_, err = a.ac.AddLevelValue(ctx, rh, "drift", status)
+		return err
+	}
+	return a.ac.UpdateStatusValue(ctx, dh, status)
+}
+
+// SetClusterPruneReport sets the report of the pruning of the orphaned
+// resources of the app on the cluster
+func (a *AppContextReference) SetClusterPruneReport(ctx context.Context, app, cluster string, report interface{}) error {
+	ch, err := a.ac.GetClusterHandle(ctx, app, cluster)
+	if err != nil {
+		return err
+	}
+	ph, _ := a.ac.GetLevelHandle(ctx, ch, "prune")
+	// If prune handle was not found, then create it
+	if ph == nil {
+		_, err = a.ac.AddLevelValue(ctx, ch, "prune", report)
+		return err
+	}
+	return a.ac.UpdateStatusValue(ctx, ph, report)
+}
+
 // CheckAppReadyOnAllClusters checks if App is ready on all clusters
 func (a *AppContextReference) CheckAppReadyOnAllClusters(ctx context.Context, app string) bool {
 	// Check if all the clusters are ready
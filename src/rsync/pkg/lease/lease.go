@@ -0,0 +1,454 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+// Package lease shares the AppContexts between the replicas of rsync.
+//
+// Each replica keeps renewing its record in the ContextDb, and handles the
+// AppContexts it holds the lease of. A lease is claimed by the replica that
+// receives the first request for the AppContext, the other replicas forward
+// the requests for it to the owner. The leases of a replica that stops
+// renewing its record are taken over together by one of the live replicas,
+// picked by rendezvous hashing of the dead replica.
+package lease
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/rpc"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	replicaPrefix string = "/rsyncreplica/"
+	leasePrefix   string = "/rsynclease/"
+	// Metadata of the requests forwarded to the owner of their AppContext
+	forwardedKey string = "emco-rsync-forwarded"
+)
+
+// Replica is the record of a replica of rsync
+type Replica struct {
+	ID        string    `json:"id"`
+	Address   string    `json:"address"`
+	RenewedAt time.Time `json:"renewedAt"`
+}
+
+// Lease is the record of the replica owning an AppContext
+type Lease struct {
+	Owner string `json:"owner"`
+}
+
+// observation is the last change of the record of a replica seen by this
+// replica. A replica is dead once its record hasn't changed for the lease
+// duration of the local clock, so the clocks of the replicas don't need to
+// be in sync.
+type observation struct {
+	revision  int64
+	changedAt time.Time
+}
+
+// Manager renews the record of the replica and takes over the leases of the
+// dead replicas
+type Manager struct {
+	self     Replica
+	duration time.Duration
+	// Called with the keys of the leases taken over from a dead replica
+	takeover func(ctx context.Context, keys []string)
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	replicas  map[string]observation
+	renewedAt time.Time
+}
+
+var manager *Manager
+
+// NewManager returns the lease manager of the replica
+func NewManager(id, address string, duration time.Duration, takeover func(context.Context, []string)) *Manager {
+	return &Manager{
+		self:     Replica{ID: id, Address: address},
+		duration: duration,
+		takeover: takeover,
+		now:      time.Now,
+		replicas: make(map[string]observation),
+	}
+}
+
+// Start registers the replica and, until ctx is done, renews its record and
+// takes over the leases of the dead replicas. lost is called if the record
+// couldn't be renewed for the lease duration, the AppContexts of the
+// replica may then have been taken over by another replica.
+func Start(ctx context.Context, id, address string, duration time.Duration, takeover func(context.Context, []string), lost func()) error {
+	m := NewManager(id, address, duration, takeover)
+	if err := m.renew(ctx); err != nil {
+		return err
+	}
+	if err := m.observe(ctx); err != nil {
+		return err
+	}
+	manager = m
+	log.Info("Sharing the AppContexts with the rsync replicas", log.Fields{"replica": id, "address": address, "duration": duration})
+	go m.run(ctx, lost)
+	return nil
+}
+
+// Enabled returns true if the AppContexts are shared between the replicas
+func Enabled() bool {
+	return manager != nil
+}
+
+func (m *Manager) run(ctx context.Context, lost func()) {
+	ticker := time.NewTicker(m.duration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.renew(ctx); err != nil {
+			log.Error("Error renewing the record of the replica", log.Fields{"replica": m.self.ID, "error": err})
+			m.mutex.Lock()
+			expired := m.now().Sub(m.renewedAt) > m.duration
+			m.mutex.Unlock()
+			if expired {
+				lost()
+				return
+			}
+			continue
+		}
+		if err := m.observe(ctx); err != nil {
+			log.Error("Error reading the records of the replicas", log.Fields{"error": err})
+			continue
+		}
+		m.takeOver(ctx)
+	}
+}
+
+// renew updates the record of the replica
+func (m *Manager) renew(ctx context.Context) error {
+	r := m.self
+	r.RenewedAt = m.now()
+	if err := contextdb.Db.Put(ctx, replicaPrefix+r.ID+"/", r); err != nil {
+		return pkgerrors.Wrap(err, "Error renewing the record of the replica")
+	}
+	m.mutex.Lock()
+	m.renewedAt = r.RenewedAt
+	m.mutex.Unlock()
+	return nil
+}
+
+// observe reads the records of the replicas and notes the ones that changed
+func (m *Manager) observe(ctx context.Context) error {
+	keys, err := contextdb.Db.GetAllKeys(ctx, replicaPrefix)
+	if err != nil {
+		return pkgerrors.Wrap(err, "Error reading the records of the replicas")
+	}
+	revisions := make(map[string]int64)
+	for _, k := range keys {
+		id := keyID(k, replicaPrefix)
+		if id == "" {
+			continue
+		}
+		var r Replica
+		rev, err := contextdb.Db.GetWithRevision(ctx, k, &r)
+		if err != nil || rev == 0 {
+			continue
+		}
+		revisions[id] = rev
+	}
+	now := m.now()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for id, rev := range revisions {
+		if o, ok := m.replicas[id]; ok && o.revision == rev {
+			continue
+		}
+		m.replicas[id] = observation{revision: rev, changedAt: now}
+	}
+	return nil
+}
+
+// alive returns true if the replica renewed its record within the lease
+// duration. A replica seen for the first time, e.g. the owner of a lease
+// claimed since the records were read, is given the lease duration.
+func (m *Manager) alive(id string) bool {
+	if id == m.self.ID {
+		return true
+	}
+	now := m.now()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	o, ok := m.replicas[id]
+	if !ok {
+		m.replicas[id] = observation{changedAt: now}
+		return true
+	}
+	return now.Sub(o.changedAt) <= m.duration
+}
+
+// claimant returns the live replica taking over the leases of the dead
+// replica, the same for all the replicas that agree on the live replicas
+func (m *Manager) claimant(dead string) string {
+	ids := []string{m.self.ID}
+	m.mutex.Lock()
+	for id := range m.replicas {
+		ids = append(ids, id)
+	}
+	m.mutex.Unlock()
+	sort.Strings(ids)
+
+	var claimant string
+	var max uint64
+	for _, id := range ids {
+		if id == dead || (id != m.self.ID && !m.alive(id)) {
+			continue
+		}
+		h := fnv.New64a()
+		h.Write([]byte(dead + "/" + id))
+		if s := h.Sum64(); claimant == "" || s > max {
+			claimant, max = id, s
+		}
+	}
+	return claimant
+}
+
+// takeOver claims the leases of the dead replicas this replica is the
+// claimant of, and forgets the dead replicas that don't own leases anymore
+func (m *Manager) takeOver(ctx context.Context) {
+	keys, err := contextdb.Db.GetAllKeys(ctx, leasePrefix)
+	if err != nil {
+		log.Error("Error reading the leases", log.Fields{"error": err})
+		return
+	}
+	owners := make(map[string]bool)
+	dead := make(map[string][]string)
+	for _, k := range keys {
+		key := keyID(k, leasePrefix)
+		if key == "" {
+			continue
+		}
+		var l Lease
+		if err := contextdb.Db.Get(ctx, k, &l); err != nil {
+			continue
+		}
+		owners[l.Owner] = true
+		if !m.alive(l.Owner) {
+			dead[l.Owner] = append(dead[l.Owner], key)
+		}
+	}
+	for owner, keys := range dead {
+		if m.claimant(owner) != m.self.ID {
+			continue
+		}
+		var claimed []string
+		for _, key := range keys {
+			ok, err := m.claim(ctx, key, owner)
+			if err != nil {
+				log.Error("Error taking over the lease", log.Fields{"key": key, "owner": owner, "error": err})
+			}
+			if ok {
+				claimed = append(claimed, key)
+			}
+		}
+		log.Info("Taking over the AppContexts of a dead replica", log.Fields{"replica": owner, "leases": claimed})
+		if len(claimed) > 0 {
+			m.takeover(ctx, claimed)
+		}
+	}
+
+	m.mutex.Lock()
+	var gone []string
+	for id := range m.replicas {
+		if !owners[id] {
+			gone = append(gone, id)
+		}
+	}
+	m.mutex.Unlock()
+	for _, id := range gone {
+		if m.alive(id) {
+			continue
+		}
+		if err := contextdb.Db.Delete(ctx, replicaPrefix+id+"/"); err != nil {
+			log.Error("Error deleting the record of a dead replica", log.Fields{"replica": id, "error": err})
+			continue
+		}
+		m.mutex.Lock()
+		delete(m.replicas, id)
+		m.mutex.Unlock()
+	}
+}
+
+// claim moves the lease to the replica if it's still owned by the owner
+func (m *Manager) claim(ctx context.Context, key, owner string) (bool, error) {
+	k := leasePrefix + key + "/"
+	var l Lease
+	rev, err := contextdb.Db.GetWithRevision(ctx, k, &l)
+	if err != nil {
+		return false, err
+	}
+	if rev == 0 || l.Owner != owner {
+		return false, nil
+	}
+	err = contextdb.Db.PutIfRevision(ctx, k, Lease{Owner: m.self.ID}, rev)
+	if err == contextdb.ErrRevisionMismatch {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Acquire returns the owner of the lease, claiming it if it's free or its
+// owner is dead, and true if the replica owns the lease
+func (m *Manager) Acquire(ctx context.Context, key string) (string, bool, error) {
+	k := leasePrefix + key + "/"
+	for {
+		var l Lease
+		rev, err := contextdb.Db.GetWithRevision(ctx, k, &l)
+		if err != nil {
+			return "", false, pkgerrors.Wrapf(err, "Error reading the lease of %s", key)
+		}
+		if rev != 0 && (l.Owner == m.self.ID || m.alive(l.Owner)) {
+			return l.Owner, l.Owner == m.self.ID, nil
+		}
+		err = contextdb.Db.PutIfRevision(ctx, k, Lease{Owner: m.self.ID}, rev)
+		if err == contextdb.ErrRevisionMismatch {
+			// Claimed by another replica meanwhile
+			continue
+		}
+		if err != nil {
+			return "", false, pkgerrors.Wrapf(err, "Error claiming the lease of %s", key)
+		}
+		if rev != 0 {
+			log.Info("Taking over the AppContext of a dead replica", log.Fields{"replica": l.Owner, "key": key})
+			m.takeover(ctx, []string{key})
+		}
+		return m.self.ID, true, nil
+	}
+}
+
+// Release frees the lease if the replica owns it
+func (m *Manager) Release(ctx context.Context, key string) error {
+	k := leasePrefix + key + "/"
+	var l Lease
+	rev, err := contextdb.Db.GetWithRevision(ctx, k, &l)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "Error reading the lease of %s", key)
+	}
+	if rev == 0 || l.Owner != m.self.ID {
+		return nil
+	}
+	if err := contextdb.Db.Delete(ctx, k); err != nil {
+		return pkgerrors.Wrapf(err, "Error releasing the lease of %s", key)
+	}
+	return nil
+}
+
+// Key returns the key of the lease of the AppContext. All the AppContexts
+// of a DeploymentIntentGroup share the lease of its status AppContext, so
+// they are handled by the same replica.
+func Key(ctx context.Context, acID string) string {
+	ref, err := utils.NewAppContextReference(ctx, acID)
+	if err != nil {
+		return acID
+	}
+	sid, err := ref.GetStatusAppContext(ctx, types.StatusAppContextIDKey)
+	if err != nil || sid == "" {
+		return acID
+	}
+	return sid
+}
+
+// Acquire claims the lease of the AppContext for the replica, if it's free
+// or its owner is dead, and returns true if the replica owns it. The replica
+// owns all the AppContexts if they aren't shared.
+func Acquire(ctx context.Context, acID string) (bool, error) {
+	if manager == nil {
+		return true, nil
+	}
+	_, owned, err := manager.Acquire(ctx, Key(ctx, acID))
+	return owned, err
+}
+
+// Release frees the lease of the AppContext if the replica owns it
+func Release(ctx context.Context, acID string) error {
+	if manager == nil {
+		return nil
+	}
+	return manager.Release(ctx, Key(ctx, acID))
+}
+
+// Route returns the connection to the replica owning the AppContext, or nil
+// if the request is handled by this replica. A request already forwarded by
+// another replica isn't forwarded again.
+func Route(ctx context.Context, acID string) (*grpc.ClientConn, error) {
+	if manager == nil {
+		return nil, nil
+	}
+	key := Key(ctx, acID)
+	owner, owned, err := manager.Acquire(ctx, key)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if owned {
+		return nil, nil
+	}
+	if forwarded(ctx) {
+		return nil, status.Errorf(codes.Unavailable, "AppContext %s is owned by the rsync replica %s", acID, owner)
+	}
+	var r Replica
+	if err := contextdb.Db.Get(ctx, replicaPrefix+owner+"/", &r); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Error reading the record of the rsync replica %s: %s", owner, err)
+	}
+	host, p, err := net.SplitHostPort(r.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Invalid address of the rsync replica %s: %s", owner, err)
+	}
+	port, err := strconv.Atoi(p)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "Invalid port of the rsync replica %s: %s", owner, err)
+	}
+	name := "rsync-replica-" + owner
+	rpc.UpdateRpcConn(name, host, port)
+	conn := rpc.GetRpcConn(ctx, name)
+	if conn == nil {
+		return nil, status.Errorf(codes.Unavailable, "No connection to the rsync replica %s", owner)
+	}
+	log.Info("Forwarding the request to the owner of the AppContext", log.Fields{"context": acID, "key": key, "replica": owner})
+	return conn, nil
+}
+
+// Forward returns the context of a request forwarded to the owner of its
+// AppContext
+func Forward(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, forwardedKey, manager.self.ID)
+}
+
+// forwarded returns true if the request was forwarded by another replica
+func forwarded(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get(forwardedKey)) > 0
+}
+
+// keyID returns the ID in a key like /<prefix>/<id>/
+func keyID(key, prefix string) string {
+	k := strings.Split(fmt.Sprintf("%v", key), "/")
+	if len(k) != 4 || "/"+k[1]+"/" != prefix {
+		return ""
+	}
+	return k[2]
+}
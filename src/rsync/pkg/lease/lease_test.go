@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package lease
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/contextdb"
+	"google.golang.org/grpc/metadata"
+)
+
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+// newTestManager returns a manager of the replica, on the shared clock,
+// recording the leases it takes over
+func newTestManager(t *testing.T, id string, c *clock, taken *[]string) *Manager {
+	m := NewManager(id, id+":9031", 30*time.Second, func(ctx context.Context, keys []string) {
+		*taken = append(*taken, keys...)
+	})
+	m.now = c.now
+	if err := m.renew(context.Background()); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	return m
+}
+
+func TestAcquire(t *testing.T) {
+	contextdb.Db = &contextdb.MockConDb{}
+	ctx := context.Background()
+	c := &clock{t: time.Now()}
+	var taken []string
+	m1 := newTestManager(t, "rsync-1", c, &taken)
+	m2 := newTestManager(t, "rsync-2", c, &taken)
+	m1.observe(ctx)
+	m2.observe(ctx)
+
+	// A free lease is claimed
+	owner, owned, err := m1.Acquire(ctx, "1234")
+	if err != nil || !owned || owner != "rsync-1" {
+		t.Fatalf("Expected rsync-1 to own the lease, got %s %v %v", owner, owned, err)
+	}
+	if owner, owned, _ = m1.Acquire(ctx, "1234"); !owned {
+		t.Errorf("Expected rsync-1 to still own the lease, got %s", owner)
+	}
+	// The lease of a live replica is kept
+	if owner, owned, _ = m2.Acquire(ctx, "1234"); owned || owner != "rsync-1" {
+		t.Errorf("Expected the lease to be owned by rsync-1, got %s %v", owner, owned)
+	}
+	// The lease of a dead replica is taken over
+	c.t = c.t.Add(31 * time.Second)
+	m2.renew(ctx)
+	m2.observe(ctx)
+	if owner, owned, _ = m2.Acquire(ctx, "1234"); !owned || owner != "rsync-2" {
+		t.Errorf("Expected rsync-2 to take over the lease, got %s %v", owner, owned)
+	}
+	if len(taken) != 1 || taken[0] != "1234" {
+		t.Errorf("Expected the AppContext 1234 to be taken over, got %v", taken)
+	}
+
+	// Only the owner releases the lease
+	m1.Release(ctx, "1234")
+	if owner, _, _ = m2.Acquire(ctx, "1234"); owner != "rsync-2" {
+		t.Errorf("Expected the lease to be owned by rsync-2, got %s", owner)
+	}
+	m2.Release(ctx, "1234")
+	if owner, owned, _ = m1.Acquire(ctx, "1234"); !owned {
+		t.Errorf("Expected rsync-1 to claim the released lease, got %s", owner)
+	}
+}
+
+func TestTakeOver(t *testing.T) {
+	contextdb.Db = &contextdb.MockConDb{}
+	ctx := context.Background()
+	c := &clock{t: time.Now()}
+	var taken2, taken3, unused []string
+	m1 := newTestManager(t, "rsync-1", c, &unused)
+	m2 := newTestManager(t, "rsync-2", c, &taken2)
+	m3 := newTestManager(t, "rsync-3", c, &taken3)
+	for _, m := range []*Manager{m1, m2, m3} {
+		m.observe(ctx)
+	}
+	for _, key := range []string{"1", "2", "3"} {
+		m1.Acquire(ctx, key)
+	}
+	m2.Acquire(ctx, "4")
+
+	// Nothing to take over while the replicas are alive
+	m2.takeOver(ctx)
+	m3.takeOver(ctx)
+	if len(taken2)+len(taken3) != 0 {
+		t.Fatalf("Expected no lease to be taken over, got %v %v", taken2, taken3)
+	}
+
+	// rsync-1 stops renewing its record
+	c.t = c.t.Add(31 * time.Second)
+	for _, m := range []*Manager{m2, m3} {
+		m.renew(ctx)
+	}
+	for _, m := range []*Manager{m2, m3} {
+		m.observe(ctx)
+	}
+	if m2.claimant("rsync-1") != m3.claimant("rsync-1") {
+		t.Fatalf("Expected the replicas to agree on the claimant, got %s and %s", m2.claimant("rsync-1"), m3.claimant("rsync-1"))
+	}
+	m2.takeOver(ctx)
+	m3.takeOver(ctx)
+	taken := taken2
+	claimant := m2
+	if len(taken2) == 0 {
+		taken, claimant = taken3, m3
+	}
+	if len(taken) != 3 || len(taken2)+len(taken3) != 3 {
+		t.Fatalf("Expected all the leases of rsync-1 to be taken over by a single replica, got %v %v", taken2, taken3)
+	}
+	for _, key := range []string{"1", "2", "3"} {
+		if owner, owned, _ := claimant.Acquire(ctx, key); !owned {
+			t.Errorf("Expected the lease %s to be owned by %s, got %s", key, claimant.self.ID, owner)
+		}
+	}
+	if owner, _, _ := m3.Acquire(ctx, "4"); owner != "rsync-2" {
+		t.Errorf("Expected the lease 4 to be kept by rsync-2, got %s", owner)
+	}
+
+	// The record of the dead replica is deleted once it owns no lease
+	var r Replica
+	if err := contextdb.Db.Get(ctx, replicaPrefix+"rsync-1/", &r); err == nil {
+		t.Errorf("Expected the record of rsync-1 to be deleted")
+	}
+}
+
+func TestForwarded(t *testing.T) {
+	manager = NewManager("rsync-1", "rsync-1:9031", 30*time.Second, nil)
+	defer func() { manager = nil }()
+
+	ctx := context.Background()
+	if forwarded(ctx) {
+		t.Errorf("Expected the request not to be forwarded")
+	}
+	md, _ := metadata.FromOutgoingContext(Forward(ctx))
+	if !forwarded(metadata.NewIncomingContext(ctx, md)) {
+		t.Errorf("Expected the request to be forwarded")
+	}
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var ClusterOpsQueuedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "emco_rsync_cluster_operations_queued",
+	Help: "Count of cluster operations waiting for a concurrency slot",
+}, []string{"cluster_provider"})
+
+var ClusterOpsInFlightGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "emco_rsync_cluster_operations_in_flight",
+	Help: "Count of cluster operations in progress",
+}, []string{"cluster_provider"})
+
+var GitOpsCommitRequestsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "emco_rsync_gitops_commit_requests_total",
+	Help: "Count of GitOps commits requested by the apps on the clusters",
+})
+
+var GitOpsCommitsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "emco_rsync_gitops_commits_total",
+	Help: "Count of GitOps commits made for the batched requests",
+})
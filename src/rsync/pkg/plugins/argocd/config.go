@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package argocd
+
+import (
+	"context"
+	"strconv"
+
+	yaml "github.com/ghodss/yaml"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Application is the Argo CD Application of an App on a cluster, with the
+// fields set by EMCO
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              ApplicationSpec    `json:"spec"`
+	Status            *ApplicationStatus `json:"status,omitempty"`
+}
+
+type ApplicationSpec struct {
+	Project     string                 `json:"project"`
+	Source      ApplicationSource      `json:"source"`
+	Destination ApplicationDestination `json:"destination"`
+	SyncPolicy  *SyncPolicy            `json:"syncPolicy,omitempty"`
+}
+
+type ApplicationSource struct {
+	RepoURL        string `json:"repoURL"`
+	TargetRevision string `json:"targetRevision"`
+	Path           string `json:"path"`
+}
+
+type ApplicationDestination struct {
+	Server    string `json:"server"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type SyncPolicy struct {
+	Automated *SyncPolicyAutomated `json:"automated,omitempty"`
+	Retry     *RetryStrategy       `json:"retry,omitempty"`
+}
+
+type SyncPolicyAutomated struct {
+	Prune    bool `json:"prune"`
+	SelfHeal bool `json:"selfHeal"`
+}
+
+type RetryStrategy struct {
+	// Limit of the retries, negative for no limit
+	Limit   int64    `json:"limit"`
+	Backoff *Backoff `json:"backoff,omitempty"`
+}
+
+type Backoff struct {
+	Duration    string `json:"duration"`
+	Factor      *int64 `json:"factor,omitempty"`
+	MaxDuration string `json:"maxDuration,omitempty"`
+}
+
+// ApplicationStatus is the sync and health state of an Application
+type ApplicationStatus struct {
+	Sync      SyncStatus       `json:"sync"`
+	Health    HealthStatus     `json:"health"`
+	Resources []ResourceStatus `json:"resources,omitempty"`
+}
+
+type SyncStatus struct {
+	Status   string `json:"status"`
+	Revision string `json:"revision,omitempty"`
+}
+
+type HealthStatus struct {
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ResourceStatus is the sync and health state of a resource of an Application
+type ResourceStatus struct {
+	Group     string        `json:"group,omitempty"`
+	Version   string        `json:"version,omitempty"`
+	Kind      string        `json:"kind,omitempty"`
+	Namespace string        `json:"namespace,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	Status    string        `json:"status,omitempty"`
+	Health    *HealthStatus `json:"health,omitempty"`
+}
+
+/*
+	Function to get the name of the Application of the App
+	params : null
+	return : string
+*/
+func (p *ArgoCDProvider) applicationName() string {
+	return p.gitProvider.App + "-" + p.gitProvider.Cid
+}
+
+/*
+	Function to get the path of the Application of the App in git. Argo CD
+	deploys it with an Application of the cluster directory.
+	params : null
+	return : string
+*/
+func (p *ArgoCDProvider) applicationPath() string {
+	return "clusters/" + p.gitProvider.Cluster + "/argocd-" + p.applicationName() + ".yaml"
+}
+
+// Create or update the Argo CD Application of the App
+func (p *ArgoCDProvider) ApplyConfig(ctx context.Context, config interface{}) error {
+
+	var files interface{}
+
+	factor := int64(2)
+	app := Application{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "argoproj.io/v1alpha1",
+			Kind:       "Application",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      p.applicationName(),
+			Namespace: p.argoNamespace,
+			// Delete the resources with the Application
+			Finalizers: []string{"resources-finalizer.argocd.argoproj.io"},
+		},
+		Spec: ApplicationSpec{
+			Project: p.project,
+			Source: ApplicationSource{
+				RepoURL:        p.gitProvider.Url,
+				TargetRevision: p.gitProvider.Branch,
+				Path:           p.gitProvider.GetPath("context"),
+			},
+			Destination: ApplicationDestination{
+				Server:    p.destination,
+				Namespace: p.gitProvider.Namespace,
+			},
+			SyncPolicy: &SyncPolicy{
+				Automated: &SyncPolicyAutomated{Prune: true, SelfHeal: true},
+				Retry: &RetryStrategy{
+					Limit: -1,
+					Backoff: &Backoff{
+						Duration: strconv.Itoa(p.retryInterval) + "s",
+						Factor:   &factor,
+					},
+				},
+			},
+		},
+	}
+	y, err := yaml.Marshal(&app)
+	if err != nil {
+		log.Error("ApplyConfig:: Marshal err", log.Fields{"err": err, "app": app})
+		return err
+	}
+	files, err = p.gitProvider.Apply(p.applicationPath(), files, y)
+	if err != nil {
+		return err
+	}
+
+	// Commit
+	err = p.gitProvider.Commit(ctx, files)
+	if err != nil {
+		log.Error("ApplyConfig:: Commit files err", log.Fields{"err": err, "files": files})
+	}
+	return err
+}
+
+// Delete the Argo CD Application of the App
+func (p *ArgoCDProvider) DeleteConfig(ctx context.Context, config interface{}) error {
+
+	var files interface{}
+
+	files, err := p.gitProvider.Delete(p.applicationPath(), files, nil)
+	if err != nil {
+		return err
+	}
+
+	err = p.gitProvider.Commit(ctx, files)
+	if err != nil {
+		log.Error("DeleteConfig:: Commit files err", log.Fields{"err": err, "files": files})
+	}
+	return err
+}
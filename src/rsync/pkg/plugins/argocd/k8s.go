@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/db"
+	gitsupport "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/gitops/gitsupport"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+)
+
+// ArgoCDProvider deploys the resources of an App with an Argo CD Application
+type ArgoCDProvider struct {
+	gitProvider gitsupport.GitProvider
+	// argoNamespace is the namespace of the Argo CD Applications
+	argoNamespace string
+	project       string
+	// destination is the API server Argo CD deploys the resources to
+	destination string
+	// argoServer is the url of the Argo CD API, the status is read from the
+	// git repo updated by the monitor if not set
+	argoServer    string
+	argoToken     string
+	argoInsecure  bool
+	syncInterval  int
+	retryInterval int
+}
+
+func NewArgoCDProvider(ctx context.Context, cid, app, cluster, level, namespace string) (*ArgoCDProvider, error) {
+
+	result := strings.SplitN(cluster, "+", 2)
+
+	c, err := utils.GetGitOpsConfig(ctx, cluster, "0", "default")
+
+	if err != nil {
+		return nil, err
+	}
+	if c.Props.GitOpsType != "argocd" {
+		log.Error("Invalid GitOps type:", log.Fields{})
+		return nil, pkgerrors.Errorf("Invalid GitOps type: " + c.Props.GitOpsType)
+	}
+
+	// Read from database
+	ccc := db.NewCloudConfigClient()
+
+	gitProvider, err := gitsupport.NewGitProvider(ctx, cid, app, cluster, level, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	resObject, err := ccc.GetClusterSyncObjects(ctx, result[0], c.Props.GitOpsResourceObject)
+	if err != nil {
+		log.Error("Invalid resObject :", log.Fields{"resObj": c.Props.GitOpsResourceObject})
+		return nil, pkgerrors.Errorf("Invalid resObject: " + c.Props.GitOpsResourceObject)
+	}
+
+	p := ArgoCDProvider{
+		gitProvider:   *gitProvider,
+		argoNamespace: "argocd",
+		project:       "default",
+		destination:   "https://kubernetes.default.svc",
+	}
+
+	// The Argo CD API can be set in the reference object, like the
+	// credentials of the other GitOps providers
+	kvRes := resObject.Spec.Kv
+	if c.Props.GitOpsReferenceObject != "" && c.Props.GitOpsReferenceObject != c.Props.GitOpsResourceObject {
+		refObject, err := ccc.GetClusterSyncObjects(ctx, result[0], c.Props.GitOpsReferenceObject)
+		if err != nil {
+			log.Error("Invalid refObject :", log.Fields{"refObj": c.Props.GitOpsReferenceObject})
+			return nil, pkgerrors.Errorf("Invalid refObject: " + c.Props.GitOpsReferenceObject)
+		}
+		kvRes = append(kvRes, refObject.Spec.Kv...)
+	}
+
+	var argoInsecureStr string
+	syncIntervalStr := "60"
+	retryIntervalStr := "5"
+
+	for _, kvpair := range kvRes {
+		log.Info("kvpair", log.Fields{"kvpair": kvpair})
+		v, ok := kvpair["argoNamespace"]
+		if ok {
+			p.argoNamespace = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["argoProject"]
+		if ok {
+			p.project = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["argoDestination"]
+		if ok {
+			p.destination = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["argoServer"]
+		if ok {
+			p.argoServer = strings.TrimSuffix(fmt.Sprintf("%v", v), "/")
+			continue
+		}
+		v, ok = kvpair["argoToken"]
+		if ok {
+			p.argoToken = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["argoInsecure"]
+		if ok {
+			argoInsecureStr = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["syncInterval"]
+		if ok {
+			syncIntervalStr = fmt.Sprintf("%v", v)
+			continue
+		}
+		v, ok = kvpair["retryInterval"]
+		if ok {
+			retryIntervalStr = fmt.Sprintf("%v", v)
+			continue
+		}
+	}
+	if len(p.argoServer) > 0 && len(p.argoToken) <= 0 {
+		log.Error("Missing token for the Argo CD API", log.Fields{"argoServer": p.argoServer})
+		return nil, pkgerrors.Errorf("Missing Information for Argo CD")
+	}
+	p.argoInsecure = argoInsecureStr == "true"
+
+	_, err = fmt.Sscan(syncIntervalStr, &p.syncInterval)
+	if err != nil || p.syncInterval <= 0 {
+		log.Error("Invalid sync interval value", log.Fields{"syncIntervalStr": syncIntervalStr, "err": err})
+		return nil, pkgerrors.Errorf("Invalid sync interval value: " + syncIntervalStr)
+	}
+
+	_, err = fmt.Sscan(retryIntervalStr, &p.retryInterval)
+	if err != nil || p.retryInterval <= 0 {
+		log.Error("Invalid retry interval value", log.Fields{"retryIntervalStr": retryIntervalStr, "err": err})
+		return nil, pkgerrors.Errorf("Invalid retry interval value: " + retryIntervalStr)
+	}
+
+	return &p, nil
+}
+
+func (p *ArgoCDProvider) CleanClientProvider() error {
+	return nil
+}
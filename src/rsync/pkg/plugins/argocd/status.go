@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package argocd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	rb "gitlab.com/project-emco/core/emco-base/src/monitor/pkg/apis/k8splugin/v1alpha1"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// watchers are the Applications whose status is read from the Argo CD API
+var watchers sync.Map
+
+// StartClusterWatcher watches the status of the App, read from the Argo CD
+// API if set, or from the CR of the monitor in the git location
+func (p *ArgoCDProvider) StartClusterWatcher(ctx context.Context) error {
+	if p.argoServer == "" {
+		return p.gitProvider.StartClusterWatcher(ctx)
+	}
+	key := p.gitProvider.Cluster + "/" + p.argoNamespace + "/" + p.applicationName()
+	if _, loaded := watchers.LoadOrStore(key, true); loaded {
+		// Already watched
+		return nil
+	}
+
+	go func() {
+		defer watchers.Delete(key)
+		// This function is executed asynchronously, so we must create
+		// a new (not derived) context to prevent the context from
+		// being cancelled when the caller completes: a cancelled
+		// context will cause the below work to exit early.  A link is
+		// used so that the traces can be associated.
+		tracer := otel.Tracer("rsync")
+		ctx, span := tracer.Start(context.Background(), "StartClusterWatcher",
+			trace.WithLinks(trace.LinkFromContext(ctx)),
+		)
+		defer span.End()
+
+		var lastStatus string
+		for {
+			select {
+			case <-time.After(time.Duration(p.syncInterval) * time.Second):
+				// Check if AppContext doesn't exist then exit the thread
+				if _, err := utils.NewAppContextReference(ctx, p.gitProvider.Cid); err != nil {
+					// AppContext deleted - Exit thread
+					return
+				}
+				app, err := p.getApplication(ctx)
+				if err != nil {
+					log.Error("Error getting Argo CD Application", log.Fields{"err": err, "application": p.applicationName(), "cluster": p.gitProvider.Cluster})
+					continue
+				}
+				if app.Status == nil {
+					continue
+				}
+				// Update the status only when it changes
+				b, err := json.Marshal(app.Status)
+				if err != nil || string(b) == lastStatus {
+					continue
+				}
+				rbData, health := p.resourcesStatus(app.Status)
+				status.HandleResourcesHealth(ctx, p.gitProvider.Cid, p.gitProvider.App, p.gitProvider.Cluster, rbData, health)
+				lastStatus = string(b)
+
+			// Check if the context is canceled
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// getApplication reads the Application of the App from the Argo CD API
+func (p *ArgoCDProvider) getApplication(ctx context.Context) (*Application, error) {
+	u := p.argoServer + "/api/v1/applications/" + url.PathEscape(p.applicationName()) +
+		"?appNamespace=" + url.QueryEscape(p.argoNamespace)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.argoToken)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	if p.argoInsecure {
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, pkgerrors.Errorf("Argo CD API returned %s", resp.Status)
+	}
+	app := &Application{}
+	if err := json.NewDecoder(resp.Body).Decode(app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// resourcesStatus converts the status of the Application to the status of
+// the resources of the App, and their readiness. A resource is ready when it
+// is synced and healthy, or synced if it has no health.
+func (p *ArgoCDProvider) resourcesStatus(as *ApplicationStatus) (*rb.ResourceBundleState, []status.ResourceHealth) {
+	rbData := &rb.ResourceBundleState{}
+	health := []status.ResourceHealth{}
+	ready := len(as.Resources) > 0
+	for _, r := range as.Resources {
+		res, err := json.Marshal(r)
+		if err != nil {
+			continue
+		}
+		rbData.Status.ResourceStatuses = append(rbData.Status.ResourceStatuses, rb.ResourceStatus{
+			Group:     r.Group,
+			Version:   r.Version,
+			Kind:      r.Kind,
+			Name:      r.Name,
+			Namespace: r.Namespace,
+			Res:       res,
+		})
+		h := status.ResourceHealth{
+			Name:  r.Name,
+			Kind:  r.Kind,
+			Ready: r.Status == "Synced" && (r.Health == nil || r.Health.Status == "" || r.Health.Status == "Healthy"),
+		}
+		if !h.Ready {
+			ready = false
+		}
+		health = append(health, h)
+	}
+	rbData.Status.Ready = ready
+	rbData.Status.ResourceCount = int32(len(as.Resources))
+	rbData.SetName(p.applicationName())
+	return rbData, health
+}
+
+// ApplyStatusCR applies status CR, not needed if the status is read from
+// the Argo CD API
+func (p *ArgoCDProvider) ApplyStatusCR(ctx context.Context, name string, content []byte) error {
+	if p.argoServer != "" {
+		return nil
+	}
+
+	// Add namespace to the status resource, needed by Argo CD
+	//Decode the yaml to create a runtime.Object
+	unstruct := &unstructured.Unstructured{}
+	//Ignore the returned obj as we expect the data in unstruct
+	_, err := utils.DecodeYAMLData(string(content), unstruct)
+	if err != nil {
+		return err
+	}
+	// Set Namespace
+	unstruct.SetNamespace(p.gitProvider.Namespace)
+	b, err := unstruct.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	path := p.gitProvider.GetPath("context") + name + ".yaml"
+	ref, err := p.gitProvider.Apply(path, nil, b)
+	if err != nil {
+		return err
+	}
+	return p.gitProvider.Commit(ctx, ref)
+}
+
+// DeleteStatusCR deletes status CR
+func (p *ArgoCDProvider) DeleteStatusCR(ctx context.Context, name string, content []byte) error {
+	if p.argoServer != "" {
+		return nil
+	}
+
+	path := p.gitProvider.GetPath("context") + name + ".yaml"
+	ref, err := p.gitProvider.Delete(path, nil, content)
+	if err != nil {
+		return err
+	}
+	return p.gitProvider.Commit(ctx, ref)
+}
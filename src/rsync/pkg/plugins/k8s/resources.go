@@ -12,6 +12,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // Creates a new resource if the not already existing
@@ -74,6 +75,16 @@ func (p *K8sProvider) Get(ctx context.Context, name string, gvkRes []byte) ([]by
 	return b, nil
 }
 
+// List the resources on the cluster that match the label selector
+func (p *K8sProvider) List(ctx context.Context, labelSelector string) ([]unstructured.Unstructured, error) {
+	objs, err := p.client.List(ctx, labelSelector)
+	if err != nil {
+		log.Error("Failed to list res", log.Fields{"error": err, "labelSelector": labelSelector})
+		return nil, err
+	}
+	return objs, nil
+}
+
 // Commit resources to the cluster
 // Not required in K8s case
 func (p *K8sProvider) Commit(ctx context.Context, ref interface{}) error {
@@ -53,10 +53,13 @@ func NewK8sProvider(ctx context.Context, cid, app, cluster, level, namespace str
 		return nil, err
 	}
 
-	client := kubeclient.New("", fileName, namespace)
+	client := kubeclient.NewForCluster(cluster, fileName, namespace)
 	if client == nil {
 		return nil, pkgerrors.New("failed to connect with the cluster")
 	}
+	if acRef, err := utils.NewAppContextReference(ctx, cid); err == nil {
+		client.ForceConflicts = acRef.GetForceConflicts(ctx)
+	}
 	p.fileName = fileName
 	p.client = client
 	return &p, nil
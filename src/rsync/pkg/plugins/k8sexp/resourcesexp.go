@@ -16,6 +16,7 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
 	. "gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type approval struct {
@@ -137,6 +138,16 @@ func (p *K8sProviderExp) Get(ctx context.Context, name string, gvkRes []byte) ([
 	return b, nil
 }
 
+// List the resources on the cluster that match the label selector
+func (p *K8sProviderExp) List(ctx context.Context, labelSelector string) ([]unstructured.Unstructured, error) {
+	objs, err := p.client.List(ctx, labelSelector)
+	if err != nil {
+		log.Error("Failed to list res", log.Fields{"error": err, "labelSelector": labelSelector})
+		return nil, err
+	}
+	return objs, nil
+}
+
 // Commit resources to the cluster
 func (p *K8sProviderExp) Commit(ctx context.Context, ref interface{}) error {
 	var exists bool
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package status
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	pkgerrors "github.com/pkg/errors"
+	rb "gitlab.com/project-emco/core/emco-base/src/monitor/pkg/apis/k8splugin/v1alpha1"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// driftIgnoredFields are the top level fields of a resource that aren't
+// compared: the identity of the resource and the status set on the cluster
+var driftIgnoredFields = map[string]bool{"apiVersion": true, "kind": true, "status": true}
+
+// DriftedFields compares the resource of the appcontext with the resource on
+// the cluster, and returns the paths of the fields that differ. Only the
+// fields set in the appcontext are compared: the fields the cluster adds,
+// like defaults or the labels and annotations of controllers, aren't drift.
+func DriftedFields(desired, live []byte) ([]string, error) {
+	var d, l map[string]interface{}
+	if err := unmarshalResource(desired, &d); err != nil {
+		return nil, pkgerrors.Wrap(err, "Invalid desired resource")
+	}
+	if err := unmarshalResource(live, &l); err != nil {
+		return nil, pkgerrors.Wrap(err, "Invalid live resource")
+	}
+
+	normalizeSecret(d)
+	normalizeSecret(l)
+
+	fields := []string{}
+	keys := sortedKeys(d)
+	for _, k := range keys {
+		if driftIgnoredFields[k] {
+			continue
+		}
+		diffValue(k, d[k], l[k], &fields)
+	}
+	return fields, nil
+}
+
+// unmarshalResource decodes a YAML or JSON resource, converting it to JSON
+// first so that the numbers of both representations compare equal
+func unmarshalResource(b []byte, v interface{}) error {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(j, v)
+}
+
+// normalizeSecret moves the stringData of a Secret to its data, as the API
+// server does, so that a Secret with stringData compares equal to the Secret
+// on the cluster
+func normalizeSecret(r map[string]interface{}) {
+	stringData, ok := r["stringData"].(map[string]interface{})
+	if r["kind"] != "Secret" || !ok {
+		return
+	}
+	data, ok := r["data"].(map[string]interface{})
+	if !ok {
+		data = make(map[string]interface{}, len(stringData))
+		r["data"] = data
+	}
+	for k, v := range stringData {
+		if v == nil {
+			continue
+		}
+		data[k] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v", v)))
+	}
+	delete(r, "stringData")
+}
+
+// GitOpsSyncStatus returns the sync status, e.g. Synced or OutOfSync, and the
+// health of a resource of a ResourceBundleState built from the status of the
+// resources reported by a GitOps agent, e.g. Argo CD, and false if the
+// ResourceBundleState has the resource itself
+func GitOpsSyncStatus(res []byte) (string, string, bool) {
+	var r struct {
+		Status interface{} `json:"status"`
+		Health *struct {
+			Status string `json:"status"`
+		} `json:"health"`
+		Metadata interface{} `json:"metadata"`
+	}
+	if err := json.Unmarshal(res, &r); err != nil || r.Metadata != nil {
+		return "", "", false
+	}
+	sync, ok := r.Status.(string)
+	if !ok {
+		return "", "", false
+	}
+	health := ""
+	if r.Health != nil {
+		health = r.Health.Status
+	}
+	return sync, health, true
+}
+
+func diffValue(path string, d, l interface{}, fields *[]string) {
+	switch dv := d.(type) {
+	case nil:
+		// A null field of the appcontext leaves the field to the cluster
+	case map[string]interface{}:
+		lv, ok := l.(map[string]interface{})
+		if !ok {
+			*fields = append(*fields, path)
+			return
+		}
+		for _, k := range sortedKeys(dv) {
+			diffValue(path+"."+k, dv[k], lv[k], fields)
+		}
+	case []interface{}:
+		lv, ok := l.([]interface{})
+		if !ok || len(lv) != len(dv) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range dv {
+			diffValue(fmt.Sprintf("%s[%d]", path, i), dv[i], lv[i], fields)
+		}
+	default:
+		if !reflect.DeepEqual(d, l) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetBundleResource returns the resource as last reported by the monitor of
+// the cluster in its ResourceBundleState, and false if the monitor didn't
+// report it
+func GetBundleResource(ctx context.Context, acID, app, cluster string, gvk schema.GroupVersionKind, name string) ([]byte, bool) {
+	var ac appcontext.AppContext
+	if _, err := ac.LoadAppContext(ctx, acID); err != nil {
+		return nil, false
+	}
+	ch, err := ac.GetClusterHandle(ctx, app, cluster)
+	if err != nil {
+		return nil, false
+	}
+	sh, err := ac.GetLevelHandle(ctx, ch, "status")
+	if err != nil {
+		return nil, false
+	}
+	v, err := ac.GetValue(ctx, sh)
+	if err != nil {
+		return nil, false
+	}
+	var rbStatus rb.ResourceBundleStateStatus
+	if err := json.Unmarshal([]byte(fmt.Sprintf("%v", v)), &rbStatus); err != nil {
+		return nil, false
+	}
+
+	for _, r := range rbStatus.ResourceStatuses {
+		if r.Group == gvk.Group && r.Kind == gvk.Kind && r.Name == name {
+			return r.Res, true
+		}
+	}
+	var objs []metav1.Object
+	switch {
+	case gvk.Group == "" && gvk.Kind == "Service":
+		for i := range rbStatus.ServiceStatuses {
+			objs = append(objs, &rbStatus.ServiceStatuses[i])
+		}
+	case gvk.Group == "" && gvk.Kind == "ConfigMap":
+		for i := range rbStatus.ConfigMapStatuses {
+			objs = append(objs, &rbStatus.ConfigMapStatuses[i])
+		}
+	case gvk.Group == "apps" && gvk.Kind == "Deployment":
+		for i := range rbStatus.DeploymentStatuses {
+			objs = append(objs, &rbStatus.DeploymentStatuses[i])
+		}
+	case gvk.Group == "apps" && gvk.Kind == "DaemonSet":
+		for i := range rbStatus.DaemonSetStatuses {
+			objs = append(objs, &rbStatus.DaemonSetStatuses[i])
+		}
+	case gvk.Group == "apps" && gvk.Kind == "StatefulSet":
+		for i := range rbStatus.StatefulSetStatuses {
+			objs = append(objs, &rbStatus.StatefulSetStatuses[i])
+		}
+	case gvk.Group == "batch" && gvk.Kind == "Job":
+		for i := range rbStatus.JobStatuses {
+			objs = append(objs, &rbStatus.JobStatuses[i])
+		}
+	}
+	for _, o := range objs {
+		if o.GetName() != name {
+			continue
+		}
+		b, err := json.Marshal(o)
+		if err != nil {
+			return nil, false
+		}
+		return b, true
+	}
+	return nil, false
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package status_test
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/status"
+)
+
+var desiredDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.21
+        ports:
+        - containerPort: 80
+`
+
+func TestDriftedFields(t *testing.T) {
+	testCases := []struct {
+		label    string
+		live     string
+		expected []string
+	}{
+		{
+			label: "Fields added on the cluster",
+			live: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","uid":"1234",
+				"labels":{"app":"web","emco/deployment-id":"1-web"}},"spec":{"replicas":2,"strategy":{},
+				"template":{"spec":{"containers":[{"name":"web","image":"nginx:1.21","ports":[{"containerPort":80,"protocol":"TCP"}]}]}}},
+				"status":{"replicas":2}}`,
+			expected: []string{},
+		},
+		{
+			label: "Fields edited on the cluster",
+			live: `{"metadata":{"name":"web","labels":{"app":"api"}},"spec":{"replicas":5,
+				"template":{"spec":{"containers":[{"name":"web","image":"nginx:latest","ports":[{"containerPort":80}]}]}}}}`,
+			expected: []string{"metadata.labels.app", "spec.replicas", "spec.template.spec.containers[0].image"},
+		},
+		{
+			label: "Container added on the cluster",
+			live: `{"metadata":{"name":"web","labels":{"app":"web"}},"spec":{"replicas":2,
+				"template":{"spec":{"containers":[{"name":"web","image":"nginx:1.21","ports":[{"containerPort":80}]},{"name":"proxy"}]}}}}`,
+			expected: []string{"spec.template.spec.containers"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			fields, err := status.DriftedFields([]byte(desiredDeployment), []byte(testCase.live))
+			if err != nil {
+				t.Fatalf("DriftedFields returned an error (%s)", err)
+			}
+			if !reflect.DeepEqual(fields, testCase.expected) {
+				t.Fatalf("DriftedFields returned %v, expected %v", fields, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestDriftedFieldsSecret(t *testing.T) {
+	desired := `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+stringData:
+  user: admin
+data:
+  token: dG9rZW4=
+`
+	testCases := []struct {
+		label    string
+		live     string
+		expected []string
+	}{
+		{
+			label:    "Secret with stringData",
+			live:     `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"creds"},"data":{"user":"YWRtaW4=","token":"dG9rZW4="},"type":"Opaque"}`,
+			expected: []string{},
+		},
+		{
+			label:    "Secret edited on the cluster",
+			live:     `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"creds"},"data":{"user":"cm9vdA==","token":"dG9rZW4="}}`,
+			expected: []string{"data.user"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			fields, err := status.DriftedFields([]byte(desired), []byte(testCase.live))
+			if err != nil {
+				t.Fatalf("DriftedFields returned an error (%s)", err)
+			}
+			if !reflect.DeepEqual(fields, testCase.expected) {
+				t.Fatalf("DriftedFields returned %v, expected %v", fields, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestGitOpsSyncStatus(t *testing.T) {
+	sync, health, ok := status.GitOpsSyncStatus([]byte(`{"kind":"Deployment","name":"web","status":"OutOfSync","health":{"status":"Healthy"}}`))
+	if !ok || sync != "OutOfSync" || health != "Healthy" {
+		t.Fatalf("GitOpsSyncStatus returned (%s, %s, %v)", sync, health, ok)
+	}
+	_, _, ok = status.GitOpsSyncStatus([]byte(`{"kind":"Deployment","metadata":{"name":"web"},"status":{"replicas":2}}`))
+	if ok {
+		t.Fatalf("GitOpsSyncStatus of a resource returned a sync status")
+	}
+}
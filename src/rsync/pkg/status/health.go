@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package status
+
+import (
+	"context"
+
+	rb "gitlab.com/project-emco/core/emco-base/src/monitor/pkg/apis/k8splugin/v1alpha1"
+	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/internal/utils"
+	"gitlab.com/project-emco/core/emco-base/src/rsync/pkg/types"
+)
+
+// ResourceHealth is the readiness of a resource reported by the GitOps agent
+// of a cluster
+type ResourceHealth struct {
+	Name  string
+	Kind  string
+	Ready bool
+}
+
+// HandleResourcesHealth updates the status of the App on a cluster with the
+// readiness of the resources reported by the GitOps agent of the cluster, e.g.
+// Argo CD, instead of computing it from the resources like the monitor
+func HandleResourcesHealth(ctx context.Context, acID, app, cluster string, rbData *rb.ResourceBundleState, health []ResourceHealth) {
+	if !saveResourcesStatus(ctx, acID, app, cluster, rbData) {
+		return
+	}
+
+	UpdateAppHealthStatus(ctx, acID, app, cluster, health)
+
+	notifyResourcesStatus(ctx, acID, app, cluster)
+}
+
+// UpdateAppHealthStatus updates the ready status of the resources, and of
+// the App on the cluster, with their readiness reported by the GitOps agent
+func UpdateAppHealthStatus(ctx context.Context, acID, app, cluster string, health []ResourceHealth) bool {
+	acUtils, err := utils.NewAppContextReference(ctx, acID)
+	if err != nil {
+		return false
+	}
+	ready := len(health) > 0
+	for _, h := range health {
+		acUtils.SetResourceReadyStatus(ctx, app, cluster, h.Name+"+"+h.Kind, string(types.ReadyStatus), h.Ready)
+		if !h.Ready {
+			ready = false
+		}
+	}
+	acUtils.SetClusterResourcesReady(ctx, app, cluster, ready)
+	if ready {
+		log.Info(" UpdateAppHealthStatus:: App is ready on cluster", log.Fields{"acID": acID, "app": app, "cluster": cluster})
+	}
+	return ready
+}
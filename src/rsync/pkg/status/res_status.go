@@ -25,23 +25,34 @@ var PreInstallHookLabel string = "emco/preinstallHook"
 
 // Update status for the App ready on a cluster and check if app ready on all clusters
 func HandleResourcesStatus(ctx context.Context, acID, app, cluster string, rbData *rb.ResourceBundleState) {
+	if !saveResourcesStatus(ctx, acID, app, cluster, rbData) {
+		return
+	}
+
+	UpdateAppReadyStatus(ctx, acID, app, cluster, rbData)
+
+	notifyResourcesStatus(ctx, acID, app, cluster)
+}
+
+// saveResourcesStatus stores the status of the App on a cluster in the appcontext
+func saveResourcesStatus(ctx context.Context, acID, app, cluster string, rbData *rb.ResourceBundleState) bool {
 	// Look up the contextId
 	var ac appcontext.AppContext
 	_, err := ac.LoadAppContext(ctx, acID)
 	if err != nil {
 		log.Error("::App context not found::", log.Fields{"acID": acID, "app": app, "cluster": cluster, "err": err})
-		return
+		return false
 	}
 	// Produce yaml representation of the status
 	vjson, err := json.Marshal(rbData.Status)
 	if err != nil {
 		log.Error("::Error marshalling status information::", log.Fields{"acID": acID, "app": app, "cluster": cluster, "err": err})
-		return
+		return false
 	}
 	chandle, err := ac.GetClusterHandle(ctx, app, cluster)
 	if err != nil {
 		log.Error("::Error getting cluster handle::", log.Fields{"acID": acID, "app": app, "cluster": cluster, "err": err})
-		return
+		return false
 	}
 	// Get the handle for the context/app/cluster status object
 	handle, _ := ac.GetLevelHandle(ctx, chandle, "status")
@@ -52,14 +63,17 @@ func HandleResourcesStatus(ctx context.Context, acID, app, cluster string, rbDat
 	} else {
 		ac.UpdateStatusValue(ctx, handle, string(vjson))
 	}
+	return true
+}
 
-	UpdateAppReadyStatus(ctx, acID, app, cluster, rbData)
-
+// notifyResourcesStatus informs the dependency management and the subscribers
+// of an update of the status of the App on a cluster
+func notifyResourcesStatus(ctx context.Context, acID, app, cluster string) {
 	// Inform Rsync dependency management of the update
 	go depend.ResourcesReady(ctx, acID, app, cluster)
 
 	// Send notification to the subscribers
-	err = readynotifyserver.SendAppContextNotification(acID, app, cluster)
+	err := readynotifyserver.SendAppContextNotification(acID, app, cluster)
 	if err != nil {
 		log.Error("::Error sending ReadyNotify to subscribers::", log.Fields{"acID": acID, "app": app, "cluster": cluster, "err": err})
 	}
@@ -86,3 +86,40 @@ func TestAppReadyOnAllClusters(t *testing.T) {
 		})
 	}
 }
+
+func TestAppHealthStatus(t *testing.T) {
+	cid, _ := contextUtils.CreateCompApp(context.Background(), TestCA)
+
+	testCases := []struct {
+		label         string
+		expectedValue bool
+		health        []status.ResourceHealth
+	}{
+		{
+			label:         "All resources healthy",
+			expectedValue: true,
+			health:        []status.ResourceHealth{{Name: "r1", Kind: "Deployment", Ready: true}, {Name: "r2", Kind: "Service", Ready: true}},
+		},
+		{
+			label:         "A resource not healthy",
+			expectedValue: false,
+			health:        []status.ResourceHealth{{Name: "r1", Kind: "Deployment", Ready: false}, {Name: "r2", Kind: "Service", Ready: true}},
+		},
+		{
+			label:         "No resources reported",
+			expectedValue: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.label, func(t *testing.T) {
+			val := status.UpdateAppHealthStatus(context.Background(), cid, "collectd", "provider1+cluster1", testCase.health)
+			if val != testCase.expectedValue {
+				t.Fatalf("TestAppHealthStatus Failed")
+			}
+			acUtils, _ := utils.NewAppContextReference(context.Background(), cid)
+			if acUtils.GetClusterResourcesReady(context.Background(), "collectd", "provider1+cluster1") != testCase.expectedValue {
+				t.Fatalf("TestAppHealthStatus cluster ready status not updated")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package types
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/appcontext"
@@ -230,6 +231,12 @@ type ReferenceProvider interface {
 	DeleteConfig(ctx context.Context, config interface{}) error
 }
 
+// ResourceLister is implemented by the client providers that can list the
+// resources on the cluster, needed to prune the orphaned resources
+type ResourceLister interface {
+	List(ctx context.Context, labelSelector string) ([]unstructured.Unstructured, error)
+}
+
 // Client Provider provides functionality to interface with the cluster
 type ClientProvider interface {
 	ResourceProvider
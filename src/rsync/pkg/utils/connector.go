@@ -71,7 +71,7 @@ func (c *Connection) GetClient(ctx context.Context, cluster string, level string
 		if err != nil {
 			return nil, err
 		}
-		client = kubeclient.New("", kubeConfig, namespace)
+		client = kubeclient.NewForCluster(cluster, kubeConfig, namespace)
 		if client != nil {
 			c.Clients[cluster] = client
 		} else {
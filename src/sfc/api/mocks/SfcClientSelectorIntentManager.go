@@ -6,6 +6,7 @@ import (
 	"context"
 	model "gitlab.com/project-emco/core/emco-base/src/sfc/pkg/model"
 	mock "github.com/stretchr/testify/mock"
+	db "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // SfcClientSelectorIntentManager is an autogenerated mock type for the SfcClientSelectorIntentManager type
@@ -71,6 +72,36 @@ func (_m *SfcClientSelectorIntentManager) GetAllSfcClientSelectorIntents(ctx con
 	return r0, r1
 }
 
+// ListSfcClientSelectorIntents provides a mock function with given fields: pr, ca, caver, dig, sfcIntent, opts
+func (_m *SfcClientSelectorIntentManager) ListSfcClientSelectorIntents(ctx context.Context, pr string, ca string, caver string, dig string, sfcIntent string, opts db.FindOptions) ([]model.SfcClientSelectorIntent, string, error) {
+	ret := _m.Called(ctx, pr, ca, caver, dig, sfcIntent, opts)
+
+	var r0 []model.SfcClientSelectorIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, db.FindOptions) []model.SfcClientSelectorIntent); ok {
+		r0 = rf(pr, ca, caver, dig, sfcIntent, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SfcClientSelectorIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(pr, ca, caver, dig, sfcIntent, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(pr, ca, caver, dig, sfcIntent, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSfcClientSelectorIntent provides a mock function with given fields: name, pr, ca, caver, dig, sfcIntent
 func (_m *SfcClientSelectorIntentManager) GetSfcClientSelectorIntent(ctx context.Context, name string, pr string, ca string, caver string, dig string, sfcIntent string) (model.SfcClientSelectorIntent, error) {
 	ret := _m.Called(ctx, name, pr, ca, caver, dig, sfcIntent)
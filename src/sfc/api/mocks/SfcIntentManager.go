@@ -6,6 +6,7 @@ import (
 	"context"
 	model "gitlab.com/project-emco/core/emco-base/src/sfc/pkg/model"
 	mock "github.com/stretchr/testify/mock"
+	db "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 )
 
 // SfcIntentManager is an autogenerated mock type for the SfcIntentManager type
@@ -71,6 +72,36 @@ func (_m *SfcIntentManager) GetAllSfcIntents(ctx context.Context, pr string, ca
 	return r0, r1
 }
 
+// ListSfcIntents provides a mock function with given fields: pr, ca, caver, dig, opts
+func (_m *SfcIntentManager) ListSfcIntents(ctx context.Context, pr string, ca string, caver string, dig string, opts db.FindOptions) ([]model.SfcIntent, string, error) {
+	ret := _m.Called(ctx, pr, ca, caver, dig, opts)
+
+	var r0 []model.SfcIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, db.FindOptions) []model.SfcIntent); ok {
+		r0 = rf(pr, ca, caver, dig, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SfcIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(pr, ca, caver, dig, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(pr, ca, caver, dig, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSfcIntent provides a mock function with given fields: name, pr, ca, caver, dig
 func (_m *SfcIntentManager) GetSfcIntent(ctx context.Context, name string, pr string, ca string, caver string, dig string) (model.SfcIntent, error) {
 	ret := _m.Called(ctx, name, pr, ca, caver, dig)
@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	"gitlab.com/project-emco/core/emco-base/src/sfc/pkg/model"
@@ -134,9 +136,17 @@ func (h sfcIntentHandler) getSfcHandler(w http.ResponseWriter, r *http.Request)
 	deployIntentGroup := vars["deploymentIntentGroup"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetAllSfcIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListSfcIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, opts)
 	} else {
 		ret, err = h.client.GetSfcIntent(ctx, name, project, compositeApp, compositeAppVersion, deployIntentGroup)
 	}
@@ -147,6 +157,7 @@ func (h sfcIntentHandler) getSfcHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -791,7 +791,7 @@ var _ = Describe("SfcClientSelectorintenthandler", func() {
 	DescribeTable("Get List SfcClientSelectorIntent tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetAllSfcClientSelectorIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "sfc-intent").Return(t.mockVals, t.mockError)
+			t.client.On("ListSfcClientSelectorIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "sfc-intent", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/network-chains/sfc-intent/client-selectors", nil)
@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	"gitlab.com/project-emco/core/emco-base/src/sfc/pkg/model"
@@ -137,9 +139,17 @@ func (h sfcLinkIntentHandler) getLinkHandler(w http.ResponseWriter, r *http.Requ
 	sfcIntent := vars["sfcIntent"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetAllSfcLinkIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, sfcIntent)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListSfcLinkIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, sfcIntent, opts)
 		if err != nil {
 			apiErr := apierror.HandleErrors(vars, err, nil, apiErrors)
 			http.Error(w, apiErr.Message, apiErr.Status)
@@ -154,6 +164,7 @@ func (h sfcLinkIntentHandler) getLinkHandler(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
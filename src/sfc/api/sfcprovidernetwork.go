@@ -10,6 +10,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	"gitlab.com/project-emco/core/emco-base/src/sfc/pkg/model"
@@ -136,9 +138,17 @@ func (h sfcProviderNetworkIntentHandler) getProviderNetworkHandler(w http.Respon
 	sfcIntent := vars["sfcIntent"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetAllSfcProviderNetworkIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, sfcIntent)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListSfcProviderNetworkIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, sfcIntent, opts)
 	} else {
 		ret, err = h.client.GetSfcProviderNetworkIntent(ctx, name, project, compositeApp, compositeAppVersion, deployIntentGroup, sfcIntent)
 	}
@@ -149,6 +159,7 @@ func (h sfcProviderNetworkIntentHandler) getProviderNetworkHandler(w http.Respon
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
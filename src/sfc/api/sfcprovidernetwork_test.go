@@ -635,7 +635,7 @@ var _ = Describe("SfcProviderNetworkintenthandler", func() {
 	DescribeTable("Get List SfcProviderNetworkIntent tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetAllSfcProviderNetworkIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "sfc-intent").Return(t.mockVals, t.mockError)
+			t.client.On("ListSfcProviderNetworkIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", "sfc-intent", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/network-chains/sfc-intent/provider-networks", nil)
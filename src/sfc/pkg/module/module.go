@@ -6,6 +6,7 @@ package module
 import (
 	"context"
 
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/sfc/pkg/model"
 )
 
@@ -23,6 +24,7 @@ type SfcIntentManager interface {
 	CreateSfcIntent(ctx context.Context, sfc model.SfcIntent, pr, ca, caver, dig string, exists bool) (model.SfcIntent, error)
 	GetSfcIntent(ctx context.Context, name, pr, ca, caver, dig string) (model.SfcIntent, error)
 	GetAllSfcIntents(ctx context.Context, pr, ca, caver, dig string) ([]model.SfcIntent, error)
+	ListSfcIntents(ctx context.Context, pr, ca, caver, dig string, opts db.FindOptions) ([]model.SfcIntent, string, error)
 	DeleteSfcIntent(ctx context.Context, name, pr, ca, caver, dig string) error
 }
 
@@ -32,6 +34,7 @@ type SfcLinkIntentManager interface {
 	CreateSfcLinkIntent(ctx context.Context, sfc model.SfcLinkIntent, pr, ca, caver, dig, sfcIntent string, exists bool) (model.SfcLinkIntent, error)
 	GetSfcLinkIntent(ctx context.Context, name, pr, ca, caver, dig, sfcIntent string) (model.SfcLinkIntent, error)
 	GetAllSfcLinkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string) ([]model.SfcLinkIntent, error)
+	ListSfcLinkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string, opts db.FindOptions) ([]model.SfcLinkIntent, string, error)
 	DeleteSfcLinkIntent(ctx context.Context, name, pr, ca, caver, dig, sfcIntent string) error
 }
 
@@ -41,6 +44,7 @@ type SfcClientSelectorIntentManager interface {
 	CreateSfcClientSelectorIntent(ctx context.Context, sfc model.SfcClientSelectorIntent, pr, ca, caver, dig, sfcIntent string, exists bool) (model.SfcClientSelectorIntent, error)
 	GetSfcClientSelectorIntent(ctx context.Context, name, pr, ca, caver, dig, sfcIntent string) (model.SfcClientSelectorIntent, error)
 	GetAllSfcClientSelectorIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string) ([]model.SfcClientSelectorIntent, error)
+	ListSfcClientSelectorIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string, opts db.FindOptions) ([]model.SfcClientSelectorIntent, string, error)
 	GetSfcClientSelectorIntentsByEnd(ctx context.Context, pr, ca, caver, dig, sfcIntent, chainEnd string) ([]model.SfcClientSelectorIntent, error)
 	DeleteSfcClientSelectorIntent(ctx context.Context, name, pr, ca, caver, dig, sfcIntent string) error
 }
@@ -51,6 +55,7 @@ type SfcProviderNetworkIntentManager interface {
 	CreateSfcProviderNetworkIntent(ctx context.Context, sfc model.SfcProviderNetworkIntent, pr, ca, caver, dig, sfcIntent string, exists bool) (model.SfcProviderNetworkIntent, error)
 	GetSfcProviderNetworkIntent(ctx context.Context, name, pr, ca, caver, dig, sfcIntent string) (model.SfcProviderNetworkIntent, error)
 	GetAllSfcProviderNetworkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string) ([]model.SfcProviderNetworkIntent, error)
+	ListSfcProviderNetworkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string, opts db.FindOptions) ([]model.SfcProviderNetworkIntent, string, error)
 	GetSfcProviderNetworkIntentsByEnd(ctx context.Context, pr, ca, caver, dig, sfcIntent, chainEnd string) ([]model.SfcProviderNetworkIntent, error)
 	DeleteSfcProviderNetworkIntent(ctx context.Context, name, pr, ca, caver, dig, sfcIntent string) error
 }
@@ -75,6 +80,18 @@ func NewClient() *Client {
 	return c
 }
 
+// sfcIntentSelectors are the fields a list of SFC intents can be filtered and sorted on
+var sfcIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":      "data.metadata.name",
+		"chainType": "data.spec.chainType",
+		"namespace": "data.spec.namespace",
+	},
+	TagTypes: map[string]interface{}{
+		"data": model.SfcIntent{},
+	},
+}
+
 // SfcIntentClient implements the SfcIntentManager
 // It will also be used to maintain some localized state
 type SfcIntentClient struct {
@@ -92,6 +109,20 @@ func NewSfcIntentClient() *SfcIntentClient {
 	}
 }
 
+// sfcLinkIntentSelectors are the fields a list of SFC link intents can be filtered and sorted on
+var sfcLinkIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":      "data.metadata.name",
+		"leftNet":   "data.spec.leftNet",
+		"rightNet":  "data.spec.rightNet",
+		"linkLabel": "data.spec.linkLabel",
+		"app":       "data.spec.app",
+	},
+	TagTypes: map[string]interface{}{
+		"data": model.SfcLinkIntent{},
+	},
+}
+
 // SfcLinkIntentClient implements the SfcLinkIntentManager
 // It will also be used to maintain some localized state
 type SfcLinkIntentClient struct {
@@ -109,6 +140,17 @@ func NewSfcLinkIntentClient() *SfcLinkIntentClient {
 	}
 }
 
+// sfcClientSelectorIntentSelectors are the fields a list of SFC client selector intents can be filtered and sorted on
+var sfcClientSelectorIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":     "data.metadata.name",
+		"chainEnd": "data.spec.chainEnd",
+	},
+	TagTypes: map[string]interface{}{
+		"data": model.SfcClientSelectorIntent{},
+	},
+}
+
 // SfcClientSelectorIntentClient implements the SfcClientSelectorIntentManager
 // It will also be used to maintain some localized state
 type SfcClientSelectorIntentClient struct {
@@ -126,6 +168,18 @@ func NewSfcClientSelectorIntentClient() *SfcClientSelectorIntentClient {
 	}
 }
 
+// sfcProviderNetworkIntentSelectors are the fields a list of SFC provider network intents can be filtered and sorted on
+var sfcProviderNetworkIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":            "data.metadata.name",
+		"chainEnd":        "data.spec.chainEnd",
+		"providerNetwork": "data.spec.providerNetwork",
+	},
+	TagTypes: map[string]interface{}{
+		"data": model.SfcProviderNetworkIntent{},
+	},
+}
+
 // SfcProviderNetworkIntentClient implements the SfcProviderNetworkIntentManager
 // It will also be used to maintain some localized state
 type SfcProviderNetworkIntentClient struct {
@@ -70,6 +70,19 @@ func (v *SfcIntentClient) GetSfcIntent(ctx context.Context, name, pr, ca, caver,
 
 // GetAllSfcIntent returns all of the SFC Intents for for the given network control intent
 func (v *SfcIntentClient) GetAllSfcIntents(ctx context.Context, pr, ca, caver, dig string) ([]model.SfcIntent, error) {
+	res, _, err := v.ListSfcIntents(ctx, pr, ca, caver, dig, db.FindOptions{})
+	return res, err
+}
+
+// ListSfcIntents returns a page of the SFC Intents for the given Deployment
+// Intent Group, filtered and sorted on the sfcIntentSelectors, and the token of
+// the next page
+func (v *SfcIntentClient) ListSfcIntents(ctx context.Context, pr, ca, caver, dig string, opts db.FindOptions) ([]model.SfcIntent, string, error) {
+	opts, err := sfcIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []model.SfcIntent{}, "", err
+	}
+
 	//Construct key and tag to select the entry
 	key := model.SfcIntentKey{
 		Project:             pr,
@@ -82,26 +95,26 @@ func (v *SfcIntentClient) GetAllSfcIntents(ctx context.Context, pr, ca, caver, d
 	resp := make([]model.SfcIntent, 0)
 
 	// Verify the Deployment Intent Group exists
-	_, err := orchmod.NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, dig, pr, ca, caver)
+	_, err = orchmod.NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, dig, pr, ca, caver)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []model.SfcIntent{}, err
+		return []model.SfcIntent{}, "", err
 	}
 
 	for _, value := range values {
 		cp := model.SfcIntent{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return []model.SfcIntent{}, err
+			return []model.SfcIntent{}, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteSfcIntent deletes the SfcIntent from the database
@@ -75,6 +75,19 @@ func (v *SfcClientSelectorIntentClient) GetSfcClientSelectorIntent(ctx context.C
 
 // GetAllSfcClientSelectorIntent returns all of the SFC Intents for for the given Deployment Intent Group
 func (v *SfcClientSelectorIntentClient) GetAllSfcClientSelectorIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string) ([]model.SfcClientSelectorIntent, error) {
+	res, _, err := v.ListSfcClientSelectorIntents(ctx, pr, ca, caver, dig, sfcIntent, db.FindOptions{})
+	return res, err
+}
+
+// ListSfcClientSelectorIntents returns a page of the SFC Client Selector Intents
+// of the SFC Intent, filtered and sorted on the sfcClientSelectorIntentSelectors,
+// and the token of the next page
+func (v *SfcClientSelectorIntentClient) ListSfcClientSelectorIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string, opts db.FindOptions) ([]model.SfcClientSelectorIntent, string, error) {
+	opts, err := sfcClientSelectorIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []model.SfcClientSelectorIntent{}, "", err
+	}
+
 	//Construct key and tag to select the entry
 	key := model.SfcClientSelectorIntentKey{
 		Project:                 pr,
@@ -88,26 +101,26 @@ func (v *SfcClientSelectorIntentClient) GetAllSfcClientSelectorIntents(ctx conte
 	resp := make([]model.SfcClientSelectorIntent, 0)
 
 	// Verify the SFC intent exists
-	_, err := NewSfcIntentClient().GetSfcIntent(ctx, sfcIntent, pr, ca, caver, dig)
+	_, err = NewSfcIntentClient().GetSfcIntent(ctx, sfcIntent, pr, ca, caver, dig)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
 	for _, value := range values {
 		cp := model.SfcClientSelectorIntent{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return resp, err
+			return resp, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // GetSfcClientSelectorIntentsByEnd returns all of the SFC Client Selector Intents for for the given Deployment Intent Group
@@ -71,6 +71,19 @@ func (v *SfcLinkIntentClient) GetSfcLinkIntent(ctx context.Context, name, pr, ca
 
 // GetAllSfcLinkIntent returns all of the SFC Intents for for the given Deployment Intent Group
 func (v *SfcLinkIntentClient) GetAllSfcLinkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string) ([]model.SfcLinkIntent, error) {
+	res, _, err := v.ListSfcLinkIntents(ctx, pr, ca, caver, dig, sfcIntent, db.FindOptions{})
+	return res, err
+}
+
+// ListSfcLinkIntents returns a page of the SFC Link Intents of the SFC Intent,
+// filtered and sorted on the sfcLinkIntentSelectors, and the token of the next
+// page
+func (v *SfcLinkIntentClient) ListSfcLinkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string, opts db.FindOptions) ([]model.SfcLinkIntent, string, error) {
+	opts, err := sfcLinkIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []model.SfcLinkIntent{}, "", err
+	}
+
 	//Construct key and tag to select the entry
 	key := model.SfcLinkIntentKey{
 		Project:             pr,
@@ -84,26 +97,26 @@ func (v *SfcLinkIntentClient) GetAllSfcLinkIntents(ctx context.Context, pr, ca,
 	resp := make([]model.SfcLinkIntent, 0)
 
 	// Verify the SFC intent exists
-	_, err := NewSfcIntentClient().GetSfcIntent(ctx, sfcIntent, pr, ca, caver, dig)
+	_, err = NewSfcIntentClient().GetSfcIntent(ctx, sfcIntent, pr, ca, caver, dig)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
 	for _, value := range values {
 		cp := model.SfcLinkIntent{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return resp, err
+			return resp, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteSfcLinkIntent deletes the SfcLinkIntent from the database
@@ -71,6 +71,19 @@ func (v *SfcProviderNetworkIntentClient) GetSfcProviderNetworkIntent(ctx context
 
 // GetAllSfcProviderNetworkIntent returns all of the SFC Intents for for the given Deployment Intent Group
 func (v *SfcProviderNetworkIntentClient) GetAllSfcProviderNetworkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string) ([]model.SfcProviderNetworkIntent, error) {
+	res, _, err := v.ListSfcProviderNetworkIntents(ctx, pr, ca, caver, dig, sfcIntent, db.FindOptions{})
+	return res, err
+}
+
+// ListSfcProviderNetworkIntents returns a page of the SFC Provider Network
+// Intents of the SFC Intent, filtered and sorted on the
+// sfcProviderNetworkIntentSelectors, and the token of the next page
+func (v *SfcProviderNetworkIntentClient) ListSfcProviderNetworkIntents(ctx context.Context, pr, ca, caver, dig, sfcIntent string, opts db.FindOptions) ([]model.SfcProviderNetworkIntent, string, error) {
+	opts, err := sfcProviderNetworkIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []model.SfcProviderNetworkIntent{}, "", err
+	}
+
 	//Construct key and tag to select the entry
 	key := model.SfcProviderNetworkIntentKey{
 		Project:                  pr,
@@ -84,26 +97,26 @@ func (v *SfcProviderNetworkIntentClient) GetAllSfcProviderNetworkIntents(ctx con
 	resp := make([]model.SfcProviderNetworkIntent, 0)
 
 	// verify SFC Intent exists
-	_, err := NewSfcIntentClient().GetSfcIntent(ctx, sfcIntent, pr, ca, caver, dig)
+	_, err = NewSfcIntentClient().GetSfcIntent(ctx, sfcIntent, pr, ca, caver, dig)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
 	for _, value := range values {
 		cp := model.SfcProviderNetworkIntent{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return resp, err
+			return resp, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // GetSfcProviderNetworkIntentByEnd returns all of the SFC Provider Network Intents for for the given Deployment Intent Group
@@ -5,6 +5,7 @@ package mocks
 import (
 	"context"
 	mock "github.com/stretchr/testify/mock"
+	db "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	model "gitlab.com/project-emco/core/emco-base/src/sfcclient/pkg/model"
 )
 
@@ -71,6 +72,36 @@ func (_m *SfcManager) GetAllSfcClientIntents(ctx context.Context, pr string, ca
 	return r0, r1
 }
 
+// ListSfcClientIntents provides a mock function with given fields: pr, ca, caver, dig, opts
+func (_m *SfcManager) ListSfcClientIntents(ctx context.Context, pr string, ca string, caver string, dig string, opts db.FindOptions) ([]model.SfcClientIntent, string, error) {
+	ret := _m.Called(ctx, pr, ca, caver, dig, opts)
+
+	var r0 []model.SfcClientIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, db.FindOptions) []model.SfcClientIntent); ok {
+		r0 = rf(pr, ca, caver, dig, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.SfcClientIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(pr, ca, caver, dig, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(pr, ca, caver, dig, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSfcClientIntent provides a mock function with given fields: name, pr, ca, caver, dig
 func (_m *SfcManager) GetSfcClientIntent(ctx context.Context, name string, pr string, ca string, caver string, dig string) (model.SfcClientIntent, error) {
 	ret := _m.Called(ctx, name, pr, ca, caver, dig)
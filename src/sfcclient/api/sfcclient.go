@@ -9,6 +9,8 @@ import (
 	"net/http"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	"gitlab.com/project-emco/core/emco-base/src/sfcclient/pkg/model"
@@ -143,9 +145,17 @@ func (h sfcHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 	deployIntentGroup := vars["deploymentIntentGroup"]
 	var ret interface{}
 	var err error
+	var next string
 
 	if len(name) == 0 {
-		ret, err = h.client.GetAllSfcClientIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListSfcClientIntents(ctx, project, compositeApp, compositeAppVersion, deployIntentGroup, opts)
 	} else {
 		ret, err = h.client.GetSfcClientIntent(ctx, name, project, compositeApp, compositeAppVersion, deployIntentGroup)
 	}
@@ -156,6 +166,7 @@ func (h sfcHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
@@ -798,7 +798,7 @@ var _ = Describe("Sfcintenthandler", func() {
 	DescribeTable("Get List SfcClientIntent tests",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetAllSfcClientIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig").Return(t.mockVals, t.mockError)
+			t.client.On("ListSfcClientIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/sfc-clients", nil)
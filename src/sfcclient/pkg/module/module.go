@@ -5,6 +5,7 @@ package module
 
 import (
 	"context"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/sfcclient/pkg/model"
 )
 
@@ -22,9 +23,23 @@ type SfcManager interface {
 	CreateSfcClientIntent(ctx context.Context, sfc model.SfcClientIntent, pr, ca, caver, dig string, exists bool) (model.SfcClientIntent, error)
 	GetSfcClientIntent(ctx context.Context, name, pr, ca, caver, dig string) (model.SfcClientIntent, error)
 	GetAllSfcClientIntents(ctx context.Context, pr, ca, caver, dig string) ([]model.SfcClientIntent, error)
+	ListSfcClientIntents(ctx context.Context, pr, ca, caver, dig string, opts db.FindOptions) ([]model.SfcClientIntent, string, error)
 	DeleteSfcClientIntent(ctx context.Context, name, pr, ca, caver, dig string) error
 }
 
+// sfcClientIntentSelectors are the fields a list of SFC client intents can be filtered and sorted on
+var sfcClientIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":      "data.metadata.name",
+		"chainEnd":  "data.spec.chainEnd",
+		"sfcIntent": "data.spec.sfcIntent",
+		"app":       "data.spec.app",
+	},
+	TagTypes: map[string]interface{}{
+		"data": model.SfcClientIntent{},
+	},
+}
+
 // SfcClient implements the Manager
 // It will also be used to maintain some localized state
 type SfcClient struct {
@@ -70,6 +70,19 @@ func (v *SfcClient) GetSfcClientIntent(ctx context.Context, name, pr, ca, caver,
 
 // GetAllSfcClientIntent returns all of the SFC Client Intents for for the given network control intent
 func (v *SfcClient) GetAllSfcClientIntents(ctx context.Context, pr, ca, caver, dig string) ([]model.SfcClientIntent, error) {
+	res, _, err := v.ListSfcClientIntents(ctx, pr, ca, caver, dig, db.FindOptions{})
+	return res, err
+}
+
+// ListSfcClientIntents returns a page of the SFC Client Intents for the given
+// Deployment Intent Group, filtered and sorted on the sfcClientIntentSelectors,
+// and the token of the next page
+func (v *SfcClient) ListSfcClientIntents(ctx context.Context, pr, ca, caver, dig string, opts db.FindOptions) ([]model.SfcClientIntent, string, error) {
+	opts, err := sfcClientIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []model.SfcClientIntent{}, "", err
+	}
+
 	//Construct key and tag to select the entry
 	key := model.SfcClientIntentKey{
 		Project:             pr,
@@ -82,26 +95,26 @@ func (v *SfcClient) GetAllSfcClientIntents(ctx context.Context, pr, ca, caver, d
 	resp := make([]model.SfcClientIntent, 0)
 
 	// Verify the Deployment Intent Group exists
-	_, err := orchmod.NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, dig, pr, ca, caver)
+	_, err = orchmod.NewDeploymentIntentGroupClient().GetDeploymentIntentGroup(ctx, dig, pr, ca, caver)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return resp, err
+		return resp, "", err
 	}
 
 	for _, value := range values {
 		cp := model.SfcClientIntent{}
 		err = db.DBconn.Unmarshal(value, &cp)
 		if err != nil {
-			return resp, err
+			return resp, "", err
 		}
 		resp = append(resp, cp)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteSfcClientIntent deletes the SfcClientIntent from the database
@@ -11,6 +11,8 @@ import (
 	"net/http/httputil"
 
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	"gitlab.com/project-emco/core/emco-base/src/tac/pkg/model"
@@ -127,6 +129,7 @@ func (h intentHandler) handleTacIntentGet(w http.ResponseWriter, r *http.Request
 	// response variables
 	var resp interface{}
 	var err error
+	var next string
 
 	vars := _wfhVars(mux.Vars(r))
 
@@ -137,7 +140,14 @@ func (h intentHandler) handleTacIntentGet(w http.ResponseWriter, r *http.Request
 
 	// make the request
 	if len(vars.tacIntent) == 0 {
-		resp, err = h.client.GetWorkflowHookIntents(ctx, vars.project, vars.cApp, vars.cAppVer, vars.dig)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			logutils.Error(":: Invalid list options ::", logutils.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, next, err = h.client.ListWorkflowHookIntents(ctx, vars.project, vars.cApp, vars.cAppVer, vars.dig, opts)
 	} else {
 		resp, err = h.client.GetWorkflowHookIntent(ctx, vars.tacIntent, vars.project, vars.cApp, vars.cAppVer, vars.dig)
 	}
@@ -150,6 +160,7 @@ func (h intentHandler) handleTacIntentGet(w http.ResponseWriter, r *http.Request
 	}
 
 	// Send the response to the client.
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(resp)
@@ -206,7 +206,7 @@ var _ = Describe("HookIntentHandlers", func() {
 	DescribeTable("Get All WorkflowIntentHooks",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetWorkflowHookIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig").Return(t.mockVals, t.mockError)
+			t.client.On("ListWorkflowHookIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/temporal-action-controller", t.inputReader)
@@ -237,7 +237,7 @@ var _ = Describe("HookIntentHandlers", func() {
 	DescribeTable("Get One WorkflowIntentHooks",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetWorkflowHookIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig").Return(t.mockVals, t.mockError)
+			t.client.On("ListWorkflowHookIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/temporal-action-controller", t.inputReader)
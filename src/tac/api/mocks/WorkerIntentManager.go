@@ -4,6 +4,7 @@ package mocks
 
 import (
 	mock "github.com/stretchr/testify/mock"
+	db "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	model "gitlab.com/project-emco/core/emco-base/src/tac/pkg/model"
 
 	testing "testing"
@@ -93,6 +94,36 @@ func (_m *WorkerIntentManager) GetWorkerIntents(project string, cApp string, cAp
 	return r0, r1
 }
 
+// ListWorkerIntents provides a mock function with given fields: project, cApp, cAppVer, dig, tac, opts
+func (_m *WorkerIntentManager) ListWorkerIntents(project string, cApp string, cAppVer string, dig string, tac string, opts db.FindOptions) ([]model.WorkerIntent, string, error) {
+	ret := _m.Called(project, cApp, cAppVer, dig, tac, opts)
+
+	var r0 []model.WorkerIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, db.FindOptions) []model.WorkerIntent); ok {
+		r0 = rf(project, cApp, cAppVer, dig, tac, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WorkerIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, cApp, cAppVer, dig, tac, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, cApp, cAppVer, dig, tac, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewWorkerIntentManager creates a new instance of WorkerIntentManager. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewWorkerIntentManager(t testing.TB) *WorkerIntentManager {
 	mock := &WorkerIntentManager{}
@@ -5,6 +5,7 @@ package mocks
 import (
 	"context"
 	mock "github.com/stretchr/testify/mock"
+	db "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	model "gitlab.com/project-emco/core/emco-base/src/tac/pkg/model"
 
 	pkgmodule "gitlab.com/project-emco/core/emco-base/src/workflowmgr/pkg/module"
@@ -156,6 +157,36 @@ func (_m *WorkflowIntentManager) GetWorkflowHookIntents(ctx context.Context, pro
 	return r0, r1
 }
 
+// ListWorkflowHookIntents provides a mock function with given fields: project, cApp, cAppVer, dig, opts
+func (_m *WorkflowIntentManager) ListWorkflowHookIntents(ctx context.Context, project string, cApp string, cAppVer string, dig string, opts db.FindOptions) ([]model.WorkflowHookIntent, string, error) {
+	ret := _m.Called(ctx, project, cApp, cAppVer, dig, opts)
+
+	var r0 []model.WorkflowHookIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, db.FindOptions) []model.WorkflowHookIntent); ok {
+		r0 = rf(project, cApp, cAppVer, dig, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WorkflowHookIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, cApp, cAppVer, dig, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, cApp, cAppVer, dig, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewWorkflowIntentManager creates a new instance of WorkflowIntentManager. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewWorkflowIntentManager(t testing.TB) *WorkflowIntentManager {
 	mock := &WorkflowIntentManager{}
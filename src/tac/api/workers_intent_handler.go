@@ -12,6 +12,8 @@ import (
 
 	"github.com/gorilla/mux"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apierror"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	"gitlab.com/project-emco/core/emco-base/src/tac/pkg/model"
@@ -131,6 +133,7 @@ func (h workerHandler) handleWorkerGet(w http.ResponseWriter, r *http.Request) {
 	// response variabels
 	var resp interface{}
 	var err error
+	var next string
 
 	// get vars from URL
 	vars := _dwVars(mux.Vars(r))
@@ -145,7 +148,14 @@ func (h workerHandler) handleWorkerGet(w http.ResponseWriter, r *http.Request) {
 	// if there is a value in the workers value then it is get one
 	if len(vars.workers) == 0 {
 		logutils.Info("Get All Workers", logutils.Fields{})
-		resp, err = h.client.GetWorkerIntents(vars.project, vars.cApp, vars.cAppVer, vars.dig, vars.tacIntent)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			logutils.Error(":: Invalid list options ::", logutils.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, next, err = h.client.ListWorkerIntents(vars.project, vars.cApp, vars.cAppVer, vars.dig, vars.tacIntent, opts)
 	} else {
 		logutils.Info("Get Just One Worker", logutils.Fields{})
 		resp, err = h.client.GetWorkerIntent(vars.workers, vars.project, vars.cApp, vars.cAppVer, vars.dig, vars.tacIntent)
@@ -159,6 +169,7 @@ func (h workerHandler) handleWorkerGet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Send the response to the client.
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(resp)
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 	mtypes "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/module/types"
 	. "gitlab.com/project-emco/core/emco-base/src/tac/api"
 	"gitlab.com/project-emco/core/emco-base/src/tac/api/mocks"
@@ -141,7 +142,7 @@ var _ = Describe("WorkerIntentHandlerTests", func() {
 	DescribeTable("Get Multiple Workers", func(t workerTestCase) {
 		// set up the client mock response.
 		//(project string, cApp string, cAppVer string, dig string, tac string) ([]model.WorkerIntent, error)
-		t.client.On("GetWorkerIntents", "test-project", "test-cApp", "v1", "test-dig", "test-tac").Return(t.mockVals, t.mockError)
+		t.client.On("ListWorkerIntents", "test-project", "test-cApp", "v1", "test-dig", "test-tac", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 		// make the mock request.
 		request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-cApp/v1/deployment-intent-groups/test-dig/temporal-action-controller/test-tac/workers", t.inputReader)
@@ -20,6 +20,17 @@ import (
 
 // WfhClientSpec is the network endpoint at which the
 
+// workflowHookIntentSelectors are the fields a list of workflow hook intents can be filtered and sorted on
+var workflowHookIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":     "data.metadata.name",
+		"hookType": "data.spec.hookType",
+	},
+	TagTypes: map[string]interface{}{
+		"data": model.WorkflowHookIntent{},
+	},
+}
+
 // WorkflowIntentClient implements the Manager
 // It will also be used to maintain some localized state
 type WorkflowIntentClient struct {
@@ -41,6 +52,7 @@ type WorkflowIntentManager interface {
 	CreateWorkflowHookIntent(ctx context.Context, wfh model.WorkflowHookIntent, project, cApp, cAppVer, dig string, exists bool) (model.WorkflowHookIntent, error)
 	GetWorkflowHookIntent(ctx context.Context, name, project, cApp, cAppVer, dig string) (model.WorkflowHookIntent, error)
 	GetWorkflowHookIntents(ctx context.Context, project, cApp, cAppVer, dig string) ([]model.WorkflowHookIntent, error)
+	ListWorkflowHookIntents(ctx context.Context, project, cApp, cAppVer, dig string, opts db.FindOptions) ([]model.WorkflowHookIntent, string, error)
 	DeleteWorkflowHookIntent(ctx context.Context, name, project, cApp, cAppVer, dig string) error
 	// monitor the status of workflows, and cancel workflows
 	GetStatusWorkflowIntent(ctx context.Context, name, project, cApp, cAppVer, dig string, query *wfMod.WfTemporalStatusQuery) (*wfMod.WfTemporalStatusResponse, error)
@@ -118,6 +130,18 @@ func (v *WorkflowIntentClient) GetWorkflowHookIntent(ctx context.Context, name,
 
 // GetWorkflowHookIntents - get all current registered hooks
 func (v *WorkflowIntentClient) GetWorkflowHookIntents(ctx context.Context, project, cApp, cAppVer, dig string) ([]model.WorkflowHookIntent, error) {
+	res, _, err := v.ListWorkflowHookIntents(ctx, project, cApp, cAppVer, dig, db.FindOptions{})
+	return res, err
+}
+
+// ListWorkflowHookIntents - get a page of the hooks, filtered and sorted on the
+// workflowHookIntentSelectors, and the token of the next page
+func (v *WorkflowIntentClient) ListWorkflowHookIntents(ctx context.Context, project, cApp, cAppVer, dig string, opts db.FindOptions) ([]model.WorkflowHookIntent, string, error) {
+	opts, err := workflowHookIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []model.WorkflowHookIntent{}, "", err
+	}
+
 	// create key and tag for the hook
 	key := model.WorkflowHookKey{
 		WorkflowHook:        "",
@@ -128,21 +152,21 @@ func (v *WorkflowIntentClient) GetWorkflowHookIntents(ctx context.Context, proje
 	}
 
 	var resp []model.WorkflowHookIntent
-	values, err := db.DBconn.Find(ctx, v.db.StoreName, key, v.db.TagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.StoreName, key, v.db.TagMeta, opts)
 	if err != nil {
-		return []model.WorkflowHookIntent{}, err
+		return []model.WorkflowHookIntent{}, "", err
 	}
 
 	for _, value := range values {
 		wfh := model.WorkflowHookIntent{}
 		err = db.DBconn.Unmarshal(value, &wfh)
 		if err != nil {
-			return []model.WorkflowHookIntent{}, err
+			return []model.WorkflowHookIntent{}, "", err
 		}
 		resp = append(resp, wfh)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // DeleteWorkflowHookIntent - delete specific hook
@@ -12,6 +12,16 @@ import (
 	"gitlab.com/project-emco/core/emco-base/src/tac/pkg/model"
 )
 
+// workerIntentSelectors are the fields a list of worker intents can be filtered and sorted on
+var workerIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name": "data.metadata.name",
+	},
+	TagTypes: map[string]interface{}{
+		"data": model.WorkerIntent{},
+	},
+}
+
 // WorkflowIntentClient implements the Manager
 // It will also be used to maintain some localized state
 type WorkerIntentClient struct {
@@ -32,6 +42,7 @@ type WorkerIntentManager interface {
 	CreateOrUpdateWorkerIntent(wi model.WorkerIntent, tac, project, cApp, cAppVer, dig string, exists bool) (model.WorkerIntent, error)
 	GetWorkerIntent(workerName, project, cApp, cAppVer, dig, tac string) (model.WorkerIntent, error)
 	GetWorkerIntents(project, cApp, cAppVer, dig, tac string) ([]model.WorkerIntent, error)
+	ListWorkerIntents(project, cApp, cAppVer, dig, tac string, opts db.FindOptions) ([]model.WorkerIntent, string, error)
 	DeleteWorkerIntents(project, cApp, cAppVer, dig, tac, workerName string) error
 }
 
@@ -104,10 +115,22 @@ func (v WorkerIntentClient) GetWorkerIntent(workerName, project, cApp, cAppVer,
 }
 
 func (v WorkerIntentClient) GetWorkerIntents(project, cApp, cAppVer, dig, tac string) ([]model.WorkerIntent, error) {
+	resp, _, err := v.ListWorkerIntents(project, cApp, cAppVer, dig, tac, db.FindOptions{})
+	return resp, err
+}
+
+// ListWorkerIntents returns a page of the workers of the tac-intent, filtered and
+// sorted on the workerIntentSelectors, and the token of the next page
+func (v WorkerIntentClient) ListWorkerIntents(project, cApp, cAppVer, dig, tac string, opts db.FindOptions) ([]model.WorkerIntent, string, error) {
 	// print where we are
-	log.Info("GetWorkerIntents", log.Fields{"tac-intent": tac, "project": project,
+	log.Info("ListWorkerIntents", log.Fields{"tac-intent": tac, "project": project,
 		"cApp": cApp})
 
+	opts, err := workerIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []model.WorkerIntent{}, "", err
+	}
+
 	// create generic key to receive all workers
 	key := model.WorkerKey{
 		WorkerName:          "",
@@ -120,9 +143,9 @@ func (v WorkerIntentClient) GetWorkerIntents(project, cApp, cAppVer, dig, tac st
 
 	// query all workers on this tac-intent
 	var resp []model.WorkerIntent
-	values, err := db.DBconn.Find(context.Background(), v.db.StoreName, key, v.db.TagMeta)
+	values, next, err := db.DBconn.FindWithOptions(context.Background(), v.db.StoreName, key, v.db.TagMeta, opts)
 	if err != nil {
-		return []model.WorkerIntent{}, nil
+		return []model.WorkerIntent{}, "", nil
 	}
 
 	// loop through raw data and put them into json objects
@@ -132,12 +155,12 @@ func (v WorkerIntentClient) GetWorkerIntents(project, cApp, cAppVer, dig, tac st
 		err = db.DBconn.Unmarshal(value, &wi)
 
 		if err != nil {
-			return []model.WorkerIntent{}, err
+			return []model.WorkerIntent{}, "", err
 		}
 		resp = append(resp, wi)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 func (v WorkerIntentClient) DeleteWorkerIntents(project, cApp, cAppVer, dig, tac, workerName string) error {
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var outputFile string
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <project>",
+	Short: "Export the configuration of a project as a bundle",
+	Long: `Export the configuration of a project, its composite apps, deployment intent
+groups, intents and logical clouds, as a bundle to import with 'emcoctl import'.
+The bundle holds the secrets of the project in the clear, protect it accordingly.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var c RestyClient
+		if len(token) > 0 {
+			c = NewRestClientToken(token[0])
+		} else {
+			c = NewRestClient()
+		}
+		if len(args) != 1 {
+			fmt.Println("Use: 'emcoctl export --help'")
+			return
+		}
+		file := outputFile
+		if file == "" {
+			file = args[0] + ".tgz"
+		}
+		if err := c.RestClientExport("projects/"+args[0]+"/export", file); err != nil {
+			fmt.Println("Export:", args[0], "Error:", err)
+		}
+	},
+}
+
+// RestClientExport writes the bundle of the anchor to the file
+func (r RestyClient) RestClientExport(anchor string, file string) error {
+	url, err := GetURL(anchor)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.R().Get(url)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		printOutput(url, "GET", resp)
+		return pkgerrors.Errorf("API Error")
+	}
+	if err := ioutil.WriteFile(file, resp.Body(), 0600); err != nil {
+		return err
+	}
+	fmt.Println("---")
+	fmt.Println("GET  --> URL:", url)
+	fmt.Println("Response Code:", resp.StatusCode())
+	fmt.Println("Bundle:", file)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Filename of the bundle, <project>.tgz by default")
+	exportCmd.Flags().StringSliceVarP(&token, "token", "t", []string{}, "Token for EMCO API")
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Intel Corporation
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var renames []string
+
+// importOptions are the options of the import of a bundle
+type importOptions struct {
+	Rename map[string]map[string]string `json:"rename,omitempty"`
+}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <project> <bundle>",
+	Short: "Import a bundle exported with 'emcoctl export' as a new project",
+	Long: `Import a bundle exported with 'emcoctl export' as a new project. The resources
+can be renamed, e.g. --rename logicalCloud:lc1=lc2 renames the logical cloud lc1
+to lc2 in the resources of the project.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var c RestyClient
+		if len(token) > 0 {
+			c = NewRestClientToken(token[0])
+		} else {
+			c = NewRestClient()
+		}
+		if len(args) != 2 {
+			fmt.Println("Use: 'emcoctl import --help'")
+			return
+		}
+		opts, err := parseRenames(renames)
+		if err != nil {
+			fmt.Println("Import:", args[0], "Error:", err)
+			return
+		}
+		body, err := json.Marshal(opts)
+		if err != nil {
+			fmt.Println("Import:", args[0], "Error:", err)
+			return
+		}
+		c.RestClientMultipartPost("projects/"+args[0]+"/import", body, args[1])
+	},
+}
+
+// parseRenames returns the import options of the renames, of the form
+// <resource type>:<name>=<new name>
+func parseRenames(renames []string) (importOptions, error) {
+	opts := importOptions{Rename: make(map[string]map[string]string)}
+	for _, r := range renames {
+		tn := strings.SplitN(r, ":", 2)
+		if len(tn) != 2 {
+			return opts, pkgerrors.Errorf("Invalid rename %s", r)
+		}
+		names := strings.SplitN(tn[1], "=", 2)
+		if tn[0] == "" || len(names) != 2 || names[0] == "" || names[1] == "" {
+			return opts, pkgerrors.Errorf("Invalid rename %s", r)
+		}
+		if opts.Rename[tn[0]] == nil {
+			opts.Rename[tn[0]] = make(map[string]string)
+		}
+		opts.Rename[tn[0]][names[0]] = names[1]
+	}
+	return opts, nil
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringSliceVarP(&renames, "rename", "r", []string{}, "Rename a resource, <resource type>:<name>=<new name>")
+	importCmd.Flags().StringSliceVarP(&token, "token", "t", []string{}, "Token for EMCO API")
+}
@@ -7,6 +7,7 @@ import (
 	testing "testing"
 
 	mock "github.com/stretchr/testify/mock"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	module "gitlab.com/project-emco/core/emco-base/src/workflowmgr/pkg/module"
 )
 
@@ -131,6 +132,36 @@ func (_m *WorkflowIntentManager) GetWorkflowIntents(ctx context.Context, project
 	return r0, r1
 }
 
+// ListWorkflowIntents provides a mock function with given fields: project, cApp, cAppVer, dig, opts
+func (_m *WorkflowIntentManager) ListWorkflowIntents(ctx context.Context, project string, cApp string, cAppVer string, dig string, opts db.FindOptions) ([]module.WorkflowIntent, string, error) {
+	ret := _m.Called(ctx, project, cApp, cAppVer, dig, opts)
+
+	var r0 []module.WorkflowIntent
+	if rf, ok := ret.Get(0).(func(string, string, string, string, db.FindOptions) []module.WorkflowIntent); ok {
+		r0 = rf(project, cApp, cAppVer, dig, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]module.WorkflowIntent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, string, string, db.FindOptions) string); ok {
+		r1 = rf(project, cApp, cAppVer, dig, opts)
+	} else {
+		r1 = ret.String(1)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, db.FindOptions) error); ok {
+		r2 = rf(project, cApp, cAppVer, dig, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // StartWorkflowIntent provides a mock function with given fields: name, project, cApp, cAppVer, dig
 func (_m *WorkflowIntentManager) StartWorkflowIntent(ctx context.Context, name string, project string, cApp string, cAppVer string, dig string) error {
 	ret := _m.Called(ctx, name, project, cApp, cAppVer, dig)
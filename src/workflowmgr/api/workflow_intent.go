@@ -11,6 +11,8 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/apilist"
+	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/db"
 	log "gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/logutils"
 	"gitlab.com/project-emco/core/emco-base/src/orchestrator/pkg/infra/validation"
 	moduleLib "gitlab.com/project-emco/core/emco-base/src/workflowmgr/pkg/module"
@@ -85,6 +87,7 @@ func (h workflowIntentHandler) getHandler(w http.ResponseWriter, r *http.Request
 	ctx := r.Context()
 	var ret interface{}
 	var err error
+	var next string
 
 	vars := mux.Vars(r)
 	name := vars["workflow-intent-name"]
@@ -98,7 +101,14 @@ func (h workflowIntentHandler) getHandler(w http.ResponseWriter, r *http.Request
 	})
 
 	if len(name) == 0 {
-		ret, err = h.client.GetWorkflowIntents(ctx, project, cApp, cAppVer, dig)
+		var opts db.FindOptions
+		opts, err = apilist.FindOptions(r)
+		if err != nil {
+			log.Error(":: Invalid workflow intent list options ::", log.Fields{"Error": err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ret, next, err = h.client.ListWorkflowIntents(ctx, project, cApp, cAppVer, dig, opts)
 	} else {
 		ret, err = h.client.GetWorkflowIntent(ctx, name, project, cApp, cAppVer, dig)
 	}
@@ -110,6 +120,7 @@ func (h workflowIntentHandler) getHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	apilist.SetContinue(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	err = json.NewEncoder(w).Encode(ret)
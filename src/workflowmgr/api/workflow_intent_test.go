@@ -134,7 +134,7 @@ var _ = Describe("workflowIntenthandler", func() {
 	DescribeTable("Workflow intent get all handlers",
 		func(t testCase) {
 			// set up client mock responses
-			t.client.On("GetWorkflowIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig").Return(t.mockVals, t.mockError)
+			t.client.On("ListWorkflowIntents", mock.Anything, "test-project", "test-compositeapp", "v1", "test-dig", mock.Anything).Return(t.mockVals, "", t.mockError)
 
 			// make HTTP request
 			request := httptest.NewRequest("GET", "/v2/projects/test-project/composite-apps/test-compositeapp/v1/deployment-intent-groups/test-dig/temporal-workflow-intents", t.inputReader)
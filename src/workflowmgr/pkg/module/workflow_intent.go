@@ -138,6 +138,7 @@ type WorkflowIntentManager interface {
 	CreateWorkflowIntent(ctx context.Context, wfi WorkflowIntent, project, cApp, cAppVer, dig string, exists bool) (WorkflowIntent, error)
 	GetWorkflowIntent(ctx context.Context, name, project, cApp, cAppVer, dig string) (WorkflowIntent, error)
 	GetWorkflowIntents(ctx context.Context, project, cApp, cAppVer, dig string) ([]WorkflowIntent, error)
+	ListWorkflowIntents(ctx context.Context, project, cApp, cAppVer, dig string, opts db.FindOptions) ([]WorkflowIntent, string, error)
 	DeleteWorkflowIntent(ctx context.Context, name, project, cApp, cAppVer, dig string) error
 	StartWorkflowIntent(ctx context.Context, name, project, cApp, cAppVer, dig string) error
 	GetStatusWorkflowIntent(ctx context.Context, name, project, cApp, cAppVer, dig string,
@@ -146,6 +147,17 @@ type WorkflowIntentManager interface {
 		req *WfTemporalCancelRequest) error
 }
 
+// workflowIntentSelectors are the fields a list of WorkflowIntents can be filtered and sorted on
+var workflowIntentSelectors = db.FieldSelectors{
+	Paths: map[string]string{
+		"name":               "data.metadata.name",
+		"clientEndpointName": "data.spec.workflowClient.clientEndpointName",
+	},
+	TagTypes: map[string]interface{}{
+		"data": WorkflowIntent{},
+	},
+}
+
 // WorkflowIntentClient implements the Manager
 // It will also be used to maintain some localized state
 type WorkflowIntentClient struct {
@@ -230,6 +242,19 @@ func (v *WorkflowIntentClient) GetWorkflowIntent(ctx context.Context, name,
 // GetWorkflowIntents returns all WorkflowIntents for a DIG.
 func (v *WorkflowIntentClient) GetWorkflowIntents(ctx context.Context,
 	project, cApp, cAppVer, dig string) ([]WorkflowIntent, error) {
+	res, _, err := v.ListWorkflowIntents(ctx, project, cApp, cAppVer, dig, db.FindOptions{})
+	return res, err
+}
+
+// ListWorkflowIntents returns a page of the WorkflowIntents of the deployment
+// intent group, filtered and sorted on the workflowIntentSelectors, and the
+// token of the next page
+func (v *WorkflowIntentClient) ListWorkflowIntents(ctx context.Context,
+	project, cApp, cAppVer, dig string, opts db.FindOptions) ([]WorkflowIntent, string, error) {
+	opts, err := workflowIntentSelectors.Resolve(opts)
+	if err != nil {
+		return []WorkflowIntent{}, "", err
+	}
 
 	//Construct key and tag to select the entry
 	key := WorkflowIntentKey{
@@ -241,21 +266,21 @@ func (v *WorkflowIntentClient) GetWorkflowIntents(ctx context.Context,
 	}
 
 	var resp []WorkflowIntent
-	values, err := db.DBconn.Find(ctx, v.db.storeName, key, v.db.tagMeta)
+	values, next, err := db.DBconn.FindWithOptions(ctx, v.db.storeName, key, v.db.tagMeta, opts)
 	if err != nil {
-		return []WorkflowIntent{}, err
+		return []WorkflowIntent{}, "", err
 	}
 
 	for _, value := range values {
 		wfi := WorkflowIntent{}
 		err = db.DBconn.Unmarshal(value, &wfi)
 		if err != nil {
-			return []WorkflowIntent{}, err
+			return []WorkflowIntent{}, "", err
 		}
 		resp = append(resp, wfi)
 	}
 
-	return resp, nil
+	return resp, next, nil
 }
 
 // Delete the  WorkflowIntent from database